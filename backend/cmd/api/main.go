@@ -11,6 +11,7 @@ import (
 
 	"github.com/gobitfly/beaconchain/pkg/api"
 	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/handlers"
 	"github.com/gobitfly/beaconchain/pkg/monitoring"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
@@ -82,6 +83,7 @@ func Run() {
 
 	if cfg.Frontend.RatelimitEnabled {
 		log.Infof("enabling ratelimit")
+		ratelimit.SetAccessTokenResolver(handlers.GetOAuthAccessTokenUserId)
 		ratelimit.Init()
 		router.Use(ratelimit.HttpMiddleware)
 	}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"flag"
+
+	"github.com/gobitfly/beaconchain/cmd/misc/misctypes"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/exporter/modules"
+
+	"github.com/pkg/errors"
+)
+
+type DashboardRecordEpochCommand struct {
+	FlagSet *flag.FlagSet
+	Config  dashboardRecordEpochCommandConfig
+}
+
+type dashboardRecordEpochCommandConfig struct {
+	StartEpoch uint64
+	EndEpoch   uint64
+	Dir        string
+}
+
+func (s *DashboardRecordEpochCommand) ParseCommandOptions() {
+	s.FlagSet.StringVar(&s.Config.Dir, "snapshot-dir", "pkg/exporter/modules/testdata/epoch_snapshots", "Directory the epoch data snapshots are written to")
+}
+
+func (s *DashboardRecordEpochCommand) Requires() misctypes.Requires {
+	return misctypes.Requires{
+		NetworkDBs: true, // execution rewards are read from the network db
+	}
+}
+
+func (s *DashboardRecordEpochCommand) Run() error {
+	if s.Config.EndEpoch == 0 {
+		s.Config.EndEpoch = s.Config.StartEpoch
+	}
+	if s.Config.StartEpoch > s.Config.EndEpoch || s.Config.Dir == "" {
+		s.showHelp()
+		return errors.New("Please specify a valid epoch range via --start-epoch and --end-epoch")
+	}
+
+	moduleContext, err := modules.GetModuleContext()
+	if err != nil {
+		return errors.Wrap(err, "error getting module context")
+	}
+
+	recorder := modules.NewDashboardDataRecorder(moduleContext)
+	for epoch := s.Config.StartEpoch; epoch <= s.Config.EndEpoch; epoch++ {
+		path, err := recorder.Record(epoch, s.Config.Dir)
+		if err != nil {
+			return errors.Wrapf(err, "error recording epoch %d", epoch)
+		}
+		log.Infof("recorded epoch %d to %s", epoch, path)
+	}
+	return nil
+}
+
+func (s *DashboardRecordEpochCommand) showHelp() {
+	log.Infof("Usage: %s --start-epoch=100000\n", "dashboard-record-epoch")
+	log.Infof("Usage: %s --start-epoch=100000 --end-epoch=100002 --snapshot-dir=/tmp/snapshots\n", "dashboard-record-epoch")
+	log.Infof("Records the data the dashboard data exporter processes for each epoch as snapshot, those can be replayed by the tests of the exporter without a node.\n")
+}
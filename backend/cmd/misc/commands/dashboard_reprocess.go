@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"flag"
+
+	"github.com/gobitfly/beaconchain/cmd/misc/misctypes"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/exporter/modules"
+
+	"github.com/pkg/errors"
+)
+
+type DashboardReprocessCommand struct {
+	FlagSet *flag.FlagSet
+	Config  dashboardReprocessCommandConfig
+}
+
+type dashboardReprocessCommandConfig struct {
+	StartEpoch uint64
+	EndEpoch   uint64
+	Restart    bool
+}
+
+func (s *DashboardReprocessCommand) ParseCommandOptions() {
+	s.FlagSet.BoolVar(&s.Config.Restart, "reprocess-restart", false, "Discard the checkpoint of a previous run of the same epoch range and start from scratch")
+}
+
+func (s *DashboardReprocessCommand) Requires() misctypes.Requires {
+	return misctypes.Requires{
+		NetworkDBs: true,
+	}
+}
+
+func (s *DashboardReprocessCommand) Run() error {
+	if s.Config.EndEpoch == 0 || s.Config.StartEpoch > s.Config.EndEpoch {
+		s.showHelp()
+		return errors.New("Please specify a valid epoch range via --start-epoch and --end-epoch")
+	}
+
+	moduleContext, err := modules.GetModuleContext()
+	if err != nil {
+		return errors.Wrap(err, "error getting module context")
+	}
+
+	err = modules.NewDashboardDataReprocessor(moduleContext).Reprocess(s.Config.StartEpoch, s.Config.EndEpoch, s.Config.Restart)
+	if err != nil {
+		return errors.Wrap(err, "error reprocessing dashboard data")
+	}
+	return nil
+}
+
+func (s *DashboardReprocessCommand) showHelp() {
+	log.Infof("Usage: %s --start-epoch=100000 --end-epoch=100225\n", "dashboard-reprocess")
+	log.Infof("Usage: %s --start-epoch=100000 --end-epoch=100225 --reprocess-restart\n", "dashboard-reprocess")
+	log.Infof("The epoch range is expanded to full UTC days, the dashboard data exporter must be stopped while reprocessing. Running the same range again resumes after the last completed day.\n")
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"flag"
+	"time"
+
+	"github.com/gobitfly/beaconchain/cmd/misc/misctypes"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+
+	"github.com/pkg/errors"
+)
+
+type MachineMetricsBackfillRollupsCommand struct {
+	FlagSet *flag.FlagSet
+	Config  machineMetricsBackfillRollupsCommandConfig
+}
+
+type machineMetricsBackfillRollupsCommandConfig struct {
+	DryRun bool
+	Days   uint64
+}
+
+func (s *MachineMetricsBackfillRollupsCommand) ParseCommandOptions() {
+	s.FlagSet.Uint64Var(&s.Config.Days, "rollup-days", 31, "Number of days of raw machine metrics that are rolled up")
+}
+
+func (s *MachineMetricsBackfillRollupsCommand) Requires() misctypes.Requires {
+	return misctypes.Requires{
+		Bigtable: true,
+		Redis:    true,
+	}
+}
+
+func (s *MachineMetricsBackfillRollupsCommand) Run() error {
+	if s.Config.Days == 0 {
+		s.showHelp()
+		return errors.New("Please specify the number of days via --rollup-days")
+	}
+
+	from := time.Now().Add(-utils.Day * time.Duration(s.Config.Days))
+	rows, err := db.BigtableClient.BackfillMachineMetricsRollups(from, s.Config.DryRun)
+	if err != nil {
+		return errors.Wrap(err, "error backfilling machine metrics rollups")
+	}
+	if s.Config.DryRun {
+		log.Infof("dry run, would have backfilled the machine metrics rollups of %d rows since %v", rows, from)
+		return nil
+	}
+	log.Infof("backfilled the machine metrics rollups of %d rows since %v", rows, from)
+	return nil
+}
+
+func (s *MachineMetricsBackfillRollupsCommand) showHelp() {
+	log.Infof("Usage: %s --rollup-days=31 --dry-run=false\n", "machine-metrics-backfill-rollups")
+	log.Infof("Writes the 5m and 1h rollups of the raw machine metrics samples, run this before shortening the retention of the raw samples.\n")
+}
@@ -78,7 +78,10 @@ var opts = struct {
  * By default, all commands that are not in the REQUIRES_LIST will automatically require everything.
  */
 var REQUIRES_LIST = map[string]misctypes.Requires{
-	"app-bundle": (&commands.AppBundleCommand{}).Requires(),
+	"app-bundle":                       (&commands.AppBundleCommand{}).Requires(),
+	"dashboard-reprocess":              (&commands.DashboardReprocessCommand{}).Requires(),
+	"dashboard-record-epoch":           (&commands.DashboardRecordEpochCommand{}).Requires(),
+	"machine-metrics-backfill-rollups": (&commands.MachineMetricsBackfillRollupsCommand{}).Requires(),
 }
 
 func Run() {
@@ -92,8 +95,20 @@ func Run() {
 		FlagSet: fs,
 	}
 
+	dashboardReprocessCommand := commands.DashboardReprocessCommand{
+		FlagSet: fs,
+	}
+
+	dashboardRecordEpochCommand := commands.DashboardRecordEpochCommand{
+		FlagSet: fs,
+	}
+
+	machineMetricsBackfillRollupsCommand := commands.MachineMetricsBackfillRollupsCommand{
+		FlagSet: fs,
+	}
+
 	configPath := fs.String("config", "config/default.config.yml", "Path to the config file")
-	fs.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, collect-notifications, collect-user-db-notifications, verify-fcm-tokens, app-bundle")
+	fs.StringVar(&opts.Command, "command", "", "command to run, available: updateAPIKey, applyDbSchema, initBigtableSchema, epoch-export, debug-rewards, debug-blocks, clear-bigtable, index-old-eth1-blocks, update-aggregation-bits, historic-prices-export, index-missing-blocks, export-epoch-missed-slots, migrate-last-attestation-slot-bigtable, export-genesis-validators, update-block-finalization-sequentially, nameValidatorsByRanges, export-stats-totals, export-sync-committee-periods, export-sync-committee-validator-stats, partition-validator-stats, migrate-app-purchases, collect-notifications, collect-user-db-notifications, verify-fcm-tokens, app-bundle, dashboard-reprocess, dashboard-record-epoch, machine-metrics-backfill-rollups")
 	fs.Uint64Var(&opts.StartEpoch, "start-epoch", 0, "start epoch")
 	fs.Uint64Var(&opts.EndEpoch, "end-epoch", 0, "end epoch")
 	fs.Uint64Var(&opts.User, "user", 0, "user id")
@@ -118,6 +133,9 @@ func Run() {
 
 	statsPartitionCommand.ParseCommandOptions()
 	appBundleCommand.ParseCommandOptions()
+	dashboardReprocessCommand.ParseCommandOptions()
+	dashboardRecordEpochCommand.ParseCommandOptions()
+	machineMetricsBackfillRollupsCommand.ParseCommandOptions()
 	_ = fs.Parse(os.Args[2:])
 
 	if *versionFlag {
@@ -159,13 +177,9 @@ func Run() {
 
 	var rpcClient *rpc.LighthouseClient
 	if requires.ClNode {
-		cl := consapi.NewClient("http://" + cfg.Indexer.Node.Host + ":" + cfg.Indexer.Node.Port)
-		nodeImpl, ok := cl.ClientInt.(*consapi.NodeClient)
-		if !ok {
-			log.Fatal(nil, "lighthouse client can only be used with real node impl", 0)
-		}
+		cl := consapi.NewClientForEndpoints(utils.BeaconNodeEndpoints(cfg))
 		chainIDBig := new(big.Int).SetUint64(utils.Config.Chain.ClConfig.DepositChainID)
-		rpcClient, err = rpc.NewLighthouseClient(nodeImpl, chainIDBig)
+		rpcClient, err = rpc.NewLighthouseClient(cl.ClientInt, chainIDBig)
 		if err != nil {
 			log.Fatal(err, "lighthouse client error", 0)
 		}
@@ -531,6 +545,17 @@ func Run() {
 	case "app-bundle":
 		appBundleCommand.Config.DryRun = opts.DryRun
 		err = appBundleCommand.Run()
+	case "dashboard-reprocess":
+		dashboardReprocessCommand.Config.StartEpoch = opts.StartEpoch
+		dashboardReprocessCommand.Config.EndEpoch = opts.EndEpoch
+		err = dashboardReprocessCommand.Run()
+	case "dashboard-record-epoch":
+		dashboardRecordEpochCommand.Config.StartEpoch = opts.StartEpoch
+		dashboardRecordEpochCommand.Config.EndEpoch = opts.EndEpoch
+		err = dashboardRecordEpochCommand.Run()
+	case "machine-metrics-backfill-rollups":
+		machineMetricsBackfillRollupsCommand.Config.DryRun = opts.DryRun
+		err = machineMetricsBackfillRollupsCommand.Run()
 	case "fix-ens":
 		err = fixEns(erigonClient)
 	case "fix-ens-addresses":
@@ -74,6 +74,7 @@ eth1ErigonEndpoint: 'http://127.0.0.1:$EL_PORT'
 eth1GethEndpoint: 'http://127.0.0.1:$EL_PORT'
 redisCacheEndpoint: '127.0.0.1:$REDIS_PORT'
 tieredCacheProvider: 'redis'
+dashboardStorage: 'postgres'
 frontend:
   debug: true
   sessionSameSiteNone: false
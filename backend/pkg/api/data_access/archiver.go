@@ -10,17 +10,18 @@ import (
 )
 
 type ArchiverRepository interface {
-	GetValidatorDashboardsCountInfo(ctx context.Context) (map[uint64][]t.ArchiverDashboard, error)
+	GetValidatorDashboardsCountInfo(ctx context.Context) (map[t.ArchiverDashboardOwner][]t.ArchiverDashboard, error)
 	UpdateValidatorDashboardsArchiving(ctx context.Context, dashboards []t.ArchiverDashboardArchiveReason) error
 	RemoveValidatorDashboards(ctx context.Context, dashboardIds []uint64) error
 }
 
-func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context) (map[uint64][]t.ArchiverDashboard, error) {
-	result := make(map[uint64][]t.ArchiverDashboard)
+func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context) (map[t.ArchiverDashboardOwner][]t.ArchiverDashboard, error) {
+	result := make(map[t.ArchiverDashboardOwner][]t.ArchiverDashboard)
 
 	type DashboardInfo struct {
 		Id             uint64         `db:"id"`
 		UserId         uint64         `db:"user_id"`
+		OrganizationId uint64         `db:"organization_id"`
 		IsArchived     sql.NullString `db:"is_archived"`
 		GroupCount     uint64         `db:"group_count"`
 		ValidatorCount uint64         `db:"validator_count"`
@@ -43,6 +44,7 @@ func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context)
 		SELECT
 			uvd.id,
 			uvd.user_id,
+			COALESCE(uvd.organization_id, 0) AS organization_id,
 			uvd.is_archived,
 		    COALESCE(dg.group_count, 0) AS group_count,
 		    COALESCE(dv.validator_count, 0) AS validator_count
@@ -55,8 +57,13 @@ func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context)
 	}
 
 	for _, dashboardInfo := range dbReturn {
-		if _, ok := result[dashboardInfo.UserId]; !ok {
-			result[dashboardInfo.UserId] = make([]t.ArchiverDashboard, 0)
+		// organization dashboards are limited by the organization, not by their creator
+		owner := t.ArchiverDashboardOwner{UserId: dashboardInfo.UserId}
+		if dashboardInfo.OrganizationId != 0 {
+			owner = t.ArchiverDashboardOwner{OrganizationId: dashboardInfo.OrganizationId}
+		}
+		if _, ok := result[owner]; !ok {
+			result[owner] = make([]t.ArchiverDashboard, 0)
 		}
 
 		dashboard := t.ArchiverDashboard{
@@ -66,7 +73,7 @@ func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context)
 			ValidatorCount: dashboardInfo.ValidatorCount,
 		}
 
-		result[dashboardInfo.UserId] = append(result[dashboardInfo.UserId], dashboard)
+		result[owner] = append(result[owner], dashboard)
 	}
 
 	return result, nil
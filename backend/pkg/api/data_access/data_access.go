@@ -11,6 +11,7 @@ import (
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/ethclients"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/jmoiron/sqlx"
@@ -24,6 +25,10 @@ type DataAccessor interface {
 	ClientRepository
 	UserRepository
 	AppRepository
+	OAuthRepository
+	TwoFactorRepository
+	UserSecurityRepository
+	OrganizationRepository
 	NotificationsRepository
 	AdminRepository
 	BlockRepository
@@ -54,6 +59,7 @@ type DataAccessService struct {
 	alloyReader             *sqlx.DB
 	alloyWriter             *sqlx.DB
 	clickhouseReader        *sqlx.DB
+	dashboardStorage        db.DashboardAggregateStorage
 	userReader              *sqlx.DB
 	userWriter              *sqlx.DB
 	bigtable                *db.Bigtable
@@ -149,33 +155,36 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 		)
 	}()
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		dataAccessService.clickhouseReader, _ = db.MustInitDB(
-			&types.DatabaseConfig{
-				Username:     cfg.ClickHouse.ReaderDatabase.Username,
-				Password:     cfg.ClickHouse.ReaderDatabase.Password,
-				Name:         cfg.ClickHouse.ReaderDatabase.Name,
-				Host:         cfg.ClickHouse.ReaderDatabase.Host,
-				Port:         cfg.ClickHouse.ReaderDatabase.Port,
-				MaxOpenConns: cfg.ClickHouse.ReaderDatabase.MaxOpenConns,
-				MaxIdleConns: cfg.ClickHouse.ReaderDatabase.MaxIdleConns,
-				SSL:          true,
-			},
-			// lets just reuse reader to be extra safe
-			&types.DatabaseConfig{
-				Username:     cfg.ClickHouse.ReaderDatabase.Username,
-				Password:     cfg.ClickHouse.ReaderDatabase.Password,
-				Name:         cfg.ClickHouse.ReaderDatabase.Name,
-				Host:         cfg.ClickHouse.ReaderDatabase.Host,
-				Port:         cfg.ClickHouse.ReaderDatabase.Port,
-				MaxOpenConns: cfg.ClickHouse.ReaderDatabase.MaxOpenConns,
-				MaxIdleConns: cfg.ClickHouse.ReaderDatabase.MaxIdleConns,
-				SSL:          true,
-			}, "clickhouse", "clickhouse",
-		)
-	}()
+	// the validator dashboard aggregates are the only data read from clickhouse
+	if cfg.DashboardStorage != db.DashboardStoragePostgres {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dataAccessService.clickhouseReader, _ = db.MustInitDB(
+				&types.DatabaseConfig{
+					Username:     cfg.ClickHouse.ReaderDatabase.Username,
+					Password:     cfg.ClickHouse.ReaderDatabase.Password,
+					Name:         cfg.ClickHouse.ReaderDatabase.Name,
+					Host:         cfg.ClickHouse.ReaderDatabase.Host,
+					Port:         cfg.ClickHouse.ReaderDatabase.Port,
+					MaxOpenConns: cfg.ClickHouse.ReaderDatabase.MaxOpenConns,
+					MaxIdleConns: cfg.ClickHouse.ReaderDatabase.MaxIdleConns,
+					SSL:          true,
+				},
+				// lets just reuse reader to be extra safe
+				&types.DatabaseConfig{
+					Username:     cfg.ClickHouse.ReaderDatabase.Username,
+					Password:     cfg.ClickHouse.ReaderDatabase.Password,
+					Name:         cfg.ClickHouse.ReaderDatabase.Name,
+					Host:         cfg.ClickHouse.ReaderDatabase.Host,
+					Port:         cfg.ClickHouse.ReaderDatabase.Port,
+					MaxOpenConns: cfg.ClickHouse.ReaderDatabase.MaxOpenConns,
+					MaxIdleConns: cfg.ClickHouse.ReaderDatabase.MaxIdleConns,
+					SSL:          true,
+				}, "clickhouse", "clickhouse",
+			)
+		}()
+	}
 
 	// Initialize the user database
 	wg.Add(1)
@@ -243,6 +252,12 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 
 	wg.Wait()
 
+	var err error
+	dataAccessService.dashboardStorage, err = db.NewDashboardAggregateStorage(cfg.DashboardStorage, dataAccessService.clickhouseReader, dataAccessService.alloyReader, nil)
+	if err != nil {
+		log.Fatal(err, "error initializing dashboard storage", 0)
+	}
+
 	if cfg.TieredCacheProvider != "redis" {
 		log.Fatal(fmt.Errorf("no cache provider set, please set TierdCacheProvider (example redis)"), "", 0)
 	}
@@ -253,10 +268,12 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 
 func (d *DataAccessService) StartDataAccessServices() {
 	// Create the services
-	d.services = services.NewServices(d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.bigtable, d.persistentRedisDbClient)
+	d.services = services.NewServices(d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.dashboardStorage, d.bigtable, d.persistentRedisDbClient)
 
 	// Initialize repositories
 	d.registerNotificationInterfaceTypes()
+	// Fetch client releases, used to detect outdated machine clients
+	ethclients.Init()
 	// Initialize the services
 
 	if d.skipServiceInitWait {
@@ -175,6 +175,30 @@ func (d *DummyService) GetUserIdByApiKey(ctx context.Context, apiKey string) (ui
 	return getDummyData[uint64](ctx)
 }
 
+func (d *DummyService) GetApiKeyScopes(ctx context.Context, apiKey string) ([]string, error) {
+	return t.ApiKeyScopes, nil
+}
+
+func (d *DummyService) GetUserApiKeys(ctx context.Context, userId uint64) ([]t.UserApiKey, error) {
+	return getDummyData[[]t.UserApiKey](ctx)
+}
+
+func (d *DummyService) CreateUserApiKey(ctx context.Context, userId uint64, label string, scopes []string) (*t.CreatedUserApiKey, error) {
+	return getDummyStruct[t.CreatedUserApiKey](ctx)
+}
+
+func (d *DummyService) UpdateUserApiKey(ctx context.Context, userId, apiKeyId uint64, label string, scopes []string) (*t.UserApiKey, error) {
+	return getDummyStruct[t.UserApiKey](ctx)
+}
+
+func (d *DummyService) RotateUserApiKey(ctx context.Context, userId, apiKeyId uint64) (*t.CreatedUserApiKey, error) {
+	return getDummyStruct[t.CreatedUserApiKey](ctx)
+}
+
+func (d *DummyService) RevokeUserApiKey(ctx context.Context, userId, apiKeyId uint64) error {
+	return nil
+}
+
 func (d *DummyService) GetUserIdByConfirmationHash(ctx context.Context, hash string) (uint64, error) {
 	return getDummyData[uint64](ctx)
 }
@@ -374,6 +398,14 @@ func (d *DummyService) GetValidatorDashboardClDeposits(ctx context.Context, dash
 	return getDummyWithPaging[t.VDBConsensusDepositsTableRow](ctx)
 }
 
+func (d *DummyService) GetValidatorDashboardConsolidations(ctx context.Context, dashboardId t.VDBId, cursor string, limit uint64) ([]t.VDBConsolidationsTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.VDBConsolidationsTableRow](ctx)
+}
+
+func (d *DummyService) GetValidatorDashboardWithdrawalRequests(ctx context.Context, dashboardId t.VDBId, cursor string, limit uint64) ([]t.VDBWithdrawalRequestsTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.VDBWithdrawalRequestsTableRow](ctx)
+}
+
 func (d *DummyService) GetValidatorDashboardTotalElDeposits(ctx context.Context, dashboardId t.VDBId) (*t.VDBTotalExecutionDepositsData, error) {
 	return getDummyStruct[t.VDBTotalExecutionDepositsData](ctx)
 }
@@ -570,6 +602,12 @@ func (d *DummyService) GetClientNotifications(ctx context.Context, userId uint64
 func (d *DummyService) GetNetworkNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationNetworksColumn], limit uint64) ([]t.NotificationNetworksTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.NotificationNetworksTableRow](ctx)
 }
+func (d *DummyService) GetNotificationIncidents(ctx context.Context, userId uint64, status commontypes.NotificationIncidentStatus, cursor string, colSort t.Sort[enums.NotificationIncidentsColumn], limit uint64) ([]t.NotificationIncidentsTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.NotificationIncidentsTableRow](ctx)
+}
+func (d *DummyService) AcknowledgeNotificationIncident(ctx context.Context, userId uint64, incidentId uint64) error {
+	return nil
+}
 
 func (d *DummyService) GetNotificationSettings(ctx context.Context, userId uint64) (*t.NotificationSettings, error) {
 	return getDummyStruct[t.NotificationSettings](ctx)
@@ -614,6 +652,27 @@ func (d *DummyService) UpdateNotificationSettingsValidatorDashboard(ctx context.
 func (d *DummyService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
 	return nil
 }
+func (d *DummyService) GetNotificationRulesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationRuleValidatorDashboard, error) {
+	return getDummyData[[]t.NotificationRuleValidatorDashboard](ctx)
+}
+func (d *DummyService) CreateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error) {
+	return getDummyStruct[t.NotificationRuleValidatorDashboard](ctx)
+}
+func (d *DummyService) UpdateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error) {
+	return getDummyStruct[t.NotificationRuleValidatorDashboard](ctx)
+}
+func (d *DummyService) DeleteNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, ruleId uint64) error {
+	return nil
+}
+func (d *DummyService) GetNotificationSnoozesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationSnoozeValidatorDashboard, error) {
+	return getDummyData[[]t.NotificationSnoozeValidatorDashboard](ctx)
+}
+func (d *DummyService) CreateNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, eventType *commontypes.EventName, snoozedUntil time.Time) (*t.NotificationSnoozeValidatorDashboard, error) {
+	return getDummyStruct[t.NotificationSnoozeValidatorDashboard](ctx)
+}
+func (d *DummyService) DeleteNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, snoozeId uint64) error {
+	return nil
+}
 func (d *DummyService) CreateAdConfiguration(ctx context.Context, key, jquerySelector string, insertMode enums.AdInsertMode, refreshInterval uint64, forAllUsers bool, bannerId uint64, htmlContent string, enabled bool) error {
 	return nil
 }
@@ -650,6 +709,166 @@ func (d *DummyService) AddUserDevice(ctx context.Context, userID uint64, hashedR
 	return nil
 }
 
+func (d *DummyService) GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error) {
+	return getDummyData[[]t.OAuthApp](ctx)
+}
+
+func (d *DummyService) CreateUserOAuthApp(ctx context.Context, userId uint64, name, redirectUri string, hashedClientSecret *string) (*t.OAuthApp, error) {
+	return getDummyStruct[t.OAuthApp](ctx)
+}
+
+func (d *DummyService) RemoveUserOAuthApp(ctx context.Context, userId, appId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOAuthApp(ctx context.Context, appId uint64) (*t.OAuthAppData, error) {
+	return getDummyStruct[t.OAuthAppData](ctx)
+}
+
+func (d *DummyService) AddOAuthCode(ctx context.Context, userId, appId uint64, hashedCode, redirectUri string, scopes []string, codeChallenge, codeChallengeMethod string) error {
+	return nil
+}
+
+func (d *DummyService) ConsumeOAuthCode(ctx context.Context, appId uint64, hashedCode string, maxAge time.Duration) (*t.OAuthCodeData, error) {
+	return getDummyStruct[t.OAuthCodeData](ctx)
+}
+
+func (d *DummyService) AddOAuthDevice(ctx context.Context, userId, appId uint64, hashedRefreshToken, deviceName string, scopes []string) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) RotateOAuthRefreshToken(ctx context.Context, appId uint64, oldHashedRefreshToken, newHashedRefreshToken string) (*t.OAuthDeviceData, error) {
+	return getDummyStruct[t.OAuthDeviceData](ctx)
+}
+
+func (d *DummyService) GetUserTwoFactorData(ctx context.Context, userId uint64) (*t.UserTwoFactorData, error) {
+	return getDummyStruct[t.UserTwoFactorData](ctx)
+}
+
+func (d *DummyService) GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) SetUserPendingTotpSecret(ctx context.Context, userId uint64, secret string) error {
+	return nil
+}
+
+func (d *DummyService) EnableUserTotp(ctx context.Context, userId uint64, counter int64, hashedRecoveryCodes []string) error {
+	return nil
+}
+
+func (d *DummyService) DisableUserTotp(ctx context.Context, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) UpdateUserTotpCounter(ctx context.Context, userId uint64, counter int64) (bool, error) {
+	return true, nil
+}
+
+func (d *DummyService) ConsumeUserRecoveryCode(ctx context.Context, userId uint64, hashedCode string) (bool, error) {
+	return true, nil
+}
+
+func (d *DummyService) ReplaceUserRecoveryCodes(ctx context.Context, userId uint64, hashedRecoveryCodes []string) error {
+	return nil
+}
+
+func (d *DummyService) RecordUserTwoFactorFailure(ctx context.Context, userId uint64, maxAttempts uint64, lockout time.Duration) (bool, error) {
+	return false, nil
+}
+
+func (d *DummyService) ResetUserTwoFactorFailures(ctx context.Context, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) AddUserWebSession(ctx context.Context, userId uint64, token string, lifetime time.Duration) error {
+	return nil
+}
+
+func (d *DummyService) RemoveUserWebSessions(ctx context.Context, userId uint64, tokens ...string) error {
+	return nil
+}
+
+func (d *DummyService) GetUserWebSessions(ctx context.Context, userId uint64) ([]string, error) {
+	return []string{}, nil
+}
+
+func (d *DummyService) GetUserDeviceSessions(ctx context.Context, userId uint64) ([]t.UserDeviceSession, error) {
+	return getDummyData[[]t.UserDeviceSession](ctx)
+}
+
+func (d *DummyService) RevokeUserDeviceSession(ctx context.Context, userId, deviceId uint64, accessTokenValidity time.Duration) error {
+	return nil
+}
+
+func (d *DummyService) IsUserDeviceRevoked(ctx context.Context, deviceId uint64) (bool, error) {
+	return false, nil
+}
+
+func (d *DummyService) UpdateUserDeviceActivity(ctx context.Context, hashedRefreshToken, ip, userAgent string) error {
+	return nil
+}
+
+func (d *DummyService) AddUserSecurityEvent(ctx context.Context, userId uint64, eventType, ip, userAgent string) error {
+	return nil
+}
+
+func (d *DummyService) GetUserSecurityEvents(ctx context.Context, userId uint64, limit uint64) ([]t.UserSecurityEvent, error) {
+	return getDummyData[[]t.UserSecurityEvent](ctx)
+}
+
+func (d *DummyService) GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error) {
+	return getDummyData[[]t.Organization](ctx)
+}
+
+func (d *DummyService) GetOrganization(ctx context.Context, organizationId, userId uint64) (*t.Organization, error) {
+	return getDummyStruct[t.Organization](ctx)
+}
+
+func (d *DummyService) CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error) {
+	return getDummyStruct[t.Organization](ctx)
+}
+
+func (d *DummyService) UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveOrganization(ctx context.Context, organizationId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOrganizationMemberRole(ctx context.Context, organizationId, userId uint64) (string, error) {
+	return t.OrganizationRoleOwner, nil
+}
+
+func (d *DummyService) GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error) {
+	return getDummyData[[]t.OrganizationMember](ctx)
+}
+
+func (d *DummyService) AddOrganizationMember(ctx context.Context, organizationId, userId uint64, role string) error {
+	return nil
+}
+
+func (d *DummyService) UpdateOrganizationMemberRole(ctx context.Context, organizationId, userId uint64, role string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveOrganizationMember(ctx context.Context, organizationId, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error) {
+	return getDummyStruct[t.PremiumPerks](ctx)
+}
+
+func (d *DummyService) GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error {
+	return nil
+}
+
 func (d *DummyService) AddMobileNotificationToken(ctx context.Context, userID uint64, deviceID, notifyToken string) error {
 	return nil
 }
@@ -734,8 +953,8 @@ func (d *DummyService) GetSlotBlobs(ctx context.Context, chainId, block uint64)
 	return getDummyData[[]t.BlockBlobTableRow](ctx)
 }
 
-func (d *DummyService) GetValidatorDashboardsCountInfo(ctx context.Context) (map[uint64][]t.ArchiverDashboard, error) {
-	return getDummyData[map[uint64][]t.ArchiverDashboard](ctx)
+func (d *DummyService) GetValidatorDashboardsCountInfo(ctx context.Context) (map[t.ArchiverDashboardOwner][]t.ArchiverDashboard, error) {
+	return getDummyData[map[t.ArchiverDashboardOwner][]t.ArchiverDashboard](ctx)
 }
 
 func (d *DummyService) GetRocketPoolOverview(ctx context.Context) (*t.RocketPoolData, error) {
@@ -746,6 +965,10 @@ func (d *DummyService) GetApiWeights(ctx context.Context) ([]t.ApiWeightItem, er
 	return getDummyData[[]t.ApiWeightItem](ctx)
 }
 
+func (d *DummyService) GetUserApiUsage(ctx context.Context, userId uint64) (*t.ApiUsageData, error) {
+	return getDummyStruct[t.ApiUsageData](ctx)
+}
+
 func (d *DummyService) GetHealthz(ctx context.Context, showAll bool) t.HealthzData {
 	r, _ := getDummyData[t.HealthzData](ctx)
 	return r
@@ -780,6 +1003,28 @@ func (d *DummyService) GetUserMachineMetrics(ctx context.Context, userID uint64,
 	return data, nil
 }
 
+func (d *DummyService) GetUserMachineMetricsHistory(ctx context.Context, userID uint64, afterTs uint64, beforeTs uint64, resolution enums.MachineMetricsResolution) (*t.MachineMetricsHistoryData, error) {
+	data, err := getDummyStruct[t.MachineMetricsHistoryData](ctx)
+	if err != nil {
+		return nil, err
+	}
+	data.Resolution = uint64(resolution.Duration().Seconds())
+	data.SystemMetrics = slices.SortedFunc(slices.Values(data.SystemMetrics), func(i, j t.MachineMetricsSystemPoint) int {
+		return int(i.Timestamp - j.Timestamp)
+	})
+	data.ValidatorMetrics = slices.SortedFunc(slices.Values(data.ValidatorMetrics), func(i, j t.MachineMetricsValidatorPoint) int {
+		return int(i.Timestamp - j.Timestamp)
+	})
+	data.NodeMetrics = slices.SortedFunc(slices.Values(data.NodeMetrics), func(i, j t.MachineMetricsNodePoint) int {
+		return int(i.Timestamp - j.Timestamp)
+	})
+	return data, nil
+}
+
+func (d *DummyService) GetUserMachineClients(ctx context.Context, userID uint64) ([]t.MachineClientVersion, error) {
+	return getDummyData[[]t.MachineClientVersion](ctx)
+}
+
 func (d *DummyService) PostUserMachineMetrics(ctx context.Context, userID uint64, machine, process string, data []byte) error {
 	return nil
 }
@@ -1,11 +1,16 @@
 package dataaccess
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	apiTypes "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/ethclients"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -13,7 +18,56 @@ import (
 
 type MachineRepository interface {
 	GetUserMachineMetrics(context context.Context, userID uint64, limit int, offset int) (*apiTypes.MachineMetricsData, error)
+	GetUserMachineMetricsHistory(context context.Context, userID uint64, afterTs uint64, beforeTs uint64, resolution enums.MachineMetricsResolution) (*apiTypes.MachineMetricsHistoryData, error)
 	PostUserMachineMetrics(context context.Context, userID uint64, machine, process string, data []byte) error
+	GetUserMachineClients(context context.Context, userID uint64) ([]apiTypes.MachineClientVersion, error)
+}
+
+// GetUserMachineClients returns the clients the user's machines reported last, compared against the latest known releases
+func (d *DataAccessService) GetUserMachineClients(ctx context.Context, userID uint64) ([]apiTypes.MachineClientVersion, error) {
+	reported, err := d.bigtable.GetMachineMetricsClients(types.UserId(userID))
+	if err != nil {
+		return nil, err
+	}
+	clients, err := d.GetAllClients()
+	if err != nil {
+		return nil, err
+	}
+	clientsByDbName := make(map[string]apiTypes.ClientInfo, len(clients))
+	dbNames := make([]string, 0, len(clients))
+	for _, client := range clients {
+		clientsByDbName[client.DbName] = client
+		dbNames = append(dbNames, client.DbName)
+	}
+
+	result := make([]apiTypes.MachineClientVersion, 0, len(reported))
+	for _, r := range reported {
+		row := apiTypes.MachineClientVersion{
+			Machine:        r.Machine,
+			Process:        r.Process,
+			ClientName:     r.ClientName,
+			RunningVersion: r.ClientVersion,
+			LastSeen:       r.InsertTs,
+		}
+		dbName := ethclients.MatchClient(r.ClientName, dbNames)
+		if client, ok := clientsByDbName[dbName]; ok {
+			row.ClientId = &client.Id
+			row.ClientName = client.Name
+			row.Category = client.Category
+		}
+		if status := ethclients.GetClientVersionStatus(dbName, r.ClientVersion); status != nil {
+			row.RunningVersion = status.RunningVersion
+			row.LatestVersion = status.LatestVersion
+			row.RequiredVersion = status.RequiredVersion
+			row.IsOutdated = status.IsOutdated
+			row.IsForkIncompatible = status.IsForkIncompatible
+		}
+		result = append(result, row)
+	}
+	slices.SortFunc(result, func(a, b apiTypes.MachineClientVersion) int {
+		return cmp.Or(cmp.Compare(a.Machine, b.Machine), cmp.Compare(a.Process, b.Process))
+	})
+	return result, nil
 }
 
 func (d *DataAccessService) GetUserMachineMetrics(ctx context.Context, userID uint64, limit int, offset int) (*apiTypes.MachineMetricsData, error) {
@@ -39,6 +93,83 @@ func (d *DataAccessService) GetUserMachineMetrics(ctx context.Context, userID ui
 		return err
 	})
 
+	g.Go(func() error {
+		var err error
+		data.ExecutionMetrics, err = d.bigtable.GetMachineMetricsExecution(types.UserId(userID), limit, offset)
+		return err
+	})
+
+	g.Go(func() error {
+		var err error
+		data.SlasherMetrics, err = d.bigtable.GetMachineMetricsSlasher(types.UserId(userID), limit, offset)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, errors.Wrap(err, "could not get stats")
+	}
+
+	return data, nil
+}
+
+func (d *DataAccessService) GetUserMachineMetricsHistory(ctx context.Context, userID uint64, afterTs uint64, beforeTs uint64, resolution enums.MachineMetricsResolution) (*apiTypes.MachineMetricsHistoryData, error) {
+	step := resolution.Duration()
+	data := &apiTypes.MachineMetricsHistoryData{
+		Resolution: uint64(step.Seconds()),
+	}
+
+	// fetch the preceding points as well so rates can be computed for the first point in range
+	from := time.Unix(int64(afterTs), 0).Add(-2 * step)
+	to := time.Unix(int64(beforeTs), 0)
+	afterMs := uint64(afterTs) * 1000
+
+	g := errgroup.Group{}
+
+	g.Go(func() error {
+		metrics, err := d.bigtable.GetMachineMetricsSystemRange(types.UserId(userID), from, to, step)
+		if err != nil {
+			return err
+		}
+		data.SystemMetrics = computeMachineMetricPoints(metrics, afterMs, convertMachineMetricSystem)
+		return nil
+	})
+
+	g.Go(func() error {
+		metrics, err := d.bigtable.GetMachineMetricsValidatorRange(types.UserId(userID), from, to, step)
+		if err != nil {
+			return err
+		}
+		data.ValidatorMetrics = computeMachineMetricPoints(metrics, afterMs, convertMachineMetricValidator)
+		return nil
+	})
+
+	g.Go(func() error {
+		metrics, err := d.bigtable.GetMachineMetricsNodeRange(types.UserId(userID), from, to, step)
+		if err != nil {
+			return err
+		}
+		data.NodeMetrics = computeMachineMetricPoints(metrics, afterMs, convertMachineMetricNode)
+		return nil
+	})
+
+	g.Go(func() error {
+		metrics, err := d.bigtable.GetMachineMetricsExecutionRange(types.UserId(userID), from, to, step)
+		if err != nil {
+			return err
+		}
+		data.ExecutionMetrics = computeMachineMetricPoints(metrics, afterMs, convertMachineMetricExecution)
+		return nil
+	})
+
+	g.Go(func() error {
+		metrics, err := d.bigtable.GetMachineMetricsSlasherRange(types.UserId(userID), from, to, step)
+		if err != nil {
+			return err
+		}
+		data.SlasherMetrics = computeMachineMetricPoints(metrics, afterMs, convertMachineMetricSlasher)
+		return nil
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, errors.Wrap(err, "could not get stats")
 	}
@@ -46,6 +177,139 @@ func (d *DataAccessService) GetUserMachineMetrics(ctx context.Context, userID ui
 	return data, nil
 }
 
+type machineMetric interface {
+	GetMachine() string
+	GetTimestamp() uint64
+}
+
+// computeMachineMetricPoints sorts the samples by machine and time and converts each sample using its predecessor
+// of the same machine, samples before afterMs are only used as predecessors
+func computeMachineMetricPoints[T machineMetric, P any](samples []T, afterMs uint64, convert func(curr, prev T, seconds float64) P) []P {
+	slices.SortFunc(samples, func(a, b T) int {
+		if c := cmp.Compare(a.GetMachine(), b.GetMachine()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.GetTimestamp(), b.GetTimestamp())
+	})
+
+	res := make([]P, 0, len(samples))
+	for i, curr := range samples {
+		if curr.GetTimestamp() < afterMs {
+			continue
+		}
+		var prev T
+		seconds := 0.0
+		if i > 0 && samples[i-1].GetMachine() == curr.GetMachine() {
+			prev = samples[i-1]
+			seconds = float64(curr.GetTimestamp()-prev.GetTimestamp()) / 1000
+		}
+		res = append(res, convert(curr, prev, seconds))
+	}
+	return res
+}
+
+// counterRate returns the per second increase of a counter, counter resets and missing predecessors result in 0
+func counterRate(curr, prev uint64, seconds float64) float64 {
+	if seconds <= 0 || curr < prev {
+		return 0
+	}
+	return float64(curr-prev) / seconds
+}
+
+func convertMachineMetricSystem(curr, prev *types.MachineMetricSystem, seconds float64) apiTypes.MachineMetricsSystemPoint {
+	point := apiTypes.MachineMetricsSystemPoint{
+		Machine:          curr.GetMachine(),
+		Timestamp:        int64(curr.Timestamp / 1000),
+		CpuCores:         curr.CpuCores,
+		CpuThreads:       curr.CpuThreads,
+		MemoryTotalBytes: curr.MemoryNodeBytesTotal,
+		DiskTotalBytes:   curr.DiskNodeBytesTotal,
+		DiskFreeBytes:    curr.DiskNodeBytesFree,
+		MiscOs:           curr.MiscOs,
+	}
+	if unavailable := curr.MemoryNodeBytesFree + curr.MemoryNodeBytesCached + curr.MemoryNodeBytesBuffers; unavailable < curr.MemoryNodeBytesTotal {
+		point.MemoryUsedBytes = curr.MemoryNodeBytesTotal - unavailable
+	}
+	if prev == nil || seconds <= 0 {
+		return point
+	}
+
+	system := counterRate(curr.CpuNodeSystemSecondsTotal, prev.CpuNodeSystemSecondsTotal, seconds)
+	user := counterRate(curr.CpuNodeUserSecondsTotal, prev.CpuNodeUserSecondsTotal, seconds)
+	iowait := counterRate(curr.CpuNodeIowaitSecondsTotal, prev.CpuNodeIowaitSecondsTotal, seconds)
+	idle := counterRate(curr.CpuNodeIdleSecondsTotal, prev.CpuNodeIdleSecondsTotal, seconds)
+	if total := system + user + iowait + idle; total > 0 {
+		point.CpuUsage = 1 - idle/total
+		point.CpuIowait = iowait / total
+	}
+
+	point.DiskReadsPerSecond = counterRate(curr.DiskNodeReadsTotal, prev.DiskNodeReadsTotal, seconds)
+	point.DiskWritesPerSecond = counterRate(curr.DiskNodeWritesTotal, prev.DiskNodeWritesTotal, seconds)
+	point.DiskIoUtilization = min(counterRate(curr.DiskNodeIoSeconds, prev.DiskNodeIoSeconds, seconds), 1)
+	point.NetworkReceiveBytesPerSecond = counterRate(curr.NetworkNodeBytesTotalReceive, prev.NetworkNodeBytesTotalReceive, seconds)
+	point.NetworkTransmitBytesPerSecond = counterRate(curr.NetworkNodeBytesTotalTransmit, prev.NetworkNodeBytesTotalTransmit, seconds)
+	return point
+}
+
+func convertMachineMetricProcess(machine string, timestamp uint64, clientName, clientVersion string, cpuSeconds, prevCpuSeconds uint64, memory uint64, seconds float64) apiTypes.MachineMetricsProcessPoint {
+	return apiTypes.MachineMetricsProcessPoint{
+		Machine:       machine,
+		Timestamp:     int64(timestamp / 1000),
+		ClientName:    clientName,
+		ClientVersion: clientVersion,
+		CpuUsage:      counterRate(cpuSeconds, prevCpuSeconds, seconds),
+		MemoryBytes:   memory,
+	}
+}
+
+func convertMachineMetricValidator(curr, prev *types.MachineMetricValidator, seconds float64) apiTypes.MachineMetricsValidatorPoint {
+	return apiTypes.MachineMetricsValidatorPoint{
+		MachineMetricsProcessPoint: convertMachineMetricProcess(curr.GetMachine(), curr.Timestamp, curr.ClientName, curr.ClientVersion,
+			curr.CpuProcessSecondsTotal, prev.GetCpuProcessSecondsTotal(), curr.MemoryProcessBytes, seconds),
+		ValidatorTotal:  curr.ValidatorTotal,
+		ValidatorActive: curr.ValidatorActive,
+	}
+}
+
+func convertMachineMetricNode(curr, prev *types.MachineMetricNode, seconds float64) apiTypes.MachineMetricsNodePoint {
+	return apiTypes.MachineMetricsNodePoint{
+		MachineMetricsProcessPoint: convertMachineMetricProcess(curr.GetMachine(), curr.Timestamp, curr.ClientName, curr.ClientVersion,
+			curr.CpuProcessSecondsTotal, prev.GetCpuProcessSecondsTotal(), curr.MemoryProcessBytes, seconds),
+		Synced:                        curr.SyncEth2Synced,
+		HeadSlot:                      curr.SyncBeaconHeadSlot,
+		PeersConnected:                curr.NetworkPeersConnected,
+		DiskBytes:                     curr.DiskBeaconchainBytesTotal,
+		NetworkReceiveBytesPerSecond:  counterRate(curr.NetworkLibp2PBytesTotalReceive, prev.GetNetworkLibp2PBytesTotalReceive(), seconds),
+		NetworkTransmitBytesPerSecond: counterRate(curr.NetworkLibp2PBytesTotalTransmit, prev.GetNetworkLibp2PBytesTotalTransmit(), seconds),
+	}
+}
+
+func convertMachineMetricExecution(curr, prev *types.MachineMetricExecution, seconds float64) apiTypes.MachineMetricsExecutionPoint {
+	return apiTypes.MachineMetricsExecutionPoint{
+		MachineMetricsProcessPoint: convertMachineMetricProcess(curr.GetMachine(), curr.Timestamp, curr.ClientName, curr.ClientVersion,
+			curr.CpuProcessSecondsTotal, prev.GetCpuProcessSecondsTotal(), curr.MemoryProcessBytes, seconds),
+		Synced:             curr.SyncExecutionSynced,
+		HeadBlock:          curr.SyncExecutionHeadBlock,
+		PeersConnected:     curr.NetworkPeersConnected,
+		TxPoolTransactions: curr.TxpoolTransactions,
+		DiskBytes:          curr.DiskExecutionBytesTotal,
+	}
+}
+
+func convertMachineMetricSlasher(curr, prev *types.MachineMetricSlasher, seconds float64) apiTypes.MachineMetricsSlasherPoint {
+	return apiTypes.MachineMetricsSlasherPoint{
+		MachineMetricsProcessPoint: convertMachineMetricProcess(curr.GetMachine(), curr.Timestamp, curr.ClientName, curr.ClientVersion,
+			curr.CpuProcessSecondsTotal, prev.GetCpuProcessSecondsTotal(), curr.MemoryProcessBytes, seconds),
+		Synced:                      curr.SyncSlasherSynced,
+		HeadEpoch:                   curr.SyncSlasherHeadEpoch,
+		AttestationsPerSecond:       counterRate(curr.SlasherAttestationsProcessedTotal, prev.GetSlasherAttestationsProcessedTotal(), seconds),
+		BlocksPerSecond:             counterRate(curr.SlasherBlocksProcessedTotal, prev.GetSlasherBlocksProcessedTotal(), seconds),
+		AttesterSlashingsFoundTotal: curr.SlasherAttesterSlashingsFoundTotal,
+		ProposerSlashingsFoundTotal: curr.SlasherProposerSlashingsFoundTotal,
+		DiskBytes:                   curr.DiskSlasherBytesTotal,
+	}
+}
+
 func (d *DataAccessService) PostUserMachineMetrics(ctx context.Context, userID uint64, machine, process string, data []byte) error {
 	err := db.BigtableClient.SaveMachineMetric(process, types.UserId(userID), machine, data)
 	if err != nil {
@@ -285,7 +285,7 @@ func (d *DataAccessService) GetValidatorDashboardMobileWidget(ctx context.Contex
 	retrieveEfficiency := func(table string, efficiency *float64) {
 		eg.Go(func() error {
 			ds := goqu.Dialect("postgres").
-				From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, table))).
+				From(goqu.L(d.dashboardStorage.RollingTable(table, "r"))).
 				With("validators", goqu.L("(SELECT dashboard_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId)).
 				Select(
 					goqu.L("COALESCE(SUM(r.attestations_reward)::decimal, 0) AS attestations_reward"),
@@ -311,7 +311,7 @@ func (d *DataAccessService) GetValidatorDashboardMobileWidget(ctx context.Contex
 				return fmt.Errorf("error preparing query: %w", err)
 			}
 
-			err = d.clickhouseReader.GetContext(ctx, &queryResult, query, args...)
+			err = d.dashboardStorage.Reader().GetContext(ctx, &queryResult, query, args...)
 			if err != nil {
 				return err
 			}
@@ -491,7 +491,7 @@ func (d *DataAccessService) GetValidatorDashboardMobileValidators(ctx context.Co
 
 func (d *DataAccessService) getIndividualEfficiencies(ctx context.Context, indices []uint64, table string) (map[uint64]float64, error) {
 	ds := goqu.Dialect("postgres").
-		From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, table))).
+		From(goqu.L(d.dashboardStorage.RollingTable(table, "r"))).
 		Select(
 			goqu.L("r.validator_index"),
 			goqu.L("COALESCE(r.attestations_reward::decimal, 0) AS attestations_reward"),
@@ -517,7 +517,7 @@ func (d *DataAccessService) getIndividualEfficiencies(ctx context.Context, indic
 		return nil, fmt.Errorf("error preparing query: %w", err)
 	}
 
-	err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+	err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +45,8 @@ type NotificationsRepository interface {
 	GetMachineNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationMachinesColumn], search string, limit uint64) ([]t.NotificationMachinesTableRow, *t.Paging, error)
 	GetClientNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationClientsColumn], search string, limit uint64) ([]t.NotificationClientsTableRow, *t.Paging, error)
 	GetNetworkNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationNetworksColumn], limit uint64) ([]t.NotificationNetworksTableRow, *t.Paging, error)
+	GetNotificationIncidents(ctx context.Context, userId uint64, status types.NotificationIncidentStatus, cursor string, colSort t.Sort[enums.NotificationIncidentsColumn], limit uint64) ([]t.NotificationIncidentsTableRow, *t.Paging, error)
+	AcknowledgeNotificationIncident(ctx context.Context, userId uint64, incidentId uint64) error
 
 	GetNotificationSettings(ctx context.Context, userId uint64) (*t.NotificationSettings, error)
 	GetNotificationSettingsDefaultValues(ctx context.Context) (*t.NotificationSettingsDefaultValues, error)
@@ -57,6 +59,13 @@ type NotificationsRepository interface {
 	GetNotificationSettingsDashboards(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationSettingsDashboardColumn], search string, limit uint64) ([]t.NotificationSettingsDashboardsTableRow, *t.Paging, error)
 	UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error
 	UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error
+	GetNotificationRulesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationRuleValidatorDashboard, error)
+	CreateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error)
+	UpdateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error)
+	DeleteNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, ruleId uint64) error
+	GetNotificationSnoozesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationSnoozeValidatorDashboard, error)
+	CreateNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, eventType *types.EventName, snoozedUntil time.Time) (*t.NotificationSnoozeValidatorDashboard, error)
+	DeleteNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, snoozeId uint64) error
 
 	QueueTestEmailNotification(ctx context.Context, userId uint64) error
 	QueueTestPushNotification(ctx context.Context, userId uint64) error
@@ -69,9 +78,11 @@ func (*DataAccessService) registerNotificationInterfaceTypes() {
 		gob.Register(&n.ValidatorProposalNotification{})
 		gob.Register(&n.ValidatorUpcomingProposalNotification{})
 		gob.Register(&n.ValidatorGroupEfficiencyNotification{})
+		gob.Register(&n.ValidatorDashboardRuleNotification{})
 		gob.Register(&n.ValidatorAttestationNotification{})
 		gob.Register(&n.ValidatorIsOfflineNotification{})
 		gob.Register(&n.ValidatorIsOnlineNotification{})
+		gob.Register(&n.ValidatorIsUnstableNotification{})
 		gob.Register(&n.ValidatorGotSlashedNotification{})
 		gob.Register(&n.ValidatorWithdrawalNotification{})
 		gob.Register(&n.NetworkNotification{})
@@ -98,6 +109,12 @@ const (
 	MachineCpuUsageThresholdDefault     float64 = 0.6
 	MachineMemoryUsageThresholdDefault  float64 = 0.8
 
+	MachineNotSyncedThresholdDefault      float64 = 5 // minutes
+	MachineElDisconnectedThresholdDefault float64 = 5 // minutes
+	MachineLowPeerCountThresholdDefault   float64 = 20
+	MachineHeadSlotLagThresholdDefault    float64 = 32
+	MachineFallbackInUseThresholdDefault  float64 = 5 // minutes
+
 	GasAboveThresholdDefault          float64 = 950
 	GasBelowThresholdDefault          float64 = 150
 	ParticipationRateThresholdDefault float64 = 0.8
@@ -411,8 +428,12 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 		Sync:                     []uint64{},
 		AttestationMissed:        []t.IndexEpoch{},
 		Withdrawal:               []t.NotificationEventWithdrawal{},
+		PartialWithdrawal:        []t.NotificationEventWithdrawal{},
+		Consolidation:            []t.NotificationEventConsolidation{},
 		ValidatorOfflineReminder: []uint64{},
 		ValidatorOnline:          []t.NotificationEventValidatorBackOnline{},
+		ValidatorUnstable:        []t.NotificationEventValidatorUnstable{},
+		CustomRules:              []t.NotificationEventValidatorDashboardRule{},
 		MinCollateral:            []t.Address{},
 		MaxCollateral:            []t.Address{},
 	}
@@ -513,6 +534,19 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					return nil, fmt.Errorf("failed to cast notification to ValidatorGroupEfficiencyNotification")
 				}
 				notificationDetails.GroupEfficiencyBelow = curNotification.Threshold
+			case types.ValidatorDashboardRuleEventName:
+				curNotification, ok := notification.(*n.ValidatorDashboardRuleNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorDashboardRuleNotification")
+				}
+				notificationDetails.CustomRules = append(notificationDetails.CustomRules, t.NotificationEventValidatorDashboardRule{
+					RuleId:     curNotification.RuleId,
+					Name:       curNotification.RuleName,
+					Metric:     string(curNotification.Metric),
+					Comparison: string(curNotification.Comparison),
+					Threshold:  curNotification.Threshold,
+					Value:      curNotification.Value,
+				})
 			case types.ValidatorMissedProposalEventName, types.ValidatorExecutedProposalEventName /*, types.ValidatorScheduledProposalEventName*/ :
 				// aggregate proposals
 				curNotification, ok := notification.(*n.ValidatorProposalNotification)
@@ -584,6 +618,15 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					continue
 				}
 				notificationDetails.ValidatorOnline = append(notificationDetails.ValidatorOnline, t.NotificationEventValidatorBackOnline{Index: curNotification.ValidatorIndex, EpochCount: curNotification.Epoch})
+			case types.ValidatorIsUnstableEventName:
+				curNotification, ok := notification.(*n.ValidatorIsUnstableNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorIsUnstableNotification")
+				}
+				if searchEnabled && !searchIndexSet[curNotification.ValidatorIndex] {
+					continue
+				}
+				notificationDetails.ValidatorUnstable = append(notificationDetails.ValidatorUnstable, t.NotificationEventValidatorUnstable{Index: curNotification.ValidatorIndex, Transitions: curNotification.Transitions})
 			case types.ValidatorReceivedWithdrawalEventName:
 				curNotification, ok := notification.(*n.ValidatorWithdrawalNotification)
 				if !ok {
@@ -606,12 +649,51 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					Amount:  decimal.NewFromUint64(curNotification.Amount).Mul(decimal.NewFromFloat(params.GWei)), // Amounts have to be in WEI
 					Address: addr,
 				})
+			case types.ValidatorPartialWithdrawalEventName:
+				curNotification, ok := notification.(*n.ValidatorPartialWithdrawalNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorPartialWithdrawalNotification")
+				}
+				if searchEnabled && !searchIndexSet[curNotification.ValidatorIndex] {
+					continue
+				}
+				contractStatusRequests = append(contractStatusRequests, db.ContractInteractionAtRequest{
+					Address:  fmt.Sprintf("%x", curNotification.Address),
+					Block:    int64(latestBlocks[curNotification.Slot%utils.Config.Chain.ClConfig.SlotsPerEpoch]),
+					TxIdx:    -1,
+					TraceIdx: -1,
+				})
+				addr := t.Address{Hash: t.Hash(hexutil.Encode(curNotification.Address))}
+				addressMapping[hexutil.Encode(curNotification.Address)] = &addr
+				notificationDetails.PartialWithdrawal = append(notificationDetails.PartialWithdrawal, t.NotificationEventWithdrawal{
+					Index:   curNotification.ValidatorIndex,
+					Amount:  decimal.NewFromUint64(curNotification.Amount).Mul(decimal.NewFromFloat(params.GWei)), // Amounts have to be in WEI
+					Address: addr,
+				})
+			case types.ValidatorConsolidationEventName:
+				curNotification, ok := notification.(*n.ValidatorConsolidationNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorConsolidationNotification")
+				}
+				if searchEnabled && !searchIndexSet[curNotification.ValidatorIndex] {
+					continue
+				}
+				notificationDetails.Consolidation = append(notificationDetails.Consolidation, t.NotificationEventConsolidation{
+					SourceIndex: curNotification.SourceValidatorIndex,
+					TargetIndex: curNotification.TargetValidatorIndex,
+				})
 			case types.NetworkLivenessIncreasedEventName,
 				types.EthClientUpdateEventName,
 				types.MonitoringMachineOfflineEventName,
 				types.MonitoringMachineDiskAlmostFullEventName,
 				types.MonitoringMachineCpuLoadEventName,
 				types.MonitoringMachineMemoryUsageEventName,
+				types.MonitoringMachineNotSyncedEventName,
+				types.MonitoringMachineElDisconnectedEventName,
+				types.MonitoringMachineLowPeerCountEventName,
+				types.MonitoringMachineHeadSlotLagEventName,
+				types.MonitoringMachineFallbackInUseEventName,
+				types.MonitoringMachineClientOutdatedEventName,
 				types.TaxReportEventName:
 				// not vdb notifications, skip
 			case types.ValidatorDidSlashEventName:
@@ -813,6 +895,180 @@ func (d *DataAccessService) GetMachineNotifications(ctx context.Context, userId
 
 	return result, p, nil
 }
+func (d *DataAccessService) GetNotificationIncidents(ctx context.Context, userId uint64, status types.NotificationIncidentStatus, cursor string, colSort t.Sort[enums.NotificationIncidentsColumn], limit uint64) ([]t.NotificationIncidentsTableRow, *t.Paging, error) {
+	result := make([]t.NotificationIncidentsTableRow, 0)
+	var paging t.Paging
+
+	// Initialize the cursor
+	var currentCursor t.NotificationIncidentsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NotificationIncidentsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NotificationIncidentsCursor: %w", err)
+		}
+	}
+
+	// -------------------------------------
+	// Get the incidents of the users dashboards
+	incidents := []struct {
+		Id             uint64          `db:"id"`
+		DashboardId    uint64          `db:"dashboard_id"`
+		DashboardName  string          `db:"dashboard_name"`
+		GroupId        uint64          `db:"group_id"`
+		GroupName      string          `db:"group_name"`
+		EventType      types.EventName `db:"event_type"`
+		EntityId       string          `db:"entity_id"`
+		Status         string          `db:"status"`
+		OpenedEpoch    uint64          `db:"opened_epoch"`
+		ResolvedEpoch  sql.NullInt64   `db:"resolved_epoch"`
+		AcknowledgedAt sql.NullTime    `db:"acknowledged_at"`
+	}{}
+
+	// use a subquery so the sort columns can be referenced without table prefix
+	incidentsDs := goqu.Dialect("postgres").
+		Select(
+			goqu.I("i.id"),
+			goqu.I("i.dashboard_id"),
+			goqu.I("uvd.name").As("dashboard_name"),
+			goqu.I("i.group_id"),
+			goqu.I("g.name").As("group_name"),
+			goqu.I("i.event_type"),
+			goqu.I("i.entity_id"),
+			goqu.I("i.status"),
+			goqu.I("i.opened_epoch"),
+			goqu.I("i.resolved_epoch"),
+			goqu.I("i.acknowledged_at")).
+		From(goqu.T("users_val_dashboards_notification_incidents").As("i")).
+		InnerJoin(goqu.T("users_val_dashboards").As("uvd"), goqu.On(goqu.I("uvd.id").Eq(goqu.I("i.dashboard_id")))).
+		InnerJoin(goqu.T("users_val_dashboards_groups").As("g"), goqu.On(
+			goqu.I("g.dashboard_id").Eq(goqu.I("i.dashboard_id")),
+			goqu.I("g.id").Eq(goqu.I("i.group_id")),
+		)).
+		Where(goqu.I("uvd.user_id").Eq(userId))
+	if status != "" {
+		incidentsDs = incidentsDs.Where(goqu.I("i.status").Eq(status))
+	}
+
+	ds := goqu.Dialect("postgres").
+		From(incidentsDs.As("incidents")).
+		Limit(uint(limit + 1))
+
+	// Sorting and limiting if cursor is present
+	// Rows can be uniquely identified by id
+	defaultColumns := []t.SortColumn{
+		{Column: enums.NotificationIncidentsColumns.OpenedEpoch.ToExpr(), Desc: true, Offset: currentCursor.OpenedEpoch},
+		{Column: enums.NotificationIncidentsColumns.Id.ToExpr(), Desc: true, Offset: currentCursor.Id},
+	}
+	var offset interface{}
+	switch colSort.Column {
+	case enums.NotificationIncidentsColumns.DashboardName:
+		offset = currentCursor.DashboardName
+	case enums.NotificationIncidentsColumns.EventType:
+		offset = currentCursor.EventType
+	}
+
+	order, directions, err := applySortAndPagination(defaultColumns, t.SortColumn{Column: colSort.Column.ToExpr(), Desc: colSort.Desc, Offset: offset}, currentCursor.GenericCursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	ds = ds.Order(order...)
+	if directions != nil {
+		ds = ds.Where(directions)
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing notification incidents query: %w", err)
+	}
+
+	err = d.alloyReader.SelectContext(ctx, &incidents, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`error retrieving data for notification incidents: %w`, err)
+	}
+
+	// -------------------------------------
+	// Calculate the result
+	cursorData := make([]t.NotificationIncidentsCursor, 0, len(incidents))
+	for _, incident := range incidents {
+		index, err := strconv.ParseUint(incident.EntityId, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing validator index of notification incident %v: %w", incident.Id, err)
+		}
+		row := t.NotificationIncidentsTableRow{
+			Id:             incident.Id,
+			DashboardId:    incident.DashboardId,
+			DashboardName:  incident.DashboardName,
+			GroupId:        incident.GroupId,
+			GroupName:      incident.GroupName,
+			EventType:      string(incident.EventType),
+			Index:          index,
+			Status:         incident.Status,
+			OpenedEpoch:    incident.OpenedEpoch,
+			IsAcknowledged: incident.AcknowledgedAt.Valid,
+		}
+		if incident.ResolvedEpoch.Valid {
+			resolvedEpoch := uint64(incident.ResolvedEpoch.Int64)
+			row.ResolvedEpoch = &resolvedEpoch
+		}
+		result = append(result, row)
+		cursorData = append(cursorData, t.NotificationIncidentsCursor{
+			Id:            incident.Id,
+			OpenedEpoch:   incident.OpenedEpoch,
+			DashboardName: incident.DashboardName,
+			EventType:     string(incident.EventType),
+		})
+	}
+
+	// -------------------------------------
+	// Paging
+
+	// Flag if above limit
+	moreDataFlag := len(result) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+
+	// Remove the last entries from data
+	if moreDataFlag {
+		result = result[:limit]
+		cursorData = cursorData[:limit]
+	}
+
+	if currentCursor.IsReverse() {
+		slices.Reverse(result)
+		slices.Reverse(cursorData)
+	}
+
+	p, err := utils.GetPagingFromData(cursorData, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return result, p, nil
+}
+
+func (d *DataAccessService) AcknowledgeNotificationIncident(ctx context.Context, userId uint64, incidentId uint64) error {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_val_dashboards_notification_incidents i
+		SET acknowledged_at = COALESCE(i.acknowledged_at, NOW())
+		FROM users_val_dashboards uvd
+		WHERE uvd.id = i.dashboard_id AND uvd.user_id = $1 AND i.id = $2`,
+		userId, incidentId)
+	if err != nil {
+		return fmt.Errorf("error acknowledging notification incident: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: notification incident %v not found", ErrNotFound, incidentId)
+	}
+	return nil
+}
+
 func (d *DataAccessService) GetClientNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationClientsColumn], search string, limit uint64) ([]t.NotificationClientsTableRow, *t.Paging, error) {
 	result := make([]t.NotificationClientsTableRow, 0)
 	var paging t.Paging
@@ -1035,9 +1291,14 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 	// Create the default settings
 	result := &t.NotificationSettings{
 		GeneralSettings: t.NotificationSettingsGeneral{
-			MachineStorageUsageThreshold: MachineStorageUsageThresholdDefault,
-			MachineCpuUsageThreshold:     MachineCpuUsageThresholdDefault,
-			MachineMemoryUsageThreshold:  MachineMemoryUsageThresholdDefault,
+			MachineStorageUsageThreshold:   MachineStorageUsageThresholdDefault,
+			MachineCpuUsageThreshold:       MachineCpuUsageThresholdDefault,
+			MachineMemoryUsageThreshold:    MachineMemoryUsageThresholdDefault,
+			MachineNotSyncedThreshold:      MachineNotSyncedThresholdDefault,
+			MachineElDisconnectedThreshold: MachineElDisconnectedThresholdDefault,
+			MachineLowPeerCountThreshold:   MachineLowPeerCountThresholdDefault,
+			MachineHeadSlotLagThreshold:    MachineHeadSlotLagThresholdDefault,
+			MachineFallbackInUseThreshold:  MachineFallbackInUseThresholdDefault,
 		},
 	}
 
@@ -1231,6 +1492,24 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 			case types.MonitoringMachineMemoryUsageEventName:
 				result.GeneralSettings.IsMachineMemoryUsageSubscribed = true
 				result.GeneralSettings.MachineMemoryUsageThreshold = event.Threshold
+			case types.MonitoringMachineNotSyncedEventName:
+				result.GeneralSettings.IsMachineNotSyncedSubscribed = true
+				result.GeneralSettings.MachineNotSyncedThreshold = event.Threshold
+			case types.MonitoringMachineElDisconnectedEventName:
+				result.GeneralSettings.IsMachineElDisconnectedSubscribed = true
+				result.GeneralSettings.MachineElDisconnectedThreshold = event.Threshold
+			case types.MonitoringMachineLowPeerCountEventName:
+				result.GeneralSettings.IsMachineLowPeerCountSubscribed = true
+				result.GeneralSettings.MachineLowPeerCountThreshold = event.Threshold
+			case types.MonitoringMachineHeadSlotLagEventName:
+				result.GeneralSettings.IsMachineHeadSlotLagSubscribed = true
+				result.GeneralSettings.MachineHeadSlotLagThreshold = event.Threshold
+			case types.MonitoringMachineFallbackInUseEventName:
+				result.GeneralSettings.IsMachineFallbackInUseSubscribed = true
+				result.GeneralSettings.MachineFallbackInUseThreshold = event.Threshold
+			case types.MonitoringMachineClientOutdatedEventName:
+				result.GeneralSettings.IsMachineClientOutdatedSubscribed = true
+				result.GeneralSettings.MachineClientOutdatedOnlyMandatory = event.Threshold >= 1
 			case types.EthClientUpdateEventName:
 				if clientSettings[event.Filter] != nil {
 					clientSettings[event.Filter].IsSubscribed = true
@@ -1283,6 +1562,12 @@ func (d *DataAccessService) GetNotificationSettingsDefaultValues(ctx context.Con
 		MachineCpuUsageThreshold:     MachineCpuUsageThresholdDefault,
 		MachineMemoryUsageThreshold:  MachineMemoryUsageThresholdDefault,
 
+		MachineNotSyncedThreshold:      MachineNotSyncedThresholdDefault,
+		MachineElDisconnectedThreshold: MachineElDisconnectedThresholdDefault,
+		MachineLowPeerCountThreshold:   MachineLowPeerCountThresholdDefault,
+		MachineHeadSlotLagThreshold:    MachineHeadSlotLagThresholdDefault,
+		MachineFallbackInUseThreshold:  MachineFallbackInUseThresholdDefault,
+
 		GasAboveThreshold: decimal.NewFromFloat(GasAboveThresholdDefault).Mul(decimal.NewFromInt(params.GWei)),
 		GasBelowThreshold: decimal.NewFromFloat(GasAboveThresholdDefault).Mul(decimal.NewFromInt(params.GWei)),
 
@@ -1339,6 +1624,16 @@ func (d *DataAccessService) UpdateNotificationSettingsGeneral(ctx context.Contex
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineStorageUsageSubscribed, userId, types.MonitoringMachineDiskAlmostFullEventName, "", "", epoch, settings.MachineStorageUsageThreshold)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineCpuUsageSubscribed, userId, types.MonitoringMachineCpuLoadEventName, "", "", epoch, settings.MachineCpuUsageThreshold)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineMemoryUsageSubscribed, userId, types.MonitoringMachineMemoryUsageEventName, "", "", epoch, settings.MachineMemoryUsageThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineNotSyncedSubscribed, userId, types.MonitoringMachineNotSyncedEventName, "", "", epoch, settings.MachineNotSyncedThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineElDisconnectedSubscribed, userId, types.MonitoringMachineElDisconnectedEventName, "", "", epoch, settings.MachineElDisconnectedThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineLowPeerCountSubscribed, userId, types.MonitoringMachineLowPeerCountEventName, "", "", epoch, settings.MachineLowPeerCountThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineHeadSlotLagSubscribed, userId, types.MonitoringMachineHeadSlotLagEventName, "", "", epoch, settings.MachineHeadSlotLagThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineFallbackInUseSubscribed, userId, types.MonitoringMachineFallbackInUseEventName, "", "", epoch, settings.MachineFallbackInUseThreshold)
+	clientOutdatedThreshold := 0.0
+	if settings.MachineClientOutdatedOnlyMandatory {
+		clientOutdatedThreshold = 1
+	}
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMachineClientOutdatedSubscribed, userId, types.MonitoringMachineClientOutdatedEventName, "", "", epoch, clientOutdatedThreshold)
 
 	// Insert all the events or update the threshold if they already exist
 	if len(eventsToInsert) > 0 {
@@ -1729,6 +2024,10 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 				settings.IsSyncSubscribed = true
 			case types.ValidatorReceivedWithdrawalEventName:
 				settings.IsWithdrawalProcessedSubscribed = true
+			case types.ValidatorPartialWithdrawalEventName:
+				settings.IsPartialWithdrawalProcessedSubscribed = true
+			case types.ValidatorConsolidationEventName:
+				settings.IsConsolidationCompletedSubscribed = true
 			case types.ValidatorGotSlashedEventName:
 				settings.IsSlashedSubscribed = true
 			case types.RocketpoolCollateralMinReachedEventName:
@@ -1958,6 +2257,8 @@ func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx con
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsUpcomingBlockProposalSubscribed, userId, types.ValidatorUpcomingProposalEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsSyncSubscribed, userId, types.SyncCommitteeSoonEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsWithdrawalProcessedSubscribed, userId, types.ValidatorReceivedWithdrawalEventName, networkName, eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsPartialWithdrawalProcessedSubscribed, userId, types.ValidatorPartialWithdrawalEventName, networkName, eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsConsolidationCompletedSubscribed, userId, types.ValidatorConsolidationEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsSlashedSubscribed, userId, types.ValidatorGotSlashedEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMaxCollateralSubscribed, userId, types.RocketpoolCollateralMaxReachedEventName, networkName, eventFilter, epoch, settings.MaxCollateralThreshold)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMinCollateralSubscribed, userId, types.RocketpoolCollateralMinReachedEventName, networkName, eventFilter, epoch, settings.MinCollateralThreshold)
@@ -2031,6 +2332,145 @@ func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx con
 
 	return nil
 }
+func (d *DataAccessService) GetNotificationRulesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationRuleValidatorDashboard, error) {
+	result := []t.NotificationRuleValidatorDashboard{}
+	err := d.alloyReader.SelectContext(ctx, &result, `
+		SELECT id, name, metric, comparison, threshold, window_epochs, cooldown_epochs, last_triggered_epoch
+		FROM users_val_dashboards_notification_rules
+		WHERE dashboard_id = $1 AND group_id = $2
+		ORDER BY id`, dashboardId, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notification rules of validator dashboard group: %w", err)
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) CreateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error) {
+	result := rule
+	// only insert the rule if the group exists
+	err := d.alloyWriter.GetContext(ctx, &result.Id, `
+		INSERT INTO users_val_dashboards_notification_rules (dashboard_id, group_id, name, metric, comparison, threshold, window_epochs, cooldown_epochs)
+		SELECT dashboard_id, id, $3, $4, $5, $6, $7, $8
+		FROM users_val_dashboards_groups
+		WHERE dashboard_id = $1 AND id = $2
+		RETURNING id`, dashboardId, groupId, rule.Name, rule.Metric, rule.Comparison, rule.Threshold, rule.WindowEpochs, rule.CooldownEpochs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: group %v for dashboard %v not found", ErrNotFound, groupId, dashboardId)
+		}
+		return nil, fmt.Errorf("error creating notification rule for validator dashboard group: %w", err)
+	}
+	result.LastTriggeredEpoch = nil
+	return &result, nil
+}
+
+func (d *DataAccessService) UpdateNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, rule t.NotificationRuleValidatorDashboard) (*t.NotificationRuleValidatorDashboard, error) {
+	result := t.NotificationRuleValidatorDashboard{}
+	// changing a rule resets its cooldown
+	err := d.alloyWriter.GetContext(ctx, &result, `
+		UPDATE users_val_dashboards_notification_rules
+		SET
+			name = $4,
+			metric = $5,
+			comparison = $6,
+			threshold = $7,
+			window_epochs = $8,
+			cooldown_epochs = $9,
+			last_triggered_epoch = NULL
+		WHERE dashboard_id = $1 AND group_id = $2 AND id = $3
+		RETURNING id, name, metric, comparison, threshold, window_epochs, cooldown_epochs, last_triggered_epoch`,
+		dashboardId, groupId, rule.Id, rule.Name, rule.Metric, rule.Comparison, rule.Threshold, rule.WindowEpochs, rule.CooldownEpochs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: notification rule %v for group %v of dashboard %v not found", ErrNotFound, rule.Id, groupId, dashboardId)
+		}
+		return nil, fmt.Errorf("error updating notification rule for validator dashboard group: %w", err)
+	}
+	return &result, nil
+}
+
+func (d *DataAccessService) DeleteNotificationRuleValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, ruleId uint64) error {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_notification_rules
+		WHERE dashboard_id = $1 AND group_id = $2 AND id = $3`,
+		dashboardId, groupId, ruleId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: notification rule %v for group %v of dashboard %v not found", ErrNotFound, ruleId, groupId, dashboardId)
+	}
+	return nil
+}
+
+func (d *DataAccessService) GetNotificationSnoozesValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) ([]t.NotificationSnoozeValidatorDashboard, error) {
+	snoozes := []struct {
+		Id           uint64           `db:"id"`
+		EventType    *types.EventName `db:"event_type"`
+		SnoozedUntil time.Time        `db:"snoozed_until"`
+	}{}
+	err := d.alloyReader.SelectContext(ctx, &snoozes, `
+		SELECT id, event_type, snoozed_until
+		FROM users_val_dashboards_notification_snoozes
+		WHERE dashboard_id = $1 AND group_id = $2 AND snoozed_until > NOW()
+		ORDER BY snoozed_until`, dashboardId, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notification snoozes of validator dashboard group: %w", err)
+	}
+	result := make([]t.NotificationSnoozeValidatorDashboard, 0, len(snoozes))
+	for _, snooze := range snoozes {
+		result = append(result, t.NotificationSnoozeValidatorDashboard{
+			Id:           snooze.Id,
+			EventType:    (*string)(snooze.EventType),
+			SnoozedUntil: snooze.SnoozedUntil.Unix(),
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) CreateNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, eventType *types.EventName, snoozedUntil time.Time) (*t.NotificationSnoozeValidatorDashboard, error) {
+	result := t.NotificationSnoozeValidatorDashboard{
+		EventType:    (*string)(eventType),
+		SnoozedUntil: snoozedUntil.Unix(),
+	}
+	// only insert the snooze if the group exists
+	err := d.alloyWriter.GetContext(ctx, &result.Id, `
+		INSERT INTO users_val_dashboards_notification_snoozes (dashboard_id, group_id, event_type, snoozed_until)
+		SELECT dashboard_id, id, $3, $4
+		FROM users_val_dashboards_groups
+		WHERE dashboard_id = $1 AND id = $2
+		RETURNING id`, dashboardId, groupId, eventType, snoozedUntil.UTC())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: group %v for dashboard %v not found", ErrNotFound, groupId, dashboardId)
+		}
+		return nil, fmt.Errorf("error creating notification snooze for validator dashboard group: %w", err)
+	}
+	return &result, nil
+}
+
+func (d *DataAccessService) DeleteNotificationSnoozeValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64, snoozeId uint64) error {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_notification_snoozes
+		WHERE dashboard_id = $1 AND group_id = $2 AND id = $3`,
+		dashboardId, groupId, snoozeId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: notification snooze %v for group %v of dashboard %v not found", ErrNotFound, snoozeId, groupId, dashboardId)
+	}
+	return nil
+}
+
 func (d *DataAccessService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
 	// TODO: Account dashboard handling will be handled later
 	// // For the given dashboardId and groupId update users_subscriptions and users_acc_dashboards_groups with the given settings
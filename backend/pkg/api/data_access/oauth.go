@@ -0,0 +1,209 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var ErrRedirectUriInUse = errors.New("redirect uri is already used by another app")
+
+// OAuthRepository handles third-party apps acting on behalf of users.
+// Apps are stored in oauth_apps, authorization codes in oauth_codes and refresh tokens in users_devices, like the mobile app.
+type OAuthRepository interface {
+	GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error)
+	CreateUserOAuthApp(ctx context.Context, userId uint64, name, redirectUri string, hashedClientSecret *string) (*t.OAuthApp, error)
+	RemoveUserOAuthApp(ctx context.Context, userId, appId uint64) error
+	GetOAuthApp(ctx context.Context, appId uint64) (*t.OAuthAppData, error)
+	AddOAuthCode(ctx context.Context, userId, appId uint64, hashedCode, redirectUri string, scopes []string, codeChallenge, codeChallengeMethod string) error
+	ConsumeOAuthCode(ctx context.Context, appId uint64, hashedCode string, maxAge time.Duration) (*t.OAuthCodeData, error)
+	AddOAuthDevice(ctx context.Context, userId, appId uint64, hashedRefreshToken, deviceName string, scopes []string) (uint64, error)
+	RotateOAuthRefreshToken(ctx context.Context, appId uint64, oldHashedRefreshToken, newHashedRefreshToken string) (*t.OAuthDeviceData, error)
+}
+
+func (d *DataAccessService) GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error) {
+	var dbApps []struct {
+		Id           uint64    `db:"id"`
+		AppName      string    `db:"app_name"`
+		RedirectUri  string    `db:"redirect_uri"`
+		Confidential bool      `db:"confidential"`
+		CreatedTs    time.Time `db:"created_ts"`
+	}
+	err := d.userReader.SelectContext(ctx, &dbApps, `
+		SELECT id, app_name, redirect_uri, client_secret IS NOT NULL AS confidential, created_ts
+		FROM oauth_apps
+		WHERE owner_id = $1 AND active
+		ORDER BY id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.OAuthApp, 0, len(dbApps))
+	for _, app := range dbApps {
+		result = append(result, t.OAuthApp{
+			ClientId:     fmt.Sprint(app.Id),
+			Name:         app.AppName,
+			RedirectUri:  app.RedirectUri,
+			Confidential: app.Confidential,
+			CreatedAt:    app.CreatedTs.Unix(),
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) CreateUserOAuthApp(ctx context.Context, userId uint64, name, redirectUri string, hashedClientSecret *string) (*t.OAuthApp, error) {
+	var dbApp struct {
+		Id        uint64    `db:"id"`
+		CreatedTs time.Time `db:"created_ts"`
+	}
+	err := d.userWriter.GetContext(ctx, &dbApp, `
+		INSERT INTO oauth_apps (owner_id, redirect_uri, app_name, active, created_ts, client_secret)
+		VALUES ($1, $2, $3, true, NOW(), $4)
+		RETURNING id, created_ts`, userId, redirectUri, name, hashedClientSecret)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, ErrRedirectUriInUse
+		}
+		return nil, err
+	}
+	return &t.OAuthApp{
+		ClientId:     fmt.Sprint(dbApp.Id),
+		Name:         name,
+		RedirectUri:  redirectUri,
+		Confidential: hashedClientSecret != nil,
+		CreatedAt:    dbApp.CreatedTs.Unix(),
+	}, nil
+}
+
+// RemoveUserOAuthApp deactivates the app and all sessions of users who authorized it
+func (d *DataAccessService) RemoveUserOAuthApp(ctx context.Context, userId, appId uint64) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to remove oauth app: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	result, err := tx.ExecContext(ctx, `UPDATE oauth_apps SET active = false WHERE id = $1 AND owner_id = $2 AND active`, appId, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: oauth app %v not found", ErrNotFound, appId)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE users_devices SET active = false WHERE app_id = $1`, appId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE oauth_codes SET consumed = true WHERE app_id = $1`, appId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to remove oauth app: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) GetOAuthApp(ctx context.Context, appId uint64) (*t.OAuthAppData, error) {
+	data := t.OAuthAppData{}
+	err := d.userReader.GetContext(ctx, &data, `SELECT id, app_name, redirect_uri, active, owner_id, client_secret FROM oauth_apps WHERE id = $1 AND active`, appId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: oauth app %v not found", ErrNotFound, appId)
+	}
+	return &data, err
+}
+
+// AddOAuthCode stores the authorization code, a previous pending code of the user for the app is replaced
+func (d *DataAccessService) AddOAuthCode(ctx context.Context, userId, appId uint64, hashedCode, redirectUri string, scopes []string, codeChallenge, codeChallengeMethod string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO oauth_codes (user_id, code, client_id, consumed, app_id, created_ts, redirect_uri, scopes, code_challenge, code_challenge_method)
+		VALUES ($1, $2, $3, false, $3, NOW(), $4, $5, $6, $7)
+		ON CONFLICT (user_id, app_id, client_id) DO UPDATE SET
+			code = EXCLUDED.code,
+			consumed = false,
+			created_ts = EXCLUDED.created_ts,
+			redirect_uri = EXCLUDED.redirect_uri,
+			scopes = EXCLUDED.scopes,
+			code_challenge = EXCLUDED.code_challenge,
+			code_challenge_method = EXCLUDED.code_challenge_method`,
+		userId, hashedCode, appId, redirectUri, pq.StringArray(scopes), codeChallenge, codeChallengeMethod)
+	return err
+}
+
+// ConsumeOAuthCode marks the authorization code as consumed, codes can only be exchanged once
+func (d *DataAccessService) ConsumeOAuthCode(ctx context.Context, appId uint64, hashedCode string, maxAge time.Duration) (*t.OAuthCodeData, error) {
+	var dbCode struct {
+		UserId              uint64         `db:"user_id"`
+		AppId               uint64         `db:"app_id"`
+		RedirectUri         string         `db:"redirect_uri"`
+		Scopes              pq.StringArray `db:"scopes"`
+		CodeChallenge       string         `db:"code_challenge"`
+		CodeChallengeMethod string         `db:"code_challenge_method"`
+	}
+	err := d.userWriter.GetContext(ctx, &dbCode, `
+		UPDATE oauth_codes SET consumed = true
+		WHERE app_id = $1 AND code = $2 AND NOT consumed AND created_ts > NOW() - $3 * INTERVAL '1 second'
+		RETURNING user_id, app_id, redirect_uri, scopes, code_challenge, code_challenge_method`, appId, hashedCode, maxAge.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: authorization code not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t.OAuthCodeData{
+		UserId:              dbCode.UserId,
+		AppId:               dbCode.AppId,
+		RedirectUri:         dbCode.RedirectUri,
+		Scopes:              dbCode.Scopes,
+		CodeChallenge:       dbCode.CodeChallenge,
+		CodeChallengeMethod: dbCode.CodeChallengeMethod,
+	}, nil
+}
+
+func (d *DataAccessService) AddOAuthDevice(ctx context.Context, userId, appId uint64, hashedRefreshToken, deviceName string, scopes []string) (uint64, error) {
+	var deviceId uint64
+	err := d.userWriter.GetContext(ctx, &deviceId, `
+		INSERT INTO users_devices (user_id, refresh_token, device_name, app_id, created_ts, scopes)
+		VALUES ($1, $2, $3, $4, NOW(), $5)
+		RETURNING id`, userId, hashedRefreshToken, deviceName, appId, pq.StringArray(scopes))
+	return deviceId, err
+}
+
+// RotateOAuthRefreshToken replaces the refresh token of an active third-party app session, the old token can not be used again
+func (d *DataAccessService) RotateOAuthRefreshToken(ctx context.Context, appId uint64, oldHashedRefreshToken, newHashedRefreshToken string) (*t.OAuthDeviceData, error) {
+	var dbDevice struct {
+		Id     uint64         `db:"id"`
+		UserId uint64         `db:"user_id"`
+		AppId  uint64         `db:"app_id"`
+		Scopes pq.StringArray `db:"scopes"`
+	}
+	err := d.userWriter.GetContext(ctx, &dbDevice, `
+		UPDATE users_devices SET refresh_token = $3
+		WHERE app_id = $1 AND refresh_token = $2 AND active AND scopes IS NOT NULL
+		RETURNING id, user_id, app_id, scopes`, appId, oldHashedRefreshToken, newHashedRefreshToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: refresh token not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t.OAuthDeviceData{
+		Id:     dbDevice.Id,
+		UserId: dbDevice.UserId,
+		AppId:  dbDevice.AppId,
+		Scopes: dbDevice.Scopes,
+	}, nil
+}
@@ -0,0 +1,324 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrOrganizationMemberExists = errors.New("user is already a member of the organization")
+var ErrLastOrganizationOwner = errors.New("organization must have at least one owner")
+
+// OrganizationRepository handles organizations which own validator dashboards shared by their members.
+// Organizations are stored next to the dashboards, the member emails are fetched from the user db.
+type OrganizationRepository interface {
+	GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error)
+	GetOrganization(ctx context.Context, organizationId, userId uint64) (*t.Organization, error)
+	CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error)
+	UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error
+	RemoveOrganization(ctx context.Context, organizationId uint64) error
+	GetOrganizationMemberRole(ctx context.Context, organizationId, userId uint64) (string, error)
+	GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error)
+	AddOrganizationMember(ctx context.Context, organizationId, userId uint64, role string) error
+	UpdateOrganizationMemberRole(ctx context.Context, organizationId, userId uint64, role string) error
+	RemoveOrganizationMember(ctx context.Context, organizationId, userId uint64) error
+	GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error)
+	GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error)
+	UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error
+}
+
+type dbOrganization struct {
+	Id          uint64    `db:"id"`
+	Name        string    `db:"name"`
+	Role        string    `db:"role"`
+	MemberCount uint64    `db:"member_count"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (o dbOrganization) toOrganization() t.Organization {
+	return t.Organization{
+		Id:          o.Id,
+		Name:        o.Name,
+		Role:        o.Role,
+		MemberCount: o.MemberCount,
+		CreatedAt:   o.CreatedAt.Unix(),
+	}
+}
+
+const organizationsQuery = `
+	SELECT
+		o.id,
+		o.name,
+		om.role,
+		(SELECT COUNT(*) FROM organization_members WHERE organization_id = o.id) AS member_count,
+		o.created_at
+	FROM organizations o
+	JOIN organization_members om ON om.organization_id = o.id
+	WHERE om.user_id = $1`
+
+func (d *DataAccessService) GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error) {
+	var dbOrganizations []dbOrganization
+	err := d.alloyReader.SelectContext(ctx, &dbOrganizations, organizationsQuery+` ORDER BY o.id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.Organization, 0, len(dbOrganizations))
+	for _, o := range dbOrganizations {
+		result = append(result, o.toOrganization())
+	}
+	return result, nil
+}
+
+// GetOrganization returns ErrNotFound if the user is not a member of the organization
+func (d *DataAccessService) GetOrganization(ctx context.Context, organizationId, userId uint64) (*t.Organization, error) {
+	var dbOrg dbOrganization
+	err := d.alloyReader.GetContext(ctx, &dbOrg, organizationsQuery+` AND o.id = $2`, userId, organizationId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: organization %v not found", ErrNotFound, organizationId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := dbOrg.toOrganization()
+	return &result, nil
+}
+
+// CreateOrganization creates the organization with the user as owner
+func (d *DataAccessService) CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error) {
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction to create organization: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	dbOrg := dbOrganization{Name: name, Role: t.OrganizationRoleOwner, MemberCount: 1}
+	err = tx.GetContext(ctx, &dbOrg, `INSERT INTO organizations (name, created_at) VALUES ($1, NOW()) RETURNING id, created_at`, name)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())`, dbOrg.Id, userId, t.OrganizationRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx to create organization: %w", err)
+	}
+	result := dbOrg.toOrganization()
+	return &result, nil
+}
+
+func (d *DataAccessService) UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `UPDATE organizations SET name = $2 WHERE id = $1`, organizationId, name)
+	return err
+}
+
+// RemoveOrganization deletes the organization, its dashboards are returned to their creators
+func (d *DataAccessService) RemoveOrganization(ctx context.Context, organizationId uint64) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, organizationId)
+	return err
+}
+
+// GetOrganizationMemberRole returns ErrNotFound if the user is not a member of the organization
+func (d *DataAccessService) GetOrganizationMemberRole(ctx context.Context, organizationId, userId uint64) (string, error) {
+	var role string
+	err := d.alloyReader.GetContext(ctx, &role, `SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2`, organizationId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: user %v is not a member of organization %v", ErrNotFound, userId, organizationId)
+	}
+	return role, err
+}
+
+func (d *DataAccessService) GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error) {
+	var dbMembers []struct {
+		UserId    uint64    `db:"user_id"`
+		Role      string    `db:"role"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	err := d.alloyReader.SelectContext(ctx, &dbMembers, `
+		SELECT user_id, role, created_at
+		FROM organization_members
+		WHERE organization_id = $1
+		ORDER BY created_at`, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	userIds := make([]uint64, len(dbMembers))
+	for i, m := range dbMembers {
+		userIds[i] = m.UserId
+	}
+
+	var dbEmails []struct {
+		Id    uint64 `db:"id"`
+		Email string `db:"email"`
+	}
+	err = d.userReader.SelectContext(ctx, &dbEmails, `SELECT id, email FROM users WHERE id = ANY($1)`, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[uint64]string, len(dbEmails))
+	for _, e := range dbEmails {
+		emails[e.Id] = utils.CensorEmail(e.Email)
+	}
+
+	result := make([]t.OrganizationMember, 0, len(dbMembers))
+	for _, m := range dbMembers {
+		result = append(result, t.OrganizationMember{
+			UserId:   m.UserId,
+			Email:    emails[m.UserId],
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt.Unix(),
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) AddOrganizationMember(ctx context.Context, organizationId, userId uint64, role string) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())`, organizationId, userId, role)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrOrganizationMemberExists
+	}
+	return err
+}
+
+// UpdateOrganizationMemberRole returns ErrLastOrganizationOwner if the last owner would be demoted
+func (d *DataAccessService) UpdateOrganizationMemberRole(ctx context.Context, organizationId, userId uint64, role string) error {
+	return d.changeOrganizationMember(ctx, organizationId, userId, func(tx *sqlx.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`, organizationId, userId, role)
+	})
+}
+
+// RemoveOrganizationMember returns ErrLastOrganizationOwner if the last owner would be removed
+func (d *DataAccessService) RemoveOrganizationMember(ctx context.Context, organizationId, userId uint64) error {
+	return d.changeOrganizationMember(ctx, organizationId, userId, func(tx *sqlx.Tx) (sql.Result, error) {
+		return tx.ExecContext(ctx, `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`, organizationId, userId)
+	})
+}
+
+// changeOrganizationMember applies the change and makes sure the organization is left with at least one owner
+func (d *DataAccessService) changeOrganizationMember(ctx context.Context, organizationId, userId uint64, change func(tx *sqlx.Tx) (sql.Result, error)) error {
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to change organization member: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	// lock the members of the organization to prevent concurrent changes from removing all owners
+	_, err = tx.ExecContext(ctx, `SELECT 1 FROM organization_members WHERE organization_id = $1 FOR UPDATE`, organizationId)
+	if err != nil {
+		return err
+	}
+	result, err := change(tx)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user %v is not a member of organization %v", ErrNotFound, userId, organizationId)
+	}
+	var ownerCount uint64
+	err = tx.GetContext(ctx, &ownerCount, `SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND role = $2`, organizationId, t.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+	if ownerCount == 0 {
+		return ErrLastOrganizationOwner
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to change organization member: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizationPremiumPerks pools the premium perks of all members, every perk is the best one of any member
+func (d *DataAccessService) GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error) {
+	var userIds []uint64
+	err := d.alloyReader.SelectContext(ctx, &userIds, `SELECT user_id FROM organization_members WHERE organization_id = $1`, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	perks := make([]t.PremiumPerks, len(userIds))
+	wg := errgroup.Group{}
+	for i, userId := range userIds {
+		wg.Go(func() error {
+			userInfo, err := d.GetUserInfo(ctx, userId)
+			if err != nil {
+				return err
+			}
+			perks[i] = userInfo.PremiumPerks
+			return nil
+		})
+	}
+	err = wg.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving premium perks of organization members: %w", err)
+	}
+
+	result, err := d.GetFreeTierPerks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range perks {
+		poolPremiumPerks(result, &p)
+	}
+	return result, nil
+}
+
+func poolPremiumPerks(pooled, p *t.PremiumPerks) {
+	pooled.AdFree = pooled.AdFree || p.AdFree
+	pooled.ValidatorDashboards = max(pooled.ValidatorDashboards, p.ValidatorDashboards)
+	pooled.ValidatorsPerDashboard = max(pooled.ValidatorsPerDashboard, p.ValidatorsPerDashboard)
+	pooled.ValidatorGroupsPerDashboard = max(pooled.ValidatorGroupsPerDashboard, p.ValidatorGroupsPerDashboard)
+	pooled.ShareCustomDashboards = pooled.ShareCustomDashboards || p.ShareCustomDashboards
+	pooled.ManageDashboardViaApi = pooled.ManageDashboardViaApi || p.ManageDashboardViaApi
+	pooled.BulkAdding = pooled.BulkAdding || p.BulkAdding
+	pooled.ChartHistorySeconds.Epoch = max(pooled.ChartHistorySeconds.Epoch, p.ChartHistorySeconds.Epoch)
+	pooled.ChartHistorySeconds.Hourly = max(pooled.ChartHistorySeconds.Hourly, p.ChartHistorySeconds.Hourly)
+	pooled.ChartHistorySeconds.Daily = max(pooled.ChartHistorySeconds.Daily, p.ChartHistorySeconds.Daily)
+	pooled.ChartHistorySeconds.Weekly = max(pooled.ChartHistorySeconds.Weekly, p.ChartHistorySeconds.Weekly)
+	pooled.EmailNotificationsPerDay = max(pooled.EmailNotificationsPerDay, p.EmailNotificationsPerDay)
+	pooled.ConfigureNotificationsViaApi = pooled.ConfigureNotificationsViaApi || p.ConfigureNotificationsViaApi
+	pooled.ValidatorGroupNotifications = max(pooled.ValidatorGroupNotifications, p.ValidatorGroupNotifications)
+	pooled.WebhookEndpoints = max(pooled.WebhookEndpoints, p.WebhookEndpoints)
+	pooled.MobileAppCustomThemes = pooled.MobileAppCustomThemes || p.MobileAppCustomThemes
+	pooled.MobileAppWidget = pooled.MobileAppWidget || p.MobileAppWidget
+	pooled.MonitorMachines = max(pooled.MonitorMachines, p.MonitorMachines)
+	pooled.MachineMonitoringHistorySeconds = max(pooled.MachineMonitoringHistorySeconds, p.MachineMonitoringHistorySeconds)
+	pooled.NotificationsMachineCustomThreshold = pooled.NotificationsMachineCustomThreshold || p.NotificationsMachineCustomThreshold
+	pooled.NotificationsValidatorDashboardGroupEfficiency = pooled.NotificationsValidatorDashboardGroupEfficiency || p.NotificationsValidatorDashboardGroupEfficiency
+}
+
+func (d *DataAccessService) GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_val_dashboards
+		WHERE organization_id = $1 AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
+	`, organizationId, active)
+	return count, err
+}
+
+// UpdateValidatorDashboardOwner transfers the dashboard to an organization or, if organizationId is nil, to the user
+func (d *DataAccessService) UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `UPDATE users_val_dashboards SET user_id = $2, organization_id = $3 WHERE id = $1`, dashboardId, userId, organizationId)
+	return err
+}
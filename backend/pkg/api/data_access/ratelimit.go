@@ -2,12 +2,18 @@ package dataaccess
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/ratelimit"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"golang.org/x/sync/errgroup"
 )
 
 type RatelimitRepository interface {
 	GetApiWeights(ctx context.Context) ([]types.ApiWeightItem, error)
+	GetUserApiUsage(ctx context.Context, userId uint64) (*types.ApiUsageData, error)
 	// TODO @patrick: move queries from commons/ratelimit/ratelimit.go to here
 }
 
@@ -20,3 +26,161 @@ func (d *DataAccessService) GetApiWeights(ctx context.Context) ([]types.ApiWeigh
 	`)
 	return result, err
 }
+
+const (
+	apiUsageHourlyWindow = 48 * time.Hour
+	apiUsageDailyWindow  = 30 * utils.Day
+	apiUsageKeyPrefixLen = 8
+	// requests authorized by an oauth access token are not made with an api key and reported as their own series
+	apiUsageAccessTokenLabel = "oauth"
+)
+
+// GetUserApiUsage returns the request counts of the api keys and oauth access tokens of the user in the default bucket.
+// Quota usage is weighted and taken from the ratelimiter if the quota is limited, otherwise the request counts are used.
+func (d *DataAccessService) GetUserApiUsage(ctx context.Context, userId uint64) (*types.ApiUsageData, error) {
+	now := time.Now().UTC()
+	hourStart := now.Truncate(time.Hour)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	accessTokenKey := ratelimit.AccessTokenKey(int64(userId))
+
+	type usageRow struct {
+		Ts       time.Time `db:"ts"`
+		ApiKey   string    `db:"apikey"`
+		Endpoint string    `db:"endpoint"`
+		Count    uint64    `db:"count"`
+	}
+	var hourlyRows, dailyRows []usageRow
+	var rateLimit *ratelimit.RateLimit
+	var hourRequests, monthRequests uint64
+	var hourUsage, monthUsage int64
+
+	wg := errgroup.Group{}
+	wg.Go(func() error {
+		err := d.userReader.SelectContext(ctx, &hourlyRows, `
+			SELECT s.ts, s.apikey, s.endpoint, SUM(s.count) AS count
+			FROM api_statistics s
+			WHERE (s.apikey IN (SELECT api_key FROM api_keys WHERE user_id = $1) OR s.apikey = $3) AND s.bucket = 'default' AND s.ts >= $2
+			GROUP BY s.ts, s.apikey, s.endpoint
+			ORDER BY s.ts`, userId, hourStart.Add(-apiUsageHourlyWindow), accessTokenKey)
+		if err != nil {
+			return fmt.Errorf("error getting hourly api usage: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		err := d.userReader.SelectContext(ctx, &dailyRows, `
+			SELECT date_trunc('day', s.ts) AS ts, s.apikey, s.endpoint, SUM(s.count) AS count
+			FROM api_statistics s
+			WHERE (s.apikey IN (SELECT api_key FROM api_keys WHERE user_id = $1) OR s.apikey = $3) AND s.bucket = 'default' AND s.ts >= $2
+			GROUP BY 1, s.apikey, s.endpoint
+			ORDER BY 1`, userId, now.Truncate(utils.Day).Add(-apiUsageDailyWindow), accessTokenKey)
+		if err != nil {
+			return fmt.Errorf("error getting daily api usage: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		err := d.userReader.GetContext(ctx, &monthRequests, `
+			SELECT COALESCE(SUM(s.count), 0)
+			FROM api_statistics s
+			WHERE (s.apikey IN (SELECT api_key FROM api_keys WHERE user_id = $1) OR s.apikey = $3) AND s.bucket = 'default' AND s.ts >= $2`, userId, monthStart, accessTokenKey)
+		if err != nil {
+			return fmt.Errorf("error getting monthly api usage: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		rateLimit, err = ratelimit.DBGetUserApiRateLimit(int64(userId))
+		if err != nil {
+			return fmt.Errorf("error getting api ratelimit: %w", err)
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		var err error
+		hourUsage, monthUsage, err = ratelimit.GetUserApiUsage(ctx, int64(userId))
+		return err
+	})
+	err := wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	// -------------------------------------
+	// Series per key and endpoint
+	keys := make([]types.ApiKeyUsage, 0)
+	keyIndices := make(map[string]int)
+	endpointIndices := make(map[string]map[string]int)
+	getUsage := func(apiKey, endpoint string) (*types.ApiKeyUsage, *types.ApiEndpointUsage) {
+		if _, ok := keyIndices[apiKey]; !ok {
+			keyIndices[apiKey] = len(keys)
+			endpointIndices[apiKey] = make(map[string]int)
+			label := utils.FirstN(apiKey, apiUsageKeyPrefixLen)
+			if apiKey == accessTokenKey {
+				label = apiUsageAccessTokenLabel
+			}
+			keys = append(keys, types.ApiKeyUsage{
+				ApiKey:    label,
+				Hourly:    []types.ApiUsageDataPoint{},
+				Daily:     []types.ApiUsageDataPoint{},
+				Endpoints: []types.ApiEndpointUsage{},
+			})
+		}
+		key := &keys[keyIndices[apiKey]]
+		if _, ok := endpointIndices[apiKey][endpoint]; !ok {
+			endpointIndices[apiKey][endpoint] = len(key.Endpoints)
+			key.Endpoints = append(key.Endpoints, types.ApiEndpointUsage{
+				Endpoint: endpoint,
+				Hourly:   []types.ApiUsageDataPoint{},
+				Daily:    []types.ApiUsageDataPoint{},
+			})
+		}
+		return key, &key.Endpoints[endpointIndices[apiKey][endpoint]]
+	}
+	// rows are sorted by time, so the last data point of a series is the one to add to
+	addDataPoint := func(series []types.ApiUsageDataPoint, ts time.Time, count uint64) []types.ApiUsageDataPoint {
+		if len(series) > 0 && series[len(series)-1].Timestamp == ts.Unix() {
+			series[len(series)-1].Count += count
+			return series
+		}
+		return append(series, types.ApiUsageDataPoint{Timestamp: ts.Unix(), Count: count})
+	}
+	for _, row := range hourlyRows {
+		key, endpoint := getUsage(row.ApiKey, row.Endpoint)
+		key.Hourly = addDataPoint(key.Hourly, row.Ts, row.Count)
+		endpoint.Hourly = addDataPoint(endpoint.Hourly, row.Ts, row.Count)
+		if !row.Ts.Before(hourStart) {
+			hourRequests += row.Count
+		}
+	}
+	for _, row := range dailyRows {
+		key, endpoint := getUsage(row.ApiKey, row.Endpoint)
+		key.Daily = addDataPoint(key.Daily, row.Ts, row.Count)
+		endpoint.Daily = addDataPoint(endpoint.Daily, row.Ts, row.Count)
+	}
+
+	// -------------------------------------
+	// Quotas
+	result := types.ApiUsageData{
+		SecondLimit: uint64(rateLimit.Second),
+		Hour:        types.ApiUsageQuota{Limit: uint64(rateLimit.Hour), Used: hourRequests},
+		Month:       types.ApiUsageQuota{Limit: uint64(rateLimit.Month), Used: monthRequests},
+		Keys:        keys,
+	}
+	if rateLimit.Hour > 0 {
+		result.Hour.Used = uint64(hourUsage)
+	}
+	if rateLimit.Month > 0 {
+		result.Month.Used = uint64(monthUsage)
+	}
+
+	// linear projection of the usage so far
+	elapsed := now.Sub(monthStart)
+	if elapsed > 0 {
+		result.ProjectedMonthUsage = uint64(float64(result.Month.Used) * float64(monthEnd.Sub(monthStart)) / float64(elapsed))
+	}
+
+	return &result, nil
+}
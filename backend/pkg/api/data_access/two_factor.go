@@ -0,0 +1,194 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// TwoFactorRepository handles the second login factor of users.
+// Totp secrets are stored in users_2fa, hashed one-time recovery codes in users_2fa_recovery_codes.
+type TwoFactorRepository interface {
+	GetUserTwoFactorData(ctx context.Context, userId uint64) (*t.UserTwoFactorData, error)
+	GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error)
+	SetUserPendingTotpSecret(ctx context.Context, userId uint64, secret string) error
+	EnableUserTotp(ctx context.Context, userId uint64, counter int64, hashedRecoveryCodes []string) error
+	DisableUserTotp(ctx context.Context, userId uint64) error
+	UpdateUserTotpCounter(ctx context.Context, userId uint64, counter int64) (bool, error)
+	ConsumeUserRecoveryCode(ctx context.Context, userId uint64, hashedCode string) (bool, error)
+	ReplaceUserRecoveryCodes(ctx context.Context, userId uint64, hashedRecoveryCodes []string) error
+	RecordUserTwoFactorFailure(ctx context.Context, userId uint64, maxAttempts uint64, lockout time.Duration) (bool, error)
+	ResetUserTwoFactorFailures(ctx context.Context, userId uint64) error
+}
+
+// GetUserTwoFactorData returns ErrNotFound if the user never started a totp enrolment
+func (d *DataAccessService) GetUserTwoFactorData(ctx context.Context, userId uint64) (*t.UserTwoFactorData, error) {
+	var result t.UserTwoFactorData
+	err := d.userReader.GetContext(ctx, &result, `
+		SELECT totp_secret, enabled, last_used_counter, COALESCE(locked_until > NOW(), false) AS locked
+		FROM users_2fa WHERE user_id = $1`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: two factor authentication not set up for user %v", ErrNotFound, userId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (d *DataAccessService) GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.userReader.GetContext(ctx, &count, `SELECT COUNT(*) FROM users_2fa_recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userId)
+	return count, err
+}
+
+// SetUserPendingTotpSecret starts a new enrolment, it is not possible to replace the secret of an enabled enrolment
+func (d *DataAccessService) SetUserPendingTotpSecret(ctx context.Context, userId uint64, secret string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_2fa (user_id, totp_secret, enabled, last_used_counter, created_at)
+		VALUES ($1, $2, false, 0, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET totp_secret = EXCLUDED.totp_secret, last_used_counter = 0, failed_attempts = 0, locked_until = NULL, created_at = NOW()
+		WHERE NOT users_2fa.enabled`, userId, secret)
+	return err
+}
+
+// EnableUserTotp confirms the pending enrolment and replaces the recovery codes
+func (d *DataAccessService) EnableUserTotp(ctx context.Context, userId uint64, counter int64, hashedRecoveryCodes []string) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to enable totp: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	result, err := tx.ExecContext(ctx, `UPDATE users_2fa SET enabled = true, last_used_counter = $2 WHERE user_id = $1 AND NOT enabled`, userId, counter)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: no pending totp enrolment for user %v", ErrNotFound, userId)
+	}
+
+	err = replaceUserRecoveryCodes(ctx, tx, userId, hashedRecoveryCodes)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to enable totp: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) DisableUserTotp(ctx context.Context, userId uint64) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to disable totp: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users_2fa WHERE user_id = $1`, userId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM users_2fa_recovery_codes WHERE user_id = $1`, userId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to disable totp: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserTotpCounter stores the time step of an accepted code.
+// Returns false if a code of the same or a later time step has already been used.
+func (d *DataAccessService) UpdateUserTotpCounter(ctx context.Context, userId uint64, counter int64) (bool, error) {
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users_2fa SET last_used_counter = $2 WHERE user_id = $1 AND enabled AND last_used_counter < $2`, userId, counter)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// ConsumeUserRecoveryCode marks the recovery code as used, returns false if the code is invalid or has already been used
+func (d *DataAccessService) ConsumeUserRecoveryCode(ctx context.Context, userId uint64, hashedCode string) (bool, error) {
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users_2fa_recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`, userId, hashedCode)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (d *DataAccessService) ReplaceUserRecoveryCodes(ctx context.Context, userId uint64, hashedRecoveryCodes []string) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to replace recovery codes: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	err = replaceUserRecoveryCodes(ctx, tx, userId, hashedRecoveryCodes)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to replace recovery codes: %w", err)
+	}
+	return nil
+}
+
+func replaceUserRecoveryCodes(ctx context.Context, tx *sqlx.Tx, userId uint64, hashedRecoveryCodes []string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM users_2fa_recovery_codes WHERE user_id = $1`, userId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users_2fa_recovery_codes (user_id, code_hash)
+		SELECT $1, UNNEST($2::TEXT[])`, userId, pq.Array(hashedRecoveryCodes))
+	return err
+}
+
+// RecordUserTwoFactorFailure counts an invalid code of the user, after maxAttempts invalid codes the user is locked for the lockout duration.
+// Returns true if the user is locked.
+func (d *DataAccessService) RecordUserTwoFactorFailure(ctx context.Context, userId uint64, maxAttempts uint64, lockout time.Duration) (bool, error) {
+	var locked bool
+	err := d.userWriter.GetContext(ctx, &locked, `
+		UPDATE users_2fa SET
+			failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN NOW() + MAKE_INTERVAL(secs => $3) ELSE locked_until END
+		WHERE user_id = $1
+		RETURNING COALESCE(locked_until > NOW(), false)`, userId, maxAttempts, lockout.Seconds())
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("%w: two factor authentication not set up for user %v", ErrNotFound, userId)
+	}
+	return locked, err
+}
+
+// ResetUserTwoFactorFailures resets the invalid code counter after a successful verification
+func (d *DataAccessService) ResetUserTwoFactorFailures(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE users_2fa SET failed_attempts = 0 WHERE user_id = $1 AND failed_attempts > 0`, userId)
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -28,6 +29,12 @@ type UserRepository interface {
 	UpdatePasswordResetHash(ctx context.Context, userId uint64, passwordHash string) error
 	GetUserCredentialInfo(ctx context.Context, userId uint64) (*t.UserCredentialInfo, error)
 	GetUserIdByApiKey(ctx context.Context, apiKey string) (uint64, error)
+	GetApiKeyScopes(ctx context.Context, apiKey string) ([]string, error)
+	GetUserApiKeys(ctx context.Context, userId uint64) ([]t.UserApiKey, error)
+	CreateUserApiKey(ctx context.Context, userId uint64, label string, scopes []string) (*t.CreatedUserApiKey, error)
+	UpdateUserApiKey(ctx context.Context, userId, apiKeyId uint64, label string, scopes []string) (*t.UserApiKey, error)
+	RotateUserApiKey(ctx context.Context, userId, apiKeyId uint64) (*t.CreatedUserApiKey, error)
+	RevokeUserApiKey(ctx context.Context, userId, apiKeyId uint64) error
 	GetUserIdByConfirmationHash(ctx context.Context, hash string) (uint64, error)
 	GetUserIdByResetHash(ctx context.Context, hash string) (uint64, error)
 	GetUserInfo(ctx context.Context, id uint64) (*t.UserInfo, error)
@@ -227,13 +234,151 @@ func (d *DataAccessService) GetUserCredentialInfo(ctx context.Context, userId ui
 
 func (d *DataAccessService) GetUserIdByApiKey(ctx context.Context, apiKey string) (uint64, error) {
 	var userId uint64
-	err := d.userReader.GetContext(ctx, &userId, `SELECT user_id FROM api_keys WHERE api_key = $1 LIMIT 1`, apiKey)
+	err := d.userReader.GetContext(ctx, &userId, `SELECT user_id FROM api_keys WHERE api_key = $1 AND valid_until > NOW() LIMIT 1`, apiKey)
 	if errors.Is(err, sql.ErrNoRows) {
 		return 0, fmt.Errorf("%w: user for api_key not found", ErrNotFound)
 	}
 	return userId, err
 }
 
+func (d *DataAccessService) GetApiKeyScopes(ctx context.Context, apiKey string) ([]string, error) {
+	var scopes pq.StringArray
+	err := d.userReader.GetContext(ctx, &scopes, `SELECT scopes FROM api_keys WHERE api_key = $1 AND valid_until > NOW()`, apiKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api_key not found", ErrNotFound)
+	}
+	return scopes, err
+}
+
+const apiKeyPrefixLength = 8
+
+type dbUserApiKey struct {
+	Id        uint64         `db:"id"`
+	ApiKey    string         `db:"api_key"`
+	Label     string         `db:"label"`
+	Scopes    pq.StringArray `db:"scopes"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (k dbUserApiKey) toUserApiKey() t.UserApiKey {
+	return t.UserApiKey{
+		Id:        k.Id,
+		Label:     k.Label,
+		Prefix:    utils.FirstN(k.ApiKey, apiKeyPrefixLength),
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.Unix(),
+	}
+}
+
+// GetUserApiKeys returns all valid api keys of the user, revoked keys are not included
+func (d *DataAccessService) GetUserApiKeys(ctx context.Context, userId uint64) ([]t.UserApiKey, error) {
+	var dbKeys []dbUserApiKey
+	err := d.userReader.SelectContext(ctx, &dbKeys, `
+		SELECT id, api_key, label, scopes, created_at
+		FROM api_keys
+		WHERE user_id = $1 AND valid_until > NOW()
+		ORDER BY id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.UserApiKey, 0, len(dbKeys))
+	for _, key := range dbKeys {
+		result = append(result, key.toUserApiKey())
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) CreateUserApiKey(ctx context.Context, userId uint64, label string, scopes []string) (*t.CreatedUserApiKey, error) {
+	apiKey, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	var dbKey dbUserApiKey
+	err = d.userWriter.GetContext(ctx, &dbKey, `
+		INSERT INTO api_keys (api_key, user_id, label, scopes, valid_until, changed_at, created_at)
+		VALUES ($1, $2, $3, $4, '9999-12-31 23:59:59', NOW(), NOW())
+		RETURNING id, api_key, label, scopes, created_at`, apiKey, userId, label, pq.StringArray(scopes))
+	if err != nil {
+		return nil, err
+	}
+	return &t.CreatedUserApiKey{UserApiKey: dbKey.toUserApiKey(), ApiKey: dbKey.ApiKey}, nil
+}
+
+func (d *DataAccessService) UpdateUserApiKey(ctx context.Context, userId, apiKeyId uint64, label string, scopes []string) (*t.UserApiKey, error) {
+	var dbKey dbUserApiKey
+	err := d.userWriter.GetContext(ctx, &dbKey, `
+		UPDATE api_keys SET label = $3, scopes = $4, changed_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND valid_until > NOW()
+		RETURNING id, api_key, label, scopes, created_at`, apiKeyId, userId, label, pq.StringArray(scopes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := dbKey.toUserApiKey()
+	return &result, nil
+}
+
+// RotateUserApiKey replaces the api key with a new one, keeping label and scopes. The old key is revoked immediately.
+func (d *DataAccessService) RotateUserApiKey(ctx context.Context, userId, apiKeyId uint64) (*t.CreatedUserApiKey, error) {
+	apiKey, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction to rotate api key: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var oldKey dbUserApiKey
+	err = tx.GetContext(ctx, &oldKey, `
+		UPDATE api_keys SET valid_until = NOW(), changed_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND valid_until > NOW()
+		RETURNING id, api_key, label, scopes, created_at`, apiKeyId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dbKey dbUserApiKey
+	err = tx.GetContext(ctx, &dbKey, `
+		INSERT INTO api_keys (api_key, user_id, label, scopes, valid_until, changed_at, created_at)
+		VALUES ($1, $2, $3, $4, '9999-12-31 23:59:59', NOW(), NOW())
+		RETURNING id, api_key, label, scopes, created_at`, apiKey, userId, oldKey.Label, oldKey.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx to rotate api key: %w", err)
+	}
+	return &t.CreatedUserApiKey{UserApiKey: dbKey.toUserApiKey(), ApiKey: dbKey.ApiKey}, nil
+}
+
+// RevokeUserApiKey invalidates the api key, the row is kept for the api usage statistics
+func (d *DataAccessService) RevokeUserApiKey(ctx context.Context, userId, apiKeyId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `
+		UPDATE api_keys SET valid_until = NOW(), changed_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND valid_until > NOW()`, apiKeyId, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	return nil
+}
+
 func (d *DataAccessService) GetUserIdByConfirmationHash(ctx context.Context, hash string) (uint64, error) {
 	var result uint64
 
@@ -270,6 +415,10 @@ func (d *DataAccessService) GetFreeTierPerks(ctx context.Context) (*t.PremiumPer
 	return db.GetFreeTierPerks(ctx)
 }
 
+// personal dashboards of the user and the dashboards of all organizations the user is a member of
+const userDashboardsCondition = `(uvd.user_id = $1 AND uvd.organization_id IS NULL)
+			OR uvd.organization_id IN (SELECT organization_id FROM organization_members WHERE user_id = $1)`
+
 func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64) (*t.UserDashboardsData, error) {
 	result := &t.UserDashboardsData{}
 
@@ -285,6 +434,7 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 			PublicId     sql.NullString `db:"public_id"`
 			PublicName   sql.NullString `db:"public_name"`
 			SharedGroups sql.NullBool   `db:"shared_groups"`
+			OrgId        sql.NullInt64  `db:"organization_id"`
 		}{}
 
 		err := d.alloyReader.SelectContext(ctx, &dbReturn, `
@@ -295,10 +445,11 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 			uvd.is_archived,
 			uvds.public_id,
 			uvds.name AS public_name,
-			uvds.shared_groups
+			uvds.shared_groups,
+			uvd.organization_id
 		FROM users_val_dashboards uvd
 		LEFT JOIN users_val_dashboards_sharing uvds ON uvd.id = uvds.dashboard_id
-		WHERE uvd.user_id = $1
+		WHERE `+userDashboardsCondition+`
 	`, userId)
 		if err != nil {
 			return err
@@ -313,6 +464,7 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 					PublicIds:      []t.VDBPublicId{},
 					IsArchived:     row.IsArchived.Valid,
 					ArchivedReason: row.IsArchived.String,
+					OrganizationId: uint64(row.OrgId.Int64),
 				}
 			}
 			if row.PublicId.Valid {
@@ -346,7 +498,7 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 		FROM users_val_dashboards uvd
 		LEFT JOIN users_val_dashboards_groups uvdg ON uvd.id = uvdg.dashboard_id
 		LEFT JOIN users_val_dashboards_validators uvdv ON uvd.id = uvdv.dashboard_id
-		WHERE uvd.user_id = $1
+		WHERE `+userDashboardsCondition+`
 		GROUP BY uvd.id
 	`, userId)
 		if err != nil {
@@ -388,12 +540,12 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 	return result, nil
 }
 
-// return number of active / archived dashboards
+// return number of active / archived personal dashboards, organization dashboards are counted per organization
 func (d *DataAccessService) GetUserValidatorDashboardCount(ctx context.Context, userId uint64, active bool) (uint64, error) {
 	var count uint64
 	err := d.alloyReader.GetContext(ctx, &count, `
 		SELECT COUNT(*) FROM users_val_dashboards
-		WHERE user_id = $1 AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
+		WHERE user_id = $1 AND organization_id IS NULL AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
 	`, userId, active)
 
 	return count, err
@@ -0,0 +1,156 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// UserSecurityRepository handles the device sessions and the security audit list of users.
+// Web sessions are kept in the session store, only the index of the session tokens of a user is handled here.
+type UserSecurityRepository interface {
+	AddUserWebSession(ctx context.Context, userId uint64, token string, lifetime time.Duration) error
+	RemoveUserWebSessions(ctx context.Context, userId uint64, tokens ...string) error
+	GetUserWebSessions(ctx context.Context, userId uint64) ([]string, error)
+	GetUserDeviceSessions(ctx context.Context, userId uint64) ([]t.UserDeviceSession, error)
+	RevokeUserDeviceSession(ctx context.Context, userId, deviceId uint64, accessTokenValidity time.Duration) error
+	IsUserDeviceRevoked(ctx context.Context, deviceId uint64) (bool, error)
+	UpdateUserDeviceActivity(ctx context.Context, hashedRefreshToken, ip, userAgent string) error
+	AddUserSecurityEvent(ctx context.Context, userId uint64, eventType, ip, userAgent string) error
+	GetUserSecurityEvents(ctx context.Context, userId uint64, limit uint64) ([]t.UserSecurityEvent, error)
+}
+
+// the session store can only list all sessions at once, so the tokens of each user are kept in a set next to it
+func userWebSessionsKey(userId uint64) string {
+	return fmt.Sprintf("user_web_sessions:%d", userId)
+}
+
+// access tokens are not stored, revoked devices are kept until all of their access tokens have expired
+func revokedUserDeviceKey(deviceId uint64) string {
+	return fmt.Sprintf("revoked_user_device:%d", deviceId)
+}
+
+// AddUserWebSession adds the token of an authenticated web session to the index of the user,
+// the index expires after the lifetime unless a session of the user is used again
+func (d *DataAccessService) AddUserWebSession(ctx context.Context, userId uint64, token string, lifetime time.Duration) error {
+	key := userWebSessionsKey(userId)
+	pipe := d.persistentRedisDbClient.TxPipeline()
+	pipe.SAdd(ctx, key, token)
+	pipe.Expire(ctx, key, lifetime)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (d *DataAccessService) RemoveUserWebSessions(ctx context.Context, userId uint64, tokens ...string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		members[i] = token
+	}
+	return d.persistentRedisDbClient.SRem(ctx, userWebSessionsKey(userId), members...).Err()
+}
+
+// GetUserWebSessions returns the indexed session tokens of the user, sessions that expired in the session store might still be contained
+func (d *DataAccessService) GetUserWebSessions(ctx context.Context, userId uint64) ([]string, error) {
+	return d.persistentRedisDbClient.SMembers(ctx, userWebSessionsKey(userId)).Result()
+}
+
+func (d *DataAccessService) GetUserDeviceSessions(ctx context.Context, userId uint64) ([]t.UserDeviceSession, error) {
+	result := []t.UserDeviceSession{}
+	err := d.userReader.SelectContext(ctx, &result, `
+		SELECT
+			ud.id,
+			ud.device_name,
+			COALESCE(oa.app_name, '') AS app_name,
+			ud.created_ts,
+			ud.last_used_ts,
+			COALESCE(ud.ip_address, '') AS ip_address,
+			COALESCE(ud.user_agent, '') AS user_agent
+		FROM users_devices ud
+		LEFT JOIN oauth_apps oa ON oa.id = ud.app_id
+		WHERE ud.user_id = $1 AND ud.active
+		ORDER BY ud.created_ts DESC`, userId)
+	return result, err
+}
+
+// RevokeUserDeviceSession deactivates the refresh token and marks the device as revoked for the validity of its access tokens
+func (d *DataAccessService) RevokeUserDeviceSession(ctx context.Context, userId, deviceId uint64, accessTokenValidity time.Duration) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to revoke device session: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	result, err := tx.ExecContext(ctx, `UPDATE users_devices SET active = false WHERE id = $1 AND user_id = $2 AND active`, deviceId, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: device session %v not found", ErrNotFound, deviceId)
+	}
+
+	// the refresh token is only deactivated once the access tokens are rejected as well
+	err = d.persistentRedisDbClient.Set(ctx, revokedUserDeviceKey(deviceId), 1, accessTokenValidity).Err()
+	if err != nil {
+		return fmt.Errorf("error marking device %v as revoked: %w", deviceId, err)
+	}
+	return tx.Commit()
+}
+
+func (d *DataAccessService) IsUserDeviceRevoked(ctx context.Context, deviceId uint64) (bool, error) {
+	count, err := d.persistentRedisDbClient.Exists(ctx, revokedUserDeviceKey(deviceId)).Result()
+	return count > 0, err
+}
+
+// UpdateUserDeviceActivity is called whenever a refresh token is issued or used
+func (d *DataAccessService) UpdateUserDeviceActivity(ctx context.Context, hashedRefreshToken, ip, userAgent string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_devices SET last_used_ts = NOW(), ip_address = $2, user_agent = LEFT($3, 500)
+		WHERE refresh_token = $1`, hashedRefreshToken, ip, userAgent)
+	return err
+}
+
+func (d *DataAccessService) AddUserSecurityEvent(ctx context.Context, userId uint64, eventType, ip, userAgent string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_security_events (user_id, event_type, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, LEFT($4, 500), NOW())`, userId, eventType, ip, userAgent)
+	return err
+}
+
+// GetUserSecurityEvents returns the latest events first
+func (d *DataAccessService) GetUserSecurityEvents(ctx context.Context, userId uint64, limit uint64) ([]t.UserSecurityEvent, error) {
+	var dbEvents []struct {
+		EventType string    `db:"event_type"`
+		IpAddress string    `db:"ip_address"`
+		UserAgent string    `db:"user_agent"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	err := d.userReader.SelectContext(ctx, &dbEvents, `
+		SELECT event_type, ip_address, user_agent, created_at
+		FROM users_security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, userId, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.UserSecurityEvent, 0, len(dbEvents))
+	for _, event := range dbEvents {
+		result = append(result, t.UserSecurityEvent{
+			Type:      event.EventType,
+			Timestamp: event.CreatedAt.Unix(),
+			Ip:        event.IpAddress,
+			UserAgent: event.UserAgent,
+		})
+	}
+	return result, nil
+}
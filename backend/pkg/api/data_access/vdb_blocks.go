@@ -351,43 +351,33 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 		slots[i] = proposal.Slot
 	}
 
-	// retrieve the cl rewards, source it from clickhouse for mainnet and from postgres for holsky
+	// retrieve the cl rewards from the dashboard storage, holesky always sources them from postgres
 	// TODO: harmonize this @invis
 	clRewardsData := []struct {
 		Slot     uint64              `db:"slot"`
 		ClReward decimal.NullDecimal `db:"cl_reward"`
 	}{}
+	clRewardsStorage := d.dashboardStorage
 	if utils.Config.Chain.ClConfig.DepositChainID == 17000 {
-		clRewardsQuery := goqu.Dialect("postgres").
-			From(goqu.T("consensus_payloads")).
-			Select(
-				goqu.C("slot"),
-				goqu.L("cl_attestations_reward / 1e9 + cl_sync_aggregate_reward / 1e9 + cl_slashing_inclusion_reward / 1e9 AS cl_reward"),
-			).Where(goqu.C("slot").In(slots))
-		clRewardsQuerySql, args, err := clRewardsQuery.Prepared(true).ToSQL()
-		if err != nil {
-			return nil, nil, err
-		}
-		err = d.alloyReader.SelectContext(ctx, &clRewardsData, clRewardsQuerySql, args...)
-		if err != nil {
-			return nil, nil, err
-		}
-	} else {
-		clRewardsQuery := goqu.Dialect("postgres").
-			From(goqu.L("mainnet.validator_proposal_rewards_slot")).
-			Select(
-				goqu.C("slot"),
-				goqu.L("attestations_reward / 1e9 + sync_aggregate_reward / 1e9 + slasher_reward / 1e9 AS cl_reward"),
-			).Where(goqu.C("slot").In(slots))
-		clRewardsQuerySql, args, err := clRewardsQuery.Prepared(true).ToSQL()
-		if err != nil {
-			return nil, nil, err
-		}
-		err = d.clickhouseReader.SelectContext(ctx, &clRewardsData, clRewardsQuerySql, args...)
+		clRewardsStorage, err = db.NewDashboardAggregateStorage(db.DashboardStoragePostgres, nil, d.alloyReader, nil)
 		if err != nil {
 			return nil, nil, err
 		}
 	}
+	clRewardsQuery := goqu.Dialect("postgres").
+		From(goqu.L(fmt.Sprintf("(%s) AS r", clRewardsStorage.ProposalClRewardsQuery()))).
+		Select(
+			goqu.C("slot"),
+			goqu.C("cl_reward"),
+		).Where(goqu.C("slot").In(slots))
+	clRewardsQuerySql, args, err := clRewardsQuery.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = clRewardsStorage.Reader().SelectContext(ctx, &clRewardsData, clRewardsQuerySql, args...)
+	if err != nil {
+		return nil, nil, err
+	}
 	clRewards := make(map[uint64]decimal.NullDecimal)
 	for _, reward := range clRewardsData {
 		clRewards[reward.Slot] = reward.ClReward
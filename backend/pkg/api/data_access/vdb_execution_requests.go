@@ -0,0 +1,409 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	executionRequestStatusPending   = "pending"
+	executionRequestStatusCompleted = "completed"
+	executionRequestStatusRejected  = "rejected"
+)
+
+func (d *DataAccessService) GetValidatorDashboardConsolidations(ctx context.Context, dashboardId t.VDBId, cursor string, limit uint64) ([]t.VDBConsolidationsTableRow, *t.Paging, error) {
+	var err error
+	currentDirection := enums.DESC // TODO: expose over parameter
+	var currentCursor t.ExecutionRequestsCursor
+
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ExecutionRequestsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ExecutionRequestsCursor: %w", err)
+		}
+	}
+
+	byteaArray, groups, err := d.getValidatorPubkeysWithGroups(ctx, dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(byteaArray) == 0 {
+		return []t.VDBConsolidationsTableRow{}, &t.Paging{}, nil
+	}
+
+	var data []struct {
+		Slot          int64  `db:"block_slot"`
+		SlotIndex     int64  `db:"block_index"`
+		SourceAddress []byte `db:"source_address"`
+		SourcePubkey  []byte `db:"source_pubkey"`
+		TargetPubkey  []byte `db:"target_pubkey"`
+	}
+
+	query := `
+			SELECT
+				cr.block_slot,
+				cr.block_index,
+				cr.source_address,
+				cr.source_pubkey,
+				cr.target_pubkey
+			FROM
+				blocks_consolidation_requests cr
+				INNER JOIN blocks b ON b.slot = cr.block_slot AND b.blockroot = cr.block_root AND b.status = '1'
+			WHERE
+				(cr.source_pubkey = ANY ($1) OR cr.target_pubkey = ANY ($1))`
+
+	params := []interface{}{byteaArray}
+	filterFragment := ` ORDER BY cr.block_slot DESC, cr.block_index DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` AND (cr.block_slot < $2 or (cr.block_slot = $2 and cr.block_index < $3)) ` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.SlotIndex)
+	}
+
+	if currentDirection == enums.ASC && !currentCursor.IsReverse() || currentDirection == enums.DESC && currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+
+	params = append(params, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(params))
+
+	err = db.AlloyReader.SelectContext(ctx, &data, query+filterFragment, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	currentEpoch := cache.LatestEpoch.Get()
+
+	responseData := make([]t.VDBConsolidationsTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, row := range data {
+		sourcePubkey := hexutil.Encode(row.SourcePubkey)
+		targetPubkey := hexutil.Encode(row.TargetPubkey)
+		responseData[i] = t.VDBConsolidationsTableRow{
+			Epoch:           utils.EpochOfSlot(uint64(row.Slot)),
+			Slot:            uint64(row.Slot),
+			SourcePublicKey: t.PubKey(sourcePubkey),
+			TargetPublicKey: t.PubKey(targetPubkey),
+			SourceAddress:   t.Address{Hash: t.Hash(hexutil.Encode(row.SourceAddress))},
+			GroupId:         t.DefaultGroupId,
+		}
+		addressMapping[hexutil.Encode(row.SourceAddress)] = nil
+		if v, ok := mapping.ValidatorIndices[sourcePubkey]; ok {
+			responseData[i].SourceIndex = &v
+		}
+		if v, ok := mapping.ValidatorIndices[targetPubkey]; ok {
+			responseData[i].TargetIndex = &v
+		}
+		if groupId, ok := groups[sourcePubkey]; ok {
+			responseData[i].GroupId = groupId
+		} else if groupId, ok := groups[targetPubkey]; ok {
+			responseData[i].GroupId = groupId
+		}
+		responseData[i].Status = getConsolidationStatus(mapping, responseData[i].SourceIndex, responseData[i].TargetIndex, responseData[i].Epoch, currentEpoch)
+	}
+
+	// populate address data
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range responseData {
+		responseData[i].SourceAddress = *addressMapping[string(responseData[i].SourceAddress.Hash)]
+	}
+
+	var paging t.Paging
+
+	moreDataFlag := len(responseData) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return responseData, &paging, nil
+	}
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		responseData = responseData[:len(responseData)-1]
+		data = data[:len(data)-1]
+	}
+
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(responseData)
+		slices.Reverse(data)
+	}
+
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return responseData, p, nil
+}
+
+func (d *DataAccessService) GetValidatorDashboardWithdrawalRequests(ctx context.Context, dashboardId t.VDBId, cursor string, limit uint64) ([]t.VDBWithdrawalRequestsTableRow, *t.Paging, error) {
+	var err error
+	currentDirection := enums.DESC // TODO: expose over parameter
+	var currentCursor t.ExecutionRequestsCursor
+
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ExecutionRequestsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ExecutionRequestsCursor: %w", err)
+		}
+	}
+
+	byteaArray, groups, err := d.getValidatorPubkeysWithGroups(ctx, dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(byteaArray) == 0 {
+		return []t.VDBWithdrawalRequestsTableRow{}, &t.Paging{}, nil
+	}
+
+	var data []struct {
+		Slot            int64  `db:"block_slot"`
+		SlotIndex       int64  `db:"block_index"`
+		SourceAddress   []byte `db:"source_address"`
+		ValidatorPubkey []byte `db:"validator_pubkey"`
+		Amount          int64  `db:"amount"`
+		Processed       bool   `db:"processed"`
+	}
+
+	// a request is processed once it is matched to the withdrawal that processed it
+	query := `
+			SELECT
+				wr.block_slot,
+				wr.block_index,
+				wr.source_address,
+				wr.validator_pubkey,
+				wr.amount,
+				m.withdrawalindex IS NOT NULL AS processed
+			FROM
+				blocks_withdrawal_requests wr
+				INNER JOIN blocks b ON b.slot = wr.block_slot AND b.blockroot = wr.block_root AND b.status = '1'
+				LEFT JOIN (` + db.WithdrawalRequestMatchesQuery(`wr.validator_pubkey = ANY ($1)`) + `
+				) m ON m.request_slot = wr.block_slot AND m.request_index = wr.block_index
+			WHERE
+				wr.validator_pubkey = ANY ($1)`
+
+	params := []interface{}{byteaArray}
+	filterFragment := ` ORDER BY wr.block_slot DESC, wr.block_index DESC`
+	if currentCursor.IsValid() {
+		filterFragment = ` AND (wr.block_slot < $2 or (wr.block_slot = $2 and wr.block_index < $3)) ` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.SlotIndex)
+	}
+
+	if currentDirection == enums.ASC && !currentCursor.IsReverse() || currentDirection == enums.DESC && currentCursor.IsReverse() {
+		filterFragment = strings.Replace(strings.Replace(filterFragment, "<", ">", -1), "DESC", "ASC", -1)
+	}
+
+	params = append(params, limit+1)
+	filterFragment += fmt.Sprintf(" LIMIT $%d", len(params))
+
+	err = db.AlloyReader.SelectContext(ctx, &data, query+filterFragment, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current validator mapping: %w", err)
+	}
+	currentEpoch := cache.LatestEpoch.Get()
+
+	responseData := make([]t.VDBWithdrawalRequestsTableRow, len(data))
+	addressMapping := make(map[string]*t.Address)
+	for i, row := range data {
+		pubkey := hexutil.Encode(row.ValidatorPubkey)
+		responseData[i] = t.VDBWithdrawalRequestsTableRow{
+			Epoch:         utils.EpochOfSlot(uint64(row.Slot)),
+			Slot:          uint64(row.Slot),
+			PublicKey:     t.PubKey(pubkey),
+			GroupId:       t.DefaultGroupId,
+			SourceAddress: t.Address{Hash: t.Hash(hexutil.Encode(row.SourceAddress))},
+			Type:          "partial",
+			Amount:        utils.GWeiToWei(big.NewInt(row.Amount)),
+		}
+		if row.Amount == 0 {
+			responseData[i].Type = "exit"
+			responseData[i].Amount = decimal.Zero
+		}
+		addressMapping[hexutil.Encode(row.SourceAddress)] = nil
+		if v, ok := mapping.ValidatorIndices[pubkey]; ok {
+			responseData[i].Index = &v
+		}
+		if groupId, ok := groups[pubkey]; ok {
+			responseData[i].GroupId = groupId
+		}
+		responseData[i].Status = getWithdrawalRequestStatus(mapping, responseData[i].Index, row.Amount == 0, row.Processed, responseData[i].Epoch, currentEpoch)
+	}
+
+	// populate address data
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+	for i := range responseData {
+		responseData[i].SourceAddress = *addressMapping[string(responseData[i].SourceAddress.Hash)]
+	}
+
+	var paging t.Paging
+
+	moreDataFlag := len(responseData) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return responseData, &paging, nil
+	}
+	if moreDataFlag {
+		// Remove the last entry as it is only required for the more data flag
+		responseData = responseData[:len(responseData)-1]
+		data = data[:len(data)-1]
+	}
+
+	if currentCursor.IsReverse() {
+		// Invert query result so response matches requested direction
+		slices.Reverse(responseData)
+		slices.Reverse(data)
+	}
+
+	p, err := utils.GetPagingFromData(data, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return responseData, p, nil
+}
+
+// getValidatorPubkeysWithGroups resolves the validators of a dashboard to their pubkeys, additionally returns the group of each pubkey (hex encoded)
+func (d *DataAccessService) getValidatorPubkeysWithGroups(ctx context.Context, dashboardId t.VDBId) (pq.ByteaArray, map[string]uint64, error) {
+	validators := dashboardId.Validators
+	groupIds := make([]uint64, len(validators))
+	if dashboardId.Validators == nil {
+		var queryResult []struct {
+			ValidatorIndex t.VDBValidator `db:"validator_index"`
+			GroupId        uint64         `db:"group_id"`
+		}
+		err := d.alloyReader.SelectContext(ctx, &queryResult, `
+			SELECT
+				validator_index,
+				group_id
+			FROM users_val_dashboards_validators
+			WHERE dashboard_id = $1`, dashboardId.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		validators = make([]t.VDBValidator, len(queryResult))
+		groupIds = make([]uint64, len(queryResult))
+		for i, res := range queryResult {
+			validators[i] = res.ValidatorIndex
+			groupIds[i] = res.GroupId
+			if dashboardId.AggregateGroups {
+				groupIds[i] = t.DefaultGroupId
+			}
+		}
+	} else {
+		for i := range groupIds {
+			groupIds[i] = t.DefaultGroupId
+		}
+	}
+
+	validatorPubkeys, err := d.services.GetPubkeySliceFromIndexSlice(validators)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve validator indices to pubkeys: %w", err)
+	}
+
+	byteaArray := make(pq.ByteaArray, len(validatorPubkeys))
+	groups := make(map[string]uint64, len(validatorPubkeys))
+	for i, p := range validatorPubkeys {
+		byteaArray[i], _ = hexutil.Decode(p)
+		groups[p] = groupIds[i]
+	}
+	return byteaArray, groups, nil
+}
+
+// getValidatorMetadata returns the current state of a validator, nil if the index is unknown
+func getValidatorMetadata(mapping *services.ValidatorMapping, index *uint64) *types.CachedValidator {
+	if index == nil || *index >= uint64(len(mapping.ValidatorMetadata)) {
+		return nil
+	}
+	return mapping.ValidatorMetadata[*index]
+}
+
+// hasExitSince returns true if the exit of the validator was initiated after the given epoch
+func hasExitSince(metadata *types.CachedValidator, epoch uint64) bool {
+	return metadata.ExitEpoch.Valid && metadata.ExitEpoch.Int64 > int64(epoch)
+}
+
+// getConsolidationStatus derives the status of a consolidation request from the current state of the involved validators.
+// Requests are processed within the block that includes them, a request without effect is considered rejected.
+func getConsolidationStatus(mapping *services.ValidatorMapping, sourceIndex, targetIndex *uint64, requestEpoch, currentEpoch uint64) string {
+	source := getValidatorMetadata(mapping, sourceIndex)
+	target := getValidatorMetadata(mapping, targetIndex)
+	if source == nil || target == nil {
+		return executionRequestStatusRejected
+	}
+
+	if *sourceIndex == *targetIndex {
+		// switch to compounding withdrawal credentials
+		if utils.HasCompoundingWithdrawalCredentials(target.WithdrawalCredentials) {
+			return executionRequestStatusCompleted
+		}
+	} else if hasExitSince(source, requestEpoch) {
+		if source.WithdrawableEpoch.Valid && source.WithdrawableEpoch.Int64 <= int64(currentEpoch) {
+			return executionRequestStatusCompleted
+		}
+		return executionRequestStatusPending
+	}
+
+	if requestEpoch < currentEpoch {
+		return executionRequestStatusRejected
+	}
+	return executionRequestStatusPending
+}
+
+// getWithdrawalRequestStatus derives the status of a withdrawal request from the current state of the validator
+func getWithdrawalRequestStatus(mapping *services.ValidatorMapping, index *uint64, isExit bool, processed bool, requestEpoch, currentEpoch uint64) string {
+	validator := getValidatorMetadata(mapping, index)
+	if validator == nil {
+		return executionRequestStatusRejected
+	}
+
+	if isExit {
+		if processed {
+			return executionRequestStatusCompleted
+		}
+		if hasExitSince(validator, requestEpoch) {
+			if validator.ExitEpoch.Int64 <= int64(currentEpoch) {
+				return executionRequestStatusCompleted
+			}
+			return executionRequestStatusPending
+		}
+		if requestEpoch < currentEpoch {
+			return executionRequestStatusRejected
+		}
+		return executionRequestStatusPending
+	}
+
+	if processed {
+		return executionRequestStatusCompleted
+	}
+	// only compounding validators can request partial withdrawals and pending partials of exiting validators are dropped
+	if !utils.HasCompoundingWithdrawalCredentials(validator.WithdrawalCredentials) || validator.ExitEpoch.Valid {
+		return executionRequestStatusRejected
+	}
+	return executionRequestStatusPending
+}
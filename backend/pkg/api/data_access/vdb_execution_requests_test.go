@@ -0,0 +1,43 @@
+package dataaccess
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/api/services"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestGetWithdrawalRequestStatus(t *testing.T) {
+	compounding := make([]byte, 32)
+	compounding[0] = utils.CompoundingWithdrawalPrefix
+	mapping := &services.ValidatorMapping{ValidatorMetadata: []*types.CachedValidator{
+		{WithdrawalCredentials: compounding},
+		{WithdrawalCredentials: compounding, ExitEpoch: sql.NullInt64{Int64: 120, Valid: true}},
+		{WithdrawalCredentials: make([]byte, 32)},
+	}}
+	active, exiting, bls, unknown := uint64(0), uint64(1), uint64(2), uint64(3)
+
+	tests := []struct {
+		name      string
+		index     *uint64
+		isExit    bool
+		processed bool
+		want      string
+	}{
+		{"unknown validator", &unknown, false, false, executionRequestStatusRejected},
+		{"processed partial", &active, false, true, executionRequestStatusCompleted},
+		{"pending partial", &active, false, false, executionRequestStatusPending},
+		{"partial of exiting validator", &exiting, false, false, executionRequestStatusRejected},
+		{"partial of non compounding validator", &bls, false, false, executionRequestStatusRejected},
+		{"processed exit", &exiting, true, true, executionRequestStatusCompleted},
+		{"initiated exit", &exiting, true, false, executionRequestStatusPending},
+		{"exit without effect", &active, true, false, executionRequestStatusRejected},
+	}
+	for _, tt := range tests {
+		if got := getWithdrawalRequestStatus(mapping, tt.index, tt.isExit, tt.processed, 100, 110); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
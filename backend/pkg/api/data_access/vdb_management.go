@@ -31,7 +31,8 @@ func (d *DataAccessService) GetValidatorDashboardUser(ctx context.Context, dashb
 	err := d.alloyReader.GetContext(ctx, result, `
 		SELECT
 			id,
-			user_id
+			user_id,
+			organization_id
 		FROM users_val_dashboards
 		WHERE id = $1
 	`, dashboardId)
@@ -71,6 +72,7 @@ func (d *DataAccessService) GetValidatorDashboardInfo(ctx context.Context, dashb
 			PublicId     sql.NullString `db:"public_id"`
 			PublicName   sql.NullString `db:"public_name"`
 			SharedGroups sql.NullBool   `db:"shared_groups"`
+			OrgId        sql.NullInt64  `db:"organization_id"`
 		}{}
 
 		err := d.alloyReader.SelectContext(ctx, &dbReturn, `
@@ -80,7 +82,8 @@ func (d *DataAccessService) GetValidatorDashboardInfo(ctx context.Context, dashb
 			uvd.is_archived,
 			uvds.public_id,
 			uvds.name AS public_name,
-			uvds.shared_groups
+			uvds.shared_groups,
+			uvd.organization_id
 		FROM users_val_dashboards uvd
 		LEFT JOIN users_val_dashboards_sharing uvds ON uvd.id = uvds.dashboard_id
 		WHERE uvd.id = $1
@@ -99,6 +102,7 @@ func (d *DataAccessService) GetValidatorDashboardInfo(ctx context.Context, dashb
 		result.Network = dbReturn[0].Network
 		result.IsArchived = dbReturn[0].IsArchived.Valid
 		result.ArchivedReason = dbReturn[0].IsArchived.String
+		result.OrganizationId = uint64(dbReturn[0].OrgId.Int64)
 
 		for _, row := range dbReturn {
 			if row.PublicId.Valid {
@@ -467,7 +471,7 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 		// Efficiency
 		eg.Go(func() error {
 			ds := goqu.Dialect("postgres").
-				From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, table))).
+				From(goqu.L(d.dashboardStorage.RollingTable(table, "r"))).
 				With("validators", goqu.L("(SELECT dashboard_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 				Select(
 					goqu.L("COALESCE(SUM(r.attestations_reward)::decimal, 0) AS attestations_reward"),
@@ -500,7 +504,7 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 				return fmt.Errorf("error preparing query: %w", err)
 			}
 
-			err = d.clickhouseReader.GetContext(ctx, &queryResult, query, args...)
+			err = d.dashboardStorage.Reader().GetContext(ctx, &queryResult, query, args...)
 			if err != nil {
 				return err
 			}
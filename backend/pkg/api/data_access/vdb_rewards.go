@@ -82,7 +82,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the query that serves as base for both the main and EL rewards queries
 	rewardsDs := goqu.Dialect("postgres").
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(d.dashboardStorage.ChartTable("validator_dashboard_data_epoch", "e"))).
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("e.epoch"),
@@ -95,7 +95,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			goqu.L("SUM(COALESCE(e.sync_executed, 0)) AS sync_executed"),
 			goqu.L("SUM(CASE WHEN e.slashed THEN 1 ELSE 0 END) AS slashed_in_epoch"),
 			goqu.L("SUM(COALESCE(e.blocks_slashing_count, 0)) AS slashed_amount")).
-		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", utils.EpochToTime(startEpoch).Unix()))
+		Where(goqu.L(fmt.Sprintf("e.epoch_timestamp >= %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(startEpoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
@@ -126,21 +126,21 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			if currentCursor.IsReverse() {
 				if currentCursor.GroupId == t.AllGroups {
 					// The cursor is on the total rewards so get the data for all groups excluding the cursor epoch
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s %s", sortSearchDirection, d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s ?", sortSearchDirection), currentCursor.Epoch))
 				} else {
 					// The cursor is on a specific group, get the data for the whole epoch since we could need it for the total rewards
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= %s", sortSearchDirection, d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s= ?", sortSearchDirection), currentCursor.Epoch))
 				}
 			} else {
 				if currentCursor.GroupId == t.AllGroups {
 					// The cursor is on the total rewards so get the data for all groups including the cursor epoch
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= %s", sortSearchDirection, d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s= ?", sortSearchDirection), currentCursor.Epoch))
 				} else {
 					// The cursor is on a specific group so get the data for groups before/after it
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("(e.epoch_timestamp %[1]s fromUnixTimestamp(?) OR (e.epoch_timestamp = fromUnixTimestamp(?) AND v.group_id %[1]s ?))", sortSearchDirection),
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("(e.epoch_timestamp %[1]s %[2]s OR (e.epoch_timestamp = %[2]s AND v.group_id %[1]s ?))", sortSearchDirection, d.dashboardStorage.FromUnixTimestamp()),
 						utils.EpochToTime(currentCursor.Epoch).Unix(), utils.EpochToTime(currentCursor.Epoch).Unix(), currentCursor.GroupId))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("(b.epoch %[1]s ? OR (b.epoch = ? AND v.group_id %[1]s ?))", sortSearchDirection),
 						currentCursor.Epoch, currentCursor.Epoch, currentCursor.GroupId))
@@ -170,7 +170,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 					}
 				}
 				if !found && epochSearch != -1 {
-					rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp = %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(uint64(epochSearch)).Unix()))
 					elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 				}
 			} else {
@@ -209,7 +209,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 				if len(groupIdSearchMap) == 0 {
 					if epochSearch != -1 {
 						// If we have an epoch search but no group search then we can restrict the query to the epoch
-						rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+						rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp = %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(uint64(epochSearch)).Unix()))
 						elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 					} else {
 						// No search for goup or epoch possible, return empty results
@@ -263,7 +263,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			GroupBy(goqu.L("b.epoch"))
 
 		if currentCursor.IsValid() {
-			rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+			rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s %s", sortSearchDirection, d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(currentCursor.Epoch).Unix()))
 			elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s ?", sortSearchDirection), currentCursor.Epoch))
 		}
 		if search != "" {
@@ -279,7 +279,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 				found = utils.ElementExists(dashboardId.Validators, t.VDBValidator(indexSearch))
 			}
 			if !found && epochSearch != -1 {
-				rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+				rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp = %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(uint64(epochSearch)).Unix()))
 				elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 			}
 		}
@@ -315,7 +315,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			return fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+		err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving rewards data: %w", err)
 		}
@@ -530,7 +530,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the query that serves as base for both the main and EL rewards queries
 	rewardsDs := goqu.Dialect("postgres").
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(d.dashboardStorage.ChartTable("validator_dashboard_data_epoch", "e"))).
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("COALESCE(e.attestations_source_reward, 0) AS attestations_source_reward"),
@@ -553,7 +553,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 			goqu.L("COALESCE(e.blocks_cl_slasher_reward, 0) AS slasher_reward"),
 			goqu.L("COALESCE(e.blocks_cl_attestations_reward, 0) AS blocks_cl_attestations_reward"),
 			goqu.L("COALESCE(e.blocks_cl_sync_aggregate_reward, 0) AS blocks_cl_sync_aggregate_reward")).
-		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(epoch).Unix()))
+		Where(goqu.L(fmt.Sprintf("e.epoch_timestamp = %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(epoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
@@ -628,7 +628,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 			return fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+		err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving group rewards data: %w", err)
 		}
@@ -726,9 +726,9 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 		Select(
 			goqu.L("e.epoch"),
 			goqu.L(`SUM(COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) + COALESCE(e.sync_rewards, 0)) AS cl_rewards`)).
-		From(goqu.L("validator_dashboard_data_epoch e")).
+		From(goqu.L(d.dashboardStorage.ChartTable("validator_dashboard_data_epoch", "e"))).
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
-		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", utils.EpochToTime(startEpoch).Unix()))
+		Where(goqu.L(fmt.Sprintf("e.epoch_timestamp >= %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(startEpoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
@@ -803,7 +803,7 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 			return fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+		err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving rewards chart data: %w", err)
 		}
@@ -974,8 +974,8 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			goqu.L("COALESCE(e.blocks_proposed, 0) AS blocks_proposed"),
 			goqu.L("COALESCE(e.blocks_cl_attestations_reward, 0) AS blocks_cl_attestations_reward"),
 			goqu.L("COALESCE(e.blocks_cl_sync_aggregate_reward, 0) AS blocks_cl_sync_aggregate_reward")).
-		From(goqu.L("validator_dashboard_data_epoch e")).
-		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(epoch).Unix())).
+		From(goqu.L(d.dashboardStorage.ChartTable("validator_dashboard_data_epoch", "e"))).
+		Where(goqu.L(fmt.Sprintf("e.epoch_timestamp = %s", d.dashboardStorage.FromUnixTimestamp()), utils.EpochToTime(epoch).Unix())).
 		Where(goqu.L(`
 			(COALESCE(e.attestations_scheduled, 0) +
 			COALESCE(e.sync_scheduled,0) +
@@ -1068,7 +1068,7 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			return fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+		err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving validator rewards data: %w", err)
 		}
@@ -18,6 +18,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
@@ -99,7 +100,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 	var queryResult []struct {
 		GroupId                int64           `db:"result_group_id"`
 		GroupName              string          `db:"group_name"`
-		ValidatorIndices       []uint64        `db:"validator_indices"`
+		ValidatorIndices       db.Uint64Array  `db:"validator_indices"`
 		ClRewards              int64           `db:"cl_rewards"`
 		AttestationReward      decimal.Decimal `db:"attestations_reward"`
 		AttestationIdealReward decimal.Decimal `db:"attestations_ideal_reward"`
@@ -114,7 +115,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 	}
 
 	ds := goqu.Dialect("postgres").
-		From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, clickhouseTable))).
+		From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTable, "r"))).
 		With("validators", goqu.L("(SELECT dashboard_id, group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("ARRAY_AGG(r.validator_index) AS validator_indices"),
@@ -162,7 +163,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		return nil, nil, fmt.Errorf("error preparing query: %w", err)
 	}
 
-	err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+	err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error retrieving data from table %s: %w", clickhouseTable, err)
 	}
@@ -541,13 +542,13 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 			Select(
 				goqu.L("MAX(last_scheduled_block_epoch) as last_scheduled_block_epoch"),
 				goqu.L("MAX(last_scheduled_sync_epoch) as last_scheduled_sync_epoch")).
-			From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, clickhouseTotalTable)))
+			From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTotalTable, "r")))
 
 		if dashboardId.Validators == nil {
 			ds = ds.
 				With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ? AND (group_id = ? OR ?::smallint = -1))", dashboardId.Id, groupId, groupId)).
 				InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
-				Where(goqu.L("validator_index IN (SELECT validator_index FROM validators)"))
+				Where(goqu.L("r.validator_index IN (SELECT validator_index FROM validators)"))
 		} else {
 			ds = ds.
 				Where(goqu.L("validator_index IN ?", validators))
@@ -562,7 +563,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 			LastScheduledBlockEpoch *int64 `db:"last_scheduled_block_epoch"`
 			LastSyncEpoch           *int64 `db:"last_scheduled_sync_epoch"`
 		}
-		err = d.clickhouseReader.GetContext(ctx, &row, query, args...)
+		err = d.dashboardStorage.Reader().GetContext(ctx, &row, query, args...)
 		if err != nil {
 			return time.Time{}, time.Time{}, err
 		}
@@ -576,7 +577,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 
 	ds := goqu.Dialect("postgres").
 		Select(
-			goqu.L("validator_index"),
+			goqu.L("r.validator_index AS validator_index"),
 			goqu.L("epoch_start"),
 			goqu.L("attestations_reward"),
 			goqu.L("attestations_ideal_reward"),
@@ -594,13 +595,13 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 			goqu.L("blocks_expected"),
 			goqu.L("inclusion_delay_sum"),
 			goqu.L("sync_committees_expected")).
-		From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, clickhouseTable)))
+		From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTable, "r")))
 
 	if dashboardId.Validators == nil {
 		ds = ds.
 			With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ? AND (group_id = ? OR ?::smallint = -1))", dashboardId.Id, groupId, groupId)).
 			InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
-			Where(goqu.L("validator_index IN (SELECT validator_index FROM validators)"))
+			Where(goqu.L("r.validator_index IN (SELECT validator_index FROM validators)"))
 	} else {
 		ds = ds.
 			Where(goqu.L("validator_index IN ?", validators))
@@ -642,7 +643,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 			return err
 		}
 
-		err = d.clickhouseReader.SelectContext(ctx, &rows, query, args...)
+		err = d.dashboardStorage.Reader().SelectContext(ctx, &rows, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving validator dashboard group summary data: %w", err)
 		}
@@ -844,6 +845,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		EpochStart     uint64        `db:"epoch_start"`
 		EpochEnd       uint64        `db:"epoch_end"`
 		ValidatorCount uint64        `db:"validator_count"`
+		StakedBalance  uint64        `db:"staked_balance"`
 		Reward         sql.NullInt64 `db:"reward"`
 	}
 
@@ -851,12 +853,14 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 	var rewardsResultTotal RewardsResult
 
 	rewardsDs := goqu.Dialect("postgres").
-		From(goqu.L(fmt.Sprintf("%s AS r FINAL", table))).
+		From(goqu.L(d.dashboardStorage.RollingTable(table, "r"))).
 		With("validators", goqu.L("(SELECT group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("MIN(epoch_start) AS epoch_start"),
 			goqu.L("MAX(epoch_end) AS epoch_end"),
 			goqu.L("COUNT(*) AS validator_count"),
+			// compounding validators can hold more than the activation balance
+			goqu.L("SUM(GREATEST(COALESCE(r.balance_start, 0), ?)) AS staked_balance", utils.Config.Chain.ClConfig.MinActivationBalance),
 			goqu.L("(SUM(COALESCE(r.balance_end,0)) + SUM(COALESCE(r.withdrawals_amount,0)) - SUM(COALESCE(r.deposits_amount,0)) - SUM(COALESCE(r.balance_start,0))) AS reward"))
 
 	if len(dashboardId.Validators) > 0 {
@@ -878,7 +882,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		return decimal.Zero, 0, decimal.Zero, 0, fmt.Errorf("error preparing query: %w", err)
 	}
 
-	err = d.clickhouseReader.GetContext(ctx, &rewardsResultTable, query, args...)
+	err = d.dashboardStorage.Reader().GetContext(ctx, &rewardsResultTable, query, args...)
 	if err != nil || !rewardsResultTable.Reward.Valid {
 		return decimal.Zero, 0, decimal.Zero, 0, err
 	}
@@ -891,7 +895,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 	if hours == -1 { // for all time APR
 		aprDivisor = 90 * 24
 	}
-	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / (float64(32e9) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / float64(rewardsResultTable.StakedBalance)) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(clAPR) {
 		clAPR = 0
 	}
@@ -900,14 +904,14 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 
 	if hours == -1 {
 		rewardsDs = rewardsDs.
-			From(goqu.L("validator_dashboard_data_rolling_total AS r FINAL"))
+			From(goqu.L(d.dashboardStorage.RollingTable("validator_dashboard_data_rolling_total", "r")))
 
 		query, args, err = rewardsDs.Prepared(true).ToSQL()
 		if err != nil {
 			return decimal.Zero, 0, decimal.Zero, 0, fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.clickhouseReader.GetContext(ctx, &rewardsResultTotal, query, args...)
+		err = d.dashboardStorage.Reader().GetContext(ctx, &rewardsResultTotal, query, args...)
 		if err != nil || !rewardsResultTotal.Reward.Valid {
 			return decimal.Zero, 0, decimal.Zero, 0, err
 		}
@@ -958,7 +962,7 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		return decimal.Zero, 0, decimal.Zero, 0, err
 	}
 	elIncomeFloat, _ := elIncome.Float64() // EL income is in ETH
-	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(32) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(rewardsResultTable.StakedBalance) / 1e9)) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(elAPR) {
 		elAPR = 0
 	}
@@ -992,23 +996,9 @@ func (d *DataAccessService) GetValidatorDashboardSummaryChart(ctx context.Contex
 	}
 
 	// log.Infof("retrieving data between %v and %v for aggregation %v", time.Unix(int64(afterTs), 0), time.Unix(int64(beforeTs), 0), aggregation)
-	dataTable := ""
-	dateColumn := ""
-	switch aggregation {
-	case enums.IntervalEpoch:
-		dataTable = "validator_dashboard_data_epoch"
-		dateColumn = "epoch_timestamp"
-	case enums.IntervalHourly:
-		dataTable = "validator_dashboard_data_hourly"
-		dateColumn = "hour"
-	case enums.IntervalDaily:
-		dataTable = "validator_dashboard_data_daily"
-		dateColumn = "day"
-	case enums.IntervalWeekly:
-		dataTable = "validator_dashboard_data_weekly"
-		dateColumn = "week"
-	default:
-		return nil, fmt.Errorf("unexpected aggregation type: %v", aggregation)
+	dataTable, dateColumn, err := getChartTableForAggregation(aggregation)
+	if err != nil {
+		return nil, err
 	}
 
 	var queryResults []*t.VDBValidatorSummaryChartRow
@@ -1025,48 +1015,42 @@ func (d *DataAccessService) GetValidatorDashboardSummaryChart(ctx context.Contex
 	totalLineRequested := requestedGroupsMap[t.AllGroups]
 	averageNetworkLineRequested := requestedGroupsMap[t.NetworkAverage]
 
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.L(fmt.Sprintf("d.%s AS ts", dateColumn)),
+			goqu.L("COALESCE(SUM(d.attestations_reward), 0) AS attestation_reward"),
+			goqu.L("COALESCE(SUM(d.attestations_ideal_reward), 0) AS attestations_ideal_reward"),
+			goqu.L("COALESCE(SUM(d.blocks_proposed), 0) AS blocks_proposed"),
+			goqu.L("COALESCE(SUM(d.blocks_scheduled), 0) AS blocks_scheduled"),
+			goqu.L("COALESCE(SUM(d.sync_executed), 0) AS sync_executed"),
+			goqu.L("COALESCE(SUM(d.sync_scheduled), 0) AS sync_scheduled")).
+		From(goqu.L(d.dashboardStorage.ChartTable(dataTable, "d"))).
+		Where(
+			goqu.L(fmt.Sprintf("d.%s >= %s", dateColumn, d.dashboardStorage.FromUnixTimestamp()), afterTs),
+			goqu.L(fmt.Sprintf("d.%s <= %s", dateColumn, d.dashboardStorage.FromUnixTimestamp()), beforeTs))
+
 	if dashboardId.Validators != nil {
-		query := fmt.Sprintf(`
-			SELECT
-				%[2]s as ts,
-				0 AS group_id, 
-				COALESCE(SUM(d.attestations_reward), 0) AS attestation_reward,
-				COALESCE(SUM(d.attestations_ideal_reward), 0) AS attestations_ideal_reward,
-				COALESCE(SUM(d.blocks_proposed), 0) AS blocks_proposed,
-				COALESCE(SUM(d.blocks_scheduled), 0) AS blocks_scheduled,
-				COALESCE(SUM(d.sync_executed), 0) AS sync_executed,
-				COALESCE(SUM(d.sync_scheduled), 0) AS sync_scheduled
-			FROM %[1]s d
-			WHERE %[2]s >= fromUnixTimestamp($1) AND %[2]s <= fromUnixTimestamp($2) AND validator_index IN ($3)
-			GROUP BY %[2]s;
-		`, dataTable, dateColumn)
-		err := d.clickhouseReader.SelectContext(ctx, &queryResults, query, afterTs, beforeTs, dashboardId.Validators)
-		if err != nil {
-			return nil, fmt.Errorf("error retrieving data from table %s: %w", dataTable, err)
-		}
+		ds = ds.
+			SelectAppend(goqu.L("0 AS group_id")).
+			Where(goqu.L("d.validator_index IN ?", dashboardId.Validators)).
+			GroupBy(goqu.L("ts"))
 	} else {
-		query := fmt.Sprintf(`
-		WITH validators AS (
-			SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = $3 AND (group_id IN ($4) OR $5)
-		)		
-		SELECT
-			%[2]s as ts,
-			v.group_id,
-			COALESCE(SUM(d.attestations_reward), 0) AS attestation_reward,
-			COALESCE(SUM(d.attestations_ideal_reward), 0) AS attestations_ideal_reward,
-			COALESCE(SUM(d.blocks_proposed), 0) AS blocks_proposed,
-			COALESCE(SUM(d.blocks_scheduled), 0) AS blocks_scheduled,
-			COALESCE(SUM(d.sync_executed), 0) AS sync_executed,
-			COALESCE(SUM(d.sync_scheduled), 0) AS sync_scheduled
-		FROM %[1]s d
-		INNER JOIN validators v ON d.validator_index = v.validator_index
-		WHERE %[2]s >= fromUnixTimestamp($1) AND %[2]s <= fromUnixTimestamp($2) AND validator_index in (select validator_index from validators)
-		GROUP BY 1, 2;`, dataTable, dateColumn)
-
-		err := d.clickhouseReader.SelectContext(ctx, &queryResults, query, afterTs, beforeTs, dashboardId.Id, groupIds, totalLineRequested)
-		if err != nil {
-			return nil, fmt.Errorf("error retrieving data from table %s: %w", dataTable, err)
-		}
+		ds = ds.
+			SelectAppend(goqu.L("v.group_id")).
+			With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ? AND (group_id IN ? OR ?))", dashboardId.Id, groupIds, totalLineRequested)).
+			InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("d.validator_index = v.validator_index"))).
+			Where(goqu.L("d.validator_index IN (SELECT validator_index FROM validators)")).
+			GroupBy(goqu.L("ts"), goqu.L("v.group_id"))
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResults, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving data from table %s: %w", dataTable, err)
 	}
 
 	// convert the returned data to the expected return type (not pretty)
@@ -1178,29 +1162,30 @@ func (d *DataAccessService) GetValidatorDashboardSummaryChart(ctx context.Contex
 	return ret, nil
 }
 
-func (d *DataAccessService) GetLatestExportedChartTs(ctx context.Context, aggregation enums.ChartAggregation) (uint64, error) {
-	var table string
-	var dateColumn string
+// getChartTableForAggregation returns the chart table of the aggregation and its timestamp column
+func getChartTableForAggregation(aggregation enums.ChartAggregation) (string, string, error) {
 	switch aggregation {
 	case enums.IntervalEpoch:
-		table = "view_validator_dashboard_data_epoch_max_ts"
-		dateColumn = "t"
+		return "validator_dashboard_data_epoch", "epoch_timestamp", nil
 	case enums.IntervalHourly:
-		table = "view_validator_dashboard_data_hourly_max_ts"
-		dateColumn = "t"
+		return "validator_dashboard_data_hourly", "hour", nil
 	case enums.IntervalDaily:
-		table = "view_validator_dashboard_data_daily_max_ts"
-		dateColumn = "t"
+		return "validator_dashboard_data_daily", "day", nil
 	case enums.IntervalWeekly:
-		table = "view_validator_dashboard_data_weekly_max_ts"
-		dateColumn = "t"
+		return "validator_dashboard_data_weekly", "week", nil
 	default:
-		return 0, fmt.Errorf("unexpected aggregation type: %v", aggregation)
+		return "", "", fmt.Errorf("unexpected aggregation type: %v", aggregation)
+	}
+}
+
+func (d *DataAccessService) GetLatestExportedChartTs(ctx context.Context, aggregation enums.ChartAggregation) (uint64, error) {
+	table, dateColumn, err := getChartTableForAggregation(aggregation)
+	if err != nil {
+		return 0, err
 	}
 
-	query := fmt.Sprintf(`SELECT max(%s) FROM %s`, dateColumn, table)
 	var ts time.Time
-	err := d.clickhouseReader.GetContext(ctx, &ts, query)
+	err = d.dashboardStorage.Reader().GetContext(ctx, &ts, d.dashboardStorage.LatestChartTsQuery(table, dateColumn))
 	if err != nil {
 		return 0, fmt.Errorf("error retrieving latest exported chart timestamp: %w", err)
 	}
@@ -1373,7 +1358,7 @@ func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx conte
 		ds := goqu.Dialect("postgres").
 			Select(
 				goqu.L("epoch_start")).
-			From(goqu.L(fmt.Sprintf("%s FINAL", clickhouseTable))).
+			From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTable, ""))).
 			Limit(1)
 
 		query, args, err := ds.Prepared(true).ToSQL()
@@ -1382,7 +1367,7 @@ func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx conte
 		}
 
 		var epochStart uint64
-		err = d.clickhouseReader.GetContext(ctx, &epochStart, query, args...)
+		err = d.dashboardStorage.Reader().GetContext(ctx, &epochStart, query, args...)
 		if err != nil {
 			return fmt.Errorf("error retrieving cutoff epoch for past sync committees: %w", err)
 		}
@@ -1457,7 +1442,7 @@ func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx
 
 	// Build the query
 	ds := goqu.Dialect("postgres").
-		From(goqu.L(fmt.Sprintf("%s AS r FINAL", clickhouseTable))).
+		From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTable, "r"))).
 		With("validators", goqu.L("(SELECT group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("r.epoch_start"),
@@ -1486,7 +1471,7 @@ func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx
 		return nil, err
 	}
 
-	err = d.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+	err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 	if err != nil {
 		log.Error(err, "error while getting validator dashboard slashed validators list", 0)
 		return nil, err
@@ -1686,7 +1671,7 @@ func (d *DataAccessService) GetValidatorDashboardProposalSummaryValidators(ctx c
 		Select(
 			goqu.L("epoch_start"),
 			goqu.L("epoch_end")).
-		From(goqu.L(fmt.Sprintf("%s FINAL", clickhouseTable))).
+		From(goqu.L(d.dashboardStorage.RollingTable(clickhouseTable, ""))).
 		Limit(1)
 
 	query, args, err := ds.Prepared(true).ToSQL()
@@ -1694,7 +1679,7 @@ func (d *DataAccessService) GetValidatorDashboardProposalSummaryValidators(ctx c
 		return nil, fmt.Errorf("error preparing query: %w", err)
 	}
 
-	err = d.clickhouseReader.GetContext(ctx, &epochQueryResult, query, args...)
+	err = d.dashboardStorage.Reader().GetContext(ctx, &epochQueryResult, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving epoch info for proposals: %w", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/doug-martin/goqu/v9"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
@@ -345,8 +346,9 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 			continue
 		}
 
+		maxEffectiveBalance := utils.GetMaxEffectiveBalance(metadata.WithdrawalCredentials)
 		if (metadata.Balance > 0 && metadata.WithdrawableEpoch.Valid && metadata.WithdrawableEpoch.Int64 <= int64(epoch)) ||
-			(metadata.EffectiveBalance == utils.Config.Chain.ClConfig.MaxEffectiveBalance && metadata.Balance > utils.Config.Chain.ClConfig.MaxEffectiveBalance) {
+			(metadata.EffectiveBalance == maxEffectiveBalance && metadata.Balance > maxEffectiveBalance) {
 			// this validator is eligible for withdrawal, check if it is the next one
 			if nextValidator == nil || validator > *stats.LatestValidatorWithdrawalIndex {
 				distance, err := d.getWithdrawableCountFromCursor(validator, *stats.LatestValidatorWithdrawalIndex)
@@ -395,16 +397,17 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, err
 	}
 
+	maxEffectiveBalance := utils.GetMaxEffectiveBalance(nextValidatorData.WithdrawalCredentials)
 	var withdrawalAmount uint64
 	if nextValidatorData.WithdrawableEpoch.Valid && nextValidatorData.WithdrawableEpoch.Int64 <= int64(epoch) {
 		// full withdrawal
 		withdrawalAmount = nextValidatorData.Balance
 	} else {
 		// partial withdrawal
-		withdrawalAmount = nextValidatorData.Balance - utils.Config.Chain.ClConfig.MaxEffectiveBalance
+		withdrawalAmount = nextValidatorData.Balance - maxEffectiveBalance
 	}
 
-	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < utils.Config.Chain.ClConfig.MaxEffectiveBalance {
+	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < maxEffectiveBalance {
 		withdrawalAmount = 0
 	}
 
@@ -458,43 +461,33 @@ func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Co
 		Amount         int64          `db:"acc_withdrawals_amount"`
 	}{}
 
-	withdrawalsQuery := `
-			WITH validators AS (
-				SELECT validator_index FROM users_val_dashboards_validators WHERE (dashboard_id = $1)
-			)
-			SELECT
-				validator_index,
-				SUM(withdrawals_amount) AS acc_withdrawals_amount,
-				MAX(epoch_end) AS epoch_end
-			FROM validator_dashboard_data_rolling_total FINAL
-			INNER JOIN validators v ON validator_dashboard_data_rolling_total.validator_index = v.validator_index
-			WHERE validator_index IN (select validator_index FROM validators)
-			GROUP BY validator_index
-		`
-
-	if dashboardId.Validators != nil {
-		withdrawalsQuery = `
-			SELECT
-				validator_index,
-				SUM(withdrawals_amount) AS acc_withdrawals_amount,
-				MAX(epoch_end) AS epoch_end
-			from validator_dashboard_data_rolling_total FINAL
-			where validator_index IN ($1)
-			group by validator_index
-		`
-	}
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.L("r.validator_index AS validator_index"),
+			goqu.L("SUM(r.withdrawals_amount) AS acc_withdrawals_amount"),
+			goqu.L("MAX(r.epoch_end) AS epoch_end")).
+		From(goqu.L(d.dashboardStorage.RollingTable("validator_dashboard_data_rolling_total", "r"))).
+		GroupBy(goqu.L("r.validator_index"))
 
-	dashboardValidators := make([]t.VDBValidator, 0)
 	if dashboardId.Validators != nil {
-		dashboardValidators = dashboardId.Validators
+		if len(dashboardId.Validators) == 0 {
+			return result, nil
+		}
+		ds = ds.
+			Where(goqu.L("r.validator_index IN ?", dashboardId.Validators))
+	} else {
+		ds = ds.
+			With("validators", goqu.L("(SELECT validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
+			InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("r.validator_index = v.validator_index"))).
+			Where(goqu.L("r.validator_index IN (SELECT validator_index FROM validators)"))
 	}
 
-	if len(dashboardValidators) > 0 {
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, withdrawalsQuery, dashboardValidators)
-	} else {
-		err = d.clickhouseReader.SelectContext(ctx, &queryResult, withdrawalsQuery, dashboardId.Id)
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
 	}
 
+	err = d.dashboardStorage.Reader().SelectContext(ctx, &queryResult, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error getting total withdrawals for validators: %+v: %w", dashboardId, err)
 	}
@@ -561,10 +554,11 @@ func (d *DataAccessService) getValidatorSearch(search string) ([]t.VDBValidator,
 				if err != nil {
 					return nil, fmt.Errorf("failed to decode search term %s as address: %w", search, err)
 				}
-				withdrawalCredentials := utils.GetWithdrawalCredentialsOfAddress(common.BytesToAddress(address))
+				addressBytes := common.BytesToAddress(address).Bytes()
 
+				// match both 0x01 and compounding 0x02 credentials of the address
 				for index, metadata := range validatorMapping.ValidatorMetadata {
-					if bytes.Equal(withdrawalCredentials, metadata.WithdrawalCredentials) {
+					if utils.HasExecutionWithdrawalCredentials(metadata.WithdrawalCredentials) && bytes.Equal(addressBytes, metadata.WithdrawalCredentials[12:]) {
 						validatorSearch = append(validatorSearch, t.VDBValidator(index))
 					}
 				}
@@ -261,3 +261,61 @@ func (c ChartAggregation) Duration(secondsPerEpoch uint64) time.Duration {
 		return 0
 	}
 }
+
+// ----------------
+// Machine Metrics Resolution
+
+type MachineMetricsResolution int
+
+var _ EnumFactory[MachineMetricsResolution] = MachineMetricsResolution(0)
+
+const (
+	MachineMetricsResolutionAuto MachineMetricsResolution = iota
+	MachineMetricsResolutionMinute
+	MachineMetricsResolutionFiveMinutes
+	MachineMetricsResolutionHour
+)
+
+func (c MachineMetricsResolution) Int() int {
+	return int(c)
+}
+
+func (MachineMetricsResolution) NewFromString(s string) MachineMetricsResolution {
+	switch s {
+	case "", "auto":
+		return MachineMetricsResolutionAuto
+	case "1m":
+		return MachineMetricsResolutionMinute
+	case "5m":
+		return MachineMetricsResolutionFiveMinutes
+	case "1h":
+		return MachineMetricsResolutionHour
+	default:
+		return MachineMetricsResolution(-1)
+	}
+}
+
+var MachineMetricsResolutions = struct {
+	Auto        MachineMetricsResolution
+	Minute      MachineMetricsResolution
+	FiveMinutes MachineMetricsResolution
+	Hour        MachineMetricsResolution
+}{
+	MachineMetricsResolutionAuto,
+	MachineMetricsResolutionMinute,
+	MachineMetricsResolutionFiveMinutes,
+	MachineMetricsResolutionHour,
+}
+
+func (c MachineMetricsResolution) Duration() time.Duration {
+	switch c {
+	case MachineMetricsResolutionMinute:
+		return time.Minute
+	case MachineMetricsResolutionFiveMinutes:
+		return 5 * time.Minute
+	case MachineMetricsResolutionHour:
+		return time.Hour
+	default:
+		return 0
+	}
+}
@@ -276,6 +276,65 @@ var NotificationNetworksColumns = struct {
 	NotificationNetworkEventType,
 }
 
+// ------------------------------------------------------------
+// Notifications Incidents Table Columns
+
+type NotificationIncidentsColumn int
+
+var _ EnumFactory[NotificationIncidentsColumn] = NotificationIncidentsColumn(0)
+
+const (
+	NotificationIncidentOpenedEpoch NotificationIncidentsColumn = iota
+	NotificationIncidentDashboardName
+	NotificationIncidentEventType
+	NotificationIncidentId // internal use
+)
+
+func (c NotificationIncidentsColumn) Int() int {
+	return int(c)
+}
+
+func (NotificationIncidentsColumn) NewFromString(s string) NotificationIncidentsColumn {
+	switch s {
+	case "opened_epoch":
+		return NotificationIncidentOpenedEpoch
+	case "dashboard_name", "dashboard_id":
+		return NotificationIncidentDashboardName
+	case "event_type":
+		return NotificationIncidentEventType
+	default:
+		return NotificationIncidentsColumn(-1)
+	}
+}
+
+// internal use, used to map to query column names
+func (c NotificationIncidentsColumn) ToExpr() OrderableSortable {
+	switch c {
+	case NotificationIncidentOpenedEpoch:
+		return goqu.C("opened_epoch")
+	case NotificationIncidentDashboardName:
+		return goqu.C("dashboard_name")
+	case NotificationIncidentEventType:
+		return goqu.C("event_type")
+	case NotificationIncidentId:
+		return goqu.C("id")
+	default:
+		return nil
+	}
+}
+
+var NotificationIncidentsColumns = struct {
+	OpenedEpoch   NotificationIncidentsColumn
+	DashboardName NotificationIncidentsColumn
+	EventType     NotificationIncidentsColumn
+	Id            NotificationIncidentsColumn
+}{
+	NotificationIncidentOpenedEpoch,
+	NotificationIncidentDashboardName,
+	NotificationIncidentEventType,
+	NotificationIncidentId,
+}
+
 // ------------------------------------------------------------
 // Notification Settings Dashboard Table Columns
 
@@ -15,6 +15,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/mail"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/ratelimit"
 	commonTypes "github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/userservice"
@@ -55,25 +56,6 @@ func (h *HandlerService) getUserBySession(r *http.Request) (types.UserCredential
 	}, nil
 }
 
-func (h *HandlerService) purgeAllSessionsForUser(ctx context.Context, userId uint64) error {
-	// invalidate all sessions for this user
-	err := h.scs.Iterate(ctx, func(ctx context.Context) error {
-		sessionUserID, ok := h.scs.Get(ctx, userIdKey).(uint64)
-		if !ok {
-			log.Error(nil, "error parsing user id from session, not a uint64", 0, nil)
-			return nil
-		}
-
-		if userId == sessionUserID {
-			return h.scs.Destroy(ctx)
-		}
-
-		return nil
-	})
-
-	return err
-}
-
 // TODO move to service?
 func (h *HandlerService) sendConfirmationEmail(ctx context.Context, userId uint64, email string) error {
 	// 1. check last confirmation time to enforce ratelimit
@@ -175,20 +157,31 @@ func (h *HandlerService) GetUserIdBySession(r *http.Request) (uint64, error) {
 
 const authHeaderPrefix = "Bearer "
 
-func (h *HandlerService) GetUserIdByApiKey(r *http.Request) (uint64, error) {
-	// TODO: store user id in context during ratelimting and use it here
+func getApiKey(r *http.Request) string {
 	query := r.URL.Query()
 	header := r.Header
-	apiKey := cmp.Or(
+	return cmp.Or(
 		strings.TrimPrefix(header.Get("Authorization"), authHeaderPrefix),
 		header.Get("X-Api-Key"),
 		query.Get("api_key"),
 		query.Get("apiKey"),
 		query.Get("apikey"),
 	)
+}
+
+func (h *HandlerService) GetUserIdByApiKey(r *http.Request) (uint64, error) {
+	// TODO: store user id in context during ratelimting and use it here
+	apiKey := getApiKey(r)
 	if apiKey == "" {
 		return 0, newUnauthorizedErr("missing api key")
 	}
+	claims, isAccessToken, err := h.getOAuthAccessTokenClaims(r.Context(), apiKey)
+	if err != nil {
+		return 0, err
+	}
+	if isAccessToken {
+		return claims.UserID, nil
+	}
 	userId, err := h.daService.GetUserIdByApiKey(r.Context(), apiKey)
 	if errors.Is(err, dataaccess.ErrNotFound) {
 		err = newUnauthorizedErr("api key not found")
@@ -207,16 +200,155 @@ func GetUserIdByContext(r *http.Request) (uint64, error) {
 
 // Handlers
 
-func (h *HandlerService) InternalPostOauthAuthorize(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+// notifyApiKeysChanged propagates created, updated and revoked api keys to the rate limiter
+func notifyApiKeysChanged(ctx context.Context) {
+	err := ratelimit.NotifyApiKeysChanged(ctx)
+	if err != nil {
+		// keys are still picked up by the next regular rate limit update
+		log.Error(err, "error notifying rate limiter about changed api keys", 0)
+	}
 }
 
-func (h *HandlerService) InternalPostOauthToken(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+func (h *HandlerService) InternalGetUserApiKeys(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.daService.GetUserApiKeys(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserApiKeysResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+type apiKeyRequest struct {
+	Label  string   `json:"label"`
+	Scopes []string `json:"scopes"`
 }
 
 func (h *HandlerService) InternalPostApiKeys(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var req apiKeyRequest
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	label := v.checkApiKeyLabel(req.Label)
+	scopes := v.checkApiKeyScopes(req.Scopes)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	userInfo, err := h.daService.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if uint64(len(userInfo.ApiKeys)) >= userInfo.ApiPerks.ApiKeys {
+		returnConflict(w, r, errors.New("maximum number of api keys reached"))
+		return
+	}
+
+	data, err := h.daService.CreateUserApiKey(r.Context(), userId, label, scopes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	notifyApiKeysChanged(r.Context())
+	response := types.InternalPostUserApiKeysResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalPutUserApiKey(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	var req apiKeyRequest
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	label := v.checkApiKeyLabel(req.Label)
+	scopes := v.checkApiKeyScopes(req.Scopes)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.daService.UpdateUserApiKey(r.Context(), userId, apiKeyId, label, scopes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	notifyApiKeysChanged(r.Context())
+	response := types.InternalPutUserApiKeyResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+func (h *HandlerService) InternalPostUserApiKeyRotation(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.daService.RotateUserApiKey(r.Context(), userId, apiKeyId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	notifyApiKeysChanged(r.Context())
+	response := types.InternalPostUserApiKeysResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteUserApiKey(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	err = h.daService.RevokeUserApiKey(r.Context(), userId, apiKeyId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	notifyApiKeysChanged(r.Context())
+	returnNoContent(w, r)
 }
 
 func (h *HandlerService) InternalPostUsers(w http.ResponseWriter, r *http.Request) {
@@ -397,6 +529,7 @@ func (h *HandlerService) InternalPostUserPasswordResetHash(w http.ResponseWriter
 		handleErr(w, r, err)
 		return
 	}
+	h.recordSecurityEvent(r, userId, types.SecurityEventPasswordChange)
 
 	// if email is not confirmed, confirm since they clicked a link emailed to them
 	userInfo, err := h.daService.GetUserCredentialInfo(r.Context(), userId)
@@ -468,19 +601,50 @@ func (h *HandlerService) InternalPostLogin(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// second factor
+	twoFactor, err := h.getUserTwoFactorData(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if twoFactor != nil {
+		err = h.startTwoFactorChallenge(r.Context(), user.Id)
+		if err != nil {
+			handleErr(w, r, errors.New("error creating session"))
+			return
+		}
+		returnOk(w, r, types.InternalPostLoginResponse{Data: types.LoginResponse{TwoFactorRequired: true}})
+		return
+	}
+
 	// change privileges
-	err = h.scs.RenewToken(r.Context())
+	err = h.renewSessionToken(r.Context())
+	if err != nil {
+		handleErr(w, r, errors.New("error creating session"))
+		return
+	}
+	err = h.putAuthenticatedSession(r, user)
 	if err != nil {
 		handleErr(w, r, errors.New("error creating session"))
 		return
 	}
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventLogin)
+
+	returnOk(w, r, types.InternalPostLoginResponse{Data: types.LoginResponse{TwoFactorRequired: false}})
+}
 
+// putAuthenticatedSession authenticates the session of the request, its token must have been renewed before
+func (h *HandlerService) putAuthenticatedSession(r *http.Request, user *types.UserCredentialInfo) error {
+	err := h.daService.AddUserWebSession(r.Context(), user.Id, h.scs.Token(r.Context()), h.scs.Lifetime)
+	if err != nil {
+		return err
+	}
 	h.scs.Put(r.Context(), authenticatedKey, true)
 	h.scs.Put(r.Context(), userIdKey, user.Id)
 	h.scs.Put(r.Context(), subscriptionKey, user.ProductId)
 	h.scs.Put(r.Context(), userGroupKey, user.UserGroup)
-
-	returnOk(w, r, nil)
+	h.putSessionMetadata(r)
+	return nil
 }
 
 // Can be used to login on mobile, requires an authenticated session
@@ -531,7 +695,7 @@ func (h *HandlerService) InternalPostMobileAuthorize(w http.ResponseWriter, r *h
 	}
 
 	// renew session and pass to callback
-	err = h.scs.RenewToken(r.Context())
+	err = h.renewSessionToken(r.Context())
 	if err != nil {
 		callback := req.RedirectURI + "?error=invalid_request&error_description=server_error" + state
 		http.Redirect(w, r, callback, http.StatusSeeOther)
@@ -547,6 +711,7 @@ func (h *HandlerService) InternalPostMobileAuthorize(w http.ResponseWriter, r *h
 		http.Redirect(w, r, callback, http.StatusSeeOther)
 		return
 	}
+	h.updateDeviceActivity(r, utils.HashAndEncode(session+session))
 
 	// pass via redirect to app oauth callback handler
 	callback := req.RedirectURI + "?access_token=" + session + "&token_type=bearer" + state // prefixed session
@@ -594,7 +759,7 @@ func (h *HandlerService) InternalPostMobileEquivalentExchange(w http.ResponseWri
 	}
 
 	// create new session
-	err = h.scs.RenewToken(r.Context())
+	err = h.renewSessionToken(r.Context())
 	if err != nil {
 		handleErr(w, r, errors.New("error creating session"))
 		return
@@ -608,12 +773,14 @@ func (h *HandlerService) InternalPostMobileEquivalentExchange(w http.ResponseWri
 		handleErr(w, r, err)
 		return
 	}
+	h.updateDeviceActivity(r, utils.HashAndEncode(session+session))
 
 	// set fields of session after invalidating refresh token
-	h.scs.Put(r.Context(), authenticatedKey, true)
-	h.scs.Put(r.Context(), userIdKey, userID)
-	h.scs.Put(r.Context(), subscriptionKey, user.ProductId)
-	h.scs.Put(r.Context(), userGroupKey, user.UserGroup)
+	err = h.putAuthenticatedSession(r, user)
+	if err != nil {
+		handleErr(w, r, errors.New("error creating session"))
+		return
+	}
 	h.scs.Put(r.Context(), mobileAuthKey, true)
 
 	returnOk(w, r, struct {
@@ -730,7 +897,7 @@ func (h *HandlerService) InternalHandleMobilePurchase(w http.ResponseWriter, r *
 }
 
 func (h *HandlerService) InternalPostLogout(w http.ResponseWriter, r *http.Request) {
-	err := h.scs.Destroy(r.Context())
+	err := h.destroySession(r.Context())
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -745,6 +912,12 @@ func (h *HandlerService) InternalDeleteUser(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = h.checkTwoFactorRecentlyVerified(r, user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
 	// TODO allow if user has any subsciptions etc?
 	err = h.daService.RemoveUser(r.Context(), user.Id)
 	if err != nil {
@@ -777,6 +950,11 @@ func (h *HandlerService) InternalPostUserEmail(w http.ResponseWriter, r *http.Re
 		handleErr(w, r, newConflictErr("email not confirmed"))
 		return
 	}
+	err = h.checkTwoFactorRecentlyVerified(r, user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
 
 	// validate request
 	var v validationError
@@ -828,6 +1006,7 @@ func (h *HandlerService) InternalPostUserEmail(w http.ResponseWriter, r *http.Re
 		handleErr(w, r, err)
 		return
 	}
+	h.recordSecurityEvent(r, userInfo.Id, types.SecurityEventEmailChange)
 
 	response := types.InternalPostUserEmailResponse{
 		Data: types.EmailUpdate{
@@ -852,6 +1031,11 @@ func (h *HandlerService) InternalPutUserPassword(w http.ResponseWriter, r *http.
 		handleErr(w, r, err)
 		return
 	}
+	err = h.checkTwoFactorRecentlyVerified(r, user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
 
 	// validate request
 	var v validationError
@@ -888,6 +1072,7 @@ func (h *HandlerService) InternalPutUserPassword(w http.ResponseWriter, r *http.
 		handleErr(w, r, err)
 		return
 	}
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventPasswordChange)
 
 	err = h.purgeAllSessionsForUser(r.Context(), user.Id)
 	if err != nil {
@@ -23,6 +23,7 @@ type CustomClaims struct {
 	DeviceID uint64 `json:"deviceID"`
 	Package  string `json:"package"`
 	Theme    string `json:"theme"`
+	Scope    string `json:"scope,omitempty"` // space separated scopes of oauth access tokens
 	jwt.StandardClaims
 }
 
@@ -50,6 +51,7 @@ func (h *HandlerService) getTokenByRefresh(r *http.Request, refreshToken string)
 		}
 		return 0, "", errors.Wrap(err, "Error getting user by refresh token")
 	}
+	h.updateDeviceActivity(r, refreshTokenHashed)
 
 	return userID, refreshTokenHashed, nil
 }
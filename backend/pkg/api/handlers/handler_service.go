@@ -205,6 +205,10 @@ func (h *HandlerService) getDashboardPremiumPerks(ctx context.Context, id types.
 	if err != nil {
 		return nil, err
 	}
+	// organization dashboards use the pooled perks of all members
+	if dashboardUser.OrganizationId != nil {
+		return h.daService.GetOrganizationPremiumPerks(ctx, *dashboardUser.OrganizationId)
+	}
 	userInfo, err := h.daService.GetUserInfo(ctx, dashboardUser.UserId)
 	if err != nil {
 		return nil, err
@@ -504,15 +508,19 @@ func mapIndexBlocksSlice(category string, validators []types.IndexBlocks) types.
 var dbEventToResponse = map[string]string{
 	string(commontypes.ValidatorIsOfflineEventName):                "validator_offline",
 	string(commontypes.ValidatorIsOnlineEventName):                 "validator_online",
+	string(commontypes.ValidatorIsUnstableEventName):               "validator_unstable",
 	string(commontypes.ValidatorMissedAttestationEventName):        "attestation_missed",
 	string(commontypes.ValidatorMissedProposalEventName):           "proposal_missed",
 	string(commontypes.ValidatorExecutedProposalEventName):         "proposal_success",
 	string(commontypes.ValidatorUpcomingProposalEventName):         "proposal_upcoming",
 	string(commontypes.SyncCommitteeSoonEventName):                 "sync",
 	string(commontypes.ValidatorReceivedWithdrawalEventName):       "withdrawal",
+	string(commontypes.ValidatorPartialWithdrawalEventName):        "partial_withdrawal",
+	string(commontypes.ValidatorConsolidationEventName):            "consolidation",
 	string(commontypes.ValidatorGotSlashedEventName):               "validator_got_slashed",
 	string(commontypes.ValidatorDidSlashEventName):                 "validator_has_slashed",
 	string(commontypes.ValidatorGroupEfficiencyEventName):          "group_efficiency_below",
+	string(commontypes.ValidatorDashboardRuleEventName):            "custom_rule",
 	string(commontypes.RocketpoolCollateralMinReachedEventName):    "min_collateral",
 	string(commontypes.RocketpoolCollateralMaxReachedEventName):    "max_collateral",
 	string(commontypes.IncomingTransactionEventName):               "incoming_tx",
@@ -524,12 +532,38 @@ var dbEventToResponse = map[string]string{
 	string(commontypes.MonitoringMachineDiskAlmostFullEventName):   "storage",
 	string(commontypes.MonitoringMachineCpuLoadEventName):          "cpu",
 	string(commontypes.MonitoringMachineMemoryUsageEventName):      "memory",
+	string(commontypes.MonitoringMachineNotSyncedEventName):        "not_synced",
+	string(commontypes.MonitoringMachineElDisconnectedEventName):   "el_disconnected",
+	string(commontypes.MonitoringMachineLowPeerCountEventName):     "low_peer_count",
+	string(commontypes.MonitoringMachineHeadSlotLagEventName):      "head_slot_lag",
+	string(commontypes.MonitoringMachineFallbackInUseEventName):    "fallback_in_use",
+	string(commontypes.MonitoringMachineClientOutdatedEventName):   "client_outdated",
 	string(commontypes.RocketpoolNewClaimRoundStartedEventName):    "new_reward_round",
 	string(commontypes.NetworkGasBelowThresholdEventName):          "gas_below",
 	string(commontypes.NetworkGasAboveThresholdEventName):          "gas_above",
 	string(commontypes.NetworkParticipationRateThresholdEventName): "participation_rate",
 }
 
+// events which can be triggered by validator dashboard groups
+var validatorDashboardEvents = []commontypes.EventName{
+	commontypes.ValidatorIsOfflineEventName,
+	commontypes.ValidatorIsOnlineEventName,
+	commontypes.ValidatorMissedAttestationEventName,
+	commontypes.ValidatorMissedProposalEventName,
+	commontypes.ValidatorExecutedProposalEventName,
+	commontypes.ValidatorUpcomingProposalEventName,
+	commontypes.SyncCommitteeSoonEventName,
+	commontypes.ValidatorReceivedWithdrawalEventName,
+	commontypes.ValidatorPartialWithdrawalEventName,
+	commontypes.ValidatorConsolidationEventName,
+	commontypes.ValidatorGotSlashedEventName,
+	commontypes.ValidatorDidSlashEventName,
+	commontypes.ValidatorGroupEfficiencyEventName,
+	commontypes.ValidatorDashboardRuleEventName,
+	commontypes.RocketpoolCollateralMinReachedEventName,
+	commontypes.RocketpoolCollateralMaxReachedEventName,
+}
+
 func mapNotificationEventName(event string) string {
 	if name, ok := dbEventToResponse[event]; ok {
 		return name
@@ -554,6 +588,23 @@ func mapMachineNotificationEventNames(data []types.NotificationMachinesTableRow)
 	return data
 }
 
+func mapNotificationIncidentEventNames(data []types.NotificationIncidentsTableRow) []types.NotificationIncidentsTableRow {
+	for rowIndex, row := range data {
+		data[rowIndex].EventType = mapNotificationEventName(row.EventType)
+	}
+	return data
+}
+
+func mapNotificationSnoozeEventNames(data []types.NotificationSnoozeValidatorDashboard) []types.NotificationSnoozeValidatorDashboard {
+	for rowIndex, row := range data {
+		if row.EventType != nil {
+			eventType := mapNotificationEventName(*row.EventType)
+			data[rowIndex].EventType = &eventType
+		}
+	}
+	return data
+}
+
 func mapNetworkNotificationEventNames(data []types.NotificationNetworksTableRow) []types.NotificationNetworksTableRow {
 	for rowIndex, row := range data {
 		data[rowIndex].EventType = mapNotificationEventName(row.EventType)
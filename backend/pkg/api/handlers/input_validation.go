@@ -9,12 +9,14 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gorilla/mux"
 	"github.com/invopop/jsonschema"
 	"github.com/shopspring/decimal"
@@ -39,6 +41,7 @@ var (
 	reEmail                        = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
 	rePassword                     = regexp.MustCompile(`^.{5,}$`)
 	reEmailUserToken               = regexp.MustCompile(`^[a-z0-9]{40}$`)
+	reTwoFactorCode                = regexp.MustCompile(`^([0-9]{6}|[a-z2-7]{5}-?[a-z2-7]{5})$`) // totp code or recovery code
 	reJsonContentType              = regexp.MustCompile(`^application\/json(;.*)?$`)
 )
 
@@ -58,6 +61,19 @@ const (
 	MaxArchivedDashboardsCount        = 10
 )
 
+// user defined notification rules
+const (
+	maxNotificationRulesPerGroup             = 10
+	maxNotificationRuleWindowEpochs   uint64 = 225     // one day, the epoch dashboard data is not kept much longer
+	maxNotificationRuleCooldownEpochs uint64 = 225 * 7 // one week
+)
+
+// notification snoozes
+const (
+	maxNotificationSnoozesPerGroup        = 20
+	maxNotificationSnoozeDuration  uint64 = 60 * 60 * 24 * 30 // 30 days in seconds
+)
+
 // All changes to common functions MUST NOT break any public handler behavior (not in effect yet)
 
 // --------------------------------------
@@ -135,6 +151,11 @@ func (v *validationError) checkPassword(password string) string {
 	return v.checkRegex(rePassword, password, "password")
 }
 
+// checkTwoFactorCode returns the code in lower case, recovery codes are accepted with and without dash
+func (v *validationError) checkTwoFactorCode(code string) string {
+	return v.checkRegex(reTwoFactorCode, strings.ToLower(strings.TrimSpace(code)), "code")
+}
+
 func (v *validationError) checkUserEmailToken(token string) string {
 	return v.checkRegex(reEmailUserToken, token, "token")
 }
@@ -512,6 +533,75 @@ func (v *validationError) checkNetworksParameter(param string) []uint64 {
 	return chainIds
 }
 
+func (v *validationError) checkNotificationRuleMetric(metric string) commontypes.NotificationRuleMetric {
+	result := commontypes.NotificationRuleMetric(metric)
+	if !slices.Contains(commontypes.NotificationRuleMetrics, result) {
+		v.add("metric", fmt.Sprintf("given value '%s' is not a valid metric", metric))
+	}
+	return result
+}
+
+func (v *validationError) checkNotificationRuleComparison(comparison string) commontypes.NotificationRuleComparison {
+	result := commontypes.NotificationRuleComparison(comparison)
+	if !slices.Contains(commontypes.NotificationRuleComparisons, result) {
+		v.add("comparison", fmt.Sprintf("given value '%s' is not a valid comparison", comparison))
+	}
+	return result
+}
+
+// checkNotificationSnoozeEventType maps the given api event name back to the internal event name, returns nil if no event is given
+func (v *validationError) checkNotificationSnoozeEventType(eventType string) *commontypes.EventName {
+	if eventType == "" {
+		return nil
+	}
+	for _, event := range validatorDashboardEvents {
+		if dbEventToResponse[string(event)] == eventType {
+			return &event
+		}
+	}
+	v.add("event_type", fmt.Sprintf("given value '%s' is not a valid validator dashboard event", eventType))
+	return nil
+}
+
+func (v *validationError) checkNotificationIncidentStatus(status string) commontypes.NotificationIncidentStatus {
+	result := commontypes.NotificationIncidentStatus(status)
+	if status != "" && result != commontypes.NotificationIncidentStatusOpen && result != commontypes.NotificationIncidentStatusResolved {
+		v.add("status", fmt.Sprintf("given value '%s' is not a valid status, allowed values are '%s' and '%s'", status, commontypes.NotificationIncidentStatusOpen, commontypes.NotificationIncidentStatusResolved))
+	}
+	return result
+}
+
+func (v *validationError) checkApiKeyLabel(label string) string {
+	label = v.checkLength(label, "label", 0)
+	return v.checkRegex(reName, label, "label")
+}
+
+// checkApiKeyScopes returns the deduplicated scopes, at least one scope is required
+func (v *validationError) checkApiKeyScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		v.add("scopes", "must contain at least one scope")
+		return nil
+	}
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(types.ApiKeyScopes, scope) {
+			v.add("scopes", fmt.Sprintf("given value '%s' is not a valid scope, allowed values are %s", scope, strings.Join(types.ApiKeyScopes, ", ")))
+			continue
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func (v *validationError) checkOrganizationRole(role string) string {
+	if !slices.Contains(types.OrganizationRoles, role) {
+		v.add("role", fmt.Sprintf("given value '%s' is not a valid role, allowed values are %s", role, strings.Join(types.OrganizationRoles, ", ")))
+	}
+	return role
+}
+
 // isValidNetwork checks if the given network is a valid network.
 // It returns the chain id of the network and true if it is valid, otherwise 0 and false.
 func isValidNetwork(network intOrString) (uint64, bool) {
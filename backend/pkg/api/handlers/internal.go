@@ -270,6 +270,10 @@ func (h *HandlerService) InternalGetUserDashboards(w http.ResponseWriter, r *htt
 	h.PublicGetUserDashboards(w, r)
 }
 
+func (h *HandlerService) InternalGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserApiUsage(w, r)
+}
+
 // --------------------------------------
 // Account Dashboards
 
@@ -492,6 +496,14 @@ func (h *HandlerService) InternalGetValidatorDashboardTotalWithdrawals(w http.Re
 	h.PublicGetValidatorDashboardTotalWithdrawals(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardConsolidations(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardConsolidations(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardWithdrawalRequests(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardWithdrawalRequests(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardRocketPool(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardRocketPool(w, r)
 }
@@ -614,6 +626,14 @@ func (h *HandlerService) InternalGetUserNotificationNetworks(w http.ResponseWrit
 	h.PublicGetUserNotificationNetworks(w, r)
 }
 
+func (h *HandlerService) InternalGetUserNotificationIncidents(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserNotificationIncidents(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationIncidentAcknowledge(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationIncidentAcknowledge(w, r)
+}
+
 func (h *HandlerService) InternalGetUserNotificationSettings(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetUserNotificationSettings(w, r)
 }
@@ -650,6 +670,34 @@ func (h *HandlerService) InternalPutUserNotificationSettingsAccountDashboard(w h
 	h.PublicPutUserNotificationSettingsAccountDashboard(w, r)
 }
 
+func (h *HandlerService) InternalGetUserNotificationSettingsValidatorDashboardRules(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserNotificationSettingsValidatorDashboardRules(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationSettingsValidatorDashboardRules(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationSettingsValidatorDashboardRules(w, r)
+}
+
+func (h *HandlerService) InternalPutUserNotificationSettingsValidatorDashboardRule(w http.ResponseWriter, r *http.Request) {
+	h.PublicPutUserNotificationSettingsValidatorDashboardRule(w, r)
+}
+
+func (h *HandlerService) InternalDeleteUserNotificationSettingsValidatorDashboardRule(w http.ResponseWriter, r *http.Request) {
+	h.PublicDeleteUserNotificationSettingsValidatorDashboardRule(w, r)
+}
+
+func (h *HandlerService) InternalGetUserNotificationSettingsValidatorDashboardSnoozes(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserNotificationSettingsValidatorDashboardSnoozes(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationSettingsValidatorDashboardSnoozes(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationSettingsValidatorDashboardSnoozes(w, r)
+}
+
+func (h *HandlerService) InternalDeleteUserNotificationSettingsValidatorDashboardSnooze(w http.ResponseWriter, r *http.Request) {
+	h.PublicDeleteUserNotificationSettingsValidatorDashboardSnooze(w, r)
+}
+
 func (h *HandlerService) InternalPostUserNotificationsTestEmail(w http.ResponseWriter, r *http.Request) {
 	h.PublicPostUserNotificationsTestEmail(w, r)
 }
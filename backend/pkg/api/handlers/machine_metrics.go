@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
@@ -22,6 +24,17 @@ func (h *HandlerService) InternalGetUserMachineMetrics(w http.ResponseWriter, r
 	h.PublicGetUserMachineMetrics(w, r)
 }
 
+// PublicGetUserMachineMetrics godoc
+//
+//	@Description	Get the raw machine metrics samples of the authenticated user, newest first. Use /users/me/machine-metrics/history for time ranges and rates.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Param			limit	query		string	false	"Number of samples per machine and process."	Default(180)
+//	@Param			offset	query		string	false	"Number of newest samples to skip."
+//	@Success		200		{object}	types.GetUserMachineMetricsRespone
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/machine-metrics [get]
 func (h *HandlerService) PublicGetUserMachineMetrics(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
@@ -63,6 +76,139 @@ func (h *HandlerService) PublicGetUserMachineMetrics(w http.ResponseWriter, r *h
 	returnOk(w, r, response)
 }
 
+func (h *HandlerService) InternalGetUserMachineMetricsHistory(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserMachineMetricsHistory(w, r)
+}
+
+// maximum number of points returned per machine and process
+const maxMachineMetricsDataPoints uint64 = 1440
+
+// PublicGetUserMachineMetricsHistory godoc
+//
+//	@Description	Get the machine metrics of the authenticated user. Counters are returned as per second rates.
+//	@Description	If no resolution is given, the finest resolution covering the requested time range is used.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Param			after_ts	query		string	false	"Return data after this timestamp."
+//	@Param			before_ts	query		string	false	"Return data before this timestamp."
+//	@Param			resolution	query		string	false	"Resolution of the returned data."	Enums(auto, 1m, 5m, 1h)	Default(auto)
+//	@Success		200			{object}	types.GetUserMachineMetricsHistoryResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		409			{object}	types.ApiErrorResponse	"Conflict. The requested time range is not available for the user's subscription."
+//	@Router			/users/me/machine-metrics/history [get]
+func (h *HandlerService) PublicGetUserMachineMetricsHistory(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	userInfo, err := h.daService.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	resolution := checkEnum[enums.MachineMetricsResolution](&v, r.URL.Query().Get("resolution"), "resolution")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	// the history available to the user's premium tier, capped by the retention of the coarsest rollup
+	rollups := db.MachineMetricsRollups
+	now := uint64(time.Now().Unix())
+	history := min(userInfo.PremiumPerks.MachineMonitoringHistorySeconds, uint64(rollups[len(rollups)-1].Retention.Seconds()))
+	limits := ChartTimeDashboardLimits{
+		MinAllowedTs:       now - min(history, now),
+		LatestExportedTs:   now,
+		MaxAllowedInterval: uint64(rollups[len(rollups)-1].Resolution.Seconds()) * maxMachineMetricsDataPoints,
+	}
+	if resolution != enums.MachineMetricsResolutions.Auto {
+		limits.MaxAllowedInterval = uint64(resolution.Duration().Seconds()) * maxMachineMetricsDataPoints
+	}
+	afterTs, beforeTs := v.checkTimestamps(r, limits)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if afterTs < limits.MinAllowedTs {
+		returnConflict(w, r, fmt.Errorf("requested time range is too old, minimum timestamp for your premium subscription is %v", limits.MinAllowedTs))
+		return
+	}
+
+	// pick the finest resolution that is still retained and does not exceed the point limit
+	if resolution == enums.MachineMetricsResolutions.Auto {
+		for _, res := range []enums.MachineMetricsResolution{enums.MachineMetricsResolutions.Minute, enums.MachineMetricsResolutions.FiveMinutes, enums.MachineMetricsResolutions.Hour} {
+			resolution = res
+			rollup, err := db.GetMachineMetricsRollup(res.Duration())
+			if err != nil {
+				handleErr(w, r, err)
+				return
+			}
+			if (beforeTs-afterTs)/uint64(rollup.Resolution.Seconds()) <= maxMachineMetricsDataPoints && afterTs >= now-min(uint64(rollup.Retention.Seconds()), now) {
+				break
+			}
+		}
+	} else {
+		rollup, err := db.GetMachineMetricsRollup(resolution.Duration())
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if afterTs < now-min(uint64(rollup.Retention.Seconds()), now) {
+			v.add("resolution", fmt.Sprintf("data of the given resolution is only retained for %d seconds, use a coarser resolution for the requested time range", uint64(rollup.Retention.Seconds())))
+			handleErr(w, r, v)
+			return
+		}
+	}
+
+	data, err := h.daService.GetUserMachineMetricsHistory(r.Context(), userId, afterTs, beforeTs, resolution)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetUserMachineMetricsHistoryResponse{
+		Data: *data,
+	}
+
+	returnOk(w, r, response)
+}
+
+func (h *HandlerService) InternalGetUserMachineClients(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserMachineClients(w, r)
+}
+
+// PublicGetUserMachineClients godoc
+//
+//	@Description	Get the clients the machines of the authenticated user reported within the last day, compared against the latest known releases.
+//	@Description	Clients which are older than the version required for the current or upcoming hard fork are flagged as fork incompatible.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Success		200	{object}	types.GetUserMachineClientsResponse
+//	@Router			/users/me/machine-metrics/clients [get]
+func (h *HandlerService) PublicGetUserMachineClients(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.daService.GetUserMachineClients(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetUserMachineClientsResponse{
+		Data: data,
+	}
+
+	returnOk(w, r, response)
+}
+
 func (h *HandlerService) LegacyPostUserMachineMetrics(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	apiKey := q.Get("apikey")
@@ -151,7 +297,7 @@ func (h *HandlerService) internal_processMachine(context context.Context, machin
 		return newBadRequestErr("unsupported data format version")
 	}
 
-	if parsedMeta.Process != "validator" && parsedMeta.Process != "beaconnode" && parsedMeta.Process != "slasher" && parsedMeta.Process != "system" {
+	if parsedMeta.Process != "validator" && parsedMeta.Process != "beaconnode" && parsedMeta.Process != "execution" && parsedMeta.Process != "slasher" && parsedMeta.Process != "system" {
 		return newBadRequestErr("unknown process")
 	}
 
@@ -198,6 +344,26 @@ func (h *HandlerService) internal_processMachine(context context.Context, machin
 		if err != nil {
 			return errors.Wrap(err, "could not parse stats (beaconnode stats)")
 		}
+	} else if parsedMeta.Process == "execution" {
+		var parsedResponse *commontypes.MachineMetricExecution
+		err = DecodeMapStructure(obj, &parsedResponse)
+		if err != nil {
+			return fmt.Errorf("%w: %w could not parse stats (execution stats)", errBadRequest, err)
+		}
+		data, err = proto.Marshal(parsedResponse)
+		if err != nil {
+			return errors.Wrap(err, "could not parse stats (execution stats)")
+		}
+	} else if parsedMeta.Process == "slasher" {
+		var parsedResponse *commontypes.MachineMetricSlasher
+		err = DecodeMapStructure(obj, &parsedResponse)
+		if err != nil {
+			return fmt.Errorf("%w: %w could not parse stats (slasher stats)", errBadRequest, err)
+		}
+		data, err = proto.Marshal(parsedResponse)
+		if err != nil {
+			return errors.Wrap(err, "could not parse stats (slasher stats)")
+		}
 	}
 
 	return h.daService.PostUserMachineMetrics(context, userInfo.Id, machine, parsedMeta.Process, data)
@@ -0,0 +1,507 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxPrometheusBodySize     = 10 * 1024 * 1024 // applies to the compressed and the decompressed remote-write body
+	prometheusExporterVersion = "prometheus"
+)
+
+// prometheusSample is a single sample of a remote-write time series or a line of a text exposition
+type prometheusSample struct {
+	Name      string
+	Labels    map[string]string
+	Value     float64
+	Timestamp int64 // unix ms, 0 if not given
+}
+
+type prometheusAggregation int
+
+const (
+	prometheusSum   prometheusAggregation = iota // sum of all matching series, e.g. per cpu or per device counters
+	prometheusMax                                // highest value of all matching series
+	prometheusCount                              // number of matching series, e.g. number of cpus
+	prometheusInfo                               // metric only identifies the client, the version is taken from the "version" label
+)
+
+// prometheusMetricMapping maps a well-known client metric onto a field of the machine metric models
+type prometheusMetricMapping struct {
+	Process     string            // process of the machine metric model, empty for generic process metrics of the scraped client
+	Field       string            // json name of the field in the machine metric model
+	Client      string            // client that exposes the metric, identifies the client of the scraped process
+	Labels      map[string]string // labels that must match
+	SkipLabels  map[string]string // series with these labels are ignored
+	Aggregation prometheusAggregation
+}
+
+// prometheusMetricMappings is the list of supported metric names, metrics of multiple clients use the same name where
+// the clients follow the beacon-metrics specification. System metrics are expected in the node_exporter format.
+var prometheusMetricMappings = map[string][]prometheusMetricMapping{
+	// generic process metrics of the prometheus client libraries
+	"process_cpu_seconds_total":     {{Field: "cpu_process_seconds_total"}},
+	"process_resident_memory_bytes": {{Field: "memory_process_bytes"}},
+
+	// system (node_exporter)
+	"node_cpu_seconds_total": {
+		{Process: "system", Field: "cpu_cores", Labels: map[string]string{"mode": "idle"}, Aggregation: prometheusCount},
+		{Process: "system", Field: "cpu_threads", Labels: map[string]string{"mode": "idle"}, Aggregation: prometheusCount},
+		{Process: "system", Field: "cpu_node_system_seconds_total", Labels: map[string]string{"mode": "system"}},
+		{Process: "system", Field: "cpu_node_user_seconds_total", Labels: map[string]string{"mode": "user"}},
+		{Process: "system", Field: "cpu_node_iowait_seconds_total", Labels: map[string]string{"mode": "iowait"}},
+		{Process: "system", Field: "cpu_node_idle_seconds_total", Labels: map[string]string{"mode": "idle"}},
+	},
+	"node_memory_MemTotal_bytes":        {{Process: "system", Field: "memory_node_bytes_total"}},
+	"node_memory_MemFree_bytes":         {{Process: "system", Field: "memory_node_bytes_free"}},
+	"node_memory_Cached_bytes":          {{Process: "system", Field: "memory_node_bytes_cached"}},
+	"node_memory_Buffers_bytes":         {{Process: "system", Field: "memory_node_bytes_buffers"}},
+	"node_filesystem_size_bytes":        {{Process: "system", Field: "disk_node_bytes_total", Labels: map[string]string{"mountpoint": "/"}}},
+	"node_filesystem_avail_bytes":       {{Process: "system", Field: "disk_node_bytes_free", Labels: map[string]string{"mountpoint": "/"}}},
+	"node_disk_io_time_seconds_total":   {{Process: "system", Field: "disk_node_io_seconds"}},
+	"node_disk_reads_completed_total":   {{Process: "system", Field: "disk_node_reads_total"}},
+	"node_disk_writes_completed_total":  {{Process: "system", Field: "disk_node_writes_total"}},
+	"node_network_receive_bytes_total":  {{Process: "system", Field: "network_node_bytes_total_receive", SkipLabels: map[string]string{"device": "lo"}}},
+	"node_network_transmit_bytes_total": {{Process: "system", Field: "network_node_bytes_total_transmit", SkipLabels: map[string]string{"device": "lo"}}},
+	"node_boot_time_seconds":            {{Process: "system", Field: "misc_node_boot_ts_seconds"}},
+
+	// beacon node (beacon-metrics specification, implemented by all consensus clients)
+	"beacon_head_slot": {{Process: "beaconnode", Field: "sync_beacon_head_slot", Aggregation: prometheusMax}},
+	"libp2p_peers":     {{Process: "beaconnode", Field: "network_peers_connected", Aggregation: prometheusMax}},
+	// beacon node (client specific)
+	"sync_eth2_synced":              {{Process: "beaconnode", Field: "sync_eth2_synced", Client: "lighthouse", Aggregation: prometheusMax}},
+	"sync_eth1_connected":           {{Process: "beaconnode", Field: "sync_eth1_connected", Client: "lighthouse", Aggregation: prometheusMax}},
+	"sync_eth1_fallback_configured": {{Process: "beaconnode", Field: "sync_eth1_fallback_configured", Client: "lighthouse", Aggregation: prometheusMax}},
+	"sync_eth1_fallback_connected":  {{Process: "beaconnode", Field: "sync_eth1_fallback_connected", Client: "lighthouse", Aggregation: prometheusMax}},
+	"store_disk_db_size":            {{Process: "beaconnode", Field: "disk_beaconchain_bytes_total", Client: "lighthouse"}},
+	"libp2p_inbound_bytes":          {{Process: "beaconnode", Field: "network_libp2p_bytes_total_receive", Client: "lighthouse"}},
+	"libp2p_outbound_bytes":         {{Process: "beaconnode", Field: "network_libp2p_bytes_total_transmit", Client: "lighthouse"}},
+	"p2p_peer_count":                {{Process: "beaconnode", Field: "network_peers_connected", Client: "prysm", Labels: map[string]string{"state": "Connected"}}},
+	"prysm_version":                 {{Process: "beaconnode", Client: "prysm", Aggregation: prometheusInfo}},
+	"lodestar_version":              {{Process: "beaconnode", Client: "lodestar", Aggregation: prometheusInfo}},
+	"nim_gc_mem_bytes":              {{Process: "beaconnode", Client: "nimbus", Aggregation: prometheusInfo}},
+
+	// validator client
+	"sync_eth2_fallback_configured": {{Process: "validator", Field: "sync_eth2_fallback_configured", Client: "lighthouse", Aggregation: prometheusMax}},
+	"sync_eth2_fallback_connected":  {{Process: "validator", Field: "sync_eth2_fallback_connected", Client: "lighthouse", Aggregation: prometheusMax}},
+	"vc_validators_total_count":     {{Process: "validator", Field: "validator_total", Client: "lighthouse"}},
+	"vc_validators_enabled_count":   {{Process: "validator", Field: "validator_active", Client: "lighthouse"}},
+	"validator_local_validator_counts": {
+		{Process: "validator", Field: "validator_total", Client: "teku"},
+		{Process: "validator", Field: "validator_active", Client: "teku", Labels: map[string]string{"status": "active_ongoing"}},
+	},
+	"vc_indices_count": {{Process: "validator", Field: "validator_active", Client: "lodestar"}},
+
+	// execution client
+	"chain_head_block":             {{Process: "execution", Field: "sync_execution_head_block", Client: "geth", Aggregation: prometheusMax}},
+	"p2p_peers":                    {{Process: "execution", Field: "network_peers_connected", Client: "geth", Aggregation: prometheusMax}},
+	"txpool_pending":               {{Process: "execution", Field: "txpool_transactions", Client: "geth"}},
+	"txpool_queued":                {{Process: "execution", Field: "txpool_transactions", Client: "geth"}},
+	"eth_db_chaindata_disk_size":   {{Process: "execution", Field: "disk_execution_bytes_total", Client: "geth"}},
+	"nethermind_blocks":            {{Process: "execution", Field: "sync_execution_head_block", Client: "nethermind", Aggregation: prometheusMax}},
+	"nethermind_sync_peers":        {{Process: "execution", Field: "network_peers_connected", Client: "nethermind", Aggregation: prometheusMax}},
+	"nethermind_transaction_count": {{Process: "execution", Field: "txpool_transactions", Client: "nethermind"}},
+	"nethermind_state_db_size":     {{Process: "execution", Field: "disk_execution_bytes_total", Client: "nethermind"}},
+}
+
+// boolean fields of the machine metric models, all other mapped fields are unsigned integers
+var prometheusBoolFields = []string{
+	"sync_eth2_synced", "sync_eth1_connected", "sync_eth1_fallback_configured", "sync_eth1_fallback_connected",
+	"sync_eth2_fallback_configured", "sync_eth2_fallback_connected",
+}
+
+// process to which the generic process metrics are attributed if a scrape contains metrics of multiple processes (e.g. teku)
+var prometheusProcessPriority = []string{"beaconnode", "execution", "validator"}
+
+func (m prometheusMetricMapping) matches(labels map[string]string) bool {
+	for name, value := range m.Labels {
+		if labels[name] != value {
+			return false
+		}
+	}
+	for name, value := range m.SkipLabels {
+		if labels[name] == value {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicPostUserMachineMetricsPrometheus godoc
+//
+//	@Description	Push machine metrics as prometheus remote-write request (`application/x-protobuf`, snappy compressed) or as prometheus text exposition (`text/plain`, timestamps in milliseconds) / OpenMetrics text (`application/openmetrics-text`, timestamps in seconds). The api key needs the `machine-metrics:write` scope. Well-known metrics of node_exporter and of the Lighthouse, Prysm, Teku, Nimbus, Lodestar, Geth and Nethermind clients are stored as system, beacon node, validator and execution client metrics. Series are grouped into machines by the `instance` label and into processes by the `job` label.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Accept			plain
+//	@Param			machine	query	string	false	"Machine name for all pushed metrics, overrides the `instance` label."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Failure		429	{object}	types.ApiErrorResponse	"At most one push per machine and process per minute."
+//	@Router			/users/me/machine-metrics/prometheus [post]
+func (h *HandlerService) PublicPostUserMachineMetricsPrometheus(w http.ResponseWriter, r *http.Request) {
+	if !h.isPostMachineMetricsEnabled {
+		returnError(w, r, http.StatusServiceUnavailable, fmt.Errorf("machine metrics pushing is temporarily disabled"))
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.daService.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPrometheusBodySize))
+	if err != nil {
+		returnBadRequest(w, r, fmt.Errorf("could not read request body"))
+		return
+	}
+	var samples []prometheusSample
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "application/x-protobuf") {
+		samples, err = parsePrometheusRemoteWrite(body)
+	} else {
+		samples, err = parsePrometheusText(body, isOpenMetricsText(contentType, body))
+	}
+	if err != nil {
+		handleErr(w, r, newBadRequestErr("could not parse metrics: %v", err))
+		return
+	}
+
+	objects := mapPrometheusSamples(samples, r.URL.Query().Get("machine"), time.Now().UnixMilli())
+	if len(objects) == 0 {
+		returnBadRequest(w, r, fmt.Errorf("no supported metrics found"))
+		return
+	}
+	if len(objects) >= 10 {
+		returnBadRequest(w, r, fmt.Errorf("Max number of stat entries are 10"))
+		return
+	}
+
+	var rateLimitErrs = 0
+	for _, obj := range objects {
+		err := h.internal_processMachine(r.Context(), obj.machine, &obj.data, userInfo)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "rate limit") {
+				rateLimitErrs++
+				continue
+			}
+			handleErr(w, r, err)
+			return
+		}
+	}
+
+	if rateLimitErrs >= len(objects) {
+		returnTooManyRequests(w, r, fmt.Errorf("too many metric requests, max allowed is 1 per user per machine per process"))
+		return
+	}
+
+	returnNoContent(w, r)
+}
+
+// parsePrometheusRemoteWrite decodes a snappy compressed remote-write request
+func parsePrometheusRemoteWrite(body []byte) ([]prometheusSample, error) {
+	decodedLen, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, err
+	}
+	if decodedLen > maxPrometheusBodySize {
+		return nil, fmt.Errorf("decompressed body exceeds %d bytes", maxPrometheusBodySize)
+	}
+	data, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, err
+	}
+	req := &commontypes.PrometheusWriteRequest{}
+	err = proto.Unmarshal(data, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []prometheusSample
+	for _, ts := range req.Timeseries {
+		labels := make(map[string]string, len(ts.Labels))
+		for _, l := range ts.Labels {
+			labels[l.Name] = l.Value
+		}
+		name := labels["__name__"]
+		delete(labels, "__name__")
+		// only the latest sample of a series is relevant
+		var latest *commontypes.PrometheusSample
+		for _, s := range ts.Samples {
+			if latest == nil || s.Timestamp > latest.Timestamp {
+				latest = s
+			}
+		}
+		if name == "" || latest == nil {
+			continue
+		}
+		samples = append(samples, prometheusSample{Name: name, Labels: labels, Value: latest.Value, Timestamp: latest.Timestamp})
+	}
+	return samples, nil
+}
+
+// isOpenMetricsText returns true if the body is OpenMetrics text instead of the prometheus text exposition format,
+// pushers that don't set the content type are recognized by the "# EOF" line that terminates OpenMetrics text
+func isOpenMetricsText(contentType string, body []byte) bool {
+	if strings.HasPrefix(contentType, "application/openmetrics-text") {
+		return true
+	}
+	if strings.HasPrefix(contentType, "text/plain") {
+		return false
+	}
+	return bytes.HasSuffix(bytes.TrimRight(body, " \t\r\n"), []byte("# EOF"))
+}
+
+// parsePrometheusText parses the prometheus text exposition format or OpenMetrics text, metadata and exemplars are ignored
+func parsePrometheusText(body []byte, openMetrics bool) ([]prometheusSample, error) {
+	var samples []prometheusSample
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxPrometheusBodySize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sample, err := parsePrometheusTextLine(line, openMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		samples = append(samples, *sample)
+	}
+	return samples, scanner.Err()
+}
+
+func parsePrometheusTextLine(line string, openMetrics bool) (*prometheusSample, error) {
+	sample := &prometheusSample{Labels: map[string]string{}}
+	nameEnd := strings.IndexAny(line, "{ ")
+	if nameEnd <= 0 {
+		return nil, fmt.Errorf("invalid sample")
+	}
+	sample.Name = line[:nameEnd]
+	rest := line[nameEnd:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parsePrometheusLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// value, optional timestamp and an optional OpenMetrics exemplar
+	if exemplar := strings.Index(rest, "#"); exemplar >= 0 {
+		rest = rest[:exemplar]
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid sample value")
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sample value: %w", err)
+	}
+	sample.Value = value
+	if len(fields) == 2 {
+		// prometheus uses integer milliseconds, OpenMetrics seconds which may be fractional
+		if openMetrics {
+			ts, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sample timestamp: %w", err)
+			}
+			sample.Timestamp = int64(math.Round(ts * 1000))
+		} else {
+			ts, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sample timestamp: %w", err)
+			}
+			sample.Timestamp = ts
+		}
+	}
+	return sample, nil
+}
+
+// parsePrometheusLabels parses the labels after the opening brace and returns the remaining line
+func parsePrometheusLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " ,")
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || len(s) < eq+2 || s[eq+1] != '"' {
+			return "", fmt.Errorf("invalid label")
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = s[eq+2:]
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				s = s[i+1:]
+				closed = true
+				break
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated label value")
+		}
+		labels[name] = value.String()
+	}
+}
+
+type prometheusMachineObject struct {
+	machine string
+	data    map[string]interface{} // legacy machine metric format, processed like pushed json stats
+}
+
+type prometheusScrapeKey struct {
+	machine string
+	job     string
+}
+
+// mapPrometheusSamples groups the samples per machine and scrape job and maps them onto one object per machine and process
+func mapPrometheusSamples(samples []prometheusSample, machine string, now int64) []prometheusMachineObject {
+	type fieldValue struct {
+		value float64
+		set   bool
+	}
+	type scrape struct {
+		fields    map[string]map[string]*fieldValue // process -> field -> value
+		client    string
+		version   string
+		timestamp int64
+	}
+	scrapes := make(map[prometheusScrapeKey]*scrape)
+	var keys []prometheusScrapeKey
+
+	for _, sample := range samples {
+		mappings, ok := prometheusMetricMappings[sample.Name]
+		if !ok || math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+			continue
+		}
+		key := prometheusScrapeKey{machine: machine, job: sample.Labels["job"]}
+		if machine == "" {
+			key.machine = sample.Labels["instance"]
+		}
+		sc, ok := scrapes[key]
+		if !ok {
+			sc = &scrape{fields: make(map[string]map[string]*fieldValue)}
+			scrapes[key] = sc
+			keys = append(keys, key)
+		}
+		sc.timestamp = max(sc.timestamp, sample.Timestamp)
+
+		for _, m := range mappings {
+			if !m.matches(sample.Labels) {
+				continue
+			}
+			if m.Client != "" && sc.client == "" {
+				sc.client = m.Client
+			}
+			if _, ok := sc.fields[m.Process]; !ok {
+				sc.fields[m.Process] = make(map[string]*fieldValue)
+			}
+			if m.Aggregation == prometheusInfo {
+				if version := sample.Labels["version"]; version != "" {
+					sc.version = version
+				}
+				continue
+			}
+			f, ok := sc.fields[m.Process][m.Field]
+			if !ok {
+				f = &fieldValue{}
+				sc.fields[m.Process][m.Field] = f
+			}
+			switch m.Aggregation {
+			case prometheusSum:
+				f.value += sample.Value
+			case prometheusMax:
+				if !f.set || sample.Value > f.value {
+					f.value = sample.Value
+				}
+			case prometheusCount:
+				f.value++
+			}
+			f.set = true
+		}
+	}
+
+	var result []prometheusMachineObject
+	for _, key := range keys {
+		sc := scrapes[key]
+		timestamp := sc.timestamp
+		if timestamp == 0 {
+			timestamp = now
+		}
+
+		// generic process metrics belong to the main process of the scrape
+		generic := sc.fields[""]
+		delete(sc.fields, "")
+		for _, process := range prometheusProcessPriority {
+			if _, ok := sc.fields[process]; ok {
+				for field, value := range generic {
+					sc.fields[process][field] = value
+				}
+				break
+			}
+		}
+
+		processes := make([]string, 0, len(sc.fields))
+		for process := range sc.fields {
+			processes = append(processes, process)
+		}
+		slices.Sort(processes)
+		for _, process := range processes {
+			data := map[string]interface{}{
+				"version":          uint64(1),
+				"timestamp":        uint64(timestamp),
+				"process":          process,
+				"exporter_version": prometheusExporterVersion,
+			}
+			if process != "system" {
+				data["client_name"] = sc.client
+				data["client_version"] = sc.version
+			}
+			for field, value := range sc.fields[process] {
+				if slices.Contains(prometheusBoolFields, field) {
+					data[field] = value.value > 0
+				} else if value.value > 0 {
+					data[field] = uint64(value.value)
+				}
+			}
+			result = append(result, prometheusMachineObject{machine: key.machine, data: data})
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"testing"
+
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const testOpenMetrics = `# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE node_cpu_seconds_total counter
+node_cpu_seconds_total{cpu="0",mode="idle",job="node"} 100.5
+node_cpu_seconds_total{cpu="1",mode="idle",job="node"} 200
+node_cpu_seconds_total{cpu="0",mode="user",job="node"} 10
+node_network_receive_bytes_total{device="lo",job="node"} 1000
+node_network_receive_bytes_total{device="eth0",job="node"} 2000 # {trace_id="a"} 1
+process_cpu_seconds_total{job="node"} 5
+beacon_head_slot{job="lighthouse"} 123 1700000000.5
+libp2p_peers{job="lighthouse"} 50
+sync_eth2_synced{job="lighthouse"} 1
+process_resident_memory_bytes{job="lighthouse"} 4096
+lodestar_unrelated{job="lighthouse",path="a \"quoted\" value"} 1
+# EOF
+`
+
+func TestParsePrometheusText(t *testing.T) {
+	samples, err := parsePrometheusText([]byte(testOpenMetrics), true)
+	require.NoError(t, err)
+	require.Len(t, samples, 11)
+	assert.Equal(t, "node_cpu_seconds_total", samples[0].Name)
+	assert.Equal(t, map[string]string{"cpu": "0", "mode": "idle", "job": "node"}, samples[0].Labels)
+	assert.Equal(t, 100.5, samples[0].Value)
+	assert.Equal(t, 2000.0, samples[4].Value)
+	assert.Equal(t, int64(1700000000500), samples[6].Timestamp)
+	assert.Equal(t, `a "quoted" value`, samples[10].Labels["path"])
+
+	_, err = parsePrometheusText([]byte(`broken{label="x" 1`), false)
+	assert.Error(t, err)
+}
+
+func TestParsePrometheusTextTimestamps(t *testing.T) {
+	// OpenMetrics timestamps are seconds, also without fraction
+	samples, err := parsePrometheusText([]byte("beacon_head_slot 1 1700000000\n# EOF\n"), true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), samples[0].Timestamp)
+
+	// prometheus timestamps are integer milliseconds
+	samples, err = parsePrometheusText([]byte("beacon_head_slot 1 1700000000123\n"), false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000123), samples[0].Timestamp)
+	_, err = parsePrometheusText([]byte("beacon_head_slot 1 1700000000.5\n"), false)
+	assert.Error(t, err)
+
+	assert.True(t, isOpenMetricsText("application/openmetrics-text; version=1.0.0; charset=utf-8", nil))
+	assert.False(t, isOpenMetricsText("text/plain; version=0.0.4", []byte(testOpenMetrics)))
+	assert.True(t, isOpenMetricsText("", []byte(testOpenMetrics)))
+	assert.False(t, isOpenMetricsText("", []byte("beacon_head_slot 1 1700000000123\n")))
+}
+
+func TestMapPrometheusSamples(t *testing.T) {
+	samples, err := parsePrometheusText([]byte(testOpenMetrics), true)
+	require.NoError(t, err)
+
+	objects := mapPrometheusSamples(samples, "my-machine", 42)
+	require.Len(t, objects, 2)
+
+	system := objects[0]
+	assert.Equal(t, "my-machine", system.machine)
+	assert.Equal(t, "system", system.data["process"])
+	assert.Equal(t, uint64(42), system.data["timestamp"])
+	assert.Equal(t, uint64(2), system.data["cpu_cores"])
+	assert.Equal(t, uint64(300), system.data["cpu_node_idle_seconds_total"])
+	assert.Equal(t, uint64(2000), system.data["network_node_bytes_total_receive"])
+	assert.NotContains(t, system.data, "cpu_process_seconds_total")
+
+	node := objects[1]
+	assert.Equal(t, "beaconnode", node.data["process"])
+	assert.Equal(t, "lighthouse", node.data["client_name"])
+	assert.Equal(t, uint64(1700000000500), node.data["timestamp"])
+	assert.Equal(t, uint64(123), node.data["sync_beacon_head_slot"])
+	assert.Equal(t, uint64(50), node.data["network_peers_connected"])
+	assert.Equal(t, true, node.data["sync_eth2_synced"])
+	assert.Equal(t, uint64(4096), node.data["memory_process_bytes"])
+
+	var parsed *commontypes.MachineMetricNode
+	require.NoError(t, DecodeMapStructure(node.data, &parsed))
+	assert.Equal(t, uint64(123), parsed.SyncBeaconHeadSlot)
+	assert.True(t, parsed.SyncEth2Synced)
+}
+
+func TestParsePrometheusRemoteWrite(t *testing.T) {
+	req := &commontypes.PrometheusWriteRequest{
+		Timeseries: []*commontypes.PrometheusTimeSeries{
+			{
+				Labels: []*commontypes.PrometheusLabel{{Name: "__name__", Value: "chain_head_block"}, {Name: "instance", Value: "geth:6060"}},
+				Samples: []*commontypes.PrometheusSample{
+					{Value: 10, Timestamp: 1000},
+					{Value: 11, Timestamp: 2000},
+				},
+			},
+			{
+				Labels:  []*commontypes.PrometheusLabel{{Name: "__name__", Value: "txpool_pending"}, {Name: "instance", Value: "geth:6060"}},
+				Samples: []*commontypes.PrometheusSample{{Value: 3, Timestamp: 2000}},
+			},
+			{
+				Labels:  []*commontypes.PrometheusLabel{{Name: "__name__", Value: "txpool_queued"}, {Name: "instance", Value: "geth:6060"}},
+				Samples: []*commontypes.PrometheusSample{{Value: 4, Timestamp: 2000}},
+			},
+		},
+	}
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	samples, err := parsePrometheusRemoteWrite(snappy.Encode(nil, data))
+	require.NoError(t, err)
+	require.Len(t, samples, 3)
+	assert.Equal(t, 11.0, samples[0].Value)
+	assert.NotContains(t, samples[0].Labels, "__name__")
+
+	objects := mapPrometheusSamples(samples, "", 0)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "geth:6060", objects[0].machine)
+	assert.Equal(t, "execution", objects[0].data["process"])
+	assert.Equal(t, "geth", objects[0].data["client_name"])
+	assert.Equal(t, uint64(11), objects[0].data["sync_execution_head_block"])
+	assert.Equal(t, uint64(7), objects[0].data["txpool_transactions"])
+}
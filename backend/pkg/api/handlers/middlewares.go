@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/ratelimit"
 	"github.com/gorilla/mux"
 )
 
@@ -38,7 +41,11 @@ func StoreUserIdMiddleware(next http.Handler, userIdFunc func(r *http.Request) (
 // middleware that stores user id in context, using the session to get the user id
 func (h *HandlerService) StoreUserIdBySessionMiddleware(next http.Handler) http.Handler {
 	return StoreUserIdMiddleware(next, func(r *http.Request) (uint64, error) {
-		return h.GetUserIdBySession(r)
+		userId, err := h.GetUserIdBySession(r)
+		if err == nil {
+			h.touchSession(r)
+		}
+		return userId, err
 	})
 }
 
@@ -77,17 +84,55 @@ func (h *HandlerService) VDBAuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		if dashboardUser.UserId != userId {
-			// user does not have access to dashboard
-			// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+		if dashboardUser.OrganizationId == nil {
+			if dashboardUser.UserId != userId {
+				// user does not have access to dashboard
+				// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+				handleErr(w, r, newNotFoundErr("dashboard with id %v not found", dashboardId))
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// organization dashboard -> access depends on the role of the user in the organization
+		role, err := h.daService.GetOrganizationMemberRole(r.Context(), *dashboardUser.OrganizationId, userId)
+		if errors.Is(err, dataaccess.ErrNotFound) {
 			handleErr(w, r, newNotFoundErr("dashboard with id %v not found", dashboardId))
 			return
 		}
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if err := checkOrganizationDashboardRole(r, role); err != nil {
+			handleErr(w, r, err)
+			return
+		}
 
 		next.ServeHTTP(w, r)
 	})
 }
 
+// checkOrganizationDashboardRole returns an error if the role does not allow the request on an organization dashboard:
+// viewers can only read, editors can modify, and only owners can delete the dashboard itself
+func checkOrganizationDashboardRole(r *http.Request, role string) error {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	case http.MethodDelete:
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil && strings.HasSuffix(template, "/{dashboard_id}") && role != types.OrganizationRoleOwner {
+				return newForbiddenErr("only organization owners can delete organization dashboards")
+			}
+		}
+	}
+	if role == types.OrganizationRoleViewer {
+		return newForbiddenErr("organization viewers cannot modify organization dashboards")
+	}
+	return nil
+}
+
 // Common middleware logic for checking user premium perks
 func (h *HandlerService) PremiumPerkCheckMiddleware(next http.Handler, hasRequiredPerk func(premiumPerks types.PremiumPerks) bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -115,16 +160,79 @@ func (h *HandlerService) PremiumPerkCheckMiddleware(next http.Handler, hasRequir
 	})
 }
 
+// getApiKeyScopes returns the scopes from the api keys loaded by the rate limiter, the db is only queried if the rate limiter is not running
+func (h *HandlerService) getApiKeyScopes(ctx context.Context, apiKey string) ([]string, error) {
+	scopes, found, loaded := ratelimit.GetApiKeyScopes(apiKey)
+	if loaded {
+		if !found {
+			return nil, newUnauthorizedErr("api key not found")
+		}
+		return scopes, nil
+	}
+	scopes, err := h.daService.GetApiKeyScopes(ctx, apiKey)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		err = newUnauthorizedErr("api key not found")
+	}
+	return scopes, err
+}
+
+// middleware that checks if the api key or oauth access token of the request has the required scope.
+// requests without api key are passed on, if next handler requires authentication, it should return 'unauthorized' itself
+func (h *HandlerService) ApiKeyScopeCheckMiddleware(next http.Handler, scope string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := getApiKey(r)
+		if apiKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		claims, isAccessToken, err := h.getOAuthAccessTokenClaims(r.Context(), apiKey)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		var scopes []string
+		if isAccessToken {
+			scopes = strings.Fields(claims.Scope)
+		} else {
+			scopes, err = h.getApiKeyScopes(r.Context(), apiKey)
+			if err != nil {
+				handleErr(w, r, err)
+				return
+			}
+		}
+		if !slices.Contains(scopes, scope) {
+			handleErr(w, r, newForbiddenErr("api key is missing the '%s' scope", scope))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Middleware for reading account data via API
+func (h *HandlerService) ReadAccountDataViaApiCheckMiddleware(next http.Handler) http.Handler {
+	return h.ApiKeyScopeCheckMiddleware(next, types.ApiKeyScopeAccountRead)
+}
+
+// Middleware for pushing machine metrics via API
+func (h *HandlerService) WriteMachineMetricsViaApiCheckMiddleware(next http.Handler) http.Handler {
+	return h.ApiKeyScopeCheckMiddleware(next, types.ApiKeyScopeMachineMetricsWrite)
+}
+
+// Middleware for reading network data via API
+func (h *HandlerService) ReadNetworkDataViaApiCheckMiddleware(next http.Handler) http.Handler {
+	return h.ApiKeyScopeCheckMiddleware(next, types.ApiKeyScopeNetworkRead)
+}
+
 // Middleware for managing dashboards via API
 func (h *HandlerService) ManageDashboardsViaApiCheckMiddleware(next http.Handler) http.Handler {
-	return h.PremiumPerkCheckMiddleware(next, func(premiumPerks types.PremiumPerks) bool {
+	return h.PremiumPerkCheckMiddleware(h.ApiKeyScopeCheckMiddleware(next, types.ApiKeyScopeDashboardsManage), func(premiumPerks types.PremiumPerks) bool {
 		return premiumPerks.ManageDashboardViaApi
 	})
 }
 
 // Middleware for managing notifications via API
 func (h *HandlerService) ManageNotificationsViaApiCheckMiddleware(next http.Handler) http.Handler {
-	return h.PremiumPerkCheckMiddleware(next, func(premiumPerks types.PremiumPerks) bool {
+	return h.PremiumPerkCheckMiddleware(h.ApiKeyScopeCheckMiddleware(next, types.ApiKeyScopeNotificationsManage), func(premiumPerks types.PremiumPerks) bool {
 		return premiumPerks.ConfigureNotificationsViaApi
 	})
 }
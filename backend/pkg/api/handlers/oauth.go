@@ -0,0 +1,562 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/mux"
+)
+
+const (
+	oauthCodeValidity                 = time.Minute * 10
+	oauthDefaultAccessTokenValidity   = time.Hour
+	oauthCodeChallengeMethodS256      = "S256"
+	oauthMaxAppNameLength             = 35  // oauth_apps.app_name
+	oauthMaxRedirectUriLength         = 100 // oauth_apps.redirect_uri
+	oauthMaxDeviceNameLength          = 20  // users_devices.device_name
+	oauthRefreshTokenLength           = 40
+	oauthAuthorizationCodeLength      = 40
+	oauthGrantTypeAuthorizationCode   = "authorization_code"
+	oauthGrantTypeRefreshToken        = "refresh_token"
+	oauthResponseTypeCode             = "code"
+	oauthErrorInvalidRequest          = "invalid_request"
+	oauthErrorInvalidClient           = "invalid_client"
+	oauthErrorInvalidGrant            = "invalid_grant"
+	oauthErrorInvalidScope            = "invalid_scope"
+	oauthErrorAccessDenied            = "access_denied"
+	oauthErrorUnsupportedGrantType    = "unsupported_grant_type"
+	oauthErrorUnsupportedResponseType = "unsupported_response_type"
+	oauthErrorServerError             = "server_error"
+)
+
+// RFC 7636, section 4.1
+var reCodeVerifier = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// oauthError is an error which can be returned to the client as defined in RFC 6749
+type oauthError struct {
+	Code        string
+	Description string
+}
+
+func (e *oauthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newOAuthErr(code, format string, args ...interface{}) *oauthError {
+	return &oauthError{Code: code, Description: fmt.Sprintf(format, args...)}
+}
+
+// ------------------------------------------
+// Access tokens
+
+func oauthAccessTokenValidity() time.Duration {
+	validity := time.Duration(utils.Config.Frontend.JwtValidityInMinutes) * time.Minute
+	if validity <= 0 {
+		validity = oauthDefaultAccessTokenValidity
+	}
+	return validity
+}
+
+// access tokens are signed like the mobile app tokens and are not stored, they are short-lived
+// and rejected once their device session has been revoked
+func createOAuthAccessToken(userId, appId, deviceId uint64, scopes []string) (string, time.Duration, error) {
+	signKey, err := getSignKey()
+	if err != nil {
+		return "", 0, err
+	}
+	if len(signKey) == 0 {
+		return "", 0, errors.New("jwt signing secret is not configured")
+	}
+	validity := oauthAccessTokenValidity()
+	now := time.Now()
+	token := jwt.NewWithClaims(signingMethod, &CustomClaims{
+		UserID:   userId,
+		AppID:    appId,
+		DeviceID: deviceId,
+		Scope:    strings.Join(scopes, " "),
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: now.Add(validity).Unix(),
+			IssuedAt:  now.Unix(),
+			Issuer:    utils.Config.Frontend.JwtIssuer,
+		},
+	})
+	signed, err := token.SignedString(signKey)
+	return signed, validity, err
+}
+
+// getOAuthAccessTokenClaims returns the validated claims if the token is an oauth access token,
+// an error is returned if the device session of the token has been revoked
+func (h *HandlerService) getOAuthAccessTokenClaims(ctx context.Context, token string) (*CustomClaims, bool, error) {
+	claims, ok := parseOAuthAccessToken(token)
+	if !ok {
+		return nil, false, nil
+	}
+	revoked, err := h.daService.IsUserDeviceRevoked(ctx, claims.DeviceID)
+	if err != nil {
+		return nil, true, err
+	}
+	if revoked {
+		return nil, true, newUnauthorizedErr("access token has been revoked")
+	}
+	return claims, true, nil
+}
+
+// GetOAuthAccessTokenUserId returns the subject of a valid oauth access token, the rate limiter uses it to key the requests of the token by user
+func GetOAuthAccessTokenUserId(token string) (uint64, bool) {
+	claims, ok := parseOAuthAccessToken(token)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
+
+// parseOAuthAccessToken returns the validated claims if the token is an oauth access token
+func parseOAuthAccessToken(token string) (*CustomClaims, bool) {
+	if strings.Count(token, ".") != 2 {
+		// api keys never contain dots
+		return nil, false
+	}
+	if signKey, err := getSignKey(); err != nil || len(signKey) == 0 {
+		return nil, false
+	}
+	claims, err := accessTokenGetClaims(token, true)
+	if err != nil || claims.Scope == "" {
+		// mobile app tokens have no scope and are not accepted for the public api
+		return nil, false
+	}
+	return claims, true
+}
+
+// ------------------------------------------
+// App registration
+
+func (h *HandlerService) InternalGetUserOAuthApps(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.daService.GetUserOAuthApps(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserOAuthAppsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+func (v *validationError) checkOAuthRedirectUri(redirectUri string) string {
+	if len(redirectUri) > oauthMaxRedirectUriLength {
+		v.add("redirect_uri", fmt.Sprintf("given value is too long, maximum length is %d", oauthMaxRedirectUriLength))
+		return redirectUri
+	}
+	u, err := url.Parse(redirectUri)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		v.add("redirect_uri", "must be an absolute url without fragment")
+		return redirectUri
+	}
+	// plain http is only allowed for apps running on the same machine as the user
+	if u.Scheme != "https" && !(u.Scheme == "http" && (u.Hostname() == "localhost" || u.Hostname() == "127.0.0.1")) {
+		v.add("redirect_uri", "must use https")
+	}
+	return redirectUri
+}
+
+func (h *HandlerService) InternalPostUserOAuthApps(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	req := struct {
+		Name         string `json:"name"`
+		RedirectUri  string `json:"redirect_uri"`
+		Confidential bool   `json:"confidential"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if len(name) > oauthMaxAppNameLength {
+		v.add("name", fmt.Sprintf("given value is too long, maximum length is %d", oauthMaxAppNameLength))
+	}
+	redirectUri := v.checkOAuthRedirectUri(req.RedirectUri)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	var clientSecret string
+	var hashedClientSecret *string
+	if req.Confidential {
+		clientSecret, err = utils.GenerateRandomAPIKey()
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		hashed := utils.HashAndEncode(clientSecret)
+		hashedClientSecret = &hashed
+	}
+	app, err := h.daService.CreateUserOAuthApp(r.Context(), userId, name, redirectUri, hashedClientSecret)
+	if errors.Is(err, dataaccess.ErrRedirectUriInUse) {
+		err = newConflictErr("redirect uri is already used by another app")
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserOAuthAppsResponse{
+		Data: types.CreatedOAuthApp{
+			OAuthApp:     *app,
+			ClientSecret: clientSecret,
+		},
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteUserOAuthApp(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	appId := v.checkUint(mux.Vars(r)["client_id"], "client_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	err = h.daService.RemoveUserOAuthApp(r.Context(), userId, appId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// ------------------------------------------
+// Authorization
+
+type oauthAuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientId            string `json:"client_id"`
+	RedirectUri         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state,omitempty"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Approve             bool   `json:"approve,omitempty"` // consent of the user, only used when posting the authorization
+}
+
+// checkOAuthAuthorizeRequest validates the client and redirect uri first, errors in the remaining parameters are returned as oauthError,
+// which may be passed to the client via the redirect uri
+func (h *HandlerService) checkOAuthAuthorizeRequest(r *http.Request, req oauthAuthorizeRequest) (*types.OAuthAppData, []string, error) {
+	appId, err := strconv.ParseUint(req.ClientId, 10, 64)
+	if err != nil {
+		return nil, nil, newBadRequestErr("invalid client_id")
+	}
+	app, err := h.daService.GetOAuthApp(r.Context(), appId)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, nil, newBadRequestErr("invalid client_id")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	// the redirect uri has to match exactly, otherwise the code could be leaked to a third party
+	if req.RedirectUri != app.RedirectURI {
+		return nil, nil, newBadRequestErr("redirect_uri does not match the registered redirect uri of the client")
+	}
+
+	if req.ResponseType != oauthResponseTypeCode {
+		return app, nil, newOAuthErr(oauthErrorUnsupportedResponseType, "only the authorization code flow is supported")
+	}
+	scopes := strings.Fields(req.Scope)
+	if len(scopes) == 0 {
+		return app, nil, newOAuthErr(oauthErrorInvalidScope, "at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(types.ApiKeyScopes, scope) {
+			return app, nil, newOAuthErr(oauthErrorInvalidScope, "unknown scope '%s'", scope)
+		}
+	}
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+	// PKCE is required for all clients
+	if req.CodeChallengeMethod != oauthCodeChallengeMethodS256 {
+		return app, nil, newOAuthErr(oauthErrorInvalidRequest, "code_challenge_method must be %s", oauthCodeChallengeMethodS256)
+	}
+	if !reCodeVerifier.MatchString(req.CodeChallenge) {
+		return app, nil, newOAuthErr(oauthErrorInvalidRequest, "invalid code_challenge")
+	}
+	return app, scopes, nil
+}
+
+// InternalGetOauthAuthorize returns the information needed to ask the user for consent
+func (h *HandlerService) InternalGetOauthAuthorize(w http.ResponseWriter, r *http.Request) {
+	if _, err := GetUserIdByContext(r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	req := oauthAuthorizeRequest{
+		ResponseType:        q.Get("response_type"),
+		ClientId:            q.Get("client_id"),
+		RedirectUri:         q.Get("redirect_uri"),
+		Scope:               q.Get("scope"),
+		State:               q.Get("state"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: q.Get("code_challenge_method"),
+	}
+	app, scopes, err := h.checkOAuthAuthorizeRequest(r, req)
+	var oauthErr *oauthError
+	if errors.As(err, &oauthErr) {
+		err = newBadRequestErr("%s", oauthErr.Description)
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetOAuthAuthorizeResponse{
+		Data: types.OAuthConsent{
+			AppName:     app.AppName,
+			RedirectUri: app.RedirectURI,
+			Scopes:      scopes,
+		},
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostOauthAuthorize is called after the user gave or denied consent, it returns the redirect uri containing the authorization code
+func (h *HandlerService) InternalPostOauthAuthorize(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var req oauthAuthorizeRequest
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	app, scopes, err := h.checkOAuthAuthorizeRequest(r, req)
+	var oauthErr *oauthError
+	if err != nil && !errors.As(err, &oauthErr) {
+		handleErr(w, r, err)
+		return
+	}
+	if oauthErr == nil && !req.Approve {
+		oauthErr = newOAuthErr(oauthErrorAccessDenied, "the user denied the request")
+	}
+
+	callback, err := url.Parse(app.RedirectURI)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	params := callback.Query()
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	if oauthErr == nil {
+		code := utils.RandomString(oauthAuthorizationCodeLength)
+		err = h.daService.AddOAuthCode(r.Context(), userId, app.ID, utils.HashAndEncode(code), req.RedirectUri, scopes, req.CodeChallenge, req.CodeChallengeMethod)
+		if err != nil {
+			logApiError(r, err, 0)
+			oauthErr = newOAuthErr(oauthErrorServerError, "error creating authorization code")
+		} else {
+			params.Set("code", code)
+		}
+	}
+	if oauthErr != nil {
+		params.Set("error", oauthErr.Code)
+		params.Set("error_description", oauthErr.Description)
+	}
+	callback.RawQuery = params.Encode()
+
+	response := types.InternalPostOAuthAuthorizeResponse{
+		Data: types.OAuthAuthorizeRedirect{
+			RedirectUri: callback.String(),
+		},
+	}
+	returnOk(w, r, response)
+}
+
+// ------------------------------------------
+// Token
+
+func returnOAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	var oauthErr *oauthError
+	if !errors.As(err, &oauthErr) {
+		logApiError(r, err, 1)
+		oauthErr = newOAuthErr(oauthErrorServerError, "internal server error")
+	}
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case oauthErrorInvalidClient:
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	case oauthErrorServerError:
+		status = http.StatusInternalServerError
+	}
+	writeResponse(w, r, status, types.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
+
+// verifyCodeChallenge checks the code verifier against the S256 challenge of the authorization request, see RFC 7636
+func verifyCodeChallenge(codeVerifier, codeChallenge string) bool {
+	if !reCodeVerifier.MatchString(codeVerifier) {
+		return false
+	}
+	hash := sha256.Sum256([]byte(codeVerifier))
+	computed := base64.RawURLEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(codeChallenge)) == 1
+}
+
+// authenticateOAuthClient checks the client credentials, public clients have no secret and are authenticated by PKCE
+func (h *HandlerService) authenticateOAuthClient(r *http.Request) (*types.OAuthAppData, error) {
+	clientId, clientSecret, hasBasicAuth := r.BasicAuth()
+	if !hasBasicAuth {
+		clientId = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+	appId, err := strconv.ParseUint(clientId, 10, 64)
+	if err != nil {
+		return nil, newOAuthErr(oauthErrorInvalidClient, "invalid client_id")
+	}
+	app, err := h.daService.GetOAuthApp(r.Context(), appId)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, newOAuthErr(oauthErrorInvalidClient, "invalid client_id")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if app.ClientSecret != nil {
+		hashed := utils.HashAndEncode(clientSecret)
+		if clientSecret == "" || subtle.ConstantTimeCompare([]byte(hashed), []byte(*app.ClientSecret)) != 1 {
+			return nil, newOAuthErr(oauthErrorInvalidClient, "invalid client credentials")
+		}
+	}
+	return app, nil
+}
+
+func (h *HandlerService) getOAuthToken(r *http.Request) (*types.OAuthTokenResponse, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, newOAuthErr(oauthErrorInvalidRequest, "malformed request body")
+	}
+	app, err := h.authenticateOAuthClient(r)
+	if err != nil {
+		return nil, err
+	}
+
+	form := r.PostForm
+	refreshToken := utils.RandomString(oauthRefreshTokenLength)
+	var userId, deviceId uint64
+	var scopes []string
+	switch form.Get("grant_type") {
+	case oauthGrantTypeAuthorizationCode:
+		code, err := h.daService.ConsumeOAuthCode(r.Context(), app.ID, utils.HashAndEncode(form.Get("code")), oauthCodeValidity)
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			return nil, newOAuthErr(oauthErrorInvalidGrant, "invalid or expired authorization code")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if code.RedirectUri != form.Get("redirect_uri") {
+			return nil, newOAuthErr(oauthErrorInvalidGrant, "redirect_uri does not match the authorization request")
+		}
+		if code.CodeChallengeMethod != oauthCodeChallengeMethodS256 || !verifyCodeChallenge(form.Get("code_verifier"), code.CodeChallenge) {
+			return nil, newOAuthErr(oauthErrorInvalidGrant, "invalid code_verifier")
+		}
+		userId, scopes = code.UserId, code.Scopes
+		deviceName := utils.FirstN(app.AppName, oauthMaxDeviceNameLength)
+		deviceId, err = h.daService.AddOAuthDevice(r.Context(), userId, app.ID, utils.HashAndEncode(refreshToken), deviceName, scopes)
+		if err != nil {
+			return nil, err
+		}
+	case oauthGrantTypeRefreshToken:
+		// refresh tokens are rotated, every token can only be used once
+		device, err := h.daService.RotateOAuthRefreshToken(r.Context(), app.ID, utils.HashAndEncode(form.Get("refresh_token")), utils.HashAndEncode(refreshToken))
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			return nil, newOAuthErr(oauthErrorInvalidGrant, "invalid refresh token")
+		}
+		if err != nil {
+			return nil, err
+		}
+		userId, deviceId, scopes = device.UserId, device.Id, device.Scopes
+	default:
+		return nil, newOAuthErr(oauthErrorUnsupportedGrantType, "grant_type must be %s or %s", oauthGrantTypeAuthorizationCode, oauthGrantTypeRefreshToken)
+	}
+
+	h.updateDeviceActivity(r, utils.HashAndEncode(refreshToken))
+
+	accessToken, validity, err := createOAuthAccessToken(userId, app.ID, deviceId, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &types.OAuthTokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    uint64(validity.Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        strings.Join(scopes, " "),
+	}, nil
+}
+
+// PublicPostOauthToken godoc
+//
+//	@Description	Exchange an authorization code or a refresh token for an access token, following RFC 6749 and RFC 7636 (PKCE). Access tokens can be used like api keys and are limited to the granted scopes.
+//	@Tags			OAuth
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			grant_type		formData	string	true	"`authorization_code` or `refresh_token`"
+//	@Param			client_id		formData	string	false	"The client id, can also be passed via HTTP basic authentication."
+//	@Param			client_secret	formData	string	false	"The client secret of confidential clients, can also be passed via HTTP basic authentication."
+//	@Param			code			formData	string	false	"The authorization code, required for `authorization_code`."
+//	@Param			redirect_uri	formData	string	false	"The redirect uri of the authorization request, required for `authorization_code`."
+//	@Param			code_verifier	formData	string	false	"The PKCE code verifier, required for `authorization_code`."
+//	@Param			refresh_token	formData	string	false	"The refresh token, required for `refresh_token`."
+//	@Success		200				{object}	types.OAuthTokenResponse
+//	@Failure		400				{object}	types.OAuthErrorResponse
+//	@Failure		401				{object}	types.OAuthErrorResponse
+//	@Router			/oauth/token [post]
+func (h *HandlerService) PublicPostOauthToken(w http.ResponseWriter, r *http.Request) {
+	data, err := h.getOAuthToken(r)
+	if err != nil {
+		returnOAuthError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeResponse(w, r, http.StatusOK, data)
+}
+
+func (h *HandlerService) InternalPostOauthToken(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostOauthToken(w, r)
+}
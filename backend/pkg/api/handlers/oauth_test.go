@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexedwards/scs/v2"
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scopeTestDataAccessor keeps the scopes of api keys in memory
+type scopeTestDataAccessor struct {
+	webSessionTestIndex
+	apiKeyScopes map[string][]string
+}
+
+func (d *scopeTestDataAccessor) GetApiKeyScopes(ctx context.Context, apiKey string) ([]string, error) {
+	scopes, ok := d.apiKeyScopes[apiKey]
+	if !ok {
+		return nil, dataaccess.ErrNotFound
+	}
+	return scopes, nil
+}
+
+func setOAuthTestConfig(t *testing.T) {
+	previousConfig := utils.Config
+	t.Cleanup(func() { utils.Config = previousConfig })
+	utils.Config = &commontypes.Config{}
+	utils.Config.Frontend.JwtSigningSecret = hex.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func TestApiKeyScopeCheckMiddlewareAccessToken(t *testing.T) {
+	setOAuthTestConfig(t)
+	da := &scopeTestDataAccessor{apiKeyScopes: map[string][]string{"networkreadkey": {types.ApiKeyScopeNetworkRead}}}
+	h := &HandlerService{daService: da, scs: scs.New()}
+	token, _, err := createOAuthAccessToken(1, 2, 3, []string{types.ApiKeyScopeNetworkRead})
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { returnNoContent(w, r) })
+	status := func(handler http.Handler, credential string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", authHeaderPrefix+credential)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// access tokens follow the same scope rules as api keys
+	for _, credential := range []string{token, "networkreadkey"} {
+		assert.Equal(t, http.StatusNoContent, status(h.ReadNetworkDataViaApiCheckMiddleware(next), credential))
+		assert.Equal(t, http.StatusForbidden, status(h.ReadAccountDataViaApiCheckMiddleware(next), credential))
+		assert.Equal(t, http.StatusForbidden, status(h.WriteMachineMetricsViaApiCheckMiddleware(next), credential))
+	}
+	assert.Equal(t, http.StatusUnauthorized, status(h.ReadNetworkDataViaApiCheckMiddleware(next), "unknownkey"))
+}
+
+func TestGetOAuthAccessTokenUserId(t *testing.T) {
+	setOAuthTestConfig(t)
+	token, _, err := createOAuthAccessToken(1, 2, 3, []string{types.ApiKeyScopeNetworkRead})
+	require.NoError(t, err)
+
+	userId, ok := GetOAuthAccessTokenUserId(token)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), userId)
+
+	// api keys are keyed by the rate limiter itself
+	_, ok = GetOAuthAccessTokenUserId("abcdefghijklmnopqrstuvwxyz")
+	assert.False(t, ok)
+}
@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+// --------------------------------------
+//   Helpers
+
+// checkOrganizationOwner returns ErrNotFound if the user is not a member and a forbidden error if the user is not an owner
+func (h *HandlerService) checkOrganizationOwner(ctx context.Context, organizationId, userId uint64) error {
+	role, err := h.daService.GetOrganizationMemberRole(ctx, organizationId, userId)
+	if err != nil {
+		return err
+	}
+	if role != types.OrganizationRoleOwner {
+		return newForbiddenErr("only organization owners can perform this action")
+	}
+	return nil
+}
+
+func (h *HandlerService) getOrganizationMember(ctx context.Context, organizationId, userId uint64) (*types.OrganizationMember, error) {
+	members, err := h.daService.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.UserId == userId {
+			return &member, nil
+		}
+	}
+	return nil, newNotFoundErr("user %v is not a member of organization %v", userId, organizationId)
+}
+
+// --------------------------------------
+//   Organizations
+
+func (h *HandlerService) InternalGetUserOrganizations(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.daService.GetUserOrganizations(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalGetUserOrganizationsResponse{Data: data})
+}
+
+func (h *HandlerService) InternalPostOrganizations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.daService.CreateOrganization(r.Context(), userId, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnCreated(w, r, types.InternalPostOrganizationsResponse{Data: *data})
+}
+
+func (h *HandlerService) InternalGetOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	organization, err := h.daService.GetOrganization(r.Context(), organizationId, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	perks, err := h.daService.GetOrganizationPremiumPerks(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalGetOrganizationResponse{
+		Data: types.OrganizationDetails{Organization: *organization, PremiumPerks: *perks},
+	})
+}
+
+func (h *HandlerService) InternalPutOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if err := h.checkOrganizationOwner(r.Context(), organizationId, userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.UpdateOrganizationName(r.Context(), organizationId, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.daService.GetOrganization(r.Context(), organizationId, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalPutOrganizationResponse{Data: *data})
+}
+
+// InternalDeleteOrganization deletes the organization, its dashboards are returned to the members who created them
+func (h *HandlerService) InternalDeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if err := h.checkOrganizationOwner(r.Context(), organizationId, userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.RemoveOrganization(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// --------------------------------------
+//   Members
+
+func (h *HandlerService) InternalGetOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	// any member can see the other members
+	_, err = h.daService.GetOrganizationMemberRole(r.Context(), organizationId, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.daService.GetOrganizationMembers(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalGetOrganizationMembersResponse{Data: data})
+}
+
+func (h *HandlerService) InternalPostOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	req := struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	email := v.checkEmail(req.Email)
+	role := v.checkOrganizationRole(req.Role)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if err := h.checkOrganizationOwner(r.Context(), organizationId, userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	memberId, err := h.daService.GetUserByEmail(r.Context(), email)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.AddOrganizationMember(r.Context(), organizationId, memberId, role)
+	if errors.Is(err, dataaccess.ErrOrganizationMemberExists) {
+		returnConflict(w, r, err)
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnCreated(w, r, types.InternalPostOrganizationMembersResponse{
+		Data: types.OrganizationMember{
+			UserId:   memberId,
+			Email:    utils.CensorEmail(email),
+			Role:     role,
+			JoinedAt: time.Now().Unix(),
+		},
+	})
+}
+
+func (h *HandlerService) InternalPutOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	organizationId := v.checkUint(vars["organization_id"], "organization_id")
+	memberId := v.checkUint(vars["user_id"], "user_id")
+	req := struct {
+		Role string `json:"role"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	role := v.checkOrganizationRole(req.Role)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if err := h.checkOrganizationOwner(r.Context(), organizationId, userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.UpdateOrganizationMemberRole(r.Context(), organizationId, memberId, role)
+	if errors.Is(err, dataaccess.ErrLastOrganizationOwner) {
+		returnConflict(w, r, err)
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getOrganizationMember(r.Context(), organizationId, memberId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalPutOrganizationMemberResponse{Data: *data})
+}
+
+// InternalDeleteOrganizationMember removes a member, owners can remove anyone and every member can leave on their own
+func (h *HandlerService) InternalDeleteOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	organizationId := v.checkUint(vars["organization_id"], "organization_id")
+	memberId := v.checkUint(vars["user_id"], "user_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if memberId != userId {
+		if err := h.checkOrganizationOwner(r.Context(), organizationId, userId); err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+	err = h.daService.RemoveOrganizationMember(r.Context(), organizationId, memberId)
+	if errors.Is(err, dataaccess.ErrLastOrganizationOwner) {
+		returnConflict(w, r, err)
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// --------------------------------------
+//   Dashboard ownership
+
+// InternalPutValidatorDashboardOrganization transfers a dashboard between the personal account and organizations.
+// Access to the dashboard itself is checked by the VDBAuthMiddleware, an organization id of 0 transfers it to the user.
+func (h *HandlerService) InternalPutValidatorDashboardOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		OrganizationId uint64 `json:"organization_id"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+
+	dashboardUser, err := h.daService.GetValidatorDashboardUser(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if dashboardUser.OrganizationId == nil {
+		if dashboardUser.UserId != userId {
+			handleErr(w, r, newNotFoundErr("dashboard with id %v not found", dashboardId))
+			return
+		}
+	} else if err := h.checkOrganizationOwner(ctx, *dashboardUser.OrganizationId, userId); err != nil {
+		// only owners can move dashboards out of an organization
+		handleErr(w, r, err)
+		return
+	}
+	currentOrganizationId := uint64(0)
+	if dashboardUser.OrganizationId != nil {
+		currentOrganizationId = *dashboardUser.OrganizationId
+	}
+	if currentOrganizationId == req.OrganizationId {
+		returnNoContent(w, r)
+		return
+	}
+
+	dashboardInfo, err := h.daService.GetValidatorDashboardInfo(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	// the new owner must not exceed its limits with the transferred dashboard
+	var dashboardCount uint64
+	var perks *types.PremiumPerks
+	var newOrganizationId *uint64
+	if req.OrganizationId != 0 {
+		role, err := h.daService.GetOrganizationMemberRole(ctx, req.OrganizationId, userId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if role == types.OrganizationRoleViewer {
+			handleErr(w, r, newForbiddenErr("organization viewers cannot add dashboards to the organization"))
+			return
+		}
+		newOrganizationId = &req.OrganizationId
+		dashboardCount, err = h.daService.GetOrganizationValidatorDashboardCount(ctx, req.OrganizationId, !dashboardInfo.IsArchived)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		perks, err = h.daService.GetOrganizationPremiumPerks(ctx, req.OrganizationId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	} else {
+		dashboardCount, err = h.daService.GetUserValidatorDashboardCount(ctx, userId, !dashboardInfo.IsArchived)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		userInfo, err := h.daService.GetUserInfo(ctx, userId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		perks = &userInfo.PremiumPerks
+	}
+	if dashboardInfo.IsArchived {
+		if dashboardCount >= MaxArchivedDashboardsCount {
+			returnConflict(w, r, errors.New("maximum number of archived validator dashboards reached"))
+			return
+		}
+	} else if dashboardCount >= perks.ValidatorDashboards {
+		returnConflict(w, r, errors.New("maximum number of active validator dashboards reached"))
+		return
+	}
+
+	// the creator is kept for organization dashboards, a dashboard moved out of an organization belongs to the requesting owner
+	creatorId := dashboardUser.UserId
+	if newOrganizationId == nil {
+		creatorId = userId
+	}
+	err = h.daService.UpdateValidatorDashboardOwner(ctx, dashboardId, creatorId, newOrganizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
@@ -85,6 +85,31 @@ func (h *HandlerService) PublicGetUserDashboards(w http.ResponseWriter, r *http.
 	returnOk(w, r, response)
 }
 
+// PublicGetUserApiUsage godoc
+//
+//	@Description	Get the api usage of the authenticated user per api key and endpoint, hourly for the last 48 hours and daily for the last 30 days, together with the quotas of the user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Success		200	{object}	types.InternalGetUserApiUsageResponse
+//	@Router			/users/me/api-usage [get]
+func (h *HandlerService) PublicGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetUserApiUsage(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserApiUsageResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
 func (h *HandlerService) PublicPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
 	returnCreated(w, r, nil)
 }
@@ -358,13 +383,8 @@ func (h *HandlerService) PublicPostValidatorDashboardGroups(w http.ResponseWrite
 		return
 	}
 	ctx := r.Context()
-	// check if user has reached the maximum number of groups
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	// check if the dashboard has reached the maximum number of groups of its owner
+	premiumPerks, err := h.getDashboardPremiumPerks(ctx, types.VDBId{Id: dashboardId})
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -374,7 +394,7 @@ func (h *HandlerService) PublicPostValidatorDashboardGroups(w http.ResponseWrite
 		handleErr(w, r, err)
 		return
 	}
-	if groupCount >= userInfo.PremiumPerks.ValidatorGroupsPerDashboard {
+	if groupCount >= premiumPerks.ValidatorGroupsPerDashboard {
 		returnConflict(w, r, errors.New("maximum number of validator dashboard groups reached"))
 		return
 	}
@@ -600,11 +620,17 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 		handleErr(w, r, err)
 		return
 	}
-	if req.Validators == nil && !userInfo.PremiumPerks.BulkAdding {
+	// organization dashboards are limited by the pooled perks of the organization
+	premiumPerks, err := h.getDashboardPremiumPerks(ctx, types.VDBId{Id: dashboardId})
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if req.Validators == nil && !premiumPerks.BulkAdding {
 		returnForbidden(w, r, errors.New("bulk adding not allowed with current subscription plan"))
 		return
 	}
-	dashboardLimit := userInfo.PremiumPerks.ValidatorsPerDashboard
+	dashboardLimit := premiumPerks.ValidatorsPerDashboard
 	existingValidatorCount, err := h.getDataAccessor(r).GetValidatorDashboardValidatorsCount(ctx, dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
@@ -952,32 +978,43 @@ func (h *HandlerService) PublicPutValidatorDashboardArchiving(w http.ResponseWri
 		handleErr(w, r, err)
 		return
 	}
-	dashboardCount, err := h.getDataAccessor(r).GetUserValidatorDashboardCount(r.Context(), userId, !req.IsArchived)
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
 
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	// organization dashboards are counted and limited per organization
+	var dashboardCount uint64
+	premiumPerks := &userInfo.PremiumPerks
+	if dashboardInfo.OrganizationId != 0 {
+		dashboardCount, err = h.getDataAccessor(r).GetOrganizationValidatorDashboardCount(r.Context(), dashboardInfo.OrganizationId, !req.IsArchived)
+		if err == nil {
+			premiumPerks, err = h.getDataAccessor(r).GetOrganizationPremiumPerks(r.Context(), dashboardInfo.OrganizationId)
+		}
+	} else {
+		dashboardCount, err = h.getDataAccessor(r).GetUserValidatorDashboardCount(r.Context(), userId, !req.IsArchived)
+	}
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+
 	if req.IsArchived {
 		if dashboardCount >= MaxArchivedDashboardsCount && !isUserAdmin(userInfo) {
 			returnConflict(w, r, errors.New("maximum number of archived validator dashboards reached"))
 			return
 		}
 	} else {
-		if dashboardCount >= userInfo.PremiumPerks.ValidatorDashboards {
+		if dashboardCount >= premiumPerks.ValidatorDashboards {
 			returnConflict(w, r, errors.New("maximum number of active validator dashboards reached"))
 			return
 		}
-		if dashboardInfo.GroupCount >= userInfo.PremiumPerks.ValidatorGroupsPerDashboard {
+		if dashboardInfo.GroupCount >= premiumPerks.ValidatorGroupsPerDashboard {
 			returnConflict(w, r, errors.New("maximum number of groups in dashboards reached"))
 			return
 		}
-		if dashboardInfo.ValidatorCount >= userInfo.PremiumPerks.ValidatorsPerDashboard {
+		if dashboardInfo.ValidatorCount >= premiumPerks.ValidatorsPerDashboard {
 			returnConflict(w, r, errors.New("maximum number of validators in dashboards reached"))
 			return
 		}
@@ -1749,6 +1786,80 @@ func (h *HandlerService) PublicGetValidatorDashboardTotalWithdrawals(w http.Resp
 	returnOk(w, r, response)
 }
 
+// PublicGetValidatorDashboardConsolidations godoc
+//
+//	@Description	Get the consolidation requests (EIP-7251) in which the validators of a specified dashboard are the source or the target.
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetValidatorDashboardConsolidationsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/consolidations [get]
+func (h *HandlerService) PublicGetValidatorDashboardConsolidations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardConsolidations(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.GetValidatorDashboardConsolidationsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardWithdrawalRequests godoc
+//
+//	@Description	Get the execution layer triggered exits and partial withdrawal requests (EIP-7002) for the validators of a specified dashboard.
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetValidatorDashboardWithdrawalRequestsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/withdrawal-requests [get]
+func (h *HandlerService) PublicGetValidatorDashboardWithdrawalRequests(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardWithdrawalRequests(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.GetValidatorDashboardWithdrawalRequestsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
 // PublicGetValidatorDashboardRocketPool godoc
 //
 //	@Description	Get an aggregated list of the Rocket Pool nodes details associated with a specified dashboard.
@@ -2127,8 +2238,84 @@ func (h *HandlerService) PublicGetUserNotificationNetworks(w http.ResponseWriter
 	returnOk(w, r, response)
 }
 
+// PublicGetUserNotificationIncidents godoc
+//
+//	@Description	Get a list of notification incidents of your validator dashboards. An incident groups related notifications, it is opened by e.g. a validator going offline and resolved once the validator is online again.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(opened_epoch, dashboard_name, event_type)
+//	@Param			status	query		string	false	"Only return incidents with the given status."	Enums(open, resolved)
+//	@Success		200		{object}	types.InternalGetUserNotificationIncidentsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/incidents [get]
+func (h *HandlerService) PublicGetUserNotificationIncidents(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.NotificationIncidentsColumn](&v, q.Get("sort"))
+	status := v.checkNotificationIncidentStatus(q.Get("status"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetNotificationIncidents(r.Context(), userId, status, pagingParams.cursor, *sort, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	mapNotificationIncidentEventNames(data)
+	response := types.InternalGetUserNotificationIncidentsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPostUserNotificationIncidentAcknowledge godoc
+//
+//	@Description	Acknowledge a notification incident of one of your validator dashboards.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			incident_id	path	integer	true	"The ID of the incident."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Failure		404	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/incidents/{incident_id}/acknowledge [post]
+func (h *HandlerService) PublicPostUserNotificationIncidentAcknowledge(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	incidentId := v.checkUint(mux.Vars(r)["incident_id"], "incident_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	err = h.getDataAccessor(r).AcknowledgeNotificationIncident(r.Context(), userId, incidentId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
 const diffTolerance = 0.0001
 
+// machine client alerts are evaluated on the latest 5 samples, one is pushed per minute
+const maxMachineClientAlertMinutes = 5
+const maxMachineHeadSlotLagThreshold = 8192
+
 // PublicGetUserNotificationPairedDevices godoc
 //
 //	@Description	Get notification settings for the authenticated user. Excludes dashboard notification settings.
@@ -2177,6 +2364,26 @@ func (h *HandlerService) PublicGetUserNotificationSettings(w http.ResponseWriter
 			userGeneralSettings.MachineMemoryUsageThreshold = defaultSettings.MachineMemoryUsageThreshold
 			userGeneralSettings.IsMachineMemoryUsageSubscribed = false
 		}
+		if math.Abs(userGeneralSettings.MachineNotSyncedThreshold-defaultSettings.MachineNotSyncedThreshold) > diffTolerance {
+			userGeneralSettings.MachineNotSyncedThreshold = defaultSettings.MachineNotSyncedThreshold
+			userGeneralSettings.IsMachineNotSyncedSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineElDisconnectedThreshold-defaultSettings.MachineElDisconnectedThreshold) > diffTolerance {
+			userGeneralSettings.MachineElDisconnectedThreshold = defaultSettings.MachineElDisconnectedThreshold
+			userGeneralSettings.IsMachineElDisconnectedSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineLowPeerCountThreshold-defaultSettings.MachineLowPeerCountThreshold) > diffTolerance {
+			userGeneralSettings.MachineLowPeerCountThreshold = defaultSettings.MachineLowPeerCountThreshold
+			userGeneralSettings.IsMachineLowPeerCountSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineHeadSlotLagThreshold-defaultSettings.MachineHeadSlotLagThreshold) > diffTolerance {
+			userGeneralSettings.MachineHeadSlotLagThreshold = defaultSettings.MachineHeadSlotLagThreshold
+			userGeneralSettings.IsMachineHeadSlotLagSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineFallbackInUseThreshold-defaultSettings.MachineFallbackInUseThreshold) > diffTolerance {
+			userGeneralSettings.MachineFallbackInUseThreshold = defaultSettings.MachineFallbackInUseThreshold
+			userGeneralSettings.IsMachineFallbackInUseSubscribed = false
+		}
 		data.GeneralSettings = userGeneralSettings
 	}
 
@@ -2212,6 +2419,11 @@ func (h *HandlerService) PublicPutUserNotificationSettingsGeneral(w http.Respons
 	checkMinMax(&v, req.MachineStorageUsageThreshold, 0, 1, "machine_storage_usage_threshold")
 	checkMinMax(&v, req.MachineCpuUsageThreshold, 0, 1, "machine_cpu_usage_threshold")
 	checkMinMax(&v, req.MachineMemoryUsageThreshold, 0, 1, "machine_memory_usage_threshold")
+	checkMinMax(&v, req.MachineNotSyncedThreshold, 1, maxMachineClientAlertMinutes, "machine_not_synced_threshold")
+	checkMinMax(&v, req.MachineElDisconnectedThreshold, 1, maxMachineClientAlertMinutes, "machine_el_disconnected_threshold")
+	checkMinMax(&v, req.MachineLowPeerCountThreshold, 0, 1000, "machine_low_peer_count_threshold")
+	checkMinMax(&v, req.MachineHeadSlotLagThreshold, 1, maxMachineHeadSlotLagThreshold, "machine_head_slot_lag_threshold")
+	checkMinMax(&v, req.MachineFallbackInUseThreshold, 1, maxMachineClientAlertMinutes, "machine_fallback_in_use_threshold")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
@@ -2233,7 +2445,12 @@ func (h *HandlerService) PublicPutUserNotificationSettingsGeneral(w http.Respons
 	// use tolarance for float comparison
 	isCustomThresholdUsed := math.Abs(req.MachineStorageUsageThreshold-defaultSettings.MachineStorageUsageThreshold) > diffTolerance ||
 		math.Abs(req.MachineCpuUsageThreshold-defaultSettings.MachineCpuUsageThreshold) > diffTolerance ||
-		math.Abs(req.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance
+		math.Abs(req.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance ||
+		math.Abs(req.MachineNotSyncedThreshold-defaultSettings.MachineNotSyncedThreshold) > diffTolerance ||
+		math.Abs(req.MachineElDisconnectedThreshold-defaultSettings.MachineElDisconnectedThreshold) > diffTolerance ||
+		math.Abs(req.MachineLowPeerCountThreshold-defaultSettings.MachineLowPeerCountThreshold) > diffTolerance ||
+		math.Abs(req.MachineHeadSlotLagThreshold-defaultSettings.MachineHeadSlotLagThreshold) > diffTolerance ||
+		math.Abs(req.MachineFallbackInUseThreshold-defaultSettings.MachineFallbackInUseThreshold) > diffTolerance
 
 	if !userInfo.PremiumPerks.NotificationsMachineCustomThreshold && isCustomThresholdUsed {
 		returnForbidden(w, r, errors.New("user does not have premium perks to set machine settings thresholds"))
@@ -2659,6 +2876,326 @@ func (h *HandlerService) PublicPutUserNotificationSettingsAccountDashboard(w htt
 	returnOk(w, r, response)
 }
 
+// PublicGetUserNotificationSettingsValidatorDashboardRules godoc
+//
+//	@Description	Get the user defined notification rules of a specific group of a validator dashboard for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Success		200				{object}	types.InternalGetUserNotificationSettingsValidatorDashboardRulesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules [get]
+func (h *HandlerService) PublicGetUserNotificationSettingsValidatorDashboardRules(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetNotificationRulesValidatorDashboard(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserNotificationSettingsValidatorDashboardRulesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+type notificationRuleRequest struct {
+	Name           string  `json:"name"`
+	Metric         string  `json:"metric"`
+	Comparison     string  `json:"comparison"`
+	Threshold      float64 `json:"threshold"`
+	WindowEpochs   uint64  `json:"window_epochs"`
+	CooldownEpochs uint64  `json:"cooldown_epochs"`
+}
+
+func (v *validationError) checkNotificationRuleRequest(req notificationRuleRequest) types.NotificationRuleValidatorDashboard {
+	return types.NotificationRuleValidatorDashboard{
+		Name:           v.checkNameNotEmpty(req.Name),
+		Metric:         string(v.checkNotificationRuleMetric(req.Metric)),
+		Comparison:     string(v.checkNotificationRuleComparison(req.Comparison)),
+		Threshold:      req.Threshold,
+		WindowEpochs:   checkMinMax(v, req.WindowEpochs, 1, maxNotificationRuleWindowEpochs, "window_epochs"),
+		CooldownEpochs: checkMinMax(v, req.CooldownEpochs, 0, maxNotificationRuleCooldownEpochs, "cooldown_epochs"),
+	}
+}
+
+// user defined rules are an extension of the group efficiency notification and share its premium perk
+func (h *HandlerService) checkNotificationRulePremiumPerks(r *http.Request) error {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		return err
+	}
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	if err != nil {
+		return err
+	}
+	if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency {
+		return newForbiddenErr("user does not have premium perks to create notification rules")
+	}
+	return nil
+}
+
+// PublicPostUserNotificationSettingsValidatorDashboardRules godoc
+//
+//	@Description	Create a user defined notification rule for a specific group of a validator dashboard for the authenticated user. The rule is triggered if the `metric` of the group, aggregated over the last `window_epochs` epochs, satisfies the `comparison` with the `threshold`. After being triggered, a rule stays silent for `cooldown_epochs` epochs.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		string								true	"The ID of the dashboard."
+//	@Param			group_id		path		integer								true	"The ID of the group."
+//	@Param			request			body		handlers.notificationRuleRequest	true	"`metric`: One of `efficiency` (0-1), `missed_attestations`, `balance_delta` (ETH), `proposal_luck` (0-n) or `sync_participation` (0-1).<br>`comparison`: One of `lt`, `lte`, `gt` or `gte`."
+//	@Success		201				{object}	types.InternalPostUserNotificationSettingsValidatorDashboardRulesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		403				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the maximum number of rules for this group has been reached."
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules [post]
+func (h *HandlerService) PublicPostUserNotificationSettingsValidatorDashboardRules(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	var req notificationRuleRequest
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	rule := v.checkNotificationRuleRequest(req)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if err := h.checkNotificationRulePremiumPerks(r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	rules, err := h.getDataAccessor(r).GetNotificationRulesValidatorDashboard(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(rules) >= maxNotificationRulesPerGroup {
+		returnConflict(w, r, errors.New("maximum number of notification rules for this group reached"))
+		return
+	}
+	data, err := h.getDataAccessor(r).CreateNotificationRuleValidatorDashboard(r.Context(), dashboardId, groupId, rule)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserNotificationSettingsValidatorDashboardRulesResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+// PublicPutUserNotificationSettingsValidatorDashboardRule godoc
+//
+//	@Description	Update a user defined notification rule of a specific group of a validator dashboard for the authenticated user. Updating a rule resets its cooldown.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		string								true	"The ID of the dashboard."
+//	@Param			group_id		path		integer								true	"The ID of the group."
+//	@Param			rule_id			path		integer								true	"The ID of the rule."
+//	@Param			request			body		handlers.notificationRuleRequest	true	"`metric`: One of `efficiency` (0-1), `missed_attestations`, `balance_delta` (ETH), `proposal_luck` (0-n) or `sync_participation` (0-1).<br>`comparison`: One of `lt`, `lte`, `gt` or `gte`."
+//	@Success		200				{object}	types.InternalPutUserNotificationSettingsValidatorDashboardRuleResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		403				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules/{rule_id} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboardRule(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	var req notificationRuleRequest
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	rule := v.checkNotificationRuleRequest(req)
+	rule.Id = v.checkUint(vars["rule_id"], "rule_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if err := h.checkNotificationRulePremiumPerks(r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getDataAccessor(r).UpdateNotificationRuleValidatorDashboard(r.Context(), dashboardId, groupId, rule)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPutUserNotificationSettingsValidatorDashboardRuleResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicDeleteUserNotificationSettingsValidatorDashboardRule godoc
+//
+//	@Description	Delete a user defined notification rule of a specific group of a validator dashboard for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path	string	true	"The ID of the dashboard."
+//	@Param			group_id		path	integer	true	"The ID of the group."
+//	@Param			rule_id			path	integer	true	"The ID of the rule."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Failure		404	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules/{rule_id} [delete]
+func (h *HandlerService) PublicDeleteUserNotificationSettingsValidatorDashboardRule(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	ruleId := v.checkUint(vars["rule_id"], "rule_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	err := h.getDataAccessor(r).DeleteNotificationRuleValidatorDashboard(r.Context(), dashboardId, groupId, ruleId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// PublicGetUserNotificationSettingsValidatorDashboardSnoozes godoc
+//
+//	@Description	Get the active notification snoozes of a specific group of a validator dashboard for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Success		200				{object}	types.InternalGetUserNotificationSettingsValidatorDashboardSnoozesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes [get]
+func (h *HandlerService) PublicGetUserNotificationSettingsValidatorDashboardSnoozes(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetNotificationSnoozesValidatorDashboard(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserNotificationSettingsValidatorDashboardSnoozesResponse{
+		Data: mapNotificationSnoozeEventNames(data),
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPostUserNotificationSettingsValidatorDashboardSnoozes godoc
+//
+//	@Description	Snooze the notifications of a specific group of a validator dashboard for the authenticated user. Snoozed notifications are not sent but still show up in the notification history.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Param			request			body		handlers.PublicPostUserNotificationSettingsValidatorDashboardSnoozes.request	true	"`event_type`: The event to snooze, e.g. `validator_offline`. Snoozes all events of the group if omitted.<br>`duration`: The snooze duration in seconds, at most 30 days."
+//	@Success		201				{object}	types.InternalPostUserNotificationSettingsValidatorDashboardSnoozesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the maximum number of snoozes for this group has been reached."
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes [post]
+func (h *HandlerService) PublicPostUserNotificationSettingsValidatorDashboardSnoozes(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	type request struct {
+		EventType string `json:"event_type,omitempty"`
+		Duration  uint64 `json:"duration"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	eventType := v.checkNotificationSnoozeEventType(req.EventType)
+	duration := checkMinMax(&v, req.Duration, 1, maxNotificationSnoozeDuration, "duration")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	snoozes, err := h.getDataAccessor(r).GetNotificationSnoozesValidatorDashboard(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(snoozes) >= maxNotificationSnoozesPerGroup {
+		returnConflict(w, r, errors.New("maximum number of notification snoozes for this group reached"))
+		return
+	}
+	snoozedUntil := time.Now().Add(time.Duration(duration) * time.Second)
+	data, err := h.getDataAccessor(r).CreateNotificationSnoozeValidatorDashboard(r.Context(), dashboardId, groupId, eventType, snoozedUntil)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data.EventType != nil {
+		eventType := mapNotificationEventName(*data.EventType)
+		data.EventType = &eventType
+	}
+	response := types.InternalPostUserNotificationSettingsValidatorDashboardSnoozesResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+// PublicDeleteUserNotificationSettingsValidatorDashboardSnooze godoc
+//
+//	@Description	Delete a notification snooze of a specific group of a validator dashboard for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path	string	true	"The ID of the dashboard."
+//	@Param			group_id		path	integer	true	"The ID of the group."
+//	@Param			snooze_id		path	integer	true	"The ID of the snooze."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Failure		404	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes/{snooze_id} [delete]
+func (h *HandlerService) PublicDeleteUserNotificationSettingsValidatorDashboardSnooze(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	snoozeId := v.checkUint(vars["snooze_id"], "snooze_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	err := h.getDataAccessor(r).DeleteNotificationSnoozeValidatorDashboard(r.Context(), dashboardId, groupId, snoozeId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
 // PublicPostUserNotificationsTestEmail godoc
 //
 //	@Description	Send a test email notification to the authenticated user.
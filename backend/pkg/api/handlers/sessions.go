@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/ratelimit"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+// session keys of the web session metadata shown in the session list
+const (
+	sessionCreatedKey   = "created"
+	sessionLastSeenKey  = "last_seen"
+	sessionIpKey        = "ip"
+	sessionUserAgentKey = "user_agent"
+)
+
+const (
+	sessionActivityInterval = time.Minute * 5 // limits session store writes, the last use is not updated more often
+	maxUserSecurityEvents   = 100
+	webSessionIdPrefix      = "web-"
+	deviceSessionIdPrefix   = "device-"
+)
+
+// --------------------------------------
+//   Helpers
+
+// webSessionId derives the public id of a web session, the session token itself must never be exposed
+func webSessionId(token string) string {
+	return webSessionIdPrefix + utils.HashAndEncode(token)[:16]
+}
+
+func (h *HandlerService) putSessionMetadata(r *http.Request) {
+	now := time.Now()
+	h.scs.Put(r.Context(), sessionCreatedKey, now)
+	h.scs.Put(r.Context(), sessionLastSeenKey, now)
+	h.scs.Put(r.Context(), sessionIpKey, ratelimit.GetIP(r))
+	h.scs.Put(r.Context(), sessionUserAgentKey, r.UserAgent())
+}
+
+// touchSession updates the last use of an authenticated web session
+func (h *HandlerService) touchSession(r *http.Request) {
+	if time.Since(h.scs.GetTime(r.Context(), sessionLastSeenKey)) < sessionActivityInterval {
+		return
+	}
+	h.scs.Put(r.Context(), sessionLastSeenKey, time.Now())
+	h.scs.Put(r.Context(), sessionIpKey, ratelimit.GetIP(r))
+	h.scs.Put(r.Context(), sessionUserAgentKey, r.UserAgent())
+	// the session expiration slides, so does the expiration of the index
+	if userId, ok := h.scs.Get(r.Context(), userIdKey).(uint64); ok {
+		err := h.daService.AddUserWebSession(r.Context(), userId, h.scs.Token(r.Context()), h.scs.Lifetime)
+		if err != nil {
+			log.Error(err, "error updating web session index", 0, log.Fields{"user_id": userId})
+		}
+	}
+}
+
+// getAuthenticatedSessionUserId returns the user of the session in ctx if it is authenticated
+func (h *HandlerService) getAuthenticatedSessionUserId(ctx context.Context) (uint64, bool) {
+	userId, ok := h.scs.Get(ctx, userIdKey).(uint64)
+	return userId, ok && h.scs.GetBool(ctx, authenticatedKey)
+}
+
+// renewSessionToken must be used instead of scs.RenewToken to keep the web session index of an authenticated session up to date
+func (h *HandlerService) renewSessionToken(ctx context.Context) error {
+	oldToken := h.scs.Token(ctx)
+	err := h.scs.RenewToken(ctx)
+	if err != nil {
+		return err
+	}
+	userId, ok := h.getAuthenticatedSessionUserId(ctx)
+	if !ok {
+		return nil
+	}
+	err = h.daService.RemoveUserWebSessions(ctx, userId, oldToken)
+	if err != nil {
+		return err
+	}
+	return h.daService.AddUserWebSession(ctx, userId, h.scs.Token(ctx), h.scs.Lifetime)
+}
+
+// destroySession must be used instead of scs.Destroy to remove an authenticated session from the web session index
+func (h *HandlerService) destroySession(ctx context.Context) error {
+	if userId, ok := h.getAuthenticatedSessionUserId(ctx); ok {
+		err := h.daService.RemoveUserWebSessions(ctx, userId, h.scs.Token(ctx))
+		if err != nil {
+			return err
+		}
+	}
+	return h.scs.Destroy(ctx)
+}
+
+// updateDeviceActivity updates the last use of a mobile or third-party app session, errors are only logged
+func (h *HandlerService) updateDeviceActivity(r *http.Request, hashedRefreshToken string) {
+	err := h.daService.UpdateUserDeviceActivity(r.Context(), hashedRefreshToken, ratelimit.GetIP(r), r.UserAgent())
+	if err != nil {
+		log.Error(err, "error updating device activity", 0)
+	}
+}
+
+// recordSecurityEvent adds an event to the audit list of the user, errors are only logged to not fail the action itself
+func (h *HandlerService) recordSecurityEvent(r *http.Request, userId uint64, eventType string) {
+	err := h.daService.AddUserSecurityEvent(r.Context(), userId, eventType, ratelimit.GetIP(r), r.UserAgent())
+	if err != nil {
+		log.Error(err, "error recording security event", 0, log.Fields{"user_id": userId, "event_type": eventType})
+	}
+}
+
+// loadWebSession returns the values of the session with the given token, ok is false if the session does not exist anymore
+func (h *HandlerService) loadWebSession(token string) (map[string]interface{}, bool, error) {
+	b, found, err := h.scs.Store.Find(token)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	_, values, err := h.scs.Codec.Decode(b)
+	if err != nil {
+		return nil, false, err
+	}
+	return values, true, nil
+}
+
+func (h *HandlerService) getUserWebSessions(ctx context.Context, userId uint64) ([]types.UserSession, error) {
+	currentToken := h.scs.Token(ctx)
+	tokens, err := h.daService.GetUserWebSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	sessions := []types.UserSession{}
+	var expiredTokens []string
+	for _, token := range tokens {
+		values, ok, err := h.loadWebSession(token)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			expiredTokens = append(expiredTokens, token)
+			continue
+		}
+		if token == currentToken {
+			// the values of the current request might not be saved yet
+			values = map[string]interface{}{
+				userIdKey:           h.scs.Get(ctx, userIdKey),
+				authenticatedKey:    h.scs.GetBool(ctx, authenticatedKey),
+				sessionIpKey:        h.scs.GetString(ctx, sessionIpKey),
+				sessionUserAgentKey: h.scs.GetString(ctx, sessionUserAgentKey),
+				sessionCreatedKey:   h.scs.GetTime(ctx, sessionCreatedKey),
+				sessionLastSeenKey:  h.scs.GetTime(ctx, sessionLastSeenKey),
+			}
+		}
+		sessionUserId, _ := values[userIdKey].(uint64)
+		authenticated, _ := values[authenticatedKey].(bool)
+		if sessionUserId != userId || !authenticated {
+			expiredTokens = append(expiredTokens, token)
+			continue
+		}
+		ip, _ := values[sessionIpKey].(string)
+		userAgent, _ := values[sessionUserAgentKey].(string)
+		session := types.UserSession{
+			Id:        webSessionId(token),
+			Type:      "web",
+			Ip:        ip,
+			UserAgent: userAgent,
+			Current:   token == currentToken,
+		}
+		// sessions created before the metadata was added have no timestamps
+		if created, _ := values[sessionCreatedKey].(time.Time); !created.IsZero() {
+			session.CreatedAt = created.Unix()
+		}
+		if lastSeen, _ := values[sessionLastSeenKey].(time.Time); !lastSeen.IsZero() {
+			session.LastUsedAt = lastSeen.Unix()
+		}
+		sessions = append(sessions, session)
+	}
+	err = h.daService.RemoveUserWebSessions(ctx, userId, expiredTokens...)
+	if err != nil {
+		log.Error(err, "error removing expired web sessions from index", 0, log.Fields{"user_id": userId})
+	}
+	return sessions, nil
+}
+
+// revokeUserWebSession returns false if no web session with the given id exists for the user
+func (h *HandlerService) revokeUserWebSession(r *http.Request, userId uint64, sessionId string) (bool, error) {
+	if webSessionId(h.scs.Token(r.Context())) == sessionId {
+		// destroy via the request context, otherwise the session would be saved again at the end of the request
+		return true, h.destroySession(r.Context())
+	}
+	tokens, err := h.daService.GetUserWebSessions(r.Context(), userId)
+	if err != nil {
+		return false, err
+	}
+	for _, token := range tokens {
+		if webSessionId(token) != sessionId {
+			continue
+		}
+		err = h.scs.Store.Delete(token)
+		if err != nil {
+			return false, err
+		}
+		return true, h.daService.RemoveUserWebSessions(r.Context(), userId, token)
+	}
+	return false, nil
+}
+
+// purgeAllSessionsForUser destroys all web sessions of the user, including the one of the request
+func (h *HandlerService) purgeAllSessionsForUser(ctx context.Context, userId uint64) error {
+	currentToken := h.scs.Token(ctx)
+	tokens, err := h.daService.GetUserWebSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token == currentToken {
+			err = h.scs.Destroy(ctx)
+		} else {
+			err = h.scs.Store.Delete(token)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return h.daService.RemoveUserWebSessions(ctx, userId, tokens...)
+}
+
+// --------------------------------------
+//   Handlers
+
+// InternalGetUserSessions lists the web sessions and the mobile and third-party app sessions of the user
+func (h *HandlerService) InternalGetUserSessions(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	sessions, err := h.getUserWebSessions(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	devices, err := h.daService.GetUserDeviceSessions(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	for _, device := range devices {
+		session := types.UserSession{
+			Id:         deviceSessionIdPrefix + strconv.FormatUint(device.Id, 10),
+			Type:       "device",
+			DeviceName: device.DeviceName,
+			AppName:    device.AppName,
+			CreatedAt:  device.CreatedTs.Unix(),
+			Ip:         device.Ip,
+			UserAgent:  device.UserAgent,
+		}
+		if device.LastUsedTs != nil {
+			session.LastUsedAt = device.LastUsedTs.Unix()
+		}
+		sessions = append(sessions, session)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt > sessions[j].LastUsedAt
+	})
+
+	returnOk(w, r, types.InternalGetUserSessionsResponse{Data: sessions})
+}
+
+func (h *HandlerService) InternalDeleteUserSession(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	sessionId := mux.Vars(r)["session_id"]
+	var deviceId uint64
+	isDevice := strings.HasPrefix(sessionId, deviceSessionIdPrefix)
+	if isDevice {
+		deviceId = v.checkUint(strings.TrimPrefix(sessionId, deviceSessionIdPrefix), "session_id")
+	} else if !strings.HasPrefix(sessionId, webSessionIdPrefix) {
+		v.add("session_id", fmt.Sprintf("given value '%s' is not a valid session id", sessionId))
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	if isDevice {
+		err = h.daService.RevokeUserDeviceSession(r.Context(), user.Id, deviceId, oauthAccessTokenValidity())
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	} else {
+		found, err := h.revokeUserWebSession(r, user.Id, sessionId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if !found {
+			handleErr(w, r, newNotFoundErr("session not found"))
+			return
+		}
+	}
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventSessionRevoked)
+
+	returnNoContent(w, r)
+}
+
+func (h *HandlerService) InternalGetUserSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	events, err := h.daService.GetUserSecurityEvents(r.Context(), user.Id, maxUserSecurityEvents)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnOk(w, r, types.InternalGetUserSecurityEventsResponse{Data: events})
+}
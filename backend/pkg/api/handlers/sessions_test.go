@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webSessionTestIndex keeps the web session index and the revoked devices in memory, the zero value is ready to use
+type webSessionTestIndex struct {
+	dataaccess.DataAccessor
+	indexMutex     sync.Mutex
+	tokens         map[uint64]map[string]bool
+	revokedDevices map[uint64]bool
+}
+
+func (i *webSessionTestIndex) AddUserWebSession(ctx context.Context, userId uint64, token string, lifetime time.Duration) error {
+	i.indexMutex.Lock()
+	defer i.indexMutex.Unlock()
+	if i.tokens == nil {
+		i.tokens = map[uint64]map[string]bool{}
+	}
+	if i.tokens[userId] == nil {
+		i.tokens[userId] = map[string]bool{}
+	}
+	i.tokens[userId][token] = true
+	return nil
+}
+
+func (i *webSessionTestIndex) RemoveUserWebSessions(ctx context.Context, userId uint64, tokens ...string) error {
+	i.indexMutex.Lock()
+	defer i.indexMutex.Unlock()
+	for _, token := range tokens {
+		delete(i.tokens[userId], token)
+	}
+	return nil
+}
+
+func (i *webSessionTestIndex) GetUserWebSessions(ctx context.Context, userId uint64) ([]string, error) {
+	i.indexMutex.Lock()
+	defer i.indexMutex.Unlock()
+	tokens := []string{}
+	for token := range i.tokens[userId] {
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (i *webSessionTestIndex) GetUserDeviceSessions(ctx context.Context, userId uint64) ([]types.UserDeviceSession, error) {
+	return []types.UserDeviceSession{}, nil
+}
+
+func (i *webSessionTestIndex) RevokeUserDeviceSession(ctx context.Context, userId, deviceId uint64, accessTokenValidity time.Duration) error {
+	i.indexMutex.Lock()
+	defer i.indexMutex.Unlock()
+	if i.revokedDevices == nil {
+		i.revokedDevices = map[uint64]bool{}
+	}
+	i.revokedDevices[deviceId] = true
+	return nil
+}
+
+func (i *webSessionTestIndex) IsUserDeviceRevoked(ctx context.Context, deviceId uint64) (bool, error) {
+	i.indexMutex.Lock()
+	defer i.indexMutex.Unlock()
+	return i.revokedDevices[deviceId], nil
+}
+
+type sessionsTestClient struct {
+	t      *testing.T
+	server *httptest.Server
+	client *http.Client
+}
+
+func (c *sessionsTestClient) do(method, path string) *http.Response {
+	req, err := http.NewRequest(method, c.server.URL+path, nil)
+	require.NoError(c.t, err)
+	resp, err := c.client.Do(req)
+	require.NoError(c.t, err)
+	c.t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func (c *sessionsTestClient) login() {
+	twoFactorClient := &twoFactorTestClient{t: c.t, server: c.server, client: c.client}
+	require.Equal(c.t, http.StatusOK, twoFactorClient.login())
+}
+
+func (c *sessionsTestClient) sessions() (int, []types.UserSession) {
+	resp := c.do(http.MethodGet, "/users/me/sessions")
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+	var response types.InternalGetUserSessionsResponse
+	require.NoError(c.t, json.NewDecoder(resp.Body).Decode(&response))
+	return resp.StatusCode, response.Data
+}
+
+func newSessionsTest(t *testing.T) (*twoFactorTestDataAccessor, func() *sessionsTestClient) {
+	da, _ := newTwoFactorTest(t)
+	da.data.Enabled = false
+
+	sessionManager := scs.New()
+	h := &HandlerService{daService: da, scs: sessionManager}
+	router := mux.NewRouter()
+	router.HandleFunc("/login", h.InternalPostLogin).Methods(http.MethodPost)
+	router.HandleFunc("/logout", h.InternalPostLogout).Methods(http.MethodPost)
+	router.HandleFunc("/users/me/sessions", h.InternalGetUserSessions).Methods(http.MethodGet)
+	router.HandleFunc("/users/me/sessions/{session_id}", h.InternalDeleteUserSession).Methods(http.MethodDelete)
+	// stands in for the password and email changes
+	router.HandleFunc("/purge", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.purgeAllSessionsForUser(r.Context(), da.user.Id); err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		returnNoContent(w, r)
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(sessionManager.LoadAndSave(router))
+	t.Cleanup(server.Close)
+
+	newClient := func() *sessionsTestClient {
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		return &sessionsTestClient{t: t, server: server, client: &http.Client{Jar: jar}}
+	}
+	return da, newClient
+}
+
+func TestUserWebSessions(t *testing.T) {
+	da, newClient := newSessionsTest(t)
+	first, second := newClient(), newClient()
+	first.login()
+	second.login()
+
+	status, sessions := first.sessions()
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, sessions, 2)
+	var secondSessionId string
+	for _, session := range sessions {
+		assert.Equal(t, "web", session.Type)
+		if !session.Current {
+			secondSessionId = session.Id
+		}
+	}
+	require.NotEmpty(t, secondSessionId)
+
+	// revoking another session destroys it in the store and removes it from the index
+	assert.Equal(t, http.StatusNoContent, first.do(http.MethodDelete, "/users/me/sessions/"+secondSessionId).StatusCode)
+	status, _ = second.sessions()
+	assert.Equal(t, http.StatusUnauthorized, status)
+	tokens, err := da.GetUserWebSessions(context.Background(), da.user.Id)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, http.StatusNotFound, first.do(http.MethodDelete, "/users/me/sessions/"+secondSessionId).StatusCode)
+
+	// logging out removes the session from the index
+	assert.Equal(t, http.StatusOK, first.do(http.MethodPost, "/logout").StatusCode)
+	tokens, err = da.GetUserWebSessions(context.Background(), da.user.Id)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestPurgeAllSessionsForUser(t *testing.T) {
+	da, newClient := newSessionsTest(t)
+	first, second := newClient(), newClient()
+	first.login()
+	second.login()
+
+	// sessions that expired in the store are removed from the index when listing
+	require.NoError(t, da.AddUserWebSession(context.Background(), da.user.Id, "expired", time.Hour))
+	status, sessions := first.sessions()
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, sessions, 2)
+	tokens, err := da.GetUserWebSessions(context.Background(), da.user.Id)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	assert.Equal(t, http.StatusNoContent, first.do(http.MethodPost, "/purge").StatusCode)
+	status, _ = first.sessions()
+	assert.Equal(t, http.StatusUnauthorized, status)
+	status, _ = second.sessions()
+	assert.Equal(t, http.StatusUnauthorized, status)
+	tokens, err = da.GetUserWebSessions(context.Background(), da.user.Id)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestRevokedDeviceAccessToken(t *testing.T) {
+	setOAuthTestConfig(t)
+	da := &twoFactorTestDataAccessor{}
+	h := &HandlerService{daService: da, scs: scs.New()}
+	token, _, err := createOAuthAccessToken(1, 2, 3, []string{types.ApiKeyScopeNetworkRead})
+	require.NoError(t, err)
+
+	claims, isAccessToken, err := h.getOAuthAccessTokenClaims(context.Background(), token)
+	require.NoError(t, err)
+	require.True(t, isAccessToken)
+	assert.Equal(t, uint64(3), claims.DeviceID)
+
+	// already issued access tokens are rejected once their device session is revoked
+	require.NoError(t, da.RevokeUserDeviceSession(context.Background(), 1, 3, oauthAccessTokenValidity()))
+	_, isAccessToken, err = h.getOAuthAccessTokenClaims(context.Background(), token)
+	assert.True(t, isAccessToken)
+	assert.Error(t, err)
+
+	// api keys are not access tokens
+	_, isAccessToken, err = h.getOAuthAccessTokenClaims(context.Background(), "abcdefghijklmnopqrstuvwxyz")
+	assert.NoError(t, err)
+	assert.False(t, isAccessToken)
+}
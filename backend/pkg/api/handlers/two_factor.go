@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// session keys of the two factor login challenge
+const (
+	twoFactorPendingUserIdKey = "2fa_pending_user_id"
+	twoFactorPendingUntilKey  = "2fa_pending_until"
+	twoFactorVerifiedKey      = "2fa_verified" // time of the last successful verification
+)
+
+const (
+	twoFactorChallengeValidity  = time.Minute * 5
+	twoFactorMaxAttempts        = 5 // invalid codes until the user is locked, counted per user across all sessions and logins
+	twoFactorLockoutDuration    = time.Minute * 15
+	twoFactorVerificationMaxAge = time.Minute * 10 // sensitive actions require a verification within this time
+	recoveryCodeCount           = 10
+)
+
+var (
+	errTwoFactorRequired = newForbiddenErr("two factor verification required, verify with a totp or recovery code first")
+	errTwoFactorLocked   = newTooManyRequestsErr("too many invalid codes, try again later")
+	errTwoFactorInvalid  = newUnauthorizedErr("invalid code")
+)
+
+func init() {
+	// session values are encoded with gob, the two factor challenge and the session metadata store time values
+	gob.Register(time.Time{})
+}
+
+// --------------------------------------
+//   Helpers
+
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b, err := utils.GenerateRandomBytesSecure(7)
+		if err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	return utils.HashAndEncode(strings.ReplaceAll(code, "-", ""))
+}
+
+func hashRecoveryCodes(codes []string) []string {
+	hashed := make([]string, len(codes))
+	for i, code := range codes {
+		hashed[i] = hashRecoveryCode(code)
+	}
+	return hashed
+}
+
+// getUserTwoFactorData returns nil if the user has not enabled two factor authentication
+func (h *HandlerService) getUserTwoFactorData(ctx context.Context, userId uint64) (*types.UserTwoFactorData, error) {
+	data, err := h.daService.GetUserTwoFactorData(ctx, userId)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !data.Enabled {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// checkSecondFactor checks a totp code or consumes a recovery code, the code must have been validated with checkTwoFactorCode
+func (h *HandlerService) checkSecondFactor(ctx context.Context, userId uint64, data *types.UserTwoFactorData, code string) (bool, error) {
+	if !reInteger.MatchString(code) {
+		return h.daService.ConsumeUserRecoveryCode(ctx, userId, hashRecoveryCode(code))
+	}
+	counter, ok := utils.ValidateTotpCode(data.TotpSecret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	// fails if the code has already been used
+	return h.daService.UpdateUserTotpCounter(ctx, userId, counter)
+}
+
+// verifySecondFactor returns errTwoFactorInvalid if the code is invalid and errTwoFactorLocked if the user is locked.
+// Invalid codes are counted in the db, so the limit can't be reset by starting a new login or session.
+func (h *HandlerService) verifySecondFactor(ctx context.Context, userId uint64, data *types.UserTwoFactorData, code string) error {
+	if data.Locked {
+		return errTwoFactorLocked
+	}
+	ok, err := h.checkSecondFactor(ctx, userId, data, code)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return h.daService.ResetUserTwoFactorFailures(ctx, userId)
+	}
+	locked, err := h.daService.RecordUserTwoFactorFailure(ctx, userId, twoFactorMaxAttempts, twoFactorLockoutDuration)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return errTwoFactorLocked
+	}
+	return errTwoFactorInvalid
+}
+
+// checkTwoFactorRecentlyVerified returns an error if the user enabled two factor authentication
+// and the session has not been verified recently
+func (h *HandlerService) checkTwoFactorRecentlyVerified(r *http.Request, userId uint64) error {
+	data, err := h.getUserTwoFactorData(r.Context(), userId)
+	if err != nil || data == nil {
+		return err
+	}
+	verified := h.scs.GetTime(r.Context(), twoFactorVerifiedKey)
+	if time.Since(verified) > twoFactorVerificationMaxAge {
+		return errTwoFactorRequired
+	}
+	return nil
+}
+
+// startTwoFactorChallenge stores the user in the session without authenticating it, the login is completed by InternalPostLoginTwoFactor
+func (h *HandlerService) startTwoFactorChallenge(ctx context.Context, userId uint64) error {
+	err := h.scs.Clear(ctx)
+	if err != nil {
+		return err
+	}
+	err = h.renewSessionToken(ctx)
+	if err != nil {
+		return err
+	}
+	h.scs.Put(ctx, twoFactorPendingUserIdKey, userId)
+	h.scs.Put(ctx, twoFactorPendingUntilKey, time.Now().Add(twoFactorChallengeValidity))
+	return nil
+}
+
+func (h *HandlerService) clearTwoFactorChallenge(ctx context.Context) {
+	h.scs.Remove(ctx, twoFactorPendingUserIdKey)
+	h.scs.Remove(ctx, twoFactorPendingUntilKey)
+}
+
+// --------------------------------------
+//   Handlers
+
+// InternalPostLoginTwoFactor completes a login of a user with two factor authentication enabled
+func (h *HandlerService) InternalPostLoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	code := v.checkTwoFactorCode(req.Code)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	ctx := r.Context()
+	userId, ok := h.scs.Get(ctx, twoFactorPendingUserIdKey).(uint64)
+	if !ok {
+		handleErr(w, r, newUnauthorizedErr("no pending login, login with email and password first"))
+		return
+	}
+	if time.Now().After(h.scs.GetTime(ctx, twoFactorPendingUntilKey)) {
+		h.clearTwoFactorChallenge(ctx)
+		handleErr(w, r, newUnauthorizedErr("login expired, login with email and password again"))
+		return
+	}
+
+	data, err := h.getUserTwoFactorData(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data != nil {
+		err = h.verifySecondFactor(ctx, userId, data, code)
+		if errors.Is(err, errTwoFactorLocked) {
+			h.clearTwoFactorChallenge(ctx)
+		}
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+	// two factor authentication might have been disabled in another session in the meantime, the password has been verified anyway
+
+	user, err := h.daService.GetUserCredentialInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	// change privileges
+	h.clearTwoFactorChallenge(ctx)
+	err = h.renewSessionToken(ctx)
+	if err != nil {
+		handleErr(w, r, errors.New("error creating session"))
+		return
+	}
+	err = h.putAuthenticatedSession(r, user)
+	if err != nil {
+		handleErr(w, r, errors.New("error creating session"))
+		return
+	}
+	h.scs.Put(ctx, twoFactorVerifiedKey, time.Now())
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventLogin)
+
+	returnOk(w, r, types.InternalPostLoginResponse{Data: types.LoginResponse{TwoFactorRequired: false}})
+}
+
+func (h *HandlerService) InternalGetUserTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getUserTwoFactorData(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var status types.UserTwoFactorStatus
+	if data != nil {
+		status.Enabled = true
+		status.RecoveryCodesRemaining, err = h.daService.GetUserRecoveryCodeCount(r.Context(), user.Id)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+	returnOk(w, r, types.InternalGetUserTwoFactorResponse{Data: status})
+}
+
+// InternalPostUserTotp starts a totp enrolment, it has to be confirmed with a code before it is enabled
+func (h *HandlerService) InternalPostUserTotp(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getUserTwoFactorData(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data != nil {
+		handleErr(w, r, newConflictErr("two factor authentication is already enabled"))
+		return
+	}
+	userInfo, err := h.daService.GetUserCredentialInfo(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	secret, err := utils.GenerateTotpSecret()
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.SetUserPendingTotpSecret(r.Context(), user.Id, secret)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.InternalPostUserTotpResponse{
+		Data: types.TotpEnrolment{
+			Secret:          secret,
+			ProvisioningUri: utils.TotpProvisioningUri(secret, utils.Config.Frontend.SiteDomain, userInfo.Email),
+		},
+	}
+	returnCreated(w, r, response)
+}
+
+// InternalPostUserTotpConfirmation enables the pending totp enrolment and returns the recovery codes
+func (h *HandlerService) InternalPostUserTotpConfirmation(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	code := v.checkTwoFactorCode(req.Code)
+	if !reInteger.MatchString(code) {
+		v.add("code", "must be a totp code")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.daService.GetUserTwoFactorData(r.Context(), user.Id)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		handleErr(w, r, newNotFoundErr("no pending totp enrolment"))
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data.Enabled {
+		handleErr(w, r, newConflictErr("two factor authentication is already enabled"))
+		return
+	}
+	counter, ok := utils.ValidateTotpCode(data.TotpSecret, code, time.Now())
+	if !ok {
+		handleErr(w, r, newBadRequestErr("invalid code"))
+		return
+	}
+
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.EnableUserTotp(r.Context(), user.Id, counter, hashRecoveryCodes(recoveryCodes))
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	h.scs.Put(r.Context(), twoFactorVerifiedKey, time.Now())
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventTwoFactorEnabled)
+
+	returnOk(w, r, types.InternalPostUserRecoveryCodesResponse{Data: types.RecoveryCodes{RecoveryCodes: recoveryCodes}})
+}
+
+func (h *HandlerService) InternalDeleteUserTotp(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.checkTwoFactorRecentlyVerified(r, user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.DisableUserTotp(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	h.recordSecurityEvent(r, user.Id, types.SecurityEventTwoFactorDisabled)
+	returnNoContent(w, r)
+}
+
+// InternalPostUserRecoveryCodes replaces all recovery codes of the user
+func (h *HandlerService) InternalPostUserRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getUserTwoFactorData(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data == nil {
+		handleErr(w, r, newConflictErr("two factor authentication is not enabled"))
+		return
+	}
+	err = h.checkTwoFactorRecentlyVerified(r, user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.ReplaceUserRecoveryCodes(r.Context(), user.Id, hashRecoveryCodes(recoveryCodes))
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnCreated(w, r, types.InternalPostUserRecoveryCodesResponse{Data: types.RecoveryCodes{RecoveryCodes: recoveryCodes}})
+}
+
+// InternalPostUserTwoFactorVerification verifies the current session before sensitive actions
+func (h *HandlerService) InternalPostUserTwoFactorVerification(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	code := v.checkTwoFactorCode(req.Code)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getUserTwoFactorData(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if data == nil {
+		handleErr(w, r, newConflictErr("two factor authentication is not enabled"))
+		return
+	}
+	err = h.verifySecondFactor(r.Context(), user.Id, data, code)
+	if errors.Is(err, errTwoFactorLocked) {
+		// limit guessing with a stolen session
+		if destroyErr := h.destroySession(r.Context()); destroyErr != nil {
+			err = destroyErr
+		}
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	h.scs.Put(r.Context(), twoFactorVerifiedKey, time.Now())
+
+	returnNoContent(w, r)
+}
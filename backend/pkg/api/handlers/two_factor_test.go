@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testTwoFactorEmail        = "admin@admin.com"
+	testTwoFactorPassword     = "admin"
+	testTwoFactorRecoveryCode = "abcde-fghij"
+)
+
+// twoFactorTestDataAccessor keeps the two factor data of a single user in memory, it mirrors the db queries of the data access service
+type twoFactorTestDataAccessor struct {
+	webSessionTestIndex
+	mutex          sync.Mutex
+	user           types.UserCredentialInfo
+	data           types.UserTwoFactorData
+	recoveryCodes  map[string]bool // hashed code -> used
+	failedAttempts uint64
+	lockedUntil    time.Time
+}
+
+func (d *twoFactorTestDataAccessor) GetUserByEmail(ctx context.Context, email string) (uint64, error) {
+	if email != d.user.Email {
+		return 0, dataaccess.ErrNotFound
+	}
+	return d.user.Id, nil
+}
+
+func (d *twoFactorTestDataAccessor) GetUserCredentialInfo(ctx context.Context, userId uint64) (*types.UserCredentialInfo, error) {
+	user := d.user
+	return &user, nil
+}
+
+func (d *twoFactorTestDataAccessor) AddUserSecurityEvent(ctx context.Context, userId uint64, eventType, ip, userAgent string) error {
+	return nil
+}
+
+func (d *twoFactorTestDataAccessor) GetUserTwoFactorData(ctx context.Context, userId uint64) (*types.UserTwoFactorData, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	data := d.data
+	data.Locked = time.Now().Before(d.lockedUntil)
+	return &data, nil
+}
+
+func (d *twoFactorTestDataAccessor) UpdateUserTotpCounter(ctx context.Context, userId uint64, counter int64) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.data.LastUsedCounter >= counter {
+		return false, nil
+	}
+	d.data.LastUsedCounter = counter
+	return true, nil
+}
+
+func (d *twoFactorTestDataAccessor) ConsumeUserRecoveryCode(ctx context.Context, userId uint64, hashedCode string) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	used, ok := d.recoveryCodes[hashedCode]
+	if !ok || used {
+		return false, nil
+	}
+	d.recoveryCodes[hashedCode] = true
+	return true, nil
+}
+
+func (d *twoFactorTestDataAccessor) RecordUserTwoFactorFailure(ctx context.Context, userId uint64, maxAttempts uint64, lockout time.Duration) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.failedAttempts++
+	if d.failedAttempts >= maxAttempts {
+		d.failedAttempts = 0
+		d.lockedUntil = time.Now().Add(lockout)
+	}
+	return time.Now().Before(d.lockedUntil), nil
+}
+
+func (d *twoFactorTestDataAccessor) ResetUserTwoFactorFailures(ctx context.Context, userId uint64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.failedAttempts = 0
+	return nil
+}
+
+type twoFactorTestClient struct {
+	t      *testing.T
+	server *httptest.Server
+	client *http.Client
+}
+
+func (c *twoFactorTestClient) post(path, body string) int {
+	resp, err := c.client.Post(c.server.URL+path, "application/json", strings.NewReader(body))
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (c *twoFactorTestClient) login() int {
+	return c.post("/login", fmt.Sprintf(`{"email": %q, "password": %q}`, testTwoFactorEmail, testTwoFactorPassword))
+}
+
+func (c *twoFactorTestClient) loginTwoFactor(code string) int {
+	return c.post("/login/2fa", fmt.Sprintf(`{"code": %q}`, code))
+}
+
+func (c *twoFactorTestClient) verify(code string) int {
+	return c.post("/2fa/verification", fmt.Sprintf(`{"code": %q}`, code))
+}
+
+func newTwoFactorTest(t *testing.T) (*twoFactorTestDataAccessor, func() *twoFactorTestClient) {
+	password, err := bcrypt.GenerateFromPassword([]byte(testTwoFactorPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	secret, err := utils.GenerateTotpSecret()
+	require.NoError(t, err)
+	da := &twoFactorTestDataAccessor{
+		user:          types.UserCredentialInfo{Id: 1, Email: testTwoFactorEmail, EmailConfirmed: true, Password: string(password)},
+		data:          types.UserTwoFactorData{TotpSecret: secret, Enabled: true},
+		recoveryCodes: map[string]bool{hashRecoveryCode(testTwoFactorRecoveryCode): false},
+	}
+
+	sessionManager := scs.New()
+	h := &HandlerService{daService: da, scs: sessionManager}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login", h.InternalPostLogin)
+	mux.HandleFunc("POST /login/2fa", h.InternalPostLoginTwoFactor)
+	mux.HandleFunc("POST /2fa/verification", h.InternalPostUserTwoFactorVerification)
+	server := httptest.NewServer(sessionManager.LoadAndSave(mux))
+	t.Cleanup(server.Close)
+
+	// every client has its own session
+	newClient := func() *twoFactorTestClient {
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		return &twoFactorTestClient{t: t, server: server, client: &http.Client{Jar: jar}}
+	}
+	return da, newClient
+}
+
+func currentTotpCode(t *testing.T, da *twoFactorTestDataAccessor) string {
+	code, err := utils.GenerateTotpCode(da.data.TotpSecret, time.Now())
+	require.NoError(t, err)
+	return code
+}
+
+// returns a valid code that is not the current one
+func wrongTotpCode(t *testing.T, da *twoFactorTestDataAccessor) string {
+	code, err := utils.GenerateTotpCode(da.data.TotpSecret, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorLoginChallenge(t *testing.T) {
+	da, newClient := newTwoFactorTest(t)
+	client := newClient()
+
+	// the challenge can't be answered without a password login
+	assert.Equal(t, http.StatusUnauthorized, client.loginTwoFactor(currentTotpCode(t, da)))
+
+	require.Equal(t, http.StatusOK, client.login())
+	// the session is not authenticated before the second factor is verified
+	assert.Equal(t, http.StatusUnauthorized, client.verify(currentTotpCode(t, da)))
+	assert.Equal(t, http.StatusOK, client.loginTwoFactor(currentTotpCode(t, da)))
+	// the challenge is completed
+	assert.Equal(t, http.StatusUnauthorized, client.loginTwoFactor(currentTotpCode(t, da)))
+}
+
+func TestTwoFactorLoginReplay(t *testing.T) {
+	da, newClient := newTwoFactorTest(t)
+	code := currentTotpCode(t, da)
+
+	client := newClient()
+	require.Equal(t, http.StatusOK, client.login())
+	require.Equal(t, http.StatusOK, client.loginTwoFactor(code))
+
+	// a code can only be used once, also in another session
+	attacker := newClient()
+	require.Equal(t, http.StatusOK, attacker.login())
+	assert.Equal(t, http.StatusUnauthorized, attacker.loginTwoFactor(code))
+	assert.Equal(t, http.StatusUnauthorized, client.verify(code))
+}
+
+func TestTwoFactorLoginRecoveryCode(t *testing.T) {
+	_, newClient := newTwoFactorTest(t)
+
+	client := newClient()
+	require.Equal(t, http.StatusOK, client.login())
+	// recovery codes are accepted in upper case and without dash
+	assert.Equal(t, http.StatusOK, client.loginTwoFactor(strings.ToUpper(strings.ReplaceAll(testTwoFactorRecoveryCode, "-", ""))))
+
+	client = newClient()
+	require.Equal(t, http.StatusOK, client.login())
+	assert.Equal(t, http.StatusUnauthorized, client.loginTwoFactor(testTwoFactorRecoveryCode))
+}
+
+func TestTwoFactorLoginLockout(t *testing.T) {
+	da, newClient := newTwoFactorTest(t)
+	client := newClient()
+
+	// a successful verification resets the invalid codes
+	require.Equal(t, http.StatusOK, client.login())
+	for i := 0; i < twoFactorMaxAttempts-1; i++ {
+		require.Equal(t, http.StatusUnauthorized, client.loginTwoFactor(wrongTotpCode(t, da)))
+	}
+	require.Equal(t, http.StatusOK, client.loginTwoFactor(currentTotpCode(t, da)))
+	assert.Zero(t, da.failedAttempts)
+
+	// starting a new login does not reset the invalid codes
+	for i := 0; i < twoFactorMaxAttempts-1; i++ {
+		require.Equal(t, http.StatusOK, client.login())
+		require.Equal(t, http.StatusUnauthorized, client.loginTwoFactor(wrongTotpCode(t, da)))
+	}
+	require.Equal(t, http.StatusOK, client.login())
+	assert.Equal(t, http.StatusTooManyRequests, client.loginTwoFactor(wrongTotpCode(t, da)))
+
+	// valid codes are rejected while the user is locked, also in other sessions
+	require.Equal(t, http.StatusOK, client.login())
+	assert.Equal(t, http.StatusTooManyRequests, client.loginTwoFactor(currentTotpCode(t, da)))
+	other := newClient()
+	require.Equal(t, http.StatusOK, other.login())
+	assert.Equal(t, http.StatusTooManyRequests, other.loginTwoFactor(testTwoFactorRecoveryCode))
+
+	// the lockout expires
+	da.mutex.Lock()
+	da.lockedUntil = time.Now()
+	da.mutex.Unlock()
+	require.Equal(t, http.StatusOK, other.login())
+	assert.Equal(t, http.StatusOK, other.loginTwoFactor(testTwoFactorRecoveryCode))
+}
+
+func TestTwoFactorVerificationLockout(t *testing.T) {
+	da, newClient := newTwoFactorTest(t)
+	client := newClient()
+	require.Equal(t, http.StatusOK, client.login())
+	require.Equal(t, http.StatusOK, client.loginTwoFactor(currentTotpCode(t, da)))
+
+	for i := 0; i < twoFactorMaxAttempts-1; i++ {
+		require.Equal(t, http.StatusUnauthorized, client.verify(wrongTotpCode(t, da)))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, client.verify(wrongTotpCode(t, da)))
+	// the session has been destroyed
+	assert.Equal(t, http.StatusUnauthorized, client.verify(testTwoFactorRecoveryCode))
+}
@@ -101,6 +101,11 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodGet, "/ratelimit-weights", nil, hs.InternalGetRatelimitWeights},
 
 		{http.MethodPost, "/login", nil, hs.InternalPostLogin},
+		{http.MethodPost, "/login/2fa", nil, hs.InternalPostLoginTwoFactor},
+
+		{http.MethodGet, "/oauth/authorize", nil, hs.InternalGetOauthAuthorize},
+		{http.MethodPost, "/oauth/authorize", nil, hs.InternalPostOauthAuthorize},
+		{http.MethodPost, "/oauth/token", hs.PublicPostOauthToken, hs.InternalPostOauthToken},
 
 		{http.MethodGet, "/mobile/authorize", nil, hs.InternalPostMobileAuthorize},
 		{http.MethodPost, "/mobile/equivalent-exchange", nil, hs.InternalPostMobileEquivalentExchange},
@@ -127,10 +132,34 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodDelete, "/users/me", nil, hs.InternalDeleteUser},
 		{http.MethodPost, "/users/me/email", nil, hs.InternalPostUserEmail},
 		{http.MethodPut, "/users/me/password", nil, hs.InternalPutUserPassword},
-		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards},
+		{http.MethodGet, "/users/me/2fa", nil, hs.InternalGetUserTwoFactor},
+		{http.MethodPost, "/users/me/2fa/totp", nil, hs.InternalPostUserTotp},
+		{http.MethodPost, "/users/me/2fa/totp/confirmation", nil, hs.InternalPostUserTotpConfirmation},
+		{http.MethodDelete, "/users/me/2fa/totp", nil, hs.InternalDeleteUserTotp},
+		{http.MethodPost, "/users/me/2fa/recovery-codes", nil, hs.InternalPostUserRecoveryCodes},
+		{http.MethodPost, "/users/me/2fa/verification", nil, hs.InternalPostUserTwoFactorVerification},
+		{http.MethodGet, "/users/me/sessions", nil, hs.InternalGetUserSessions},
+		{http.MethodDelete, "/users/me/sessions/{session_id}", nil, hs.InternalDeleteUserSession},
+		{http.MethodGet, "/users/me/security-events", nil, hs.InternalGetUserSecurityEvents},
+		{http.MethodGet, "/users/me/api-keys", nil, hs.InternalGetUserApiKeys},
+		{http.MethodPost, "/users/me/api-keys", nil, hs.InternalPostApiKeys},
+		{http.MethodPut, "/users/me/api-keys/{api_key_id}", nil, hs.InternalPutUserApiKey},
+		{http.MethodPost, "/users/me/api-keys/{api_key_id}/rotation", nil, hs.InternalPostUserApiKeyRotation},
+		{http.MethodDelete, "/users/me/api-keys/{api_key_id}", nil, hs.InternalDeleteUserApiKey},
+		{http.MethodGet, "/users/me/oauth-apps", nil, hs.InternalGetUserOAuthApps},
+		{http.MethodPost, "/users/me/oauth-apps", nil, hs.InternalPostUserOAuthApps},
+		{http.MethodDelete, "/users/me/oauth-apps/{client_id}", nil, hs.InternalDeleteUserOAuthApp},
+		{http.MethodGet, "/users/me/organizations", nil, hs.InternalGetUserOrganizations},
 		{http.MethodPut, "/users/me/notifications/settings/paired-devices/{client_id}/token", nil, hs.InternalPostUsersMeNotificationSettingsPairedDevicesToken},
 
-		{http.MethodGet, "/users/me/machine-metrics", hs.PublicGetUserMachineMetrics, hs.InternalGetUserMachineMetrics},
+		{http.MethodPost, "/organizations", nil, hs.InternalPostOrganizations},
+		{http.MethodGet, "/organizations/{organization_id}", nil, hs.InternalGetOrganization},
+		{http.MethodPut, "/organizations/{organization_id}", nil, hs.InternalPutOrganization},
+		{http.MethodDelete, "/organizations/{organization_id}", nil, hs.InternalDeleteOrganization},
+		{http.MethodGet, "/organizations/{organization_id}/members", nil, hs.InternalGetOrganizationMembers},
+		{http.MethodPost, "/organizations/{organization_id}/members", nil, hs.InternalPostOrganizationMembers},
+		{http.MethodPut, "/organizations/{organization_id}/members/{user_id}", nil, hs.InternalPutOrganizationMember},
+		{http.MethodDelete, "/organizations/{organization_id}/members/{user_id}", nil, hs.InternalDeleteOrganizationMember},
 
 		{http.MethodPost, "/search", nil, hs.InternalPostSearch},
 
@@ -148,7 +177,40 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodDelete, "/account-dashboards/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteAccountDashboardPublicId, hs.InternalDeleteAccountDashboardPublicId},
 		{http.MethodGet, "/account-dashboards/{dashboard_id}/transactions", hs.PublicGetAccountDashboardTransactions, hs.InternalGetAccountDashboardTransactions},
 		{http.MethodPut, "/account-dashboards/{dashboard_id}/transactions/settings", hs.PublicPutAccountDashboardTransactionsSettings, hs.InternalPutAccountDashboardTransactionsSettings},
+	}
+	addEndpointsToRouters(endpoints, publicRouter, internalRouter)
 
+	// account data endpoints, api keys need the account read scope to access them
+	publicAccountRouter := publicRouter.NewRoute().Subrouter()
+	if !cfg.Frontend.Debug {
+		publicAccountRouter.Use(hs.ReadAccountDataViaApiCheckMiddleware)
+	}
+	accountEndpoints := []endpoint{
+		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards},
+		{http.MethodGet, "/users/me/api-usage", hs.PublicGetUserApiUsage, hs.InternalGetUserApiUsage},
+
+		{http.MethodGet, "/users/me/machine-metrics", hs.PublicGetUserMachineMetrics, hs.InternalGetUserMachineMetrics},
+		{http.MethodGet, "/users/me/machine-metrics/history", hs.PublicGetUserMachineMetricsHistory, hs.InternalGetUserMachineMetricsHistory},
+		{http.MethodGet, "/users/me/machine-metrics/clients", hs.PublicGetUserMachineClients, hs.InternalGetUserMachineClients},
+	}
+	addEndpointsToRouters(accountEndpoints, publicAccountRouter, internalRouter)
+
+	// machine metrics push endpoints, api keys need the machine metrics write scope to access them
+	publicMachineMetricsRouter := publicRouter.NewRoute().Subrouter()
+	if !cfg.Frontend.Debug {
+		publicMachineMetricsRouter.Use(hs.WriteMachineMetricsViaApiCheckMiddleware)
+	}
+	machineMetricsEndpoints := []endpoint{
+		{http.MethodPost, "/users/me/machine-metrics/prometheus", hs.PublicPostUserMachineMetricsPrometheus, nil},
+	}
+	addEndpointsToRouters(machineMetricsEndpoints, publicMachineMetricsRouter, internalRouter)
+
+	// network data endpoints, api keys need the network read scope to access them
+	publicNetworkRouter := publicRouter.NewRoute().Subrouter()
+	if !cfg.Frontend.Debug {
+		publicNetworkRouter.Use(hs.ReadNetworkDataViaApiCheckMiddleware)
+	}
+	networkEndpoints := []endpoint{
 		{http.MethodGet, "/networks/{network}/validators", hs.PublicGetNetworkValidators, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}", hs.PublicGetNetworkValidator, nil},
 		{http.MethodGet, "/networks/{network}/validators/{validator}/duties", hs.PublicGetNetworkValidatorDuties, nil},
@@ -247,7 +309,7 @@ func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Ro
 		{http.MethodGet, "/multisig-safes/{address}/transactions", hs.PublicGetMultisigSafeTransactions, nil},
 		{http.MethodGet, "/multisig-transactions/{hash}/confirmations", hs.PublicGetMultisigTransactionConfirmations, nil},
 	}
-	addEndpointsToRouters(endpoints, publicRouter, internalRouter)
+	addEndpointsToRouters(networkEndpoints, publicNetworkRouter, internalRouter)
 }
 
 // Legacy routes are available behind the /v1 prefix and guarantee backwards compatibility with the old API
@@ -257,7 +319,12 @@ func addLegacyRoutes(hs *handlers.HandlerService, publicRouter *mux.Router) {
 
 func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
 	vdbPath := "/validator-dashboards"
-	publicRouter.HandleFunc(vdbPath, hs.PublicPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
+	// creating a dashboard requires the same scope as managing it
+	var publicPostValidatorDashboards http.Handler = http.HandlerFunc(hs.PublicPostValidatorDashboards)
+	if !cfg.Frontend.Debug {
+		publicPostValidatorDashboards = hs.ManageDashboardsViaApiCheckMiddleware(publicPostValidatorDashboards)
+	}
+	publicRouter.Handle(vdbPath, publicPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
 	internalRouter.HandleFunc(vdbPath, hs.InternalPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
 
 	publicDashboardRouter := publicRouter.PathPrefix(vdbPath).Subrouter()
@@ -272,6 +339,7 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 	archivalEndpoints := []endpoint{
 		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteValidatorDashboard, hs.InternalDeleteValidatorDashboard},
 		{http.MethodPut, "/{dashboard_id}/archiving", hs.PublicPutValidatorDashboardArchiving, hs.InternalPutValidatorDashboardArchiving},
+		{http.MethodPut, "/{dashboard_id}/organization", nil, hs.InternalPutValidatorDashboardOrganization},
 	}
 
 	addEndpointsToRouters(archivalEndpoints, publicDashboardRouter, internalDashboardRouter)
@@ -315,6 +383,8 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 		{http.MethodGet, "/{dashboard_id}/total-consensus-layer-deposits", hs.PublicGetValidatorDashboardTotalConsensusLayerDeposits, hs.InternalGetValidatorDashboardTotalConsensusLayerDeposits},
 		{http.MethodGet, "/{dashboard_id}/withdrawals", hs.PublicGetValidatorDashboardWithdrawals, hs.InternalGetValidatorDashboardWithdrawals},
 		{http.MethodGet, "/{dashboard_id}/total-withdrawals", hs.PublicGetValidatorDashboardTotalWithdrawals, hs.InternalGetValidatorDashboardTotalWithdrawals},
+		{http.MethodGet, "/{dashboard_id}/consolidations", hs.PublicGetValidatorDashboardConsolidations, hs.InternalGetValidatorDashboardConsolidations},
+		{http.MethodGet, "/{dashboard_id}/withdrawal-requests", hs.PublicGetValidatorDashboardWithdrawalRequests, hs.InternalGetValidatorDashboardWithdrawalRequests},
 		{http.MethodGet, "/{dashboard_id}/rocket-pool", hs.PublicGetValidatorDashboardRocketPool, hs.InternalGetValidatorDashboardRocketPool},
 		{http.MethodGet, "/{dashboard_id}/total-rocket-pool", hs.PublicGetValidatorDashboardTotalRocketPool, hs.InternalGetValidatorDashboardTotalRocketPool},
 		{http.MethodGet, "/{dashboard_id}/rocket-pool/{node_address}/minipools", hs.PublicGetValidatorDashboardRocketPoolMinipools, hs.InternalGetValidatorDashboardRocketPoolMinipools},
@@ -338,6 +408,8 @@ func addNotificationRoutes(hs *handlers.HandlerService, publicRouter, internalRo
 		{http.MethodGet, "/machines", hs.PublicGetUserNotificationMachines, hs.InternalGetUserNotificationMachines},
 		{http.MethodGet, "/clients", hs.PublicGetUserNotificationClients, hs.InternalGetUserNotificationClients},
 		{http.MethodGet, "/networks", hs.PublicGetUserNotificationNetworks, hs.InternalGetUserNotificationNetworks},
+		{http.MethodGet, "/incidents", hs.PublicGetUserNotificationIncidents, hs.InternalGetUserNotificationIncidents},
+		{http.MethodPost, "/incidents/{incident_id}/acknowledge", hs.PublicPostUserNotificationIncidentAcknowledge, hs.InternalPostUserNotificationIncidentAcknowledge},
 		{http.MethodGet, "/settings", hs.PublicGetUserNotificationSettings, hs.InternalGetUserNotificationSettings},
 		{http.MethodPut, "/settings/general", hs.PublicPutUserNotificationSettingsGeneral, hs.InternalPutUserNotificationSettingsGeneral},
 		{http.MethodPut, "/settings/networks/{network}", hs.PublicPutUserNotificationSettingsNetworks, hs.InternalPutUserNotificationSettingsNetworks},
@@ -363,6 +435,13 @@ func addNotificationRoutes(hs *handlers.HandlerService, publicRouter, internalRo
 		{http.MethodGet, "/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsAccountDashboard, hs.InternalGetUserNotificationsAccountDashboard},
 		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsValidatorDashboard, hs.InternalPutUserNotificationSettingsValidatorDashboard},
 		{http.MethodPut, "/settings/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsAccountDashboard, hs.InternalPutUserNotificationSettingsAccountDashboard},
+		{http.MethodGet, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules", hs.PublicGetUserNotificationSettingsValidatorDashboardRules, hs.InternalGetUserNotificationSettingsValidatorDashboardRules},
+		{http.MethodPost, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules", hs.PublicPostUserNotificationSettingsValidatorDashboardRules, hs.InternalPostUserNotificationSettingsValidatorDashboardRules},
+		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules/{rule_id}", hs.PublicPutUserNotificationSettingsValidatorDashboardRule, hs.InternalPutUserNotificationSettingsValidatorDashboardRule},
+		{http.MethodDelete, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/rules/{rule_id}", hs.PublicDeleteUserNotificationSettingsValidatorDashboardRule, hs.InternalDeleteUserNotificationSettingsValidatorDashboardRule},
+		{http.MethodGet, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes", hs.PublicGetUserNotificationSettingsValidatorDashboardSnoozes, hs.InternalGetUserNotificationSettingsValidatorDashboardSnoozes},
+		{http.MethodPost, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes", hs.PublicPostUserNotificationSettingsValidatorDashboardSnoozes, hs.InternalPostUserNotificationSettingsValidatorDashboardSnoozes},
+		{http.MethodDelete, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/snoozes/{snooze_id}", hs.PublicDeleteUserNotificationSettingsValidatorDashboardSnooze, hs.InternalDeleteUserNotificationSettingsValidatorDashboardSnooze},
 	}
 	addEndpointsToRouters(dashboardSettingsEndpoints, publicDashboardNotificationSettingsRouter, internalDashboardNotificationSettingsRouter)
 }
@@ -20,17 +20,19 @@ type Services struct {
 	alloyReader             *sqlx.DB
 	alloyWriter             *sqlx.DB
 	clickhouseReader        *sqlx.DB
+	dashboardStorage        db.DashboardAggregateStorage
 	bigtable                *db.Bigtable
 	persistentRedisDbClient *redis.Client
 }
 
-func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
+func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, dashboardStorage db.DashboardAggregateStorage, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
 	return &Services{
 		readerDb:                readerDb,
 		writerDb:                writerDb,
 		alloyReader:             alloyReader,
 		alloyWriter:             alloyWriter,
 		clickhouseReader:        clickhouseReader,
+		dashboardStorage:        dashboardStorage,
 		bigtable:                bigtable,
 		persistentRedisDbClient: persistentRedisDbClient,
 	}
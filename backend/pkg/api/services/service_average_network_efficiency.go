@@ -60,7 +60,7 @@ func (s *Services) updateEfficiencyData() error {
 		}
 
 		ds := goqu.Dialect("postgres").
-			From(goqu.L(fmt.Sprintf(`%s AS r FINAL`, tableName))).
+			From(goqu.L(s.dashboardStorage.RollingTable(tableName, "r"))).
 			Select(
 				goqu.L("COALESCE(SUM(r.attestations_reward)::decimal, 0) AS attestations_reward"),
 				goqu.L("COALESCE(SUM(r.attestations_ideal_reward)::decimal, 0) AS attestations_ideal_reward"),
@@ -69,12 +69,17 @@ func (s *Services) updateEfficiencyData() error {
 				goqu.L("COALESCE(SUM(r.sync_executed), 0) AS sync_executed"),
 				goqu.L("COALESCE(SUM(r.sync_scheduled), 0) AS sync_scheduled"))
 
+		// in tracked validators mode only the network sample represents the network
+		if utils.Config.TrackedValidators.Enabled {
+			ds = ds.Where(goqu.L("r.validator_index % ? = 0", utils.Config.TrackedValidators.NetworkSampleInterval))
+		}
+
 		query, args, err := ds.Prepared(true).ToSQL()
 		if err != nil {
 			return fmt.Errorf("error preparing query: %v", err)
 		}
 
-		err = s.clickhouseReader.Get(&queryResult, query, args...)
+		err = s.dashboardStorage.Reader().Get(&queryResult, query, args...)
 		if err != nil {
 			return err
 		}
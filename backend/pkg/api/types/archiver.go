@@ -2,6 +2,12 @@ package types
 
 import "github.com/gobitfly/beaconchain/pkg/api/enums"
 
+// ArchiverDashboardOwner is either a user or an organization, limits are checked per owner
+type ArchiverDashboardOwner struct {
+	UserId         uint64
+	OrganizationId uint64
+}
+
 type ArchiverDashboard struct {
 	DashboardId    uint64
 	IsArchived     bool
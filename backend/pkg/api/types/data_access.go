@@ -45,8 +45,9 @@ type VDBId struct {
 type VDBValidator = types.ValidatorIndex
 
 type DashboardUser struct {
-	Id     VDBIdPrimary `db:"id"` // this must be the bigint id
-	UserId uint64       `db:"user_id"`
+	Id             VDBIdPrimary `db:"id"` // this must be the bigint id
+	UserId         uint64       `db:"user_id"`
+	OrganizationId *uint64      `db:"organization_id"` // if set, the dashboard is owned by the organization and UserId is only the creator
 }
 
 type CursorLike interface {
@@ -79,6 +80,12 @@ type CLDepositsCursor struct {
 	SlotIndex int64
 }
 
+type ExecutionRequestsCursor struct {
+	GenericCursor
+	Slot      int64
+	SlotIndex int64
+}
+
 type ELDepositsCursor struct {
 	GenericCursor
 	BlockNumber int64
@@ -148,6 +155,15 @@ type NotificationNetworksCursor struct {
 	EventType t.EventName
 }
 
+type NotificationIncidentsCursor struct {
+	GenericCursor
+
+	Id            uint64
+	OpenedEpoch   uint64
+	DashboardName string
+	EventType     string
+}
+
 type UserCredentialInfo struct {
 	Id             uint64 `db:"id"`
 	Email          string `db:"email"`
@@ -157,6 +173,25 @@ type UserCredentialInfo struct {
 	UserGroup      string `db:"user_group"`
 }
 
+// UserTwoFactorData is the totp enrolment of a user, the secret is pending until the enrolment is confirmed
+type UserTwoFactorData struct {
+	TotpSecret      string `db:"totp_secret"`
+	Enabled         bool   `db:"enabled"`
+	LastUsedCounter int64  `db:"last_used_counter"`
+	Locked          bool   `db:"locked"` // too many invalid codes, no codes are accepted until the lockout expired
+}
+
+// UserDeviceSession is an active refresh token of the mobile app or a third-party app
+type UserDeviceSession struct {
+	Id         uint64     `db:"id"`
+	DeviceName string     `db:"device_name"`
+	AppName    string     `db:"app_name"`
+	CreatedTs  time.Time  `db:"created_ts"`
+	LastUsedTs *time.Time `db:"last_used_ts"`
+	Ip         string     `db:"ip_address"`
+	UserAgent  string     `db:"user_agent"`
+}
+
 type BlocksCursor struct {
 	GenericCursor
 
@@ -311,6 +346,12 @@ type NotificationSettingsDefaultValues struct {
 	MachineCpuUsageThreshold     float64
 	MachineMemoryUsageThreshold  float64
 
+	MachineNotSyncedThreshold      float64
+	MachineElDisconnectedThreshold float64
+	MachineLowPeerCountThreshold   float64
+	MachineHeadSlotLagThreshold    float64
+	MachineFallbackInUseThreshold  float64
+
 	GasAboveThreshold                 decimal.Decimal
 	GasBelowThreshold                 decimal.Decimal
 	NetworkParticipationRateThreshold float64
@@ -6,6 +6,110 @@ type MachineMetricsData struct {
 	SystemMetrics    []*types.MachineMetricSystem    `json:"system_metrics" faker:"slice_len=30"`
 	ValidatorMetrics []*types.MachineMetricValidator `json:"validator_metrics" faker:"slice_len=30"`
 	NodeMetrics      []*types.MachineMetricNode      `json:"node_metrics" faker:"slice_len=30"`
+	ExecutionMetrics []*types.MachineMetricExecution `json:"execution_metrics" faker:"slice_len=30"`
+	SlasherMetrics   []*types.MachineMetricSlasher   `json:"slasher_metrics" faker:"slice_len=30"`
 }
 
 type GetUserMachineMetricsRespone ApiDataResponse[MachineMetricsData]
+
+// counters are converted to per second rates (or fractions for cpu time) between consecutive points of a machine
+type MachineMetricsHistoryData struct {
+	Resolution       uint64                         `json:"resolution"` // seconds between points
+	SystemMetrics    []MachineMetricsSystemPoint    `json:"system_metrics" faker:"slice_len=30"`
+	ValidatorMetrics []MachineMetricsValidatorPoint `json:"validator_metrics" faker:"slice_len=30"`
+	NodeMetrics      []MachineMetricsNodePoint      `json:"node_metrics" faker:"slice_len=30"`
+	ExecutionMetrics []MachineMetricsExecutionPoint `json:"execution_metrics" faker:"slice_len=30"`
+	SlasherMetrics   []MachineMetricsSlasherPoint   `json:"slasher_metrics" faker:"slice_len=30"`
+}
+
+type MachineMetricsSystemPoint struct {
+	Machine   string `json:"machine"`
+	Timestamp int64  `json:"timestamp"`
+
+	CpuCores   uint64  `json:"cpu_cores"`
+	CpuThreads uint64  `json:"cpu_threads"`
+	CpuUsage   float64 `json:"cpu_usage"` // fraction of total cpu time not spent idle
+	CpuIowait  float64 `json:"cpu_iowait"`
+
+	MemoryTotalBytes uint64 `json:"memory_total_bytes"`
+	MemoryUsedBytes  uint64 `json:"memory_used_bytes"` // excluding cache and buffers
+
+	DiskTotalBytes      uint64  `json:"disk_total_bytes"`
+	DiskFreeBytes       uint64  `json:"disk_free_bytes"`
+	DiskReadsPerSecond  float64 `json:"disk_reads_per_second"`
+	DiskWritesPerSecond float64 `json:"disk_writes_per_second"`
+	DiskIoUtilization   float64 `json:"disk_io_utilization"` // fraction of time spent doing io
+
+	NetworkReceiveBytesPerSecond  float64 `json:"network_receive_bytes_per_second"`
+	NetworkTransmitBytesPerSecond float64 `json:"network_transmit_bytes_per_second"`
+
+	MiscOs string `json:"misc_os"`
+}
+
+type MachineMetricsProcessPoint struct {
+	Machine   string `json:"machine"`
+	Timestamp int64  `json:"timestamp"`
+
+	ClientName    string  `json:"client_name"`
+	ClientVersion string  `json:"client_version"`
+	CpuUsage      float64 `json:"cpu_usage"` // cpu seconds per second, can exceed 1 on multiple cores
+	MemoryBytes   uint64  `json:"memory_bytes"`
+}
+
+type MachineMetricsValidatorPoint struct {
+	MachineMetricsProcessPoint `tstype:",extends"`
+
+	ValidatorTotal  uint64 `json:"validator_total"`
+	ValidatorActive uint64 `json:"validator_active"`
+}
+
+type MachineMetricsNodePoint struct {
+	MachineMetricsProcessPoint `tstype:",extends"`
+
+	Synced                        bool    `json:"synced"`
+	HeadSlot                      uint64  `json:"head_slot"`
+	PeersConnected                uint64  `json:"peers_connected"`
+	DiskBytes                     uint64  `json:"disk_bytes"`
+	NetworkReceiveBytesPerSecond  float64 `json:"network_receive_bytes_per_second"`
+	NetworkTransmitBytesPerSecond float64 `json:"network_transmit_bytes_per_second"`
+}
+
+type MachineMetricsExecutionPoint struct {
+	MachineMetricsProcessPoint `tstype:",extends"`
+
+	Synced             bool   `json:"synced"`
+	HeadBlock          uint64 `json:"head_block"`
+	PeersConnected     uint64 `json:"peers_connected"`
+	TxPoolTransactions uint64 `json:"txpool_transactions"`
+	DiskBytes          uint64 `json:"disk_bytes"`
+}
+
+type MachineMetricsSlasherPoint struct {
+	MachineMetricsProcessPoint `tstype:",extends"`
+
+	Synced                      bool    `json:"synced"`
+	HeadEpoch                   uint64  `json:"head_epoch"`
+	AttestationsPerSecond       float64 `json:"attestations_per_second"`
+	BlocksPerSecond             float64 `json:"blocks_per_second"`
+	AttesterSlashingsFoundTotal uint64  `json:"attester_slashings_found_total"`
+	ProposerSlashingsFoundTotal uint64  `json:"proposer_slashings_found_total"`
+	DiskBytes                   uint64  `json:"disk_bytes"`
+}
+
+type GetUserMachineMetricsHistoryResponse ApiDataResponse[MachineMetricsHistoryData]
+
+type MachineClientVersion struct {
+	Machine            string  `json:"machine"`
+	Process            string  `json:"process" tstype:"'beaconnode' | 'validator' | 'execution' | 'slasher'" faker:"oneof: beaconnode, validator, execution, slasher"`
+	ClientId           *uint64 `json:"client_id,omitempty"` // nil if the client is unknown
+	ClientName         string  `json:"client_name"`
+	Category           string  `json:"category,omitempty"` // Execution Clients, Consensus Clients, Other
+	RunningVersion     string  `json:"running_version"`
+	LatestVersion      string  `json:"latest_version,omitempty"`   // empty if no release is known for the client
+	RequiredVersion    string  `json:"required_version,omitempty"` // minimum version for the current or upcoming hard fork, empty if unknown
+	IsOutdated         bool    `json:"is_outdated"`
+	IsForkIncompatible bool    `json:"is_fork_incompatible"`
+	LastSeen           int64   `json:"last_seen"`
+}
+
+type GetUserMachineClientsResponse ApiDataResponse[[]MachineClientVersion]
@@ -41,7 +41,7 @@ type NotificationDashboardsTableRow struct {
 	GroupId            uint64         `db:"group_id" json:"group_id"`
 	GroupName          string         `db:"group_name" json:"group_name"`
 	EntityCount        uint64         `db:"entity_count" json:"entity_count"`
-	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, group_efficiency_below, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, validator_got_slashed, validator_has_slashed, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
+	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'validator_unstable' | 'group_efficiency_below' | 'custom_rule' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'partial_withdrawal' | 'consolidation' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, validator_unstable, group_efficiency_below, custom_rule, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, partial_withdrawal, consolidation, validator_got_slashed, validator_has_slashed, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
 }
 
 type InternalGetUserNotificationDashboardsResponse ApiPagingResponse[NotificationDashboardsTableRow]
@@ -54,28 +54,51 @@ type NotificationEventValidatorBackOnline struct {
 	EpochCount uint64 `json:"epoch_count"`
 }
 
+type NotificationEventValidatorUnstable struct {
+	Index       uint64 `json:"index"`
+	Transitions uint64 `json:"transitions"`
+}
+
+type NotificationEventValidatorDashboardRule struct {
+	RuleId     uint64  `json:"rule_id"`
+	Name       string  `json:"name"`
+	Metric     string  `json:"metric" tstype:"'efficiency' | 'missed_attestations' | 'balance_delta' | 'proposal_luck' | 'sync_participation'" faker:"oneof: efficiency, missed_attestations, balance_delta, proposal_luck, sync_participation"`
+	Comparison string  `json:"comparison" tstype:"'lt' | 'lte' | 'gt' | 'gte'" faker:"oneof: lt, lte, gt, gte"`
+	Threshold  float64 `json:"threshold"`
+	Value      float64 `json:"value"`
+}
+
 type NotificationEventWithdrawal struct {
 	Index   uint64          `json:"index"`
 	Amount  decimal.Decimal `json:"amount"`
 	Address Address         `json:"address"`
 }
 
+type NotificationEventConsolidation struct {
+	SourceIndex uint64 `json:"source_index"`
+	TargetIndex uint64 `json:"target_index"`
+}
+
 type NotificationValidatorDashboardDetail struct {
-	DashboardName            string                                 `db:"dashboard_name" json:"dashboard_name"`
-	GroupName                string                                 `db:"group_name" json:"group_name"`
-	ValidatorOffline         []uint64                               `json:"validator_offline"`          // validator indices
-	ValidatorOfflineReminder []uint64                               `json:"validator_offline_reminder"` // validator indices; TODO not filled yet
-	ValidatorOnline          []NotificationEventValidatorBackOnline `json:"validator_online"`
-	GroupEfficiencyBelow     float64                                `json:"group_efficiency_below,omitempty"` // fill with the `group_efficiency_below` threshold if event is present
-	ProposalMissed           []IndexSlots                           `json:"proposal_missed"`
-	ProposalSuccess          []IndexBlocks                          `json:"proposal_success"`
-	ProposalUpcoming         []IndexSlots                           `json:"proposal_upcoming"`
-	Slashed                  []uint64                               `json:"slashed"`            // validator indices
-	Sync                     []uint64                               `json:"sync"`               // validator indices
-	AttestationMissed        []IndexEpoch                           `json:"attestation_missed"` // index (epoch)
-	Withdrawal               []NotificationEventWithdrawal          `json:"withdrawal"`
-	MinCollateral            []Address                              `json:"min_collateral"` // node addresses
-	MaxCollateral            []Address                              `json:"max_collateral"` // node addresses
+	DashboardName            string                                    `db:"dashboard_name" json:"dashboard_name"`
+	GroupName                string                                    `db:"group_name" json:"group_name"`
+	ValidatorOffline         []uint64                                  `json:"validator_offline"`          // validator indices
+	ValidatorOfflineReminder []uint64                                  `json:"validator_offline_reminder"` // validator indices; TODO not filled yet
+	ValidatorOnline          []NotificationEventValidatorBackOnline    `json:"validator_online"`
+	ValidatorUnstable        []NotificationEventValidatorUnstable      `json:"validator_unstable"`
+	GroupEfficiencyBelow     float64                                   `json:"group_efficiency_below,omitempty"` // fill with the `group_efficiency_below` threshold if event is present
+	CustomRules              []NotificationEventValidatorDashboardRule `json:"custom_rules"`
+	ProposalMissed           []IndexSlots                              `json:"proposal_missed"`
+	ProposalSuccess          []IndexBlocks                             `json:"proposal_success"`
+	ProposalUpcoming         []IndexSlots                              `json:"proposal_upcoming"`
+	Slashed                  []uint64                                  `json:"slashed"`            // validator indices
+	Sync                     []uint64                                  `json:"sync"`               // validator indices
+	AttestationMissed        []IndexEpoch                              `json:"attestation_missed"` // index (epoch)
+	Withdrawal               []NotificationEventWithdrawal             `json:"withdrawal"`
+	PartialWithdrawal        []NotificationEventWithdrawal             `json:"partial_withdrawal"` // withdrawals requested from the execution layer
+	Consolidation            []NotificationEventConsolidation          `json:"consolidation"`
+	MinCollateral            []Address                                 `json:"min_collateral"` // node addresses
+	MaxCollateral            []Address                                 `json:"max_collateral"` // node addresses
 }
 
 type InternalGetUserNotificationsValidatorDashboardResponse ApiDataResponse[NotificationValidatorDashboardDetail]
@@ -102,7 +125,7 @@ type InternalGetUserNotificationsAccountDashboardResponse ApiDataResponse[Notifi
 type NotificationMachinesTableRow struct {
 	MachineName string  `json:"machine_name"`
 	Threshold   float64 `json:"threshold,omitempty" faker:"boundary_start=0, boundary_end=1"`
-	EventType   string  `json:"event_type" tstype:"'offline' | 'storage' | 'cpu' | 'memory'" faker:"oneof: offline, storage, cpu, memory"`
+	EventType   string  `json:"event_type" tstype:"'offline' | 'storage' | 'cpu' | 'memory' | 'not_synced' | 'el_disconnected' | 'low_peer_count' | 'head_slot_lag' | 'fallback_in_use' | 'client_outdated'" faker:"oneof: offline, storage, cpu, memory, not_synced, el_disconnected, low_peer_count, head_slot_lag, fallback_in_use, client_outdated"`
 	Timestamp   int64   `json:"timestamp"`
 }
 
@@ -130,6 +153,24 @@ type NotificationNetworksTableRow struct {
 
 type InternalGetUserNotificationNetworksResponse ApiPagingResponse[NotificationNetworksTableRow]
 
+// ------------------------------------------------------------
+// Incidents Table
+type NotificationIncidentsTableRow struct {
+	Id             uint64  `json:"id"`
+	DashboardId    uint64  `json:"dashboard_id"`
+	DashboardName  string  `json:"dashboard_name"`
+	GroupId        uint64  `json:"group_id"`
+	GroupName      string  `json:"group_name"`
+	EventType      string  `json:"event_type" tstype:"'validator_offline'" faker:"oneof: validator_offline"`
+	Index          uint64  `json:"index"`
+	Status         string  `json:"status" tstype:"'open' | 'resolved'" faker:"oneof: open, resolved"`
+	OpenedEpoch    uint64  `json:"opened_epoch"`
+	ResolvedEpoch  *uint64 `json:"resolved_epoch,omitempty"`
+	IsAcknowledged bool    `json:"is_acknowledged"`
+}
+
+type InternalGetUserNotificationIncidentsResponse ApiPagingResponse[NotificationIncidentsTableRow]
+
 // ------------------------------------------------------------
 // Notification Settings
 type NotificationSettingsNetwork struct {
@@ -177,6 +218,20 @@ type NotificationSettingsGeneral struct {
 	MachineCpuUsageThreshold        float64 `json:"machine_cpu_usage_threshold" faker:"boundary_start=0, boundary_end=1"`
 	IsMachineMemoryUsageSubscribed  bool    `json:"is_machine_memory_usage_subscribed"`
 	MachineMemoryUsageThreshold     float64 `json:"machine_memory_usage_threshold" faker:"boundary_start=0, boundary_end=1"`
+
+	IsMachineNotSyncedSubscribed      bool    `json:"is_machine_not_synced_subscribed"`
+	MachineNotSyncedThreshold         float64 `json:"machine_not_synced_threshold" faker:"boundary_start=1, boundary_end=5"` // minutes
+	IsMachineElDisconnectedSubscribed bool    `json:"is_machine_el_disconnected_subscribed"`
+	MachineElDisconnectedThreshold    float64 `json:"machine_el_disconnected_threshold" faker:"boundary_start=1, boundary_end=5"` // minutes
+	IsMachineLowPeerCountSubscribed   bool    `json:"is_machine_low_peer_count_subscribed"`
+	MachineLowPeerCountThreshold      float64 `json:"machine_low_peer_count_threshold" faker:"boundary_start=0, boundary_end=100"` // peers
+	IsMachineHeadSlotLagSubscribed    bool    `json:"is_machine_head_slot_lag_subscribed"`
+	MachineHeadSlotLagThreshold       float64 `json:"machine_head_slot_lag_threshold" faker:"boundary_start=1, boundary_end=64"` // slots
+	IsMachineFallbackInUseSubscribed  bool    `json:"is_machine_fallback_in_use_subscribed"`
+	MachineFallbackInUseThreshold     float64 `json:"machine_fallback_in_use_threshold" faker:"boundary_start=1, boundary_end=5"` // minutes
+
+	IsMachineClientOutdatedSubscribed  bool `json:"is_machine_client_outdated_subscribed"`
+	MachineClientOutdatedOnlyMandatory bool `json:"machine_client_outdated_only_mandatory"` // only notify about releases required for a hard fork
 }
 type InternalPutUserNotificationSettingsGeneralResponse ApiDataResponse[NotificationSettingsGeneral]
 type NotificationSettings struct {
@@ -202,6 +257,9 @@ type NotificationSettingsValidatorDashboard struct {
 	IsWithdrawalProcessedSubscribed   bool    `json:"is_withdrawal_processed_subscribed"`
 	IsSlashedSubscribed               bool    `json:"is_slashed_subscribed"`
 
+	IsPartialWithdrawalProcessedSubscribed bool `json:"is_partial_withdrawal_processed_subscribed"`
+	IsConsolidationCompletedSubscribed     bool `json:"is_consolidation_completed_subscribed"`
+
 	IsMaxCollateralSubscribed bool    `json:"is_max_collateral_subscribed"`
 	MaxCollateralThreshold    float64 `json:"max_collateral_threshold" faker:"boundary_start=0, boundary_end=1"`
 	IsMinCollateralSubscribed bool    `json:"is_min_collateral_subscribed"`
@@ -210,6 +268,30 @@ type NotificationSettingsValidatorDashboard struct {
 
 type InternalPutUserNotificationSettingsValidatorDashboardResponse ApiDataResponse[NotificationSettingsValidatorDashboard]
 
+type NotificationRuleValidatorDashboard struct {
+	Id                 uint64  `db:"id" json:"id"`
+	Name               string  `db:"name" json:"name"`
+	Metric             string  `db:"metric" json:"metric" tstype:"'efficiency' | 'missed_attestations' | 'balance_delta' | 'proposal_luck' | 'sync_participation'" faker:"oneof: efficiency, missed_attestations, balance_delta, proposal_luck, sync_participation"`
+	Comparison         string  `db:"comparison" json:"comparison" tstype:"'lt' | 'lte' | 'gt' | 'gte'" faker:"oneof: lt, lte, gt, gte"`
+	Threshold          float64 `db:"threshold" json:"threshold"`
+	WindowEpochs       uint64  `db:"window_epochs" json:"window_epochs" faker:"boundary_start=1, boundary_end=225"`
+	CooldownEpochs     uint64  `db:"cooldown_epochs" json:"cooldown_epochs" faker:"boundary_start=0, boundary_end=225"`
+	LastTriggeredEpoch *uint64 `db:"last_triggered_epoch" json:"last_triggered_epoch,omitempty"`
+}
+
+type InternalGetUserNotificationSettingsValidatorDashboardRulesResponse ApiDataResponse[[]NotificationRuleValidatorDashboard]
+type InternalPostUserNotificationSettingsValidatorDashboardRulesResponse ApiDataResponse[NotificationRuleValidatorDashboard]
+type InternalPutUserNotificationSettingsValidatorDashboardRuleResponse ApiDataResponse[NotificationRuleValidatorDashboard]
+
+type NotificationSnoozeValidatorDashboard struct {
+	Id           uint64  `json:"id"`
+	EventType    *string `json:"event_type,omitempty"` // all events of the group are snoozed if not set
+	SnoozedUntil int64   `json:"snoozed_until"`
+}
+
+type InternalGetUserNotificationSettingsValidatorDashboardSnoozesResponse ApiDataResponse[[]NotificationSnoozeValidatorDashboard]
+type InternalPostUserNotificationSettingsValidatorDashboardSnoozesResponse ApiDataResponse[NotificationSnoozeValidatorDashboard]
+
 type NotificationSettingsAccountDashboard struct {
 	WebhookUrl                      string   `json:"webhook_url" faker:"url"`
 	IsWebhookDiscordEnabled         bool     `json:"is_webhook_discord_enabled"`
@@ -8,3 +8,36 @@ type ApiWeightItem struct {
 }
 
 type InternalGetRatelimitWeightsResponse ApiDataResponse[[]ApiWeightItem]
+
+type ApiUsageDataPoint struct {
+	Timestamp int64  `json:"timestamp"`
+	Count     uint64 `json:"count"`
+}
+
+type ApiEndpointUsage struct {
+	Endpoint string              `json:"endpoint"`
+	Hourly   []ApiUsageDataPoint `json:"hourly"`
+	Daily    []ApiUsageDataPoint `json:"daily"`
+}
+
+type ApiKeyUsage struct {
+	ApiKey    string              `json:"api_key"` // only the first characters of the key, "oauth" for requests authorized by an oauth access token
+	Hourly    []ApiUsageDataPoint `json:"hourly"`
+	Daily     []ApiUsageDataPoint `json:"daily"`
+	Endpoints []ApiEndpointUsage  `json:"endpoints"`
+}
+
+type ApiUsageQuota struct {
+	Limit uint64 `json:"limit"` // 0 means unlimited
+	Used  uint64 `json:"used"`
+}
+
+type ApiUsageData struct {
+	SecondLimit         uint64        `json:"second_limit"`
+	Hour                ApiUsageQuota `json:"hour"`
+	Month               ApiUsageQuota `json:"month"`
+	ProjectedMonthUsage uint64        `json:"projected_month_usage"`
+	Keys                []ApiKeyUsage `json:"keys"`
+}
+
+type InternalGetUserApiUsageResponse ApiDataResponse[ApiUsageData]
@@ -24,6 +24,36 @@ type UserSubscription struct {
 
 type InternalGetUserInfoResponse ApiDataResponse[UserInfo]
 
+// scopes of an api key, keys without a scope can not be used for the matching endpoints
+const (
+	ApiKeyScopeNetworkRead         = "network:read"
+	ApiKeyScopeDashboardsManage    = "dashboards:manage"
+	ApiKeyScopeNotificationsManage = "notifications:manage"
+	ApiKeyScopeAccountRead         = "account:read"
+	ApiKeyScopeMachineMetricsWrite = "machine-metrics:write"
+)
+
+var ApiKeyScopes = []string{ApiKeyScopeNetworkRead, ApiKeyScopeDashboardsManage, ApiKeyScopeNotificationsManage, ApiKeyScopeAccountRead, ApiKeyScopeMachineMetricsWrite}
+
+type UserApiKey struct {
+	Id        uint64   `json:"id"`
+	Label     string   `json:"label"`
+	Prefix    string   `json:"prefix"` // first characters of the key, the full key is only returned once after creation
+	Scopes    []string `json:"scopes" tstype:"('network:read' | 'dashboards:manage' | 'notifications:manage' | 'account:read' | 'machine-metrics:write')[]" faker:"slice_len=2"`
+	CreatedAt int64    `json:"created_at" faker:"unix_time"`
+}
+
+type InternalGetUserApiKeysResponse ApiDataResponse[[]UserApiKey]
+
+type CreatedUserApiKey struct {
+	UserApiKey `tstype:",extends"`
+	ApiKey     string `json:"api_key"`
+}
+
+type InternalPostUserApiKeysResponse ApiDataResponse[CreatedUserApiKey]
+
+type InternalPutUserApiKeyResponse ApiDataResponse[UserApiKey]
+
 type EmailUpdate struct {
 	Id           uint64 `json:"id"`
 	CurrentEmail string `json:"current_email"`
@@ -149,9 +179,175 @@ type StripeCustomerPortal struct {
 }
 
 type OAuthAppData struct {
-	ID          uint64 `db:"id"`
-	Owner       uint64 `db:"owner_id"`
-	AppName     string `db:"app_name"`
-	RedirectURI string `db:"redirect_uri"`
-	Active      bool   `db:"active"`
+	ID           uint64  `db:"id"`
+	Owner        uint64  `db:"owner_id"`
+	AppName      string  `db:"app_name"`
+	RedirectURI  string  `db:"redirect_uri"`
+	Active       bool    `db:"active"`
+	ClientSecret *string `db:"client_secret"` // hashed, nil for public clients
+}
+
+// OAuthCodeData is a consumed authorization code
+type OAuthCodeData struct {
+	UserId              uint64
+	AppId               uint64
+	RedirectUri         string
+	Scopes              []string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthDeviceData is an authorized third-party app session, stored alongside the mobile app devices
+type OAuthDeviceData struct {
+	Id     uint64
+	UserId uint64
+	AppId  uint64
+	Scopes []string
+}
+
+type OAuthApp struct {
+	ClientId     string `json:"client_id"`
+	Name         string `json:"name"`
+	RedirectUri  string `json:"redirect_uri"`
+	Confidential bool   `json:"confidential"` // confidential apps have to authenticate with their client secret when requesting tokens
+	CreatedAt    int64  `json:"created_at" faker:"unix_time"`
+}
+
+type InternalGetUserOAuthAppsResponse ApiDataResponse[[]OAuthApp]
+
+type CreatedOAuthApp struct {
+	OAuthApp     `tstype:",extends"`
+	ClientSecret string `json:"client_secret,omitempty"` // only returned once after creation
+}
+
+type InternalPostUserOAuthAppsResponse ApiDataResponse[CreatedOAuthApp]
+
+type OAuthConsent struct {
+	AppName     string   `json:"app_name"`
+	RedirectUri string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes" tstype:"('network:read' | 'dashboards:manage' | 'notifications:manage' | 'account:read' | 'machine-metrics:write')[]" faker:"slice_len=2"`
+}
+
+type InternalGetOAuthAuthorizeResponse ApiDataResponse[OAuthConsent]
+
+type OAuthAuthorizeRedirect struct {
+	RedirectUri string `json:"redirect_uri"` // contains the authorization code or the error
 }
+
+type InternalPostOAuthAuthorizeResponse ApiDataResponse[OAuthAuthorizeRedirect]
+
+// token endpoint responses follow RFC 6749 and are not wrapped
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    uint64 `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type LoginResponse struct {
+	TwoFactorRequired bool `json:"two_factor_required"` // if true, the login has to be completed with a second factor
+}
+
+type InternalPostLoginResponse ApiDataResponse[LoginResponse]
+
+type UserTwoFactorStatus struct {
+	Enabled                bool   `json:"enabled"`
+	RecoveryCodesRemaining uint64 `json:"recovery_codes_remaining"`
+}
+
+type InternalGetUserTwoFactorResponse ApiDataResponse[UserTwoFactorStatus]
+
+type TotpEnrolment struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioning_uri"` // otpauth uri, should be displayed as QR code
+}
+
+type InternalPostUserTotpResponse ApiDataResponse[TotpEnrolment]
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" faker:"slice_len=10"` // only returned once, each code can be used once instead of a totp code
+}
+
+type InternalPostUserRecoveryCodesResponse ApiDataResponse[RecoveryCodes]
+
+// security events recorded for the audit list of a user
+const (
+	SecurityEventLogin             = "login"
+	SecurityEventPasswordChange    = "password_change"
+	SecurityEventEmailChange       = "email_change"
+	SecurityEventTwoFactorEnabled  = "two_factor_enabled"
+	SecurityEventTwoFactorDisabled = "two_factor_disabled"
+	SecurityEventSessionRevoked    = "session_revoked"
+)
+
+type UserSession struct {
+	Id         string `json:"id"`
+	Type       string `json:"type" tstype:"'web' | 'device'" faker:"oneof: web, device"` // devices are mobile app and third-party app sessions using refresh tokens
+	DeviceName string `json:"device_name,omitempty"`
+	AppName    string `json:"app_name,omitempty"`
+	CreatedAt  int64  `json:"created_at" faker:"unix_time"`
+	LastUsedAt int64  `json:"last_used_at" faker:"unix_time"`
+	Ip         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	Current    bool   `json:"current"` // the session of the request
+}
+
+type InternalGetUserSessionsResponse ApiDataResponse[[]UserSession]
+
+type UserSecurityEvent struct {
+	Type      string `json:"type" tstype:"'login' | 'password_change' | 'email_change' | 'two_factor_enabled' | 'two_factor_disabled' | 'session_revoked'" faker:"oneof: login, password_change, email_change, two_factor_enabled, two_factor_disabled, session_revoked"`
+	Timestamp int64  `json:"timestamp" faker:"unix_time"`
+	Ip        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+}
+
+type InternalGetUserSecurityEventsResponse ApiDataResponse[[]UserSecurityEvent]
+
+// roles of organization members
+const (
+	OrganizationRoleOwner  = "owner"  // can manage members, perks of all members are pooled
+	OrganizationRoleEditor = "editor" // can create and modify dashboards
+	OrganizationRoleViewer = "viewer" // can only view dashboards
+)
+
+var OrganizationRoles = []string{OrganizationRoleOwner, OrganizationRoleEditor, OrganizationRoleViewer}
+
+type Organization struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Role        string `json:"role" tstype:"'owner' | 'editor' | 'viewer'" faker:"oneof: owner, editor, viewer"` // role of the requesting user
+	MemberCount uint64 `json:"member_count"`
+	CreatedAt   int64  `json:"created_at" faker:"unix_time"`
+}
+
+type InternalGetUserOrganizationsResponse ApiDataResponse[[]Organization]
+
+type InternalPostOrganizationsResponse ApiDataResponse[Organization]
+
+type InternalPutOrganizationResponse ApiDataResponse[Organization]
+
+type OrganizationDetails struct {
+	Organization `tstype:",extends"`
+	PremiumPerks PremiumPerks `json:"premium_perks"` // pooled perks of all members, used for dashboards owned by the organization
+}
+
+type InternalGetOrganizationResponse ApiDataResponse[OrganizationDetails]
+
+type OrganizationMember struct {
+	UserId   uint64 `json:"user_id"`
+	Email    string `json:"email"` // censored
+	Role     string `json:"role" tstype:"'owner' | 'editor' | 'viewer'" faker:"oneof: owner, editor, viewer"`
+	JoinedAt int64  `json:"joined_at" faker:"unix_time"`
+}
+
+type InternalGetOrganizationMembersResponse ApiDataResponse[[]OrganizationMember]
+
+type InternalPostOrganizationMembersResponse ApiDataResponse[OrganizationMember]
+
+type InternalPutOrganizationMemberResponse ApiDataResponse[OrganizationMember]
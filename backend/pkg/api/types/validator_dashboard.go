@@ -282,6 +282,36 @@ type VDBTotalWithdrawalsData struct {
 
 type GetValidatorDashboardTotalWithdrawalsResponse ApiDataResponse[VDBTotalWithdrawalsData]
 
+// ------------------------------------------------------------
+// Consolidations Tab
+type VDBConsolidationsTableRow struct {
+	Epoch           uint64  `json:"epoch"`
+	Slot            uint64  `json:"slot"`
+	GroupId         uint64  `json:"group_id"`
+	SourceIndex     *uint64 `json:"source_index,omitempty"` // not set if the source pubkey is not a known validator
+	SourcePublicKey PubKey  `json:"source_public_key"`
+	TargetIndex     *uint64 `json:"target_index,omitempty"` // not set if the target pubkey is not a known validator
+	TargetPublicKey PubKey  `json:"target_public_key"`
+	SourceAddress   Address `json:"source_address"`
+	Status          string  `json:"status" tstype:"'pending' | 'completed' | 'rejected'" faker:"oneof: pending, completed, rejected"`
+}
+type GetValidatorDashboardConsolidationsResponse ApiPagingResponse[VDBConsolidationsTableRow]
+
+// ------------------------------------------------------------
+// Withdrawal Requests Tab (EIP-7002 execution layer triggered exits and partial withdrawals)
+type VDBWithdrawalRequestsTableRow struct {
+	Epoch         uint64          `json:"epoch"`
+	Slot          uint64          `json:"slot"`
+	Index         *uint64         `json:"index,omitempty"` // not set if the pubkey is not a known validator
+	PublicKey     PubKey          `json:"public_key"`
+	GroupId       uint64          `json:"group_id"`
+	SourceAddress Address         `json:"source_address"`
+	Type          string          `json:"type" tstype:"'exit' | 'partial'" faker:"oneof: exit, partial"`
+	Amount        decimal.Decimal `json:"amount"` // zero for exits
+	Status        string          `json:"status" tstype:"'pending' | 'completed' | 'rejected'" faker:"oneof: pending, completed, rejected"`
+}
+type GetValidatorDashboardWithdrawalRequestsResponse ApiPagingResponse[VDBWithdrawalRequestsTableRow]
+
 // ------------------------------------------------------------
 // Rocket Pool Tab
 type VDBRocketPoolTableRow struct {
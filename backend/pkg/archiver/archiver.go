@@ -41,20 +41,18 @@ func (a *Archiver) updateArchivedStatus() error {
 	var dashboardsToBeArchived []t.ArchiverDashboardArchiveReason
 	var dashboardsToBeDeleted []uint64
 
-	// Get all dashboards for all users
-	userDashboards, err := a.das.GetValidatorDashboardsCountInfo(ctx)
+	// Get all dashboards for all users and organizations
+	ownerDashboards, err := a.das.GetValidatorDashboardsCountInfo(ctx)
 	if err != nil {
 		return err
 	}
 
-	for userId, dashboards := range userDashboards {
-		// TODO: For better performance there should exist a method to get all user info at once
-		userInfo, err := a.das.GetUserInfo(ctx, userId)
+	for owner, dashboards := range ownerDashboards {
+		perks, err := a.getOwnerPremiumPerks(ctx, owner)
 		if err != nil {
 			return err
 		}
-
-		if userInfo.UserGroup == t.UserGroupAdmin {
+		if perks == nil {
 			// Don't archive or delete anything for admins
 			continue
 		}
@@ -67,9 +65,9 @@ func (a *Archiver) updateArchivedStatus() error {
 			if dashboardInfo.IsArchived {
 				archivedDashboards = append(archivedDashboards, dashboardInfo.DashboardId)
 			} else {
-				if dashboardInfo.GroupCount > userInfo.PremiumPerks.ValidatorGroupsPerDashboard {
+				if dashboardInfo.GroupCount > perks.ValidatorGroupsPerDashboard {
 					dashboardsToBeArchived = append(dashboardsToBeArchived, t.ArchiverDashboardArchiveReason{DashboardId: dashboardInfo.DashboardId, ArchivedReason: enums.VDBArchivedReasons.Groups})
-				} else if dashboardInfo.ValidatorCount > userInfo.PremiumPerks.ValidatorsPerDashboard {
+				} else if dashboardInfo.ValidatorCount > perks.ValidatorsPerDashboard {
 					dashboardsToBeArchived = append(dashboardsToBeArchived, t.ArchiverDashboardArchiveReason{DashboardId: dashboardInfo.DashboardId, ArchivedReason: enums.VDBArchivedReasons.Validators})
 				} else {
 					activeDashboards = append(activeDashboards, dashboardInfo.DashboardId)
@@ -78,7 +76,7 @@ func (a *Archiver) updateArchivedStatus() error {
 		}
 
 		// Check if the user still exceeds the maximum number of active dashboards
-		dashboardLimit := int(userInfo.PremiumPerks.ValidatorDashboards)
+		dashboardLimit := int(perks.ValidatorDashboards)
 		if len(activeDashboards) > dashboardLimit {
 			slices.Sort(activeDashboards)
 			for id := 0; id < len(activeDashboards)-dashboardLimit; id++ {
@@ -131,3 +129,19 @@ func (a *Archiver) updateArchivedStatus() error {
 
 	return nil
 }
+
+// getOwnerPremiumPerks returns the perks of a user or the pooled perks of an organization, nil means no limits apply
+func (a *Archiver) getOwnerPremiumPerks(ctx context.Context, owner t.ArchiverDashboardOwner) (*t.PremiumPerks, error) {
+	if owner.OrganizationId != 0 {
+		return a.das.GetOrganizationPremiumPerks(ctx, owner.OrganizationId)
+	}
+	// TODO: For better performance there should exist a method to get all user info at once
+	userInfo, err := a.das.GetUserInfo(ctx, owner.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if userInfo.UserGroup == t.UserGroupAdmin {
+		return nil, nil
+	}
+	return &userInfo.PremiumPerks, nil
+}
@@ -74,7 +74,7 @@ func NewBlobIndexer() (*BlobIndexer, error) {
 		S3Client:          s3Client,
 		runningMu:         &sync.Mutex{},
 		clEndpoint:        "http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port,
-		cl:                consapi.NewClient("http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port),
+		cl:                consapi.NewClientForEndpoints(utils.BeaconNodeEndpoints(utils.Config)),
 		id:                id,
 		writtenBlobsCache: writtenBlobsCache,
 	}
@@ -36,6 +36,8 @@ const (
 	INCOME_DETAILS_COLUMN_FAMILY          = "id"
 	STATS_COLUMN_FAMILY                   = "stats"
 	MACHINE_METRICS_COLUMN_FAMILY         = "mm"
+	MACHINE_METRICS_5M_COLUMN_FAMILY      = "mm5m"
+	MACHINE_METRICS_1H_COLUMN_FAMILY      = "mm1h"
 	SERIES_FAMILY                         = "series"
 
 	SUM_COLUMN = "sum"
@@ -194,6 +196,39 @@ func (bigtable *Bigtable) GetClient() *gcp_bigtable.Client {
 	return bigtable.client
 }
 
+// MachineMetricsRollup describes a resolution machine metrics are stored in.
+// Rollup cells are written with their timestamp truncated to the resolution, so later
+// inserts overwrite earlier ones and each bucket holds the last sample of its interval.
+// As metrics are mostly counters this keeps rates computed from rollups exact.
+type MachineMetricsRollup struct {
+	Family     string
+	Resolution time.Duration
+	Retention  time.Duration
+}
+
+// MachineMetricsRollups are ordered by resolution, the first entry holds the raw 1 minute samples
+var MachineMetricsRollups = []MachineMetricsRollup{
+	// the raw samples keep their full retention until the rollups have been backfilled (see the machine-metrics-backfill-rollups misc command)
+	{Family: MACHINE_METRICS_COLUMN_FAMILY, Resolution: time.Minute, Retention: utils.Day * 31},
+	{Family: MACHINE_METRICS_5M_COLUMN_FAMILY, Resolution: time.Minute * 5, Retention: utils.Day * 31},
+	{Family: MACHINE_METRICS_1H_COLUMN_FAMILY, Resolution: time.Hour, Retention: utils.Day * 400},
+}
+
+// GetMachineMetricsRollup returns the rollup storing the given resolution
+func GetMachineMetricsRollup(resolution time.Duration) (MachineMetricsRollup, error) {
+	for _, rollup := range MachineMetricsRollups {
+		if rollup.Resolution == resolution {
+			return rollup, nil
+		}
+	}
+	return MachineMetricsRollup{}, fmt.Errorf("unsupported machine metrics resolution %v", resolution)
+}
+
+// the family filter takes a regex, anchor it so "mm" does not match the rollup families
+func machineMetricsFamilyFilter(family string) gcp_bigtable.Filter {
+	return gcp_bigtable.FamilyFilter(fmt.Sprintf("^%s$", family))
+}
+
 func (bigtable *Bigtable) SaveMachineMetric(process string, userID types.UserId, machine string, data []byte) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
@@ -222,7 +257,9 @@ func (bigtable *Bigtable) SaveMachineMetric(process string, userID types.UserId,
 	}
 
 	dataMut := gcp_bigtable.NewMutation()
-	dataMut.Set(MACHINE_METRICS_COLUMN_FAMILY, "v1", ts, data)
+	for _, rollup := range MachineMetricsRollups {
+		dataMut.Set(rollup.Family, "v1", gcp_bigtable.Time(ts.Time().Truncate(rollup.Resolution)), data)
+	}
 
 	bulkMut := types.BulkMutation{ // schedule the mutation for writing
 		Key: rowKeyData,
@@ -241,7 +278,7 @@ func (bigtable Bigtable) getMachineMetricNamesMap(userID types.UserId, searchDep
 	rangePrefix := fmt.Sprintf("u:%s:p:", bigtable.reversePaddedUserID(userID))
 
 	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		machineMetricsFamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
 		gcp_bigtable.LatestNFilter(searchDepth),
 		gcp_bigtable.TimestampRangeFilter(time.Now().Add(time.Duration(searchDepth*-1)*time.Minute), time.Now()),
 		gcp_bigtable.StripValueFilter(),
@@ -310,6 +347,7 @@ func (bigtable Bigtable) GetMachineMetricsMachineCount(userID types.UserId) (uin
 	return uint64(card), nil
 }
 
+// GetMachineMetricsNode returns the raw samples of the beaconnode process paged by limit and offset, newest first per machine
 func (bigtable Bigtable) GetMachineMetricsNode(userID types.UserId, limit, offset int) ([]*types.MachineMetricNode, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -322,19 +360,144 @@ func (bigtable Bigtable) GetMachineMetricsNode(userID types.UserId, limit, offse
 	})
 	defer tmr.Stop()
 
-	return getMachineMetrics(bigtable, "beaconnode", userID, limit, offset,
-		func(data []byte, machine string) *types.MachineMetricNode {
-			obj := &types.MachineMetricNode{}
-			err := proto.Unmarshal(data, obj)
-			if err != nil {
-				return nil
-			}
-			obj.Machine = &machine
-			return obj
-		},
-	)
+	return getMachineMetrics(bigtable, "beaconnode", userID, machineMetricsPageQuery(limit, offset), unmarshalMachineMetricNode)
+}
+
+// GetMachineMetricsNodeRange returns the samples of the beaconnode process in [from, to] stored at the given resolution
+func (bigtable Bigtable) GetMachineMetricsNodeRange(userID types.UserId, from, to time.Time, resolution time.Duration) ([]*types.MachineMetricNode, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":     userID,
+			"from":       from,
+			"to":         to,
+			"resolution": resolution,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	query, err := machineMetricsRangeQuery(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return getMachineMetrics(bigtable, "beaconnode", userID, query, unmarshalMachineMetricNode)
+}
+
+func unmarshalMachineMetricNode(data []byte, machine string, ts time.Time) *types.MachineMetricNode {
+	obj := &types.MachineMetricNode{}
+	err := proto.Unmarshal(data, obj)
+	if err != nil {
+		return nil
+	}
+	obj.Machine = &machine
+	if obj.Timestamp == 0 {
+		obj.Timestamp = uint64(ts.UnixMilli())
+	}
+	return obj
+}
+
+// GetMachineMetricsExecution returns the raw samples of the execution process paged by limit and offset, newest first per machine
+func (bigtable Bigtable) GetMachineMetricsExecution(userID types.UserId, limit, offset int) ([]*types.MachineMetricExecution, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":   userID,
+			"limit":    limit,
+			"offset":   offset,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	return getMachineMetrics(bigtable, "execution", userID, machineMetricsPageQuery(limit, offset), unmarshalMachineMetricExecution)
+}
+
+// GetMachineMetricsExecutionRange returns the samples of the execution process in [from, to] stored at the given resolution
+func (bigtable Bigtable) GetMachineMetricsExecutionRange(userID types.UserId, from, to time.Time, resolution time.Duration) ([]*types.MachineMetricExecution, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":     userID,
+			"from":       from,
+			"to":         to,
+			"resolution": resolution,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	query, err := machineMetricsRangeQuery(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return getMachineMetrics(bigtable, "execution", userID, query, unmarshalMachineMetricExecution)
 }
 
+func unmarshalMachineMetricExecution(data []byte, machine string, ts time.Time) *types.MachineMetricExecution {
+	obj := &types.MachineMetricExecution{}
+	err := proto.Unmarshal(data, obj)
+	if err != nil {
+		return nil
+	}
+	obj.Machine = &machine
+	if obj.Timestamp == 0 {
+		obj.Timestamp = uint64(ts.UnixMilli())
+	}
+	return obj
+}
+
+// GetMachineMetricsSlasher returns the raw samples of the slasher process paged by limit and offset, newest first per machine
+func (bigtable Bigtable) GetMachineMetricsSlasher(userID types.UserId, limit, offset int) ([]*types.MachineMetricSlasher, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":   userID,
+			"limit":    limit,
+			"offset":   offset,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	return getMachineMetrics(bigtable, "slasher", userID, machineMetricsPageQuery(limit, offset), unmarshalMachineMetricSlasher)
+}
+
+// GetMachineMetricsSlasherRange returns the samples of the slasher process in [from, to] stored at the given resolution
+func (bigtable Bigtable) GetMachineMetricsSlasherRange(userID types.UserId, from, to time.Time, resolution time.Duration) ([]*types.MachineMetricSlasher, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":     userID,
+			"from":       from,
+			"to":         to,
+			"resolution": resolution,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	query, err := machineMetricsRangeQuery(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return getMachineMetrics(bigtable, "slasher", userID, query, unmarshalMachineMetricSlasher)
+}
+
+func unmarshalMachineMetricSlasher(data []byte, machine string, ts time.Time) *types.MachineMetricSlasher {
+	obj := &types.MachineMetricSlasher{}
+	err := proto.Unmarshal(data, obj)
+	if err != nil {
+		return nil
+	}
+	obj.Machine = &machine
+	if obj.Timestamp == 0 {
+		obj.Timestamp = uint64(ts.UnixMilli())
+	}
+	return obj
+}
+
+// GetMachineMetricsValidator returns the raw samples of the validator process paged by limit and offset, newest first per machine
 func (bigtable Bigtable) GetMachineMetricsValidator(userID types.UserId, limit, offset int) ([]*types.MachineMetricValidator, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -347,19 +510,44 @@ func (bigtable Bigtable) GetMachineMetricsValidator(userID types.UserId, limit,
 	})
 	defer tmr.Stop()
 
-	return getMachineMetrics(bigtable, "validator", userID, limit, offset,
-		func(data []byte, machine string) *types.MachineMetricValidator {
-			obj := &types.MachineMetricValidator{}
-			err := proto.Unmarshal(data, obj)
-			if err != nil {
-				return nil
-			}
-			obj.Machine = &machine
-			return obj
-		},
-	)
+	return getMachineMetrics(bigtable, "validator", userID, machineMetricsPageQuery(limit, offset), unmarshalMachineMetricValidator)
 }
 
+// GetMachineMetricsValidatorRange returns the samples of the validator process in [from, to] stored at the given resolution
+func (bigtable Bigtable) GetMachineMetricsValidatorRange(userID types.UserId, from, to time.Time, resolution time.Duration) ([]*types.MachineMetricValidator, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":     userID,
+			"from":       from,
+			"to":         to,
+			"resolution": resolution,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	query, err := machineMetricsRangeQuery(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return getMachineMetrics(bigtable, "validator", userID, query, unmarshalMachineMetricValidator)
+}
+
+func unmarshalMachineMetricValidator(data []byte, machine string, ts time.Time) *types.MachineMetricValidator {
+	obj := &types.MachineMetricValidator{}
+	err := proto.Unmarshal(data, obj)
+	if err != nil {
+		return nil
+	}
+	obj.Machine = &machine
+	if obj.Timestamp == 0 {
+		obj.Timestamp = uint64(ts.UnixMilli())
+	}
+	return obj
+}
+
+// GetMachineMetricsSystem returns the raw samples of the system process paged by limit and offset, newest first per machine
 func (bigtable Bigtable) GetMachineMetricsSystem(userID types.UserId, limit, offset int) ([]*types.MachineMetricSystem, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -372,48 +560,102 @@ func (bigtable Bigtable) GetMachineMetricsSystem(userID types.UserId, limit, off
 	})
 	defer tmr.Stop()
 
-	return getMachineMetrics(bigtable, "system", userID, limit, offset,
-		func(data []byte, machine string) *types.MachineMetricSystem {
-			obj := &types.MachineMetricSystem{}
-			err := proto.Unmarshal(data, obj)
-			if err != nil {
-				return nil
-			}
-			obj.Machine = &machine
-			return obj
-		},
-	)
+	return getMachineMetrics(bigtable, "system", userID, machineMetricsPageQuery(limit, offset), unmarshalMachineMetricSystem)
 }
 
-func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | types.MachineMetricValidator](bigtable Bigtable, process string, userID types.UserId, limit, offset int, marshler func(data []byte, machine string) *T) ([]*T, error) {
+// GetMachineMetricsSystemRange returns the samples of the system process in [from, to] stored at the given resolution
+func (bigtable Bigtable) GetMachineMetricsSystemRange(userID types.UserId, from, to time.Time, resolution time.Duration) ([]*types.MachineMetricSystem, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":     userID,
+			"from":       from,
+			"to":         to,
+			"resolution": resolution,
+			"func":       utils.GetCurrentFuncName(),
+			"duration":   REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	query, err := machineMetricsRangeQuery(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return getMachineMetrics(bigtable, "system", userID, query, unmarshalMachineMetricSystem)
+}
+
+func unmarshalMachineMetricSystem(data []byte, machine string, ts time.Time) *types.MachineMetricSystem {
+	obj := &types.MachineMetricSystem{}
+	err := proto.Unmarshal(data, obj)
+	if err != nil {
+		return nil
+	}
+	obj.Machine = &machine
+	if obj.Timestamp == 0 {
+		obj.Timestamp = uint64(ts.UnixMilli())
+	}
+	return obj
+}
+
+// machineMetricsQuery selects the cells returned by getMachineMetrics
+type machineMetricsQuery struct {
+	family  string
+	filter  gcp_bigtable.Filter
+	gapSize int // only every gapSize-th cell of a machine is returned
+}
+
+// machineMetricsPageQuery pages over the raw samples, thinning them out for large limits
+func machineMetricsPageQuery(limit, offset int) machineMetricsQuery {
+	if offset <= 0 {
+		offset = 1
+	}
+	return machineMetricsQuery{
+		family: MACHINE_METRICS_COLUMN_FAMILY,
+		filter: gcp_bigtable.ChainFilters(
+			machineMetricsFamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+			gcp_bigtable.LatestNFilter(limit),
+			gcp_bigtable.CellsPerRowOffsetFilter(offset),
+		),
+		gapSize: utils.GetMachineStatsGap(uint64(limit)),
+	}
+}
+
+// machineMetricsRangeQuery selects all samples in [from, to] of the rollup storing the given resolution
+func machineMetricsRangeQuery(from, to time.Time, resolution time.Duration) (machineMetricsQuery, error) {
+	rollup, err := GetMachineMetricsRollup(resolution)
+	if err != nil {
+		return machineMetricsQuery{}, err
+	}
+	return machineMetricsQuery{
+		family: rollup.Family,
+		filter: gcp_bigtable.ChainFilters(
+			machineMetricsFamilyFilter(rollup.Family),
+			// the end of the timestamp range is exclusive
+			gcp_bigtable.TimestampRangeFilter(from, to.Add(time.Millisecond)),
+		),
+		gapSize: 1,
+	}, nil
+}
+
+// getMachineMetrics returns the samples of a process selected by the query, newest first per machine
+func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | types.MachineMetricValidator | types.MachineMetricExecution | types.MachineMetricSlasher](bigtable Bigtable, process string, userID types.UserId, query machineMetricsQuery, marshler func(data []byte, machine string, ts time.Time) *T) ([]*T, error) {
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
 	rangePrefix := fmt.Sprintf("u:%s:p:%s:m:", bigtable.reversePaddedUserID(userID), process)
 	res := make([]*T, 0)
-	if offset <= 0 {
-		offset = 1
-	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
-		gcp_bigtable.LatestNFilter(limit),
-		gcp_bigtable.CellsPerRowOffsetFilter(offset),
-	)
-	gapSize := utils.GetMachineStatsGap(uint64(limit))
 	err := bigtable.tableMachineMetrics.ReadRows(ctx, gcp_bigtable.PrefixRange(rangePrefix), func(r gcp_bigtable.Row) bool {
 		success, _, machine, _ := machineMetricRowParts(r.Key())
 		if !success {
 			return false
 		}
-		var count = -1
-		for _, ri := range r[MACHINE_METRICS_COLUMN_FAMILY] {
-			count++
-			if count%gapSize != 0 {
+		for i, ri := range r[query.family] {
+			if i%query.gapSize != 0 {
 				continue
 			}
 
-			obj := marshler(ri.Value, machine)
+			obj := marshler(ri.Value, machine, ri.Timestamp.Time())
 			if obj == nil {
 				return false
 			}
@@ -421,6 +663,142 @@ func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | t
 			res = append(res, obj)
 		}
 		return true
+	}, gcp_bigtable.RowFilter(query.filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// BackfillMachineMetricsRollups writes the rollup cells of all raw samples since from, so the rollups cover the full
+// history of the raw samples. Returns the number of rows that were backfilled.
+func (bigtable *Bigtable) BackfillMachineMetricsRollups(from time.Time, dryRun bool) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour*6)
+	defer cancel()
+
+	filter := gcp_bigtable.ChainFilters(
+		machineMetricsFamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		gcp_bigtable.TimestampRangeFilter(from, time.Now()),
+	)
+
+	batchSize := 1000
+	muts := types.NewBulkMutations(batchSize)
+	rows := 0
+	var writeErr error
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, gcp_bigtable.PrefixRange("u:"), func(r gcp_bigtable.Row) bool {
+		cells := r[MACHINE_METRICS_COLUMN_FAMILY]
+		if len(cells) == 0 {
+			return true
+		}
+
+		mut := gcp_bigtable.NewMutation()
+		for _, rollup := range MachineMetricsRollups[1:] {
+			// cells are sorted newest first, the first cell of a bucket is the last sample of its interval
+			lastBucket := time.Time{}
+			for _, cell := range cells {
+				bucket := cell.Timestamp.Time().Truncate(rollup.Resolution)
+				if bucket.Equal(lastBucket) {
+					continue
+				}
+				lastBucket = bucket
+				mut.Set(rollup.Family, "v1", gcp_bigtable.Time(bucket), cell.Value)
+			}
+		}
+		rows++
+
+		if dryRun {
+			return true
+		}
+		muts.Keys = append(muts.Keys, r.Key())
+		muts.Muts = append(muts.Muts, mut)
+		if len(muts.Keys) >= batchSize {
+			writeErr = bigtable.WriteBulk(muts, bigtable.tableMachineMetrics, batchSize)
+			if writeErr != nil {
+				return false
+			}
+			log.Infof("backfilled machine metrics rollups of %v rows", rows)
+			muts = types.NewBulkMutations(batchSize)
+		}
+		return true
+	}, gcp_bigtable.RowFilter(filter))
+	if err != nil {
+		return rows, err
+	}
+	if writeErr != nil {
+		return rows, writeErr
+	}
+
+	if len(muts.Keys) > 0 {
+		err = bigtable.WriteBulk(muts, bigtable.tableMachineMetrics, batchSize)
+		if err != nil {
+			return rows, err
+		}
+	}
+	return rows, nil
+}
+
+func (bigtable Bigtable) GetMachineMetricsClients(userID types.UserId) ([]*types.MachineMetricClient, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":   userID,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	rangePrefix := fmt.Sprintf("u:%s:p:", bigtable.reversePaddedUserID(userID))
+	filter := gcp_bigtable.ChainFilters(
+		machineMetricsFamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		gcp_bigtable.TimestampRangeFilter(time.Now().Add(-utils.Day), time.Now()),
+		gcp_bigtable.LatestNFilter(1),
+	)
+
+	res := make([]*types.MachineMetricClient, 0)
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, gcp_bigtable.PrefixRange(rangePrefix), func(r gcp_bigtable.Row) bool {
+		success, _, machine, process := machineMetricRowParts(r.Key())
+		if !success {
+			return false
+		}
+		cells := r[MACHINE_METRICS_COLUMN_FAMILY]
+		if len(cells) == 0 {
+			return true
+		}
+
+		var obj interface {
+			proto.Message
+			GetClientName() string
+			GetClientVersion() string
+		}
+		switch process {
+		case "beaconnode":
+			obj = &types.MachineMetricNode{}
+		case "validator":
+			obj = &types.MachineMetricValidator{}
+		case "execution":
+			obj = &types.MachineMetricExecution{}
+		case "slasher":
+			obj = &types.MachineMetricSlasher{}
+		default: // system metrics carry no client
+			return true
+		}
+		err := proto.Unmarshal(cells[0].Value, obj)
+		if err != nil {
+			return false
+		}
+
+		res = append(res, &types.MachineMetricClient{
+			Machine:       machine,
+			Process:       process,
+			ClientName:    obj.GetClientName(),
+			ClientVersion: obj.GetClientVersion(),
+			InsertTs:      cells[0].Timestamp.Time().Unix(),
+		})
+		return true
 	}, gcp_bigtable.RowFilter(filter))
 	if err != nil {
 		return nil, err
@@ -437,6 +815,8 @@ func (bigtable Bigtable) GetMachineRowKey(userID types.UserId, process string, m
 // machineData contains the latest machine data in CurrentData
 // and 5 minute old data in fiveMinuteOldData (defined in limit)
 // as well as the insert timestamps of both
+// Rows of the system, beaconnode, validator and execution processes are decoded, rows of other processes only update LastDataInsertTs
+// to the latest insert of any process of the machine, CurrentData is nil if the machine reports no system metrics
 func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.RowList) (map[types.UserId]map[string]*types.MachineMetricSystemUser, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -449,21 +829,74 @@ func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*200))
 	defer cancel()
 
-	res := make(map[types.UserId]map[string]*types.MachineMetricSystemUser) // userID -> machine -> data
+	res := make(map[types.UserId]map[string]*types.MachineMetricSystemUser)         // userID -> machine -> data
+	processData := make(map[types.UserId]map[string]*types.MachineMetricSystemUser) // userID -> machine -> data of non system processes
 
 	limit := 5
 
 	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		machineMetricsFamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
 		gcp_bigtable.LatestNFilter(limit),
 	)
 
 	err := bigtable.tableMachineMetrics.ReadRows(ctx, rowKeys, func(r gcp_bigtable.Row) bool {
-		success, userID, machine, _ := machineMetricRowParts(r.Key())
+		success, userID, machine, process := machineMetricRowParts(r.Key())
 		if !success {
 			return false
 		}
 
+		if process != "system" {
+			cells := r[MACHINE_METRICS_COLUMN_FAMILY]
+			if len(cells) == 0 {
+				return true
+			}
+			if _, found := processData[userID]; !found {
+				processData[userID] = make(map[string]*types.MachineMetricSystemUser)
+			}
+			data, found := processData[userID][machine]
+			if !found {
+				data = &types.MachineMetricSystemUser{}
+				processData[userID][machine] = data
+			}
+			// cells are ordered newest first
+			insertTs := cells[0].Timestamp.Time().Unix()
+			data.LastDataInsertTs = max(data.LastDataInsertTs, insertTs)
+
+			switch process {
+			case "beaconnode":
+				data.NodeDataInsertTs = insertTs
+				for _, ri := range cells {
+					obj := &types.MachineMetricNode{}
+					err := proto.Unmarshal(ri.Value, obj)
+					if err != nil {
+						return false
+					}
+					data.NodeData = append(data.NodeData, obj)
+				}
+			case "validator":
+				data.ValidatorDataInsertTs = insertTs
+				for _, ri := range cells {
+					obj := &types.MachineMetricValidator{}
+					err := proto.Unmarshal(ri.Value, obj)
+					if err != nil {
+						return false
+					}
+					data.ValidatorData = append(data.ValidatorData, obj)
+				}
+			case "execution":
+				data.ExecutionDataInsertTs = insertTs
+				for _, ri := range cells {
+					obj := &types.MachineMetricExecution{}
+					err := proto.Unmarshal(ri.Value, obj)
+					if err != nil {
+						return false
+					}
+					data.ExecutionData = append(data.ExecutionData, obj)
+				}
+			}
+			return true
+		}
+
 		count := 0
 		for _, ri := range r[MACHINE_METRICS_COLUMN_FAMILY] {
 			obj := &types.MachineMetricSystem{}
@@ -505,6 +938,34 @@ func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.
 		return nil, err
 	}
 
+	for userID, machines := range processData {
+		if _, found := res[userID]; !found {
+			res[userID] = make(map[string]*types.MachineMetricSystemUser)
+		}
+		for machine, processes := range machines {
+			data, found := res[userID][machine]
+			if !found {
+				data = &types.MachineMetricSystemUser{
+					UserID:  userID,
+					Machine: machine,
+				}
+				res[userID][machine] = data
+			}
+			data.LastDataInsertTs = processes.LastDataInsertTs
+			data.NodeData = processes.NodeData
+			data.NodeDataInsertTs = processes.NodeDataInsertTs
+			data.ValidatorData = processes.ValidatorData
+			data.ValidatorDataInsertTs = processes.ValidatorDataInsertTs
+			data.ExecutionData = processes.ExecutionData
+			data.ExecutionDataInsertTs = processes.ExecutionDataInsertTs
+		}
+	}
+	for _, machines := range res {
+		for _, data := range machines {
+			data.LastDataInsertTs = max(data.LastDataInsertTs, data.CurrentDataInsertTs)
+		}
+	}
+
 	return res, nil
 }
 
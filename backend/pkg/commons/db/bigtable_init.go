@@ -32,8 +32,9 @@ func InitBigtableSchema() error {
 		CONTRACT_METADATA_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day),
 		DEFAULT_FAMILY:           nil,
 	}
-	tables["machine_metrics"] = map[string]gcp_bigtable.GCPolicy{
-		MACHINE_METRICS_COLUMN_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day * 31),
+	tables["machine_metrics"] = map[string]gcp_bigtable.GCPolicy{}
+	for _, rollup := range MachineMetricsRollups {
+		tables["machine_metrics"][rollup.Family] = gcp_bigtable.MaxAgeGCPolicy(rollup.Retention)
 	}
 	tables["metadata"] = map[string]gcp_bigtable.GCPolicy{
 		ACCOUNT_METADATA_FAMILY:  nil,
@@ -0,0 +1,275 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	DashboardStorageClickHouse = "clickhouse"
+	DashboardStoragePostgres   = "postgres"
+)
+
+// schema of the views that expose the exporter's tables under the names of the clickhouse rolling tables
+const postgresDashboardStorageSchema = "dashboard_api"
+
+// DashboardAggregateStorage is the database the validator dashboard aggregates are read from.
+// With ClickHouse they are read from the clickhouse tables, with Postgres/TimescaleDB they are read from the tables
+// written by the dashboard data exporter via the dashboard_api views of the alloy migrations, so no clickhouse is needed for them.
+type DashboardAggregateStorage interface {
+	// Reader returns the connection the aggregates are read from
+	Reader() *sqlx.DB
+	// RollingTable returns the FROM expression of a rolling table, alias may be empty
+	RollingTable(table string, alias string) string
+	// ChartTable returns the FROM expression of a chart table (validator_dashboard_data_epoch, _hourly, _daily or _weekly),
+	// the rows carry the timestamp column of the clickhouse table (epoch_timestamp, hour, day or week)
+	ChartTable(table string, alias string) string
+	// FromUnixTimestamp returns the expression that converts a bound unix timestamp to the type of the chart timestamp columns
+	FromUnixTimestamp() string
+	// LatestChartTsQuery returns the query for the latest timestamp of a chart table
+	LatestChartTsQuery(table string, dateColumn string) string
+	// ProposalClRewardsQuery returns the query of the cl rewards of proposed blocks in ETH, the rows have the columns slot and cl_reward
+	ProposalClRewardsQuery() string
+	// WriteAggregates is called by the dashboard data exporter after headEpoch has been aggregated,
+	// it writes the aggregates that are only read through the storage
+	WriteAggregates(headEpoch uint64) error
+}
+
+type clickhouseDashboardStorage struct {
+	reader *sqlx.DB
+}
+
+func (s *clickhouseDashboardStorage) Reader() *sqlx.DB {
+	return s.reader
+}
+
+// rolling tables are ReplacingMergeTrees, FINAL merges the rows of not yet merged parts
+func (s *clickhouseDashboardStorage) RollingTable(table string, alias string) string {
+	if alias == "" {
+		return fmt.Sprintf("%s FINAL", table)
+	}
+	return fmt.Sprintf("%s AS %s FINAL", table, alias)
+}
+
+func (s *clickhouseDashboardStorage) ChartTable(table string, alias string) string {
+	if alias == "" {
+		return table
+	}
+	return fmt.Sprintf("%s AS %s", table, alias)
+}
+
+func (s *clickhouseDashboardStorage) FromUnixTimestamp() string {
+	return "fromUnixTimestamp(?)"
+}
+
+// the max timestamps are kept up to date by views, querying the tables would scan them
+func (s *clickhouseDashboardStorage) LatestChartTsQuery(table string, dateColumn string) string {
+	return fmt.Sprintf("SELECT max(t) FROM view_%s_max_ts", table)
+}
+
+func (s *clickhouseDashboardStorage) ProposalClRewardsQuery() string {
+	return "SELECT slot, attestations_reward / 1e9 + sync_aggregate_reward / 1e9 + slasher_reward / 1e9 AS cl_reward FROM mainnet.validator_proposal_rewards_slot"
+}
+
+// the clickhouse tables are filled by the clickhouse ingestion of the exporter's tables, including the 1h window and the last scheduled duties
+func (s *clickhouseDashboardStorage) WriteAggregates(headEpoch uint64) error {
+	return nil
+}
+
+type postgresDashboardStorage struct {
+	reader *sqlx.DB
+	writer *sqlx.DB
+}
+
+func (s *postgresDashboardStorage) Reader() *sqlx.DB {
+	return s.reader
+}
+
+func (s *postgresDashboardStorage) RollingTable(table string, alias string) string {
+	if alias == "" {
+		return fmt.Sprintf("%s.%s", postgresDashboardStorageSchema, table)
+	}
+	return fmt.Sprintf("%s.%s AS %s", postgresDashboardStorageSchema, table, alias)
+}
+
+// The exporter's tables have no timestamp columns, they are derived from the epochs.
+// There is no weekly table, the weekly chart groups the daily rows by their week.
+func (s *postgresDashboardStorage) ChartTable(table string, alias string) string {
+	if alias == "" {
+		alias = table
+	}
+	genesisTs := utils.Config.Chain.GenesisTimestamp
+	secondsPerEpoch := utils.Config.Chain.ClConfig.SecondsPerSlot * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	switch table {
+	case "validator_dashboard_data_epoch":
+		// like the rolling views the epoch rows carry the slasher reward and slashing count columns of the clickhouse epoch table
+		return fmt.Sprintf(`(SELECT t.*, TO_TIMESTAMP(%d + t.epoch * %d) AS epoch_timestamp, t.slasher_reward AS blocks_cl_slasher_reward, COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count `+
+			`FROM validator_dashboard_data_epoch t LEFT JOIN (SELECT epoch, slashed_by, COUNT(*) AS slashing_count FROM validator_dashboard_data_epoch WHERE slashed_by IS NOT NULL GROUP BY epoch, slashed_by) s `+
+			`ON s.epoch = t.epoch AND s.slashed_by = t.validator_index) AS %s`, genesisTs, secondsPerEpoch, alias)
+	case "validator_dashboard_data_hourly":
+		return fmt.Sprintf("(SELECT t.*, DATE_TRUNC('hour', TO_TIMESTAMP(%d + t.epoch_start * %d)) AS hour FROM validator_dashboard_data_hourly t) AS %s", genesisTs, secondsPerEpoch, alias)
+	case "validator_dashboard_data_weekly":
+		return fmt.Sprintf("(SELECT t.*, DATE_TRUNC('week', t.day) AS week FROM validator_dashboard_data_daily t) AS %s", alias)
+	default:
+		return fmt.Sprintf("%s AS %s", table, alias)
+	}
+}
+
+func (s *postgresDashboardStorage) FromUnixTimestamp() string {
+	return "TO_TIMESTAMP(?)"
+}
+
+func (s *postgresDashboardStorage) LatestChartTsQuery(table string, dateColumn string) string {
+	return fmt.Sprintf("SELECT MAX(t.%s) FROM %s", dateColumn, s.ChartTable(table, "t"))
+}
+
+// the cl rewards of the blocks are exported to the consensus payloads by the indexer
+func (s *postgresDashboardStorage) ProposalClRewardsQuery() string {
+	return "SELECT slot, cl_attestations_reward / 1e9 + cl_sync_aggregate_reward / 1e9 + cl_slashing_inclusion_reward / 1e9 AS cl_reward FROM consensus_payloads"
+}
+
+// Tables of the postgres storage that are not written by the exporter's aggregators
+const (
+	postgresRolling1hTable     = "validator_dashboard_data_rolling_1h"
+	postgresLastScheduledTable = "validator_dashboard_data_last_scheduled"
+)
+
+// columns of the epoch table that are summed up in the 1h window
+var postgresRolling1hSumColumns = []string{
+	"attestations_source_reward", "attestations_target_reward", "attestations_head_reward", "attestations_inactivity_reward",
+	"attestations_inclusion_reward", "attestations_reward", "attestations_ideal_source_reward", "attestations_ideal_target_reward",
+	"attestations_ideal_head_reward", "attestations_ideal_inactivity_reward", "attestations_ideal_inclusion_reward", "attestations_ideal_reward",
+	"blocks_scheduled", "blocks_proposed", "blocks_cl_reward", "blocks_cl_attestations_reward", "blocks_cl_sync_aggregate_reward", "blocks_el_reward",
+	"sync_scheduled", "sync_executed", "sync_rewards", "deposits_count", "deposits_amount", "withdrawals_count", "withdrawals_amount",
+	"inclusion_delay_sum", "blocks_expected", "sync_committees_expected", "attestations_scheduled", "attestations_executed",
+	"attestation_head_executed", "attestation_source_executed", "attestation_target_executed", "optimal_inclusion_delay_sum", "slasher_reward",
+}
+
+// columns of the epoch table of which the latest value is kept in the 1h window
+var postgresRolling1hMaxColumns = []string{"slashed_by", "slashed_violation", "last_executed_duty_epoch"}
+
+// postgresRolling1hQuery replaces the 1h window with the epochs [epochStart, epochEnd) of the epoch table
+func postgresRolling1hQuery() string {
+	columns := []string{"validator_index", "epoch_start", "epoch_end", "balance_start", "balance_end", "slashed"}
+	values := []string{
+		"validator_index",
+		"$1",
+		"$2",
+		"(ARRAY_AGG(balance_start ORDER BY epoch ASC))[1]",
+		"(ARRAY_AGG(balance_end ORDER BY epoch DESC))[1]",
+		"BOOL_OR(slashed)",
+	}
+	for _, column := range postgresRolling1hSumColumns {
+		columns = append(columns, column)
+		values = append(values, fmt.Sprintf("COALESCE(SUM(%s), 0)", column))
+	}
+	for _, column := range postgresRolling1hMaxColumns {
+		columns = append(columns, column)
+		values = append(values, fmt.Sprintf("MAX(%s)", column))
+	}
+	return fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		SELECT %s
+		FROM validator_dashboard_data_epoch
+		WHERE epoch >= $1 AND epoch < $2
+		GROUP BY validator_index`,
+		postgresRolling1hTable, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+// the epochs still in the epoch table are scanned each time, they overlap between runs so no epoch is missed
+var postgresLastScheduledQuery = fmt.Sprintf(`
+	INSERT INTO %[1]s (validator_index, last_scheduled_block_epoch, last_scheduled_sync_epoch)
+	SELECT
+		validator_index,
+		MAX(epoch) FILTER (WHERE blocks_scheduled > 0),
+		MAX(epoch) FILTER (WHERE sync_scheduled > 0)
+	FROM validator_dashboard_data_epoch
+	WHERE epoch <= $1 AND (blocks_scheduled > 0 OR sync_scheduled > 0)
+	GROUP BY validator_index
+	ON CONFLICT (validator_index) DO UPDATE SET
+		last_scheduled_block_epoch = GREATEST(%[1]s.last_scheduled_block_epoch, EXCLUDED.last_scheduled_block_epoch),
+		last_scheduled_sync_epoch = GREATEST(%[1]s.last_scheduled_sync_epoch, EXCLUDED.last_scheduled_sync_epoch)`,
+	postgresLastScheduledTable)
+
+// getRolling1hBounds returns the epochs of the 1h window ending with headEpoch, start inclusive and end exclusive
+func getRolling1hBounds(headEpoch uint64) (uint64, uint64) {
+	width := utils.EpochsPerDay() / 24
+	if headEpoch+1 < width {
+		return 0, headEpoch + 1
+	}
+	return headEpoch + 1 - width, headEpoch + 1
+}
+
+// WriteAggregates writes the 1h window, which is too short for the exporter's day based rolling tables,
+// and the last epochs the validators were scheduled for block proposals and sync committees
+func (s *postgresDashboardStorage) WriteAggregates(headEpoch uint64) error {
+	tx, err := s.writer.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction to write dashboard aggregates: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	epochStart, epochEnd := getRolling1hBounds(headEpoch)
+	_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s", postgresRolling1hTable))
+	if err != nil {
+		return fmt.Errorf("error clearing the 1h window: %w", err)
+	}
+	_, err = tx.Exec(postgresRolling1hQuery(), epochStart, epochEnd)
+	if err != nil {
+		return fmt.Errorf("error writing the 1h window: %w", err)
+	}
+
+	_, err = tx.Exec(postgresLastScheduledQuery, headEpoch)
+	if err != nil {
+		return fmt.Errorf("error writing the last scheduled duties: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to write dashboard aggregates: %w", err)
+	}
+	return nil
+}
+
+// NewDashboardAggregateStorage returns the storage selected by the dashboardStorage config, ClickHouse is the default.
+// Connections that are not needed by a caller may be nil, the api does not write and the exporter does not read.
+func NewDashboardAggregateStorage(storage string, clickhouseReader *sqlx.DB, alloyReader *sqlx.DB, alloyWriter *sqlx.DB) (DashboardAggregateStorage, error) {
+	switch storage {
+	case "", DashboardStorageClickHouse:
+		return &clickhouseDashboardStorage{reader: clickhouseReader}, nil
+	case DashboardStoragePostgres:
+		return &postgresDashboardStorage{reader: alloyReader, writer: alloyWriter}, nil
+	default:
+		return nil, fmt.Errorf("unknown dashboard storage %q, supported are %q and %q", storage, DashboardStorageClickHouse, DashboardStoragePostgres)
+	}
+}
+
+// Uint64Array scans arrays of both storages, ClickHouse returns them as []uint64 while Postgres returns their text representation
+type Uint64Array []uint64
+
+func (a *Uint64Array) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []uint64:
+		*a = v
+		return nil
+	}
+
+	var values pq.Int64Array
+	err := values.Scan(src)
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into Uint64Array: %w", src, err)
+	}
+	result := make(Uint64Array, len(values))
+	for i, value := range values {
+		result[i] = uint64(value)
+	}
+	*a = result
+	return nil
+}
@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// recordingDriver records the statements executed on its connections, queries are not supported
+type recordingDriver struct {
+	mutex      sync.Mutex
+	statements []recordedStatement
+	commits    int
+	rollbacks  int
+}
+
+type recordedStatement struct {
+	query string
+	args  []driver.Value
+}
+
+type recordingConn struct{ driver *recordingDriver }
+type recordingTx struct{ driver *recordingDriver }
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return &recordingTx{driver: c.driver}, nil
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	c.driver.mutex.Lock()
+	defer c.driver.mutex.Unlock()
+	c.driver.statements = append(c.driver.statements, recordedStatement{query: query, args: values})
+	return driver.RowsAffected(0), nil
+}
+
+func (tx *recordingTx) Commit() error {
+	tx.driver.mutex.Lock()
+	defer tx.driver.mutex.Unlock()
+	tx.driver.commits++
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	tx.driver.mutex.Lock()
+	defer tx.driver.mutex.Unlock()
+	tx.driver.rollbacks++
+	return nil
+}
+
+type recordingConnector struct{ driver *recordingDriver }
+
+func (c *recordingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func newRecordingDB() (*sqlx.DB, *recordingDriver) {
+	d := &recordingDriver{}
+	return sqlx.NewDb(sql.OpenDB(&recordingConnector{driver: d}), "postgres"), d
+}
+
+func setDashboardStorageTestConfig(t *testing.T) {
+	previousConfig := utils.Config
+	t.Cleanup(func() { utils.Config = previousConfig })
+	utils.Config = &types.Config{}
+	utils.Config.Chain.GenesisTimestamp = 1606824023
+	utils.Config.Chain.ClConfig.SecondsPerSlot = 12
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+}
+
+func TestNewDashboardAggregateStorage(t *testing.T) {
+	clickhouseReader, _ := newRecordingDB()
+	alloyReader, _ := newRecordingDB()
+
+	for _, storage := range []string{"", DashboardStorageClickHouse} {
+		s, err := NewDashboardAggregateStorage(storage, clickhouseReader, alloyReader, nil)
+		if err != nil {
+			t.Fatalf("storage %q: unexpected error: %v", storage, err)
+		}
+		if s.Reader() != clickhouseReader {
+			t.Errorf("storage %q: expected the clickhouse reader", storage)
+		}
+	}
+
+	s, err := NewDashboardAggregateStorage(DashboardStoragePostgres, clickhouseReader, alloyReader, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Reader() != alloyReader {
+		t.Errorf("expected the alloy reader")
+	}
+
+	if _, err := NewDashboardAggregateStorage("mysql", clickhouseReader, alloyReader, nil); err == nil {
+		t.Errorf("expected an error for an unknown storage")
+	}
+}
+
+func TestDashboardStorageTables(t *testing.T) {
+	setDashboardStorageTestConfig(t)
+	clickhouse := &clickhouseDashboardStorage{}
+	postgres := &postgresDashboardStorage{}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"clickhouse rolling", clickhouse.RollingTable("validator_dashboard_data_rolling_1h", "r"), "validator_dashboard_data_rolling_1h AS r FINAL"},
+		{"clickhouse rolling without alias", clickhouse.RollingTable("validator_dashboard_data_rolling_total", ""), "validator_dashboard_data_rolling_total FINAL"},
+		{"postgres rolling", postgres.RollingTable("validator_dashboard_data_rolling_1h", "r"), "dashboard_api.validator_dashboard_data_rolling_1h AS r"},
+		{"postgres rolling without alias", postgres.RollingTable("validator_dashboard_data_rolling_total", ""), "dashboard_api.validator_dashboard_data_rolling_total"},
+		{"clickhouse chart", clickhouse.ChartTable("validator_dashboard_data_weekly", "d"), "validator_dashboard_data_weekly AS d"},
+		{"postgres epoch chart", postgres.ChartTable("validator_dashboard_data_epoch", "d"), "(SELECT t.*, TO_TIMESTAMP(1606824023 + t.epoch * 384) AS epoch_timestamp, t.slasher_reward AS blocks_cl_slasher_reward, COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count " +
+			"FROM validator_dashboard_data_epoch t LEFT JOIN (SELECT epoch, slashed_by, COUNT(*) AS slashing_count FROM validator_dashboard_data_epoch WHERE slashed_by IS NOT NULL GROUP BY epoch, slashed_by) s " +
+			"ON s.epoch = t.epoch AND s.slashed_by = t.validator_index) AS d"},
+		{"postgres hourly chart", postgres.ChartTable("validator_dashboard_data_hourly", "d"), "(SELECT t.*, DATE_TRUNC('hour', TO_TIMESTAMP(1606824023 + t.epoch_start * 384)) AS hour FROM validator_dashboard_data_hourly t) AS d"},
+		{"postgres daily chart", postgres.ChartTable("validator_dashboard_data_daily", "d"), "validator_dashboard_data_daily AS d"},
+		{"postgres weekly chart", postgres.ChartTable("validator_dashboard_data_weekly", "d"), "(SELECT t.*, DATE_TRUNC('week', t.day) AS week FROM validator_dashboard_data_daily t) AS d"},
+		{"clickhouse timestamp", clickhouse.FromUnixTimestamp(), "fromUnixTimestamp(?)"},
+		{"postgres timestamp", postgres.FromUnixTimestamp(), "TO_TIMESTAMP(?)"},
+		{"clickhouse latest chart ts", clickhouse.LatestChartTsQuery("validator_dashboard_data_daily", "day"), "SELECT max(t) FROM view_validator_dashboard_data_daily_max_ts"},
+		{"postgres latest chart ts", postgres.LatestChartTsQuery("validator_dashboard_data_daily", "day"), "SELECT MAX(t.day) FROM validator_dashboard_data_daily AS t"},
+		{"clickhouse proposal cl rewards", clickhouse.ProposalClRewardsQuery(), "SELECT slot, attestations_reward / 1e9 + sync_aggregate_reward / 1e9 + slasher_reward / 1e9 AS cl_reward FROM mainnet.validator_proposal_rewards_slot"},
+		{"postgres proposal cl rewards", postgres.ProposalClRewardsQuery(), "SELECT slot, cl_attestations_reward / 1e9 + cl_sync_aggregate_reward / 1e9 + cl_slashing_inclusion_reward / 1e9 AS cl_reward FROM consensus_payloads"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRolling1hBounds(t *testing.T) {
+	setDashboardStorageTestConfig(t)
+	// 225 epochs per day, the window spans 9 epochs
+	tests := []struct {
+		head  uint64
+		start uint64
+		end   uint64
+	}{
+		{0, 0, 1},
+		{7, 0, 8},
+		{8, 0, 9},
+		{9, 1, 10},
+		{1000, 992, 1001},
+	}
+	for _, tt := range tests {
+		start, end := getRolling1hBounds(tt.head)
+		if start != tt.start || end != tt.end {
+			t.Errorf("head %d: got [%d, %d), want [%d, %d)", tt.head, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestClickhouseDashboardStorageWriteAggregates(t *testing.T) {
+	// the clickhouse tables are not written by the exporter, there is no writer
+	s, err := NewDashboardAggregateStorage(DashboardStorageClickHouse, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.WriteAggregates(1000); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPostgresDashboardStorageWriteAggregates(t *testing.T) {
+	setDashboardStorageTestConfig(t)
+	writer, recorder := newRecordingDB()
+	s, err := NewDashboardAggregateStorage(DashboardStoragePostgres, nil, nil, writer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.WriteAggregates(1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if recorder.commits != 1 {
+		t.Errorf("expected 1 commit, got %d", recorder.commits)
+	}
+	if len(recorder.statements) != 3 {
+		t.Fatalf("expected 3 statements, got %d", len(recorder.statements))
+	}
+
+	deleteRolling := recorder.statements[0]
+	if deleteRolling.query != "DELETE FROM validator_dashboard_data_rolling_1h" {
+		t.Errorf("unexpected clear query: %s", deleteRolling.query)
+	}
+
+	rolling := recorder.statements[1]
+	if !strings.Contains(rolling.query, "INSERT INTO validator_dashboard_data_rolling_1h") || !strings.Contains(rolling.query, "WHERE epoch >= $1 AND epoch < $2") {
+		t.Errorf("unexpected rolling 1h query: %s", rolling.query)
+	}
+	for _, column := range append(append([]string{}, postgresRolling1hSumColumns...), postgresRolling1hMaxColumns...) {
+		if !strings.Contains(rolling.query, column) {
+			t.Errorf("rolling 1h query misses column %s", column)
+		}
+	}
+	if fmt.Sprint(rolling.args) != "[992 1001]" {
+		t.Errorf("unexpected rolling 1h args: %v", rolling.args)
+	}
+
+	lastScheduled := recorder.statements[2]
+	if !strings.Contains(lastScheduled.query, "INSERT INTO validator_dashboard_data_last_scheduled") || !strings.Contains(lastScheduled.query, "ON CONFLICT (validator_index) DO UPDATE") {
+		t.Errorf("unexpected last scheduled query: %s", lastScheduled.query)
+	}
+	if fmt.Sprint(lastScheduled.args) != "[1000]" {
+		t.Errorf("unexpected last scheduled args: %v", lastScheduled.args)
+	}
+}
+
+func TestUint64ArrayScan(t *testing.T) {
+	var a Uint64Array
+	if err := a.Scan([]uint64{3, 1, 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(a) != "[3 1 2]" {
+		t.Errorf("unexpected clickhouse array: %v", a)
+	}
+
+	if err := a.Scan([]byte("{5,4}")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(a) != "[5 4]" {
+		t.Errorf("unexpected postgres array: %v", a)
+	}
+
+	if err := a.Scan(nil); err != nil || a != nil {
+		t.Errorf("expected a nil array, got %v (%v)", a, err)
+	}
+}
@@ -1401,6 +1401,112 @@ func GetEpochWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error)
 	return withdrawals, nil
 }
 
+// WithdrawalRequestMatchesQuery returns a query that matches the canonical withdrawal requests (EIP-7002) of the validators selected by requestFilter
+// to the canonical withdrawal that processed them. A partial withdrawal request is processed by the first withdrawal which is capped to the
+// excess balance of the validator once MIN_VALIDATOR_WITHDRAWABILITY_DELAY epochs have passed since the request, a full exit by the first
+// withdrawal once the validator is withdrawable. Requests of the same block are processed in order and a withdrawal is matched to one request only.
+// The query returns request_slot, request_index, request_amount and the matched withdrawal as block_slot, withdrawalindex, validatorindex, address, amount and pubkey.
+func WithdrawalRequestMatchesQuery(requestFilter string) string {
+	return fmt.Sprintf(`
+	SELECT DISTINCT ON (w.withdrawalindex)
+		wr.block_slot AS request_slot,
+		wr.block_index AS request_index,
+		wr.amount AS request_amount,
+		w.block_slot,
+		w.withdrawalindex,
+		w.validatorindex,
+		w.address,
+		w.amount,
+		v.pubkey
+	FROM (
+		SELECT
+			wr.block_slot,
+			wr.block_index,
+			wr.validator_pubkey,
+			wr.amount,
+			ROW_NUMBER() OVER (PARTITION BY wr.validator_pubkey, wr.block_slot, wr.amount = 0 ORDER BY wr.block_index) AS block_rank
+		FROM blocks_withdrawal_requests wr
+		INNER JOIN blocks rb ON rb.slot = wr.block_slot AND rb.blockroot = wr.block_root AND rb.status = '1'
+		WHERE %[1]s
+	) wr
+	INNER JOIN validators v ON v.pubkey = wr.validator_pubkey
+	INNER JOIN LATERAL (
+		SELECT w.block_slot, w.withdrawalindex, w.validatorindex, w.address, w.amount
+		FROM blocks_withdrawals w
+		INNER JOIN blocks b ON b.slot = w.block_slot AND b.blockroot = w.block_root AND b.status = '1'
+		WHERE w.validatorindex = v.validatorindex AND w.block_slot > wr.block_slot AND (
+			(wr.amount > 0 AND w.amount <= wr.amount AND w.block_slot / %[2]d >= wr.block_slot / %[2]d + %[3]d) OR
+			(wr.amount = 0 AND w.block_slot / %[2]d >= v.withdrawableepoch)
+		)
+		ORDER BY w.block_slot, w.withdrawalindex
+		OFFSET wr.block_rank - 1 LIMIT 1
+	) w ON TRUE
+	ORDER BY w.withdrawalindex, wr.block_slot, wr.block_index`, requestFilter, utils.Config.Chain.ClConfig.SlotsPerEpoch, utils.Config.Chain.ClConfig.MinValidatorWithdrawabilityDelay)
+}
+
+// GetEpochPartialWithdrawals returns the withdrawals of the epoch that processed an execution layer partial withdrawal request (EIP-7002)
+func GetEpochPartialWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error) {
+	var withdrawals []*types.WithdrawalsNotification
+
+	query, args := getEpochPartialWithdrawalsQuery(epoch)
+	err := ReaderDb.Select(&withdrawals, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting partial withdrawals for epoch: %d: %w", epoch, err)
+	}
+
+	return withdrawals, nil
+}
+
+func getEpochPartialWithdrawalsQuery(epoch uint64) (string, []any) {
+	// only the requests of validators that got a withdrawal in the epoch can match one of its withdrawals
+	return `
+	SELECT
+		m.block_slot as slot,
+		m.withdrawalindex as index,
+		m.validatorindex,
+		m.address,
+		m.amount,
+		m.pubkey
+	FROM (` + WithdrawalRequestMatchesQuery(`EXISTS (
+			SELECT 1 FROM blocks_withdrawals ew
+			INNER JOIN validators ev ON ev.validatorindex = ew.validatorindex
+			WHERE ev.pubkey = wr.validator_pubkey AND ew.block_slot >= $1 AND ew.block_slot < $2
+		)`) + `
+	) m
+	WHERE m.request_amount > 0 AND m.block_slot >= $1 AND m.block_slot < $2 ORDER BY m.withdrawalindex`, []any{epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch, (epoch + 1) * utils.Config.Chain.ClConfig.SlotsPerEpoch}
+}
+
+// GetEpochConsolidations returns the consolidation requests (EIP-7251) of the source validators that become withdrawable in the epoch.
+// A request is only processed if the beacon chain accepted it, callers have to confirm this with the pending consolidations of the beacon state.
+func GetEpochConsolidations(epoch uint64) ([]*types.ConsolidationsNotification, error) {
+	var consolidations []*types.ConsolidationsNotification
+
+	err := ReaderDb.Select(&consolidations, `
+	SELECT DISTINCT ON (vs.validatorindex)
+		cr.block_slot as slot,
+		vs.validatorindex as source_validatorindex,
+		vs.pubkey as source_pubkey,
+		vt.validatorindex as target_validatorindex,
+		vt.pubkey as target_pubkey
+	FROM blocks_consolidation_requests cr
+	INNER JOIN blocks b ON b.blockroot = cr.block_root AND b.status = '1'
+	INNER JOIN validators vs ON vs.pubkey = cr.source_pubkey
+	INNER JOIN validators vt ON vt.pubkey = cr.target_pubkey
+	WHERE cr.source_pubkey != cr.target_pubkey AND vs.withdrawableepoch = $1 AND cr.block_slot / $2 < vs.exitepoch
+	ORDER BY vs.validatorindex, cr.block_slot DESC`, epoch, utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting consolidations for epoch: %d: %w", epoch, err)
+	}
+
+	return consolidations, nil
+}
+
 func GetValidatorWithdrawals(validator uint64, limit uint64, offset uint64, orderBy string, orderDir string) ([]*types.Withdrawals, error) {
 	var withdrawals []*types.Withdrawals
 	if limit == 0 {
@@ -2034,7 +2140,8 @@ func GetWithdrawableValidatorCount(epoch uint64) (uint64, error) {
         WHERE DAY = (SELECT COALESCE(MAX(day), 0) FROM validator_stats_status)) as stats
 	ON stats.validatorindex = validators.validatorindex
 	WHERE
-		validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND ((stats.end_effective_balance = $1 AND stats.end_balance > $1) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0));`, utils.Config.Chain.ClConfig.MaxEffectiveBalance, epoch)
+		(validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND ((stats.end_effective_balance = $1 AND stats.end_balance > $1) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0))) OR
+		(validators.withdrawalcredentials LIKE '\x02' || '%'::bytea AND ((stats.end_effective_balance = $3 AND stats.end_balance > $3) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0)));`, utils.Config.Chain.ClConfig.MinActivationBalance, epoch, utils.Config.Chain.ClConfig.MaxEffectiveBalanceElectra)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
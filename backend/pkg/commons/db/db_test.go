@@ -0,0 +1,57 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestWithdrawalRequestMatchesQuery(t *testing.T) {
+	setDashboardStorageTestConfig(t)
+	utils.Config.Chain.ClConfig.MinValidatorWithdrawabilityDelay = 256
+	query := WithdrawalRequestMatchesQuery("wr.validator_pubkey = ANY ($1)")
+
+	for _, fragment := range []string{
+		// requests and withdrawals of orphaned blocks are ignored
+		"INNER JOIN blocks rb ON rb.slot = wr.block_slot AND rb.blockroot = wr.block_root AND rb.status = '1'",
+		"INNER JOIN blocks b ON b.slot = w.block_slot AND b.blockroot = w.block_root AND b.status = '1'",
+		"WHERE wr.validator_pubkey = ANY ($1)",
+		// partial withdrawals are capped to the excess balance and processed after the withdrawability delay,
+		// exits are processed once the validator is withdrawable
+		"(wr.amount > 0 AND w.amount <= wr.amount AND w.block_slot / 32 >= wr.block_slot / 32 + 256)",
+		"(wr.amount = 0 AND w.block_slot / 32 >= v.withdrawableepoch)",
+		// the n-th request of a block takes the n-th withdrawal after it
+		"ROW_NUMBER() OVER (PARTITION BY wr.validator_pubkey, wr.block_slot, wr.amount = 0 ORDER BY wr.block_index) AS block_rank",
+		"ORDER BY w.block_slot, w.withdrawalindex\n\t\tOFFSET wr.block_rank - 1 LIMIT 1",
+		// a withdrawal matches the earliest request only
+		"SELECT DISTINCT ON (w.withdrawalindex)",
+		"ORDER BY w.withdrawalindex, wr.block_slot, wr.block_index",
+	} {
+		if !strings.Contains(query, fragment) {
+			t.Errorf("query misses %q", fragment)
+		}
+	}
+	if strings.Contains(query, "w.amount = wr.amount") {
+		t.Errorf("query must not require the requested amount to be withdrawn")
+	}
+}
+
+func TestGetEpochPartialWithdrawalsQuery(t *testing.T) {
+	setDashboardStorageTestConfig(t)
+
+	query, args := getEpochPartialWithdrawalsQuery(10)
+	if fmt.Sprint(args) != "[320 352]" {
+		t.Errorf("unexpected args: %v", args)
+	}
+	for _, fragment := range []string{
+		"WHERE m.request_amount > 0 AND m.block_slot >= $1 AND m.block_slot < $2",
+		"WHERE ev.pubkey = wr.validator_pubkey AND ew.block_slot >= $1 AND ew.block_slot < $2",
+		"OFFSET wr.block_rank - 1 LIMIT 1",
+	} {
+		if !strings.Contains(query, fragment) {
+			t.Errorf("query misses %q", fragment)
+		}
+	}
+}
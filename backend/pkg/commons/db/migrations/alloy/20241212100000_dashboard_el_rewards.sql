@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add el rewards to the validator dashboard tables';
+ALTER TABLE validator_dashboard_data_epoch ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_hourly ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_daily ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_rolling_daily ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_rolling_weekly ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_rolling_monthly ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_rolling_90d ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+ALTER TABLE validator_dashboard_data_rolling_total ADD COLUMN IF NOT EXISTS blocks_el_reward BIGINT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - remove el rewards from the validator dashboard tables';
+ALTER TABLE validator_dashboard_data_epoch DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_hourly DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_daily DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_rolling_daily DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_rolling_weekly DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_rolling_monthly DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_rolling_90d DROP COLUMN IF EXISTS blocks_el_reward;
+ALTER TABLE validator_dashboard_data_rolling_total DROP COLUMN IF EXISTS blocks_el_reward;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add checkpoint table for dashboard data reprocessing';
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_reprocess_progress (
+    start_epoch BIGINT NOT NULL,
+    end_epoch BIGINT NOT NULL,
+    next_epoch BIGINT NOT NULL, -- first epoch of the next utc day to reprocess
+    started_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (start_epoch, end_epoch)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop checkpoint table for dashboard data reprocessing';
+DROP TABLE IF EXISTS validator_dashboard_data_reprocess_progress;
+-- +goose StatementEnd
@@ -0,0 +1,113 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add clickhouse compatible rolling views for the postgres dashboard storage';
+-- The api reads the rolling aggregates from these views when dashboardStorage is set to postgres.
+-- They carry the names and the derived columns of the clickhouse rolling tables:
+--   blocks_slashing_count is the number of validators slashed by the validator within the window
+--   last_scheduled_block_epoch and last_scheduled_sync_epoch are not tracked by the exporter and always NULL
+-- The 1h window is served by the most recent hour of the hourly table.
+CREATE SCHEMA IF NOT EXISTS dashboard_api;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_1h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_hourly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_hourly
+        WHERE slashed_by IS NOT NULL AND epoch_start = (SELECT MAX(epoch_start) FROM validator_dashboard_data_hourly)
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    WHERE r.epoch_start = (SELECT MAX(epoch_start) FROM validator_dashboard_data_hourly);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_24h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_daily r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_daily
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_7d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_weekly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_weekly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_30d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_monthly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_monthly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_90d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_90d r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_90d
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW dashboard_api.validator_dashboard_data_rolling_total AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_total r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_total
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop clickhouse compatible rolling views for the postgres dashboard storage';
+DROP SCHEMA IF EXISTS dashboard_api CASCADE;
+-- +goose StatementEnd
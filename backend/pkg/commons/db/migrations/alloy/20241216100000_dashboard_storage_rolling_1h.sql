@@ -0,0 +1,240 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add the rolling 1h window and the last scheduled duties to the postgres dashboard storage';
+-- Both are written by the dashboard data exporter after each epoch when dashboardStorage is set to postgres:
+--   validator_dashboard_data_rolling_1h holds the sums of the epochs of the last hour, replaced on each write
+--   validator_dashboard_data_last_scheduled holds the last epochs a validator was scheduled for a block proposal or a sync committee
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_rolling_1h (LIKE validator_dashboard_data_rolling_daily INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING INDEXES);
+CREATE TABLE IF NOT EXISTS validator_dashboard_data_last_scheduled (
+    validator_index INT NOT NULL PRIMARY KEY,
+    last_scheduled_block_epoch BIGINT,
+    last_scheduled_sync_epoch BIGINT
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_1h;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_24h;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_7d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_30d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_90d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_total;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_1h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_1h r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_1h
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_24h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_daily r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_daily
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_7d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_weekly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_weekly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_30d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_monthly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_monthly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_90d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_90d r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_90d
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_total AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        l.last_scheduled_block_epoch,
+        l.last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_total r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_total
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    LEFT JOIN validator_dashboard_data_last_scheduled l ON l.validator_index = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - restore the rolling views without the rolling 1h window and the last scheduled duties';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_1h;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_24h;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_7d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_30d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_90d;
+DROP VIEW IF EXISTS dashboard_api.validator_dashboard_data_rolling_total;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_1h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_hourly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_hourly
+        WHERE slashed_by IS NOT NULL AND epoch_start = (SELECT MAX(epoch_start) FROM validator_dashboard_data_hourly)
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index
+    WHERE r.epoch_start = (SELECT MAX(epoch_start) FROM validator_dashboard_data_hourly);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_24h AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_daily r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_daily
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_7d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_weekly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_weekly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_30d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_monthly r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_monthly
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_90d AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_90d r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_90d
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE VIEW dashboard_api.validator_dashboard_data_rolling_total AS
+    SELECT
+        r.*,
+        COALESCE(s.slashing_count, 0)::INT AS blocks_slashing_count,
+        NULL::BIGINT AS last_scheduled_block_epoch,
+        NULL::BIGINT AS last_scheduled_sync_epoch
+    FROM validator_dashboard_data_rolling_total r
+    LEFT JOIN (
+        SELECT slashed_by, COUNT(*) AS slashing_count
+        FROM validator_dashboard_data_rolling_total
+        WHERE slashed_by IS NOT NULL
+        GROUP BY slashed_by
+    ) s ON s.slashed_by = r.validator_index;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS validator_dashboard_data_last_scheduled;
+DROP TABLE IF EXISTS validator_dashboard_data_rolling_1h;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS validator_liveness_states (
+    validatorindex INT NOT NULL,
+    is_offline BOOLEAN NOT NULL,
+    state_since_epoch INT NOT NULL,
+    window_start_epoch INT NOT NULL,
+    window_transitions INT NOT NULL DEFAULT 0,
+    is_unstable BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (validatorindex)
+);
+
+CREATE INDEX IF NOT EXISTS validator_liveness_states_is_unstable_idx ON validator_liveness_states (is_unstable) WHERE is_unstable;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS validator_liveness_states_is_unstable_idx;
+DROP TABLE IF EXISTS validator_liveness_states;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'create users_val_dashboards_notification_rules table';
+CREATE TABLE IF NOT EXISTS users_val_dashboards_notification_rules (
+    id                   BIGSERIAL   NOT NULL,
+    dashboard_id         BIGINT      NOT NULL,
+    group_id             SMALLINT    NOT NULL,
+    name                 VARCHAR(50) NOT NULL,
+    metric               TEXT        NOT NULL,
+    comparison           TEXT        NOT NULL,
+    threshold            DOUBLE PRECISION NOT NULL,
+    window_epochs        INT         NOT NULL DEFAULT 1,
+    cooldown_epochs      INT         NOT NULL DEFAULT 0,
+    last_triggered_epoch INT,
+    created_at           TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    foreign key (dashboard_id, group_id) references users_val_dashboards_groups(dashboard_id, id) ON DELETE CASCADE,
+    primary key (id)
+);
+CREATE INDEX IF NOT EXISTS users_val_dashboards_notification_rules_dashboard_id_group_id_idx ON users_val_dashboards_notification_rules (dashboard_id, group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop users_val_dashboards_notification_rules table';
+DROP INDEX IF EXISTS users_val_dashboards_notification_rules_dashboard_id_group_id_idx;
+DROP TABLE IF EXISTS users_val_dashboards_notification_rules;
+-- +goose StatementEnd
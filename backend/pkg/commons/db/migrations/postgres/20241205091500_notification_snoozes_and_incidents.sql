@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'create users_val_dashboards_notification_snoozes table';
+CREATE TABLE IF NOT EXISTS users_val_dashboards_notification_snoozes (
+    id            BIGSERIAL NOT NULL,
+    dashboard_id  BIGINT    NOT NULL,
+    group_id      SMALLINT  NOT NULL,
+    event_type    TEXT, -- NULL snoozes all events of the group
+    snoozed_until TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_at    TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    foreign key (dashboard_id, group_id) references users_val_dashboards_groups(dashboard_id, id) ON DELETE CASCADE,
+    primary key (id)
+);
+CREATE INDEX IF NOT EXISTS users_val_dashboards_notification_snoozes_dashboard_id_group_id_idx ON users_val_dashboards_notification_snoozes (dashboard_id, group_id);
+CREATE INDEX IF NOT EXISTS users_val_dashboards_notification_snoozes_snoozed_until_idx ON users_val_dashboards_notification_snoozes (snoozed_until);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create users_val_dashboards_notification_incidents table';
+CREATE TABLE IF NOT EXISTS users_val_dashboards_notification_incidents (
+    id              BIGSERIAL NOT NULL,
+    dashboard_id    BIGINT    NOT NULL,
+    group_id        SMALLINT  NOT NULL,
+    event_type      TEXT      NOT NULL, -- the event that opened the incident
+    entity_id       TEXT      NOT NULL, -- e.g. the validator index
+    status          TEXT      NOT NULL DEFAULT 'open',
+    opened_epoch    INT       NOT NULL,
+    resolved_epoch  INT,
+    acknowledged_at TIMESTAMP WITHOUT TIME ZONE,
+    foreign key (dashboard_id, group_id) references users_val_dashboards_groups(dashboard_id, id) ON DELETE CASCADE,
+    primary key (id)
+);
+-- there can only be one open incident per entity and event
+CREATE UNIQUE INDEX IF NOT EXISTS users_val_dashboards_notification_incidents_open_idx ON users_val_dashboards_notification_incidents (dashboard_id, group_id, event_type, entity_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS users_val_dashboards_notification_incidents_dashboard_id_opened_epoch_idx ON users_val_dashboards_notification_incidents (dashboard_id, opened_epoch);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop users_val_dashboards_notification_incidents and users_val_dashboards_notification_snoozes tables';
+DROP INDEX IF EXISTS users_val_dashboards_notification_incidents_dashboard_id_opened_epoch_idx;
+DROP INDEX IF EXISTS users_val_dashboards_notification_incidents_open_idx;
+DROP TABLE IF EXISTS users_val_dashboards_notification_incidents;
+DROP INDEX IF EXISTS users_val_dashboards_notification_snoozes_snoozed_until_idx;
+DROP INDEX IF EXISTS users_val_dashboards_notification_snoozes_dashboard_id_group_id_idx;
+DROP TABLE IF EXISTS users_val_dashboards_notification_snoozes;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add api key management columns to api_keys';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS id BIGSERIAL;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS label VARCHAR(50) NOT NULL DEFAULT '';
+-- existing keys get all scopes that exist at this point, later scopes are granted to them by 20241217100000_api_key_scopes_backfill
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS scopes TEXT[] NOT NULL DEFAULT '{network:read,dashboards:manage,notifications:manage}';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_id ON api_keys (id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop api key management columns from api_keys';
+DROP INDEX IF EXISTS idx_api_keys_user_id;
+DROP INDEX IF EXISTS idx_api_keys_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS created_at;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS label;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS id;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add client secret to oauth_apps';
+-- apps without secret are public clients and can only use the authorization code flow with PKCE
+ALTER TABLE oauth_apps ADD COLUMN IF NOT EXISTS client_secret VARCHAR(64);
+CREATE INDEX IF NOT EXISTS idx_oauth_apps_owner_id ON oauth_apps (owner_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'add pkce and scopes to oauth_codes';
+ALTER TABLE oauth_codes ADD COLUMN IF NOT EXISTS redirect_uri VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE oauth_codes ADD COLUMN IF NOT EXISTS scopes TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE oauth_codes ADD COLUMN IF NOT EXISTS code_challenge VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE oauth_codes ADD COLUMN IF NOT EXISTS code_challenge_method VARCHAR(10) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_oauth_codes_code ON oauth_codes (code);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'add scopes to users_devices';
+-- NULL for mobile app sessions, which are not limited by scopes
+ALTER TABLE users_devices ADD COLUMN IF NOT EXISTS scopes TEXT[];
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop scopes from users_devices';
+ALTER TABLE users_devices DROP COLUMN IF EXISTS scopes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop pkce and scopes from oauth_codes';
+DROP INDEX IF EXISTS idx_oauth_codes_code;
+ALTER TABLE oauth_codes DROP COLUMN IF EXISTS code_challenge_method;
+ALTER TABLE oauth_codes DROP COLUMN IF EXISTS code_challenge;
+ALTER TABLE oauth_codes DROP COLUMN IF EXISTS scopes;
+ALTER TABLE oauth_codes DROP COLUMN IF EXISTS redirect_uri;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop client secret from oauth_apps';
+DROP INDEX IF EXISTS idx_oauth_apps_owner_id;
+ALTER TABLE oauth_apps DROP COLUMN IF EXISTS client_secret;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'create users_2fa table';
+CREATE TABLE IF NOT EXISTS users_2fa (
+    user_id INT NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret VARCHAR(64) NOT NULL,
+    -- the secret is only enabled after the user confirmed the enrolment with a valid code
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    -- time step of the last accepted code, codes can't be used twice
+    last_used_counter BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create users_2fa_recovery_codes table';
+CREATE TABLE IF NOT EXISTS users_2fa_recovery_codes (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop users_2fa_recovery_codes table';
+DROP TABLE IF EXISTS users_2fa_recovery_codes;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop users_2fa table';
+DROP TABLE IF EXISTS users_2fa;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add activity to users_devices';
+ALTER TABLE users_devices ADD COLUMN IF NOT EXISTS last_used_ts TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE users_devices ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+ALTER TABLE users_devices ADD COLUMN IF NOT EXISTS user_agent VARCHAR(500);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create users_security_events table';
+CREATE TABLE IF NOT EXISTS users_security_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_users_security_events_user_id_created_at ON users_security_events (user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop users_security_events table';
+DROP TABLE IF EXISTS users_security_events;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop activity from users_devices';
+ALTER TABLE users_devices DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE users_devices DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE users_devices DROP COLUMN IF EXISTS last_used_ts;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'create organizations table';
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create organization_members table';
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'add organization_id to users_val_dashboards';
+-- user_id stays the creator of the dashboard, dashboards of deleted organizations fall back to their creator
+ALTER TABLE users_val_dashboards ADD COLUMN IF NOT EXISTS organization_id BIGINT REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_users_val_dashboards_organization_id ON users_val_dashboards (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop organization_id from users_val_dashboards';
+DROP INDEX IF EXISTS idx_users_val_dashboards_organization_id;
+ALTER TABLE users_val_dashboards DROP COLUMN IF EXISTS organization_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop organization_members table';
+DROP TABLE IF EXISTS organization_members;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop organizations table';
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add request_index to blocks_deposits';
+-- EIP-6110 deposit requests are stored next to the deposits of a block (block_index continues after the last deposit)
+-- request_index is the deposit index assigned by the deposit contract and is NULL for deposits processed via the eth1 bridge
+ALTER TABLE blocks_deposits ADD COLUMN IF NOT EXISTS request_index BIGINT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create blocks_withdrawal_requests table';
+CREATE TABLE IF NOT EXISTS blocks_withdrawal_requests (
+    block_slot INT NOT NULL,
+    block_index INT NOT NULL,
+    block_root bytea NOT NULL DEFAULT '',
+    source_address bytea NOT NULL,
+    validator_pubkey bytea NOT NULL,
+    amount BIGINT NOT NULL, -- 0 requests a full exit
+    PRIMARY KEY (block_slot, block_index)
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_withdrawal_requests_validator_pubkey ON blocks_withdrawal_requests (validator_pubkey);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'create blocks_consolidation_requests table';
+CREATE TABLE IF NOT EXISTS blocks_consolidation_requests (
+    block_slot INT NOT NULL,
+    block_index INT NOT NULL,
+    block_root bytea NOT NULL DEFAULT '',
+    source_address bytea NOT NULL,
+    source_pubkey bytea NOT NULL,
+    target_pubkey bytea NOT NULL,
+    PRIMARY KEY (block_slot, block_index)
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_source_pubkey ON blocks_consolidation_requests (source_pubkey);
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_target_pubkey ON blocks_consolidation_requests (target_pubkey);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop blocks_consolidation_requests table';
+DROP TABLE IF EXISTS blocks_consolidation_requests;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop blocks_withdrawal_requests table';
+DROP TABLE IF EXISTS blocks_withdrawal_requests;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+SELECT 'drop request_index from blocks_deposits';
+ALTER TABLE blocks_deposits DROP COLUMN IF EXISTS request_index;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add two factor lockout columns to users_2fa';
+ALTER TABLE users_2fa
+    -- invalid codes since the last successful verification, counted across all sessions of the user
+    ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0,
+    -- no codes are accepted until this time
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITHOUT TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop two factor lockout columns from users_2fa';
+ALTER TABLE users_2fa
+    DROP COLUMN IF EXISTS failed_attempts,
+    DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'grant the account and machine metrics scopes to keys with full access';
+-- keys that had all scopes before the account:read and machine-metrics:write scopes were added keep full access,
+-- this covers the keys that existed before scopes were introduced and the keys synced from users.api_key
+UPDATE api_keys
+SET scopes = scopes || '{account:read,machine-metrics:write}'
+WHERE scopes @> '{network:read,dashboards:manage,notifications:manage}' AND NOT scopes && '{account:read,machine-metrics:write}';
+ALTER TABLE api_keys ALTER COLUMN scopes SET DEFAULT '{network:read,dashboards:manage,notifications:manage,account:read,machine-metrics:write}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'restore the previous default scopes of api_keys';
+ALTER TABLE api_keys ALTER COLUMN scopes SET DEFAULT '{network:read,dashboards:manage,notifications:manage}';
+UPDATE api_keys
+SET scopes = array_remove(array_remove(scopes, 'account:read'), 'machine-metrics:write')
+WHERE scopes @> '{network:read,dashboards:manage,notifications:manage,account:read,machine-metrics:write}';
+-- +goose StatementEnd
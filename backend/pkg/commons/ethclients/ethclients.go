@@ -40,10 +40,11 @@ type gitAPIResponse struct {
 }
 
 type clientUpdateInfo struct {
-	Name    string
-	Url     string
-	Version string
-	Date    time.Time
+	Name      string
+	Url       string
+	Version   string
+	Date      time.Time
+	Mandatory bool // the release notes flag the release as mandatory, e.g. for a hard fork
 }
 
 type EthClients struct {
@@ -73,10 +74,15 @@ type EthClientServicesPageData struct {
 var ethClients = EthClientServicesPageData{}
 var ethClientsMux = &sync.RWMutex{}
 var bannerClients = []clientUpdateInfo{}
+var latestReleases = map[string]clientUpdateInfo{}    // lower case client name -> latest release, guarded by ethClientsMux
+var mandatoryReleases = map[string]clientUpdateInfo{} // lower case client name -> latest mandatory release, guarded by ethClientsMux
 var bannerClientsMux = &sync.RWMutex{}
 
 var httpClient = &http.Client{Timeout: time.Second * 10}
 
+// number of releases scanned for a mandatory release, hard fork releases are usually followed by a few bug fix releases
+const recentReleasesCount = 20
+
 // Init starts a go routine to update the ETH Clients Info
 func Init() {
 	go update()
@@ -109,6 +115,31 @@ func fetchClientData(repo string) *gitAPIResponse {
 	return gitAPI
 }
 
+// fetchRecentReleases returns the most recent releases of the repo, newest first
+func fetchRecentReleases(repo string) []gitAPIResponse {
+	var releases []gitAPIResponse
+	resp, err := httpClient.Get(fmt.Sprintf("https://api.github.com/repos%s/releases?per_page=%d", repo, recentReleasesCount))
+	if err != nil {
+		log.Error(err, "error retrieving ETH Client releases", 0)
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Error(nil, "error retrieving ETH Client releases", 0, log.Fields{"status code": resp.StatusCode})
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&releases)
+	if err != nil {
+		log.Error(err, "error decoding ETH Client releases json response to struct", 0)
+		return nil
+	}
+
+	return releases
+}
+
 var ethernodesAPI []ethernodesAPIStruct
 
 func fetchClientNetworkShare() []ethernodesAPIStruct {
@@ -178,8 +209,17 @@ func prepareEthClientData(repo string, name string, curTime time.Time) (string,
 		}
 		timeDiff := (curTime.Sub(rTime).Hours() / 24.0)
 
+		update := clientUpdateInfo{Name: name, Date: rTime, Url: client.HTMLURL, Version: client.TagName, Mandatory: isMandatoryRelease(client.Body)}
+		latestReleases[strings.ToLower(name)] = update
+		// the latest release is often a bug fix release following the mandatory one, so the recent releases are scanned as well
+		if mandatory := findMandatoryRelease(name, fetchRecentReleases(repo)); mandatory != nil {
+			mandatoryReleases[strings.ToLower(name)] = *mandatory
+		} else if update.Mandatory {
+			mandatoryReleases[strings.ToLower(name)] = update
+		}
+		time.Sleep(time.Millisecond * 250) // consider github rate limit
+
 		if timeDiff < 1 { // add recent releases for notification collector to be collected
-			update := clientUpdateInfo{Name: name, Date: rTime, Url: client.HTMLURL, Version: client.TagName}
 			bannerClients = append(bannerClients, update)
 		}
 		return client.Name, rTime.Unix()
@@ -213,8 +253,8 @@ func updateEthClientNetShare() {
 
 func updateEthClient() {
 	curTime := time.Now()
-	// sending 8 requests to github per call
-	// git api rate-limit 60 per hour : 60/8 = 7.5 minutes minimum
+	// sending 2 requests per client (22 in total) to github per call
+	// git api rate-limit 60 per hour : 60/22 = 2.7 minutes minimum
 	if curTime.Sub(ethClients.LastUpdate) < time.Hour { // LastUpdate is initialized at January 1, year 1 so no need to check for nil
 		return
 	}
@@ -0,0 +1,128 @@
+package ethclients
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/hashicorp/go-version"
+)
+
+// matches the numeric part of versions like "Lighthouse/v5.1.0-8fdc0c4/x86_64-linux" or "24.4.0"
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+var mandatoryReleaseRegex = regexp.MustCompile(`(?i)\b(mandatory|hard[- ]?fork)\b`)
+var optionalReleaseRegex = regexp.MustCompile(`(?i)\bnot (a )?mandatory\b`)
+
+type ClientVersionStatus struct {
+	Client             string // lower case client name
+	Name               string // display name of the client
+	RunningVersion     string
+	LatestVersion      string
+	LatestUrl          string
+	RequiredVersion    string // minimum version for the current or upcoming hard fork, empty if unknown
+	IsOutdated         bool
+	IsForkIncompatible bool
+}
+
+func isMandatoryRelease(body string) bool {
+	return mandatoryReleaseRegex.MatchString(body) && !optionalReleaseRegex.MatchString(body)
+}
+
+// findMandatoryRelease returns the newest published release flagged as mandatory, releases are expected newest first
+func findMandatoryRelease(name string, releases []gitAPIResponse) *clientUpdateInfo {
+	for _, release := range releases {
+		if release.Draft || release.PreRelease || !isMandatoryRelease(release.Body) {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, release.PublishedDate)
+		if err != nil {
+			log.Error(err, "error parsing release date", 0, log.Fields{"client": name, "version": release.TagName})
+		}
+		return &clientUpdateInfo{Name: name, Date: date, Url: release.HTMLURL, Version: release.TagName, Mandatory: true}
+	}
+	return nil
+}
+
+// parseClientVersion extracts the numeric version of a client version string, pre-release and build suffixes are ignored
+func parseClientVersion(s string) *version.Version {
+	match := versionRegex.FindString(s)
+	if match == "" {
+		return nil
+	}
+	v, err := version.NewVersion(match)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// MatchClient returns the lower case name of the given client out of clientNames, or an empty string if none matches
+func MatchClient(clientName string, clientNames []string) string {
+	clientName = strings.ToLower(clientName)
+	for _, name := range clientNames {
+		if name != "" && strings.Contains(clientName, strings.ToLower(name)) {
+			return strings.ToLower(name)
+		}
+	}
+	return ""
+}
+
+// GetReleasedClients returns the lower case names of all clients a release is known for
+func GetReleasedClients() []string {
+	ethClientsMux.Lock()
+	defer ethClientsMux.Unlock()
+	names := make([]string, 0, len(latestReleases))
+	for name := range latestReleases {
+		names = append(names, name)
+	}
+	return names
+}
+
+// GetClientVersionStatus compares a running client version against the latest release and the version required for
+// the current or upcoming hard fork. It returns nil if the version can't be parsed or no release is known for the client.
+func GetClientVersionStatus(client string, runningVersion string) *ClientVersionStatus {
+	client = strings.ToLower(client)
+	running := parseClientVersion(runningVersion)
+	if running == nil {
+		return nil
+	}
+
+	ethClientsMux.Lock()
+	latestRelease, found := latestReleases[client]
+	mandatoryRelease, hasMandatoryRelease := mandatoryReleases[client]
+	ethClientsMux.Unlock()
+	if !found {
+		return nil
+	}
+	latest := parseClientVersion(latestRelease.Version)
+	if latest == nil {
+		return nil
+	}
+
+	status := &ClientVersionStatus{
+		Client:         client,
+		Name:           latestRelease.Name,
+		RunningVersion: "v" + running.String(),
+		LatestVersion:  "v" + latest.String(),
+		LatestUrl:      latestRelease.Url,
+		IsOutdated:     running.LessThan(latest),
+	}
+
+	var required *version.Version
+	if utils.Config != nil {
+		required = parseClientVersion(utils.Config.Notifications.ClientForkMinimumVersions[client])
+	}
+	if hasMandatoryRelease {
+		if mandatory := parseClientVersion(mandatoryRelease.Version); mandatory != nil && (required == nil || required.LessThan(mandatory)) {
+			required = mandatory
+		}
+	}
+	if required != nil {
+		status.RequiredVersion = "v" + required.String()
+		status.IsForkIncompatible = running.LessThan(required)
+	}
+	return status
+}
@@ -96,14 +96,7 @@ func SendMailRateLimited(content types.TransitEmailContent, maxEmailsPerDay int6
 	// make sure the threshold reached email arrives last
 	if sendThresholdReachedMail {
 		// send an email if this was the last email for today
-		err := SendHTMLMail(content.Address,
-			"beaconcha.in - Email notification threshold limit reached",
-			types.Email{
-				Title: "Email notification threshold limit reached",
-				//nolint: gosec
-				Body: template.HTML(fmt.Sprintf("You have reached the email notification threshold limit of %d emails per day. Further notification emails will be suppressed for %.1f hours.", maxEmailsPerDay, timeLeft.Hours())),
-			},
-			[]types.EmailAttachment{})
+		err := SendThresholdReachedMail(content.Address, maxEmailsPerDay)
 		if err != nil {
 			return err
 		}
@@ -112,6 +105,19 @@ func SendMailRateLimited(content types.TransitEmailContent, maxEmailsPerDay int6
 	return nil
 }
 
+// SendThresholdReachedMail informs the given address that further emails will be suppressed for the rest of the day.
+func SendThresholdReachedMail(to string, maxEmailsPerDay int64) error {
+	timeLeft := time.Until(time.Now().Add(utils.Day).Truncate(utils.Day))
+	return SendHTMLMail(to,
+		"beaconcha.in - Email notification threshold limit reached",
+		types.Email{
+			Title: "Email notification threshold limit reached",
+			//nolint: gosec
+			Body: template.HTML(fmt.Sprintf("You have reached the email notification threshold limit of %d emails per day. Further notification emails will be suppressed for %.1f hours.", maxEmailsPerDay, timeLeft.Hours())),
+		},
+		[]types.EmailAttachment{})
+}
+
 // SendMailSMTP sends an email to the given address with the given message, using smtp.
 func SendMailSMTP(to string, msg []byte) error {
 	server := utils.Config.Frontend.Mail.SMTP.Server // eg. smtp.gmail.com:587
@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
+	"github.com/lib/pq"
 	"golang.org/x/time/rate"
 )
 
@@ -60,6 +61,9 @@ const (
 	defaultBucket = "default" // if no bucket is set for a route, use this one
 
 	statsTruncateDuration = time.Hour * 1 // ratelimit-stats are truncated to this duration
+
+	apiKeysChangedChannel = "rl:api_keys_changed" // redis channel to notify all instances about created or revoked api keys
+	accessTokenKeyPrefix  = "oauth_"              // requests authorized by an oauth access token are keyed by this prefix and the subject of the token
 )
 
 var updateInterval = time.Second * 60 // how often to update ratelimits, weights and stats
@@ -83,6 +87,8 @@ var rateLimitsMu = &sync.RWMutex{}
 var rateLimits = map[string]*RateLimit{}         // guarded by rateLimitsMu
 var rateLimitsByUserId = map[string]*RateLimit{} // guarded by rateLimitsMu, key: <bucket>:<userId>
 var userIdByApiKey = map[string]int64{}          // guarded by rateLimitsMu
+var scopesByApiKey = map[string][]string{}       // guarded by rateLimitsMu
+var apiKeysLoaded = false                        // guarded by rateLimitsMu, set once the api keys have been loaded
 
 var weightsMu = &sync.RWMutex{}
 var weights = map[string]int64{}  // guarded by weightsMu
@@ -209,6 +215,28 @@ func GetRequestFilter() func(req *http.Request) bool {
 	return requestFilter
 }
 
+// accessTokenResolver returns the user id of a valid oauth access token, it is set by the api which issues the tokens
+var accessTokenResolver func(token string) (userId uint64, ok bool)
+var accessTokenResolverMu = &sync.RWMutex{}
+
+// SetAccessTokenResolver sets the func used to key requests that are authorized by an oauth access token by the subject of the token
+func SetAccessTokenResolver(resolver func(token string) (userId uint64, ok bool)) {
+	accessTokenResolverMu.Lock()
+	defer accessTokenResolverMu.Unlock()
+	accessTokenResolver = resolver
+}
+
+// AccessTokenKey returns the key the requests of a user authorized by an oauth access token are rate limited and tracked by
+func AccessTokenKey(userId int64) string {
+	return fmt.Sprintf("%s%d", accessTokenKeyPrefix, userId)
+}
+
+func getAccessTokenResolver() func(token string) (userId uint64, ok bool) {
+	accessTokenResolverMu.RLock()
+	defer accessTokenResolverMu.RUnlock()
+	return accessTokenResolver
+}
+
 var maxBadRequestWeight int64 = 1
 
 func SetMaxBadRequestWeight(weight int64) {
@@ -283,6 +311,17 @@ func Init() {
 			time.Sleep(time.Second * 1)
 		}
 	}()
+	go func() {
+		// reload api keys as soon as they are changed instead of waiting for the next update
+		pubsub := redisClient.Subscribe(context.Background(), apiKeysChangedChannel)
+		defer pubsub.Close()
+		for range pubsub.Channel() {
+			err := updateRateLimits()
+			if err != nil {
+				log.Error(err, "error updating ratelimits after api keys changed", 0)
+			}
+		}
+	}()
 
 	initializedWg.Wait()
 }
@@ -588,7 +627,7 @@ func updateStatsEntries(entries []DbEntry) error {
 	return nil
 }
 
-// updateRateLimits updates the maps rateLimits, rateLimitsByUserId and userIdByApiKey with data from postgres-tables api_keys and api_ratelimits.
+// updateRateLimits updates the maps rateLimits, rateLimitsByUserId, userIdByApiKey and scopesByApiKey with data from postgres-tables api_keys and api_ratelimits.
 func updateRateLimits() error {
 	start := time.Now()
 	defer func() {
@@ -608,13 +647,14 @@ func updateRateLimits() error {
 	defer utils.Rollback(tx)
 
 	dbApiKeys := []struct {
-		UserID     int64     `db:"user_id"`
-		ApiKey     string    `db:"api_key"`
-		ValidUntil time.Time `db:"valid_until"`
-		ChangedAt  time.Time `db:"changed_at"`
+		UserID     int64          `db:"user_id"`
+		ApiKey     string         `db:"api_key"`
+		Scopes     pq.StringArray `db:"scopes"`
+		ValidUntil time.Time      `db:"valid_until"`
+		ChangedAt  time.Time      `db:"changed_at"`
 	}{}
 
-	err = tx.Select(&dbApiKeys, `SELECT user_id, api_key, valid_until, changed_at FROM api_keys WHERE changed_at > $1 OR valid_until < NOW()`, lastTKeys)
+	err = tx.Select(&dbApiKeys, `SELECT user_id, api_key, COALESCE(scopes, '{}') AS scopes, valid_until, changed_at FROM api_keys WHERE changed_at > $1 OR valid_until < NOW()`, lastTKeys)
 	if err != nil {
 		return fmt.Errorf("error getting api_keys: %w", err)
 	}
@@ -657,10 +697,13 @@ func updateRateLimits() error {
 		}
 		if dbKey.ValidUntil.Before(now) {
 			delete(userIdByApiKey, dbKey.ApiKey)
+			delete(scopesByApiKey, dbKey.ApiKey)
 			continue
 		}
 		userIdByApiKey[dbKey.ApiKey] = dbKey.UserID
+		scopesByApiKey[dbKey.ApiKey] = dbKey.Scopes
 	}
+	apiKeysLoaded = true
 
 	for _, dbRl := range dbRateLimits {
 		k := fmt.Sprintf("%s/%d", dbRl.Bucket, dbRl.UserID)
@@ -694,6 +737,23 @@ func updateRateLimits() error {
 	return nil
 }
 
+// GetApiKeyScopes returns the scopes of an api key from the keys loaded by the rate limiter, so they don't have to be queried for every request.
+// loaded is false if the rate limiter is not running, found is false if the key is unknown or revoked.
+func GetApiKeyScopes(apiKey string) (scopes []string, found bool, loaded bool) {
+	rateLimitsMu.RLock()
+	defer rateLimitsMu.RUnlock()
+	scopes, found = scopesByApiKey[apiKey]
+	return scopes, found, apiKeysLoaded
+}
+
+// NotifyApiKeysChanged tells all instances of the rate limiter to reload the api keys, so created keys can be used and revoked keys are rejected without waiting for the next update.
+func NotifyApiKeysChanged(ctx context.Context) error {
+	if redisClient == nil {
+		return nil
+	}
+	return redisClient.Publish(ctx, apiKeysChangedChannel, "").Err()
+}
+
 // postRateLimit decrements the rate limit keys in redis if the status is not 200.
 func postRateLimit(rl *RateLimitResult, status int) error {
 	if !(status >= 500 && status <= 599) && status != 429 {
@@ -735,7 +795,7 @@ func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 	res := &RateLimitResult{}
 	// defer func() { logger.Infof("rateLimitRequest: %+v", *res) }()
 
-	key, ip := getKey(r)
+	key, ip, accessTokenUserId := getKey(r)
 	res.Key = key
 	res.IP = ip
 
@@ -748,6 +808,9 @@ func rateLimitRequest(r *http.Request) (*RateLimitResult, error) {
 
 	rateLimitsMu.RLock()
 	userId, ok := userIdByApiKey[key]
+	if accessTokenUserId > 0 {
+		userId, ok = accessTokenUserId, true
+	}
 	if !ok {
 		res.UserId = -1
 		res.IsValidKey = false
@@ -926,22 +989,32 @@ func max(vals ...int64) int64 {
 	return max
 }
 
-// getKey returns the key used for RateLimiting. It first checks the query params, then the header and finally the ip address.
-func getKey(r *http.Request) (key, ip string) {
-	ip = getIP(r)
+// getKey returns the key used for RateLimiting. It first checks the authorization header, then the query params, then the other headers and finally the ip address.
+// Oauth access tokens are keyed by their subject, accessTokenUserId is only set for them.
+func getKey(r *http.Request) (key, ip string, accessTokenUserId int64) {
+	ip = GetIP(r)
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		if resolver := getAccessTokenResolver(); resolver != nil {
+			if userId, ok := resolver(token); ok {
+				return AccessTokenKey(int64(userId)), ip, int64(userId)
+			}
+		}
+		// api keys can be passed as bearer token as well
+		return token, ip, 0
+	}
 	key = r.URL.Query().Get("apikey")
 	if key != "" {
-		return key, ip
+		return key, ip, 0
 	}
 	key = r.Header.Get("apikey")
 	if key != "" {
-		return key, ip
+		return key, ip, 0
 	}
 	key = r.Header.Get("X-API-KEY")
 	if key != "" {
-		return key, ip
+		return key, ip, 0
 	}
-	return "nokey", ip
+	return "nokey", ip, 0
 }
 
 // getWeight returns the weight of an endpoint. if the weight of the endpoint is not defined, it returns 1.
@@ -969,8 +1042,8 @@ func getRoute(r *http.Request) string {
 	return pathTpl
 }
 
-// getIP returns the ip address from the http request
-func getIP(r *http.Request) string {
+// GetIP returns the ip address of the client from the http request
+func GetIP(r *http.Request) string {
 	ips := r.Header.Get("CF-Connecting-IP")
 	if ips == "" {
 		ips = r.Header.Get("X-Forwarded-For")
@@ -1032,7 +1105,7 @@ func NewFallbackRateLimiter() *FallbackRateLimiter {
 }
 
 func (rl *FallbackRateLimiter) Handle(w http.ResponseWriter, r *http.Request, next func(writer http.ResponseWriter, request *http.Request)) {
-	key, _ := getKey(r)
+	key, _, _ := getKey(r)
 	rl.mu.Lock()
 	if _, found := rl.clients[key]; !found {
 		rl.clients[key] = &FallbackRateLimiterClient{limiter: rate.NewLimiter(FallbackRateLimitSecond, FallbackRateLimitBurst)}
@@ -1228,3 +1301,33 @@ func DBUpdateApiRatelimits() (sql.Result, error) {
 			or api_ratelimits.hour != excluded.hour 
 			or api_ratelimits.month != excluded.month`)
 }
+
+// GetUserApiUsage returns the weighted usage of the current hour and month of the user in the default bucket.
+// Usage is only tracked for time windows with a limit and is 0 if the ratelimiter has not been initialized.
+func GetUserApiUsage(ctx context.Context, userId int64) (hour, month int64, err error) {
+	if redisClient == nil {
+		return 0, 0, nil
+	}
+	nowUtc := time.Now().UTC()
+	rateLimitHourKey := fmt.Sprintf("rl:c:h:%04d-%02d-%02d-%02d:%s:%d", nowUtc.Year(), nowUtc.Month(), nowUtc.Day(), nowUtc.Hour(), "default", userId)
+	rateLimitMonthKey := fmt.Sprintf("rl:c:m:%04d-%02d:%s:%d", nowUtc.Year(), nowUtc.Month(), "default", userId)
+	res, err := redisClient.MGet(ctx, rateLimitHourKey, rateLimitMonthKey).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting ratelimit counters from redis: %w", err)
+	}
+	counts := make([]int64, len(res))
+	for i, v := range res {
+		if v == nil {
+			continue
+		}
+		vStr, ok := v.(string)
+		if !ok {
+			return 0, 0, fmt.Errorf("error parsing ratelimit counter from redis: value is not string: %v", v)
+		}
+		counts[i], err = strconv.ParseInt(vStr, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error parsing ratelimit counter from redis: value is not int64: %v: %w", v, err)
+		}
+	}
+	return counts[0], counts[1], nil
+}
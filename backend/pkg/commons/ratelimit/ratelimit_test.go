@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetKey(t *testing.T) {
+	SetAccessTokenResolver(func(token string) (uint64, bool) {
+		return 42, token == "a.b.c"
+	})
+	t.Cleanup(func() { SetAccessTokenResolver(nil) })
+
+	tests := []struct {
+		name              string
+		target            string
+		header            http.Header
+		key               string
+		accessTokenUserId int64
+	}{
+		{"no key", "/", http.Header{}, "nokey", 0},
+		{"query param", "/?apikey=querykey", http.Header{}, "querykey", 0},
+		{"apikey header", "/", http.Header{"Apikey": {"headerkey"}}, "headerkey", 0},
+		{"x-api-key header", "/", http.Header{"X-Api-Key": {"headerkey"}}, "headerkey", 0},
+		{"api key as bearer token", "/?apikey=querykey", http.Header{"Authorization": {"Bearer bearerkey"}}, "bearerkey", 0},
+		// access tokens are keyed by their subject, not by the token which changes on every refresh
+		{"access token", "/", http.Header{"Authorization": {"Bearer a.b.c"}}, "oauth_42", 42},
+		// the prefix of access tokens can't be used to impersonate a user
+		{"forged access token key", "/?apikey=oauth_42", http.Header{}, "oauth_42", 0},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		r.Header = tt.header
+		key, _, accessTokenUserId := getKey(r)
+		if key != tt.key || accessTokenUserId != tt.accessTokenUserId {
+			t.Errorf("%s: got key %q and user %d, want key %q and user %d", tt.name, key, accessTokenUserId, tt.key, tt.accessTokenUserId)
+		}
+	}
+}
@@ -26,14 +26,13 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
-// TODO replace most lc.cl.Endpoint with lc.cl and use the interface
-
 // LighthouseLatestHeadEpoch is used to cache the latest head epoch for participation requests
 var LighthouseLatestHeadEpoch uint64 = 0
 
 // LighthouseClient holds the Lighthouse client info
 type LighthouseClient struct {
-	cl                  *consapi.NodeClient
+	cl                  consapi.ClientInt
+	endpoint            consapi.EndpointProvider
 	assignmentsCache    *lru.Cache
 	assignmentsCacheMux *sync.Mutex
 	slotsCache          *lru.Cache
@@ -42,10 +41,15 @@ type LighthouseClient struct {
 }
 
 // NewLighthouseClient is used to create a new Lighthouse client
-func NewLighthouseClient(cl *consapi.NodeClient, chainID *big.Int) (*LighthouseClient, error) {
+func NewLighthouseClient(cl consapi.ClientInt, chainID *big.Int) (*LighthouseClient, error) {
+	endpoint, ok := cl.(consapi.EndpointProvider)
+	if !ok {
+		return nil, fmt.Errorf("lighthouse client can only be used with a client that exposes its node endpoint")
+	}
 	signer := gethtypes.NewCancunSigner(chainID)
 	client := &LighthouseClient{
 		cl:                  cl,
+		endpoint:            endpoint,
 		assignmentsCacheMux: &sync.Mutex{},
 		slotsCacheMux:       &sync.Mutex{},
 		signer:              signer,
@@ -950,27 +954,22 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *constypes.StandardB
 			Signature: attestation.Signature,
 		}
 
-		aggregationBits := bitfield.Bitlist(a.AggregationBits)
 		assignments, err := lc.GetEpochAssignments(a.Data.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch)
 		if err != nil {
 			return nil, fmt.Errorf("error receiving epoch assignment for epoch %v: %w", a.Data.Slot/utils.Config.Chain.ClConfig.SlotsPerEpoch, err)
 		}
 
-		for i := uint64(0); i < aggregationBits.Len(); i++ {
-			if aggregationBits.BitAt(i) {
-				validator, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(a.Data.Slot, uint64(a.Data.CommitteeIndex), i)]
-				if !found { // This should never happen!
-					validator = 0
-					log.Fatal(fmt.Errorf("error retrieving assigned validator for attestation %v of block %v for slot %v committee index %v member index %v", i, block.Slot, a.Data.Slot, a.Data.CommitteeIndex, i), "", 0)
-				}
-				a.Attesters = append(a.Attesters, validator)
+		attesters, err := utils.GetAttestingValidators(bitfield.Bitlist(a.AggregationBits), attestation.CommitteeIndices(), func(committeeIndex, memberIndex uint64) (uint64, bool) {
+			validator, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(a.Data.Slot, committeeIndex, memberIndex)]
+			return validator, found
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving assigned validators for attestation %v of block %v for slot %v: %w", i, block.Slot, a.Data.Slot, err)
+		}
 
-				if block.AttestationDuties[types.ValidatorIndex(validator)] == nil {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = []types.Slot{types.Slot(a.Data.Slot)}
-				} else {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
-				}
-			}
+		a.Attesters = attesters
+		for _, validator := range attesters {
+			block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
 		}
 
 		block.Attestations[i] = a
@@ -1007,6 +1006,35 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *constypes.StandardB
 		}
 	}
 
+	if requests := parsedBlock.Message.Body.ExecutionRequests; requests != nil {
+		block.DepositRequests = make([]*types.DepositRequest, len(requests.Deposits))
+		for i, r := range requests.Deposits {
+			block.DepositRequests[i] = &types.DepositRequest{
+				PublicKey:             r.Pubkey,
+				WithdrawalCredentials: r.WithdrawalCredentials,
+				Amount:                r.Amount,
+				Signature:             r.Signature,
+				Index:                 r.Index,
+			}
+		}
+		block.WithdrawalRequests = make([]*types.WithdrawalRequest, len(requests.Withdrawals))
+		for i, r := range requests.Withdrawals {
+			block.WithdrawalRequests[i] = &types.WithdrawalRequest{
+				SourceAddress:   r.SourceAddress,
+				ValidatorPubkey: r.ValidatorPubkey,
+				Amount:          r.Amount,
+			}
+		}
+		block.ConsolidationRequests = make([]*types.ConsolidationRequest, len(requests.Consolidations))
+		for i, r := range requests.Consolidations {
+			block.ConsolidationRequests[i] = &types.ConsolidationRequest{
+				SourceAddress: r.SourceAddress,
+				SourcePubkey:  r.SourcePubkey,
+				TargetPubkey:  r.TargetPubkey,
+			}
+		}
+	}
+
 	return block, nil
 }
 
@@ -1020,7 +1048,8 @@ func syncCommitteeParticipation(bits []byte) float64 {
 	return float64(participating) / float64(utils.Config.Chain.ClConfig.SyncCommitteeSize)
 }
 
-// GetValidatorParticipation will get the validator participation from the Lighthouse RPC api
+// GetValidatorParticipation will get the validator participation from the Lighthouse RPC api.
+// The lighthouse specific api is requested from the primary node only, the fallback nodes of a pool might run other clients.
 func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.ValidatorParticipation, error) {
 	head, err := lc.GetChainHead()
 	if err != nil {
@@ -1044,7 +1073,7 @@ func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.Vali
 
 	log.Infof("requesting validator inclusion data for epoch %v", request_epoch)
 
-	parsedResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.cl.Endpoint, request_epoch))
+	parsedResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.endpoint.PrimaryEndpoint(), request_epoch))
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator participation data for epoch %v: %w", request_epoch, err)
 	}
@@ -1056,7 +1085,7 @@ func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.Vali
 		prevEpochActiveGwei := parsedResponse.Data.PreviousEpochActiveGwei
 		if prevEpochActiveGwei == 0 {
 			// lh@5.2.0+ has no previous_epoch_active_gwei field anymore, see https://github.com/sigp/lighthouse/pull/5279
-			parsedPrevResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.cl.Endpoint, request_epoch-1))
+			parsedPrevResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.endpoint.PrimaryEndpoint(), request_epoch-1))
 			if err != nil {
 				return nil, fmt.Errorf("error retrieving validator participation data for prevEpoch %v: %w", request_epoch-1, err)
 			}
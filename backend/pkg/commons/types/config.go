@@ -78,7 +78,10 @@ type Config struct {
 	RedisSessionStoreEndpoint string `yaml:"redisSessionStoreEndpoint" envconfig:"REDIS_SESSION_STORE_ENDPOINT"`
 	TieredCacheProvider       string `yaml:"tieredCacheProvider" envconfig:"CACHE_PROVIDER"`
 	ReportServiceStatus       bool   `yaml:"reportServiceStatus" envconfig:"REPORT_SERVICE_STATUS"`
-	ClickHouse                struct {
+	DashboardStorage          string `yaml:"dashboardStorage" envconfig:"DASHBOARD_STORAGE"` // database the validator dashboard aggregates are read from, clickhouse (default) or postgres (the api does not connect to clickhouse then)
+	// how long the dashboard data exporter waits for the el rewards of an epoch before it is exported without them, defaults to 30m
+	DashboardExecutionRewardsMaxWait time.Duration `yaml:"dashboardExecutionRewardsMaxWait" envconfig:"DASHBOARD_EXECUTION_REWARDS_MAX_WAIT"`
+	ClickHouse                       struct {
 		ReaderDatabase struct {
 			Username     string `yaml:"user" envconfig:"CLICKHOUSE_READER_DB_USERNAME"`
 			Password     string `yaml:"password" envconfig:"CLICKHOUSE_READER_DB_PASSWORD"`
@@ -98,6 +101,14 @@ type Config struct {
 			MaxIdleConns int    `yaml:"maxIdleConns" envconfig:"CLICKHOUSE_WRITER_DB_MAX_IDLE_CONNS"`
 		} `yaml:"writerDatabase"`
 	} `yaml:"clickhouse"`
+	TrackedValidators struct {
+		// if enabled the dashboard data exporter only exports the tracked validators and a sample of the network instead of every validator
+		Enabled           bool     `yaml:"enabled" envconfig:"TRACKED_VALIDATORS_ENABLED"`
+		Indices           []uint64 `yaml:"indices" envconfig:"TRACKED_VALIDATORS_INDICES"`
+		IncludeDashboards bool     `yaml:"includeDashboards" envconfig:"TRACKED_VALIDATORS_INCLUDE_DASHBOARDS"` // track every validator that is part of a validator dashboard
+		// every n-th validator index is exported as well, the network wide averages are calculated from this sample
+		NetworkSampleInterval uint64 `yaml:"networkSampleInterval" envconfig:"TRACKED_VALIDATORS_NETWORK_SAMPLE_INTERVAL"`
+	} `yaml:"trackedValidators"`
 	Indexer struct {
 		Enabled bool `yaml:"enabled" envconfig:"INDEXER_ENABLED"`
 		Node    struct {
@@ -105,6 +116,8 @@ type Config struct {
 			Host     string `yaml:"host" envconfig:"INDEXER_NODE_HOST"`
 			Type     string `yaml:"type" envconfig:"INDEXER_NODE_TYPE"`
 			PageSize int32  `yaml:"pageSize" envconfig:"INDEXER_NODE_PAGE_SIZE"`
+			// Fallbacks are full urls of additional beacon nodes, requests fail over to them if the primary node is unhealthy or out of sync
+			Fallbacks []string `yaml:"fallbacks" envconfig:"INDEXER_NODE_FALLBACKS"`
 		} `yaml:"node"`
 		ELDepositContractFirstBlock uint64 `yaml:"eth1DepositContractFirstBlock" envconfig:"INDEXER_ETH1_DEPOSIT_CONTRACT_FIRST_BLOCK"`
 		DoNotTraceDeposits          bool   `yaml:"doNotTraceDeposits" envconfig:"INDEXER_DO_NOT_TRACE_DEPOSITS"`
@@ -270,15 +283,20 @@ type Config struct {
 		PprofExtra bool   `yaml:"pprofExtra" envconfig:"METRICS_PPROF_EXTRA"`
 	} `yaml:"metrics"`
 	Notifications struct {
-		UserDBNotifications                           bool    `yaml:"userDbNotifications" envconfig:"USERDB_NOTIFICATIONS_ENABLED"`
-		FirebaseCredentialsPath                       string  `yaml:"firebaseCredentialsPath" envconfig:"NOTIFICATIONS_FIREBASE_CRED_PATH"`
-		ValidatorBalanceDecreasedNotificationsEnabled bool    `yaml:"validatorBalanceDecreasedNotificationsEnabled" envconfig:"VALIDATOR_BALANCE_DECREASED_NOTIFICATIONS_ENABLED"`
-		PubkeyCachePath                               string  `yaml:"pubkeyCachePath" envconfig:"NOTIFICATIONS_PUBKEY_CACHE_PATH"`
-		OnlineDetectionLimit                          int     `yaml:"onlineDetectionLimit" envconfig:"ONLINE_DETECTION_LIMIT"`
-		OfflineDetectionLimit                         int     `yaml:"offlineDetectionLimit" envconfig:"OFFLINE_DETECTION_LIMIT"`
-		MachineEventThreshold                         uint64  `yaml:"machineEventThreshold" envconfig:"MACHINE_EVENT_THRESHOLD"`
-		MachineEventFirstRatioThreshold               float64 `yaml:"machineEventFirstRatioThreshold" envconfig:"MACHINE_EVENT_FIRST_RATIO_THRESHOLD"`
-		MachineEventSecondRatioThreshold              float64 `yaml:"machineEventSecondRatioThreshold" envconfig:"MACHINE_EVENT_SECOND_RATIO_THRESHOLD"`
+		UserDBNotifications                           bool              `yaml:"userDbNotifications" envconfig:"USERDB_NOTIFICATIONS_ENABLED"`
+		FirebaseCredentialsPath                       string            `yaml:"firebaseCredentialsPath" envconfig:"NOTIFICATIONS_FIREBASE_CRED_PATH"`
+		ValidatorBalanceDecreasedNotificationsEnabled bool              `yaml:"validatorBalanceDecreasedNotificationsEnabled" envconfig:"VALIDATOR_BALANCE_DECREASED_NOTIFICATIONS_ENABLED"`
+		PubkeyCachePath                               string            `yaml:"pubkeyCachePath" envconfig:"NOTIFICATIONS_PUBKEY_CACHE_PATH"`
+		OnlineDetectionLimit                          int               `yaml:"onlineDetectionLimit" envconfig:"ONLINE_DETECTION_LIMIT"`
+		OfflineDetectionLimit                         int               `yaml:"offlineDetectionLimit" envconfig:"OFFLINE_DETECTION_LIMIT"`
+		OfflineEpochsThreshold                        uint64            `yaml:"offlineEpochsThreshold" envconfig:"OFFLINE_EPOCHS_THRESHOLD"`
+		OnlineEpochsThreshold                         uint64            `yaml:"onlineEpochsThreshold" envconfig:"ONLINE_EPOCHS_THRESHOLD"`
+		UnstableWindowEpochs                          uint64            `yaml:"unstableWindowEpochs" envconfig:"UNSTABLE_WINDOW_EPOCHS"`
+		UnstableTransitionsThreshold                  uint64            `yaml:"unstableTransitionsThreshold" envconfig:"UNSTABLE_TRANSITIONS_THRESHOLD"`
+		MachineEventThreshold                         uint64            `yaml:"machineEventThreshold" envconfig:"MACHINE_EVENT_THRESHOLD"`
+		MachineEventFirstRatioThreshold               float64           `yaml:"machineEventFirstRatioThreshold" envconfig:"MACHINE_EVENT_FIRST_RATIO_THRESHOLD"`
+		MachineEventSecondRatioThreshold              float64           `yaml:"machineEventSecondRatioThreshold" envconfig:"MACHINE_EVENT_SECOND_RATIO_THRESHOLD"`
+		ClientForkMinimumVersions                     map[string]string `yaml:"clientForkMinimumVersions" envconfig:"CLIENT_FORK_MINIMUM_VERSIONS"` // lower case client name -> minimum version supporting the current or upcoming hard fork, needed if the release notes of the client do not flag it as mandatory or it is older than the scanned recent releases
 	} `yaml:"notifications"`
 	SSVExporter struct {
 		Enabled bool   `yaml:"enabled" envconfig:"SSV_EXPORTER_ENABLED"`
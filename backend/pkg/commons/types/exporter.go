@@ -126,6 +126,9 @@ type Block struct {
 	SyncAggregate              *SyncAggregate    // warning: sync aggregate may be nil, for phase0 blocks
 	ExecutionPayload           *ExecutionPayload // warning: payload may be nil, for phase0/altair blocks
 	SignedBLSToExecutionChange []*SignedBLSToExecutionChange
+	DepositRequests            []*DepositRequest       // present only after electra
+	WithdrawalRequests         []*WithdrawalRequest    // present only after electra
+	ConsolidationRequests      []*ConsolidationRequest // present only after electra
 	BlobGasUsed                uint64
 	ExcessBlobGas              uint64
 	BlobKZGCommitments         [][]byte
@@ -213,6 +216,15 @@ type WithdrawalsNotification struct {
 	Pubkey         []byte `json:"pubkey"`
 }
 
+// ConsolidationsNotification is a consolidation whose source balance was moved to the target validator
+type ConsolidationsNotification struct {
+	Slot                 uint64 `db:"slot" json:"slot"` // slot of the block that included the consolidation request
+	SourceValidatorIndex uint64 `db:"source_validatorindex" json:"source_validatorindex"`
+	SourcePubkey         []byte `db:"source_pubkey" json:"source_pubkey"`
+	TargetValidatorIndex uint64 `db:"target_validatorindex" json:"target_validatorindex"`
+	TargetPubkey         []byte `db:"target_pubkey" json:"target_pubkey"`
+}
+
 // Eth1Data is a struct to hold the ETH1 data
 type Eth1Data struct {
 	DepositRoot  []byte
@@ -272,6 +284,29 @@ type Deposit struct {
 	Signature             []byte
 }
 
+// DepositRequest is a struct to hold EIP-6110 execution layer deposit request data
+type DepositRequest struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+// WithdrawalRequest is a struct to hold EIP-7002 execution layer withdrawal request data, an amount of 0 requests a full exit
+type WithdrawalRequest struct {
+	SourceAddress   []byte
+	ValidatorPubkey []byte
+	Amount          uint64
+}
+
+// ConsolidationRequest is a struct to hold EIP-7251 consolidation request data
+type ConsolidationRequest struct {
+	SourceAddress []byte
+	SourcePubkey  []byte
+	TargetPubkey  []byte
+}
+
 // VoluntaryExit is a struct to hold voluntary exit data
 type VoluntaryExit struct {
 	Epoch          uint64
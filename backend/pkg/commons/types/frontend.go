@@ -81,14 +81,18 @@ const (
 	// Validator dashboard events
 	ValidatorIsOfflineEventName             EventName = "validator_is_offline"
 	ValidatorIsOnlineEventName              EventName = "validator_is_online"
+	ValidatorIsUnstableEventName            EventName = "validator_is_unstable"
 	ValidatorMissedAttestationEventName     EventName = "validator_attestation_missed"
 	ValidatorMissedProposalEventName        EventName = "validator_proposal_missed"
 	ValidatorExecutedProposalEventName      EventName = "validator_proposal_submitted"
 	ValidatorUpcomingProposalEventName      EventName = "validator_proposal_upcoming"
 	SyncCommitteeSoonEventName              EventName = "validator_synccommittee_soon"
 	ValidatorReceivedWithdrawalEventName    EventName = "validator_withdrawal"
+	ValidatorPartialWithdrawalEventName     EventName = "validator_partial_withdrawal_processed"
+	ValidatorConsolidationEventName         EventName = "validator_consolidation_completed"
 	ValidatorGotSlashedEventName            EventName = "validator_got_slashed"
 	ValidatorGroupEfficiencyEventName       EventName = "validator_group_efficiency"
+	ValidatorDashboardRuleEventName         EventName = "validator_dashboard_rule"
 	RocketpoolCollateralMinReachedEventName EventName = "rocketpool_colleteral_min" //nolint:misspell
 	RocketpoolCollateralMaxReachedEventName EventName = "rocketpool_colleteral_max" //nolint:misspell
 
@@ -104,6 +108,12 @@ const (
 	MonitoringMachineDiskAlmostFullEventName EventName = "monitoring_hdd_almostfull"
 	MonitoringMachineCpuLoadEventName        EventName = "monitoring_cpu_load"
 	MonitoringMachineMemoryUsageEventName    EventName = "monitoring_memory_usage"
+	MonitoringMachineNotSyncedEventName      EventName = "monitoring_beaconnode_not_synced"
+	MonitoringMachineElDisconnectedEventName EventName = "monitoring_execution_disconnected"
+	MonitoringMachineLowPeerCountEventName   EventName = "monitoring_low_peer_count"
+	MonitoringMachineHeadSlotLagEventName    EventName = "monitoring_head_slot_lag"
+	MonitoringMachineFallbackInUseEventName  EventName = "monitoring_fallback_in_use"
+	MonitoringMachineClientOutdatedEventName EventName = "monitoring_client_outdated"
 
 	// Client events
 	EthClientUpdateEventName EventName = "eth_client_update"
@@ -125,11 +135,21 @@ var EventSortOrder = []EventName{
 	MonitoringMachineDiskAlmostFullEventName,
 	MonitoringMachineCpuLoadEventName,
 	MonitoringMachineMemoryUsageEventName,
+	MonitoringMachineNotSyncedEventName,
+	MonitoringMachineElDisconnectedEventName,
+	MonitoringMachineLowPeerCountEventName,
+	MonitoringMachineHeadSlotLagEventName,
+	MonitoringMachineFallbackInUseEventName,
+	MonitoringMachineClientOutdatedEventName,
 	SyncCommitteeSoonEventName,
 	ValidatorIsOfflineEventName,
 	ValidatorIsOnlineEventName,
+	ValidatorIsUnstableEventName,
 	ValidatorGroupEfficiencyEventName,
+	ValidatorDashboardRuleEventName,
 	ValidatorReceivedWithdrawalEventName,
+	ValidatorPartialWithdrawalEventName,
+	ValidatorConsolidationEventName,
 	NetworkLivenessIncreasedEventName,
 	EthClientUpdateEventName,
 	TaxReportEventName,
@@ -147,6 +167,12 @@ var MachineEvents = []EventName{
 	MonitoringMachineOfflineEventName,
 	MonitoringMachineDiskAlmostFullEventName,
 	MonitoringMachineMemoryUsageEventName,
+	MonitoringMachineNotSyncedEventName,
+	MonitoringMachineElDisconnectedEventName,
+	MonitoringMachineLowPeerCountEventName,
+	MonitoringMachineHeadSlotLagEventName,
+	MonitoringMachineFallbackInUseEventName,
+	MonitoringMachineClientOutdatedEventName,
 }
 
 var UserIndexEvents = []EventName{
@@ -155,6 +181,12 @@ var UserIndexEvents = []EventName{
 	MonitoringMachineOfflineEventName,
 	MonitoringMachineDiskAlmostFullEventName,
 	MonitoringMachineMemoryUsageEventName,
+	MonitoringMachineNotSyncedEventName,
+	MonitoringMachineElDisconnectedEventName,
+	MonitoringMachineLowPeerCountEventName,
+	MonitoringMachineHeadSlotLagEventName,
+	MonitoringMachineFallbackInUseEventName,
+	MonitoringMachineClientOutdatedEventName,
 }
 
 var UserIndexEventsMap = map[EventName]struct{}{
@@ -163,6 +195,12 @@ var UserIndexEventsMap = map[EventName]struct{}{
 	MonitoringMachineOfflineEventName:        {},
 	MonitoringMachineDiskAlmostFullEventName: {},
 	MonitoringMachineMemoryUsageEventName:    {},
+	MonitoringMachineNotSyncedEventName:      {},
+	MonitoringMachineElDisconnectedEventName: {},
+	MonitoringMachineLowPeerCountEventName:   {},
+	MonitoringMachineHeadSlotLagEventName:    {},
+	MonitoringMachineFallbackInUseEventName:  {},
+	MonitoringMachineClientOutdatedEventName: {},
 }
 
 var MachineEventsMap = map[EventName]struct{}{
@@ -170,11 +208,18 @@ var MachineEventsMap = map[EventName]struct{}{
 	MonitoringMachineOfflineEventName:        {},
 	MonitoringMachineDiskAlmostFullEventName: {},
 	MonitoringMachineMemoryUsageEventName:    {},
+	MonitoringMachineNotSyncedEventName:      {},
+	MonitoringMachineElDisconnectedEventName: {},
+	MonitoringMachineLowPeerCountEventName:   {},
+	MonitoringMachineHeadSlotLagEventName:    {},
+	MonitoringMachineFallbackInUseEventName:  {},
+	MonitoringMachineClientOutdatedEventName: {},
 }
 
 var LegacyEventLabel map[EventName]string = map[EventName]string{
 	ValidatorUpcomingProposalEventName:       "Your validator(s) will soon propose a block",
 	ValidatorGroupEfficiencyEventName:        "Your validator group efficiency is low",
+	ValidatorDashboardRuleEventName:          "One of your validator group rules was triggered",
 	ValidatorMissedProposalEventName:         "Your validator(s) missed a proposal",
 	ValidatorExecutedProposalEventName:       "Your validator(s) submitted a proposal",
 	ValidatorMissedAttestationEventName:      "Your validator(s) missed an attestation",
@@ -182,13 +227,22 @@ var LegacyEventLabel map[EventName]string = map[EventName]string{
 	ValidatorDidSlashEventName:               "Your validator(s) slashed another validator",
 	ValidatorIsOfflineEventName:              "Your validator(s) went offline",
 	ValidatorIsOnlineEventName:               "Your validator(s) came back online",
+	ValidatorIsUnstableEventName:             "Your validator(s) are repeatedly going offline",
 	ValidatorReceivedWithdrawalEventName:     "A withdrawal was initiated for your validators",
+	ValidatorPartialWithdrawalEventName:      "A requested partial withdrawal was processed for your validators",
+	ValidatorConsolidationEventName:          "A consolidation of your validators was completed",
 	NetworkLivenessIncreasedEventName:        "The network is experiencing liveness issues",
 	EthClientUpdateEventName:                 "An Ethereum client has a new update available",
 	MonitoringMachineOfflineEventName:        "Your machine(s) might be offline",
 	MonitoringMachineDiskAlmostFullEventName: "Your machine(s) disk space is running low",
 	MonitoringMachineCpuLoadEventName:        "Your machine(s) has a high CPU load",
 	MonitoringMachineMemoryUsageEventName:    "Your machine(s) has a high memory load",
+	MonitoringMachineNotSyncedEventName:      "Your machine(s) beacon node is not synced",
+	MonitoringMachineElDisconnectedEventName: "Your machine(s) execution client is disconnected",
+	MonitoringMachineLowPeerCountEventName:   "Your machine(s) beacon node has a low peer count",
+	MonitoringMachineHeadSlotLagEventName:    "Your machine(s) beacon node is lagging behind the network",
+	MonitoringMachineFallbackInUseEventName:  "Your machine(s) is using a fallback node",
+	MonitoringMachineClientOutdatedEventName: "Your machine(s) is running an outdated client",
 	TaxReportEventName:                       "You have an available tax report",
 	RocketpoolCommissionThresholdEventName:   "Your configured Rocket Pool commission threshold is reached",
 	RocketpoolNewClaimRoundStartedEventName:  "Your Rocket Pool claim from last round is available",
@@ -202,6 +256,7 @@ var LegacyEventLabel map[EventName]string = map[EventName]string{
 var EventLabel map[EventName]string = map[EventName]string{
 	ValidatorUpcomingProposalEventName:       "Upcoming block proposal",
 	ValidatorGroupEfficiencyEventName:        "Low validator group efficiency",
+	ValidatorDashboardRuleEventName:          "Validator group rule triggered",
 	ValidatorMissedProposalEventName:         "Block proposal missed",
 	ValidatorExecutedProposalEventName:       "Block proposal submitted",
 	ValidatorMissedAttestationEventName:      "Attestation missed",
@@ -209,13 +264,22 @@ var EventLabel map[EventName]string = map[EventName]string{
 	ValidatorDidSlashEventName:               "Validator has slashed",
 	ValidatorIsOfflineEventName:              "Validator offline",
 	ValidatorIsOnlineEventName:               "Validator back online",
+	ValidatorIsUnstableEventName:             "Validator unstable",
 	ValidatorReceivedWithdrawalEventName:     "Withdrawal processed",
+	ValidatorPartialWithdrawalEventName:      "Partial withdrawal processed",
+	ValidatorConsolidationEventName:          "Consolidation completed",
 	NetworkLivenessIncreasedEventName:        "The network is experiencing liveness issues",
 	EthClientUpdateEventName:                 "An Ethereum client has a new update available",
 	MonitoringMachineOfflineEventName:        "Machine offline",
 	MonitoringMachineDiskAlmostFullEventName: "Machine low disk space",
 	MonitoringMachineCpuLoadEventName:        "Machine high CPU load",
 	MonitoringMachineMemoryUsageEventName:    "Machine high memory load",
+	MonitoringMachineNotSyncedEventName:      "Machine beacon node not synced",
+	MonitoringMachineElDisconnectedEventName: "Machine execution client disconnected",
+	MonitoringMachineLowPeerCountEventName:   "Machine low peer count",
+	MonitoringMachineHeadSlotLagEventName:    "Machine head slot lagging",
+	MonitoringMachineFallbackInUseEventName:  "Machine fallback in use",
+	MonitoringMachineClientOutdatedEventName: "Machine client outdated",
 	TaxReportEventName:                       "Tax report available",
 	RocketpoolCommissionThresholdEventName:   "Rocket pool commission threshold is reached",
 	RocketpoolNewClaimRoundStartedEventName:  "Rocket pool claim from last round is available",
@@ -239,19 +303,29 @@ func IsMachineNotification(event EventName) bool {
 var EventNames = []EventName{
 	ValidatorExecutedProposalEventName,
 	ValidatorGroupEfficiencyEventName,
+	ValidatorDashboardRuleEventName,
 	ValidatorMissedProposalEventName,
 	ValidatorMissedAttestationEventName,
 	ValidatorGotSlashedEventName,
 	ValidatorDidSlashEventName,
 	ValidatorIsOfflineEventName,
 	ValidatorIsOnlineEventName,
+	ValidatorIsUnstableEventName,
 	ValidatorReceivedWithdrawalEventName,
+	ValidatorPartialWithdrawalEventName,
+	ValidatorConsolidationEventName,
 	NetworkLivenessIncreasedEventName,
 	EthClientUpdateEventName,
 	MonitoringMachineOfflineEventName,
 	MonitoringMachineDiskAlmostFullEventName,
 	MonitoringMachineCpuLoadEventName,
 	MonitoringMachineMemoryUsageEventName,
+	MonitoringMachineNotSyncedEventName,
+	MonitoringMachineElDisconnectedEventName,
+	MonitoringMachineLowPeerCountEventName,
+	MonitoringMachineHeadSlotLagEventName,
+	MonitoringMachineFallbackInUseEventName,
+	MonitoringMachineClientOutdatedEventName,
 	TaxReportEventName,
 	RocketpoolCommissionThresholdEventName,
 	RocketpoolNewClaimRoundStartedEventName,
@@ -276,6 +350,22 @@ type MachineMetricSystemUser struct {
 	CurrentDataInsertTs       int64
 	FiveMinuteOldData         *MachineMetricSystem
 	FiveMinuteOldDataInsertTs int64
+	NodeData                  []*MachineMetricNode      // latest beacon node samples, newest first
+	NodeDataInsertTs          int64                     // insert timestamp of the latest beacon node sample
+	ValidatorData             []*MachineMetricValidator // latest validator client samples, newest first
+	ValidatorDataInsertTs     int64                     // insert timestamp of the latest validator client sample
+	ExecutionData             []*MachineMetricExecution // latest execution client samples, newest first
+	ExecutionDataInsertTs     int64                     // insert timestamp of the latest execution client sample
+	LastDataInsertTs          int64                     // insert timestamp of the latest sample of any process, only used to detect offline machines
+}
+
+// MachineMetricClient is the client a process of a machine last reported
+type MachineMetricClient struct {
+	Machine       string
+	Process       string
+	ClientName    string
+	ClientVersion string
+	InsertTs      int64
 }
 
 // this is the source of truth for the validator events that are supported by the user/notification page
@@ -311,6 +401,16 @@ var AddWatchlistEvents = []EventNameDesc{
 		Event: ValidatorReceivedWithdrawalEventName,
 		Info:  template.HTML(`<i data-toggle="tooltip" data-html="true" title="<div class='text-left'>Will trigger a notifcation when:<br><ul><li>A partial withdrawal is processed</li><li>Your validator exits and its full balance is withdrawn</li></ul> <div>Requires that your validator has 0x01 credentials</div></div>" class="fas fa-question-circle"></i>`),
 	},
+	{
+		Desc:  "Requested partial withdrawal processed",
+		Event: ValidatorPartialWithdrawalEventName,
+		Info:  template.HTML(`<i data-toggle="tooltip" data-html="true" title="<div class='text-left'>Will trigger a notifcation when a partial withdrawal requested from the withdrawal address is processed</div><div>Requires that your validator has 0x02 credentials</div>" class="fas fa-question-circle"></i>`),
+	},
+	{
+		Desc:  "Consolidation completed",
+		Event: ValidatorConsolidationEventName,
+		Info:  template.HTML(`<i data-toggle="tooltip" data-html="true" title="<div class='text-left'>Will trigger a notifcation when the balance of a consolidated validator was moved to its target validator</div>" class="fas fa-question-circle"></i>`),
+	},
 }
 
 // this is the source of truth for the network events that are supported by the user/notification page
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.33.0
 // 	protoc        v3.21.9
 // source: machine.proto
 
@@ -569,6 +569,314 @@ func (x *MachineMetricNode) GetMachine() string {
 	return ""
 }
 
+type MachineMetricExecution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp       uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExporterVersion string `protobuf:"bytes,2,opt,name=exporter_version,json=exporterVersion,proto3" json:"exporter_version,omitempty"`
+	// process
+	CpuProcessSecondsTotal uint64 `protobuf:"varint,3,opt,name=cpu_process_seconds_total,json=cpuProcessSecondsTotal,proto3" json:"cpu_process_seconds_total,omitempty"`
+	MemoryProcessBytes     uint64 `protobuf:"varint,4,opt,name=memory_process_bytes,json=memoryProcessBytes,proto3" json:"memory_process_bytes,omitempty"`
+	ClientName             string `protobuf:"bytes,5,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientVersion          string `protobuf:"bytes,6,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	ClientBuild            uint64 `protobuf:"varint,7,opt,name=client_build,json=clientBuild,proto3" json:"client_build,omitempty"`
+	// execution
+	SyncExecutionSynced     bool   `protobuf:"varint,8,opt,name=sync_execution_synced,json=syncExecutionSynced,proto3" json:"sync_execution_synced,omitempty"`
+	SyncExecutionHeadBlock  uint64 `protobuf:"varint,9,opt,name=sync_execution_head_block,json=syncExecutionHeadBlock,proto3" json:"sync_execution_head_block,omitempty"`
+	NetworkPeersConnected   uint64 `protobuf:"varint,10,opt,name=network_peers_connected,json=networkPeersConnected,proto3" json:"network_peers_connected,omitempty"`
+	TxpoolTransactions      uint64 `protobuf:"varint,11,opt,name=txpool_transactions,json=txpoolTransactions,proto3" json:"txpool_transactions,omitempty"`
+	DiskExecutionBytesTotal uint64 `protobuf:"varint,12,opt,name=disk_execution_bytes_total,json=diskExecutionBytesTotal,proto3" json:"disk_execution_bytes_total,omitempty"`
+	// do not store in bigtable but include them in generated model
+	Machine *string `protobuf:"bytes,13,opt,name=machine,proto3,oneof" json:"machine,omitempty"`
+}
+
+func (x *MachineMetricExecution) Reset() {
+	*x = MachineMetricExecution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machine_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineMetricExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineMetricExecution) ProtoMessage() {}
+
+func (x *MachineMetricExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_machine_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineMetricExecution.ProtoReflect.Descriptor instead.
+func (*MachineMetricExecution) Descriptor() ([]byte, []int) {
+	return file_machine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MachineMetricExecution) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetExporterVersion() string {
+	if x != nil {
+		return x.ExporterVersion
+	}
+	return ""
+}
+
+func (x *MachineMetricExecution) GetCpuProcessSecondsTotal() uint64 {
+	if x != nil {
+		return x.CpuProcessSecondsTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetMemoryProcessBytes() uint64 {
+	if x != nil {
+		return x.MemoryProcessBytes
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *MachineMetricExecution) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *MachineMetricExecution) GetClientBuild() uint64 {
+	if x != nil {
+		return x.ClientBuild
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetSyncExecutionSynced() bool {
+	if x != nil {
+		return x.SyncExecutionSynced
+	}
+	return false
+}
+
+func (x *MachineMetricExecution) GetSyncExecutionHeadBlock() uint64 {
+	if x != nil {
+		return x.SyncExecutionHeadBlock
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetNetworkPeersConnected() uint64 {
+	if x != nil {
+		return x.NetworkPeersConnected
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetTxpoolTransactions() uint64 {
+	if x != nil {
+		return x.TxpoolTransactions
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetDiskExecutionBytesTotal() uint64 {
+	if x != nil {
+		return x.DiskExecutionBytesTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricExecution) GetMachine() string {
+	if x != nil && x.Machine != nil {
+		return *x.Machine
+	}
+	return ""
+}
+
+type MachineMetricSlasher struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp       uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExporterVersion string `protobuf:"bytes,2,opt,name=exporter_version,json=exporterVersion,proto3" json:"exporter_version,omitempty"`
+	// process
+	CpuProcessSecondsTotal uint64 `protobuf:"varint,3,opt,name=cpu_process_seconds_total,json=cpuProcessSecondsTotal,proto3" json:"cpu_process_seconds_total,omitempty"`
+	MemoryProcessBytes     uint64 `protobuf:"varint,4,opt,name=memory_process_bytes,json=memoryProcessBytes,proto3" json:"memory_process_bytes,omitempty"`
+	ClientName             string `protobuf:"bytes,5,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientVersion          string `protobuf:"bytes,6,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	ClientBuild            uint64 `protobuf:"varint,7,opt,name=client_build,json=clientBuild,proto3" json:"client_build,omitempty"`
+	// slasher
+	SyncSlasherSynced                  bool   `protobuf:"varint,8,opt,name=sync_slasher_synced,json=syncSlasherSynced,proto3" json:"sync_slasher_synced,omitempty"`
+	SyncSlasherHeadEpoch               uint64 `protobuf:"varint,9,opt,name=sync_slasher_head_epoch,json=syncSlasherHeadEpoch,proto3" json:"sync_slasher_head_epoch,omitempty"`
+	SlasherAttestationsProcessedTotal  uint64 `protobuf:"varint,10,opt,name=slasher_attestations_processed_total,json=slasherAttestationsProcessedTotal,proto3" json:"slasher_attestations_processed_total,omitempty"`
+	SlasherBlocksProcessedTotal        uint64 `protobuf:"varint,11,opt,name=slasher_blocks_processed_total,json=slasherBlocksProcessedTotal,proto3" json:"slasher_blocks_processed_total,omitempty"`
+	SlasherAttesterSlashingsFoundTotal uint64 `protobuf:"varint,12,opt,name=slasher_attester_slashings_found_total,json=slasherAttesterSlashingsFoundTotal,proto3" json:"slasher_attester_slashings_found_total,omitempty"`
+	SlasherProposerSlashingsFoundTotal uint64 `protobuf:"varint,13,opt,name=slasher_proposer_slashings_found_total,json=slasherProposerSlashingsFoundTotal,proto3" json:"slasher_proposer_slashings_found_total,omitempty"`
+	DiskSlasherBytesTotal              uint64 `protobuf:"varint,14,opt,name=disk_slasher_bytes_total,json=diskSlasherBytesTotal,proto3" json:"disk_slasher_bytes_total,omitempty"`
+	// do not store in bigtable but include them in generated model
+	Machine *string `protobuf:"bytes,15,opt,name=machine,proto3,oneof" json:"machine,omitempty"`
+}
+
+func (x *MachineMetricSlasher) Reset() {
+	*x = MachineMetricSlasher{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_machine_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineMetricSlasher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineMetricSlasher) ProtoMessage() {}
+
+func (x *MachineMetricSlasher) ProtoReflect() protoreflect.Message {
+	mi := &file_machine_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineMetricSlasher.ProtoReflect.Descriptor instead.
+func (*MachineMetricSlasher) Descriptor() ([]byte, []int) {
+	return file_machine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MachineMetricSlasher) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetExporterVersion() string {
+	if x != nil {
+		return x.ExporterVersion
+	}
+	return ""
+}
+
+func (x *MachineMetricSlasher) GetCpuProcessSecondsTotal() uint64 {
+	if x != nil {
+		return x.CpuProcessSecondsTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetMemoryProcessBytes() uint64 {
+	if x != nil {
+		return x.MemoryProcessBytes
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *MachineMetricSlasher) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *MachineMetricSlasher) GetClientBuild() uint64 {
+	if x != nil {
+		return x.ClientBuild
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetSyncSlasherSynced() bool {
+	if x != nil {
+		return x.SyncSlasherSynced
+	}
+	return false
+}
+
+func (x *MachineMetricSlasher) GetSyncSlasherHeadEpoch() uint64 {
+	if x != nil {
+		return x.SyncSlasherHeadEpoch
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetSlasherAttestationsProcessedTotal() uint64 {
+	if x != nil {
+		return x.SlasherAttestationsProcessedTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetSlasherBlocksProcessedTotal() uint64 {
+	if x != nil {
+		return x.SlasherBlocksProcessedTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetSlasherAttesterSlashingsFoundTotal() uint64 {
+	if x != nil {
+		return x.SlasherAttesterSlashingsFoundTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetSlasherProposerSlashingsFoundTotal() uint64 {
+	if x != nil {
+		return x.SlasherProposerSlashingsFoundTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetDiskSlasherBytesTotal() uint64 {
+	if x != nil {
+		return x.DiskSlasherBytesTotal
+	}
+	return 0
+}
+
+func (x *MachineMetricSlasher) GetMachine() string {
+	if x != nil && x.Machine != nil {
+		return *x.Machine
+	}
+	return ""
+}
+
 var File_machine_proto protoreflect.FileDescriptor
 
 var file_machine_proto_rawDesc = []byte{
@@ -746,8 +1054,100 @@ var file_machine_proto_rawDesc = []byte{
 	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
 	0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28,
 	0x09, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x42,
-	0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e,
-	0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x22, 0xf9, 0x04, 0x0a, 0x16,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x39, 0x0a, 0x19, 0x63, 0x70, 0x75, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x16, 0x63, 0x70, 0x75, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x79, 0x6e, 0x63, 0x5f,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x19, 0x73,
+	0x79, 0x6e, 0x63, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65,
+	0x61, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16,
+	0x73, 0x79, 0x6e, 0x63, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x36, 0x0a, 0x17, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x2f,
+	0x0a, 0x13, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3b, 0x0a, 0x1a, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x17, 0x64, 0x69, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x07,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x22, 0xc0, 0x06, 0x0a, 0x14, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29,
+	0x0a, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x19, 0x63, 0x70, 0x75,
+	0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x63, 0x70,
+	0x75, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65,
+	0x72, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x73, 0x79, 0x6e, 0x63, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x53, 0x79, 0x6e, 0x63, 0x65,
+	0x64, 0x12, 0x35, 0x0a, 0x17, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65,
+	0x72, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x14, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x48,
+	0x65, 0x61, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x4f, 0x0a, 0x24, 0x73, 0x6c, 0x61, 0x73,
+	0x68, 0x65, 0x72, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x21, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x41,
+	0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x43, 0x0a, 0x1e, 0x73, 0x6c, 0x61,
+	0x73, 0x68, 0x65, 0x72, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x1b, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x52,
+	0x0a, 0x26, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x22,
+	0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x72, 0x53,
+	0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x52, 0x0a, 0x26, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x5f, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73,
+	0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x22, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x65, 0x72, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x46, 0x6f, 0x75, 0x6e,
+	0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x37, 0x0a, 0x18, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73,
+	0x6c, 0x61, 0x73, 0x68, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x6c,
+	0x61, 0x73, 0x68, 0x65, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x1d, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0a,
+	0x0a, 0x08, 0x5f, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -762,11 +1162,13 @@ func file_machine_proto_rawDescGZIP() []byte {
 	return file_machine_proto_rawDescData
 }
 
-var file_machine_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_machine_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_machine_proto_goTypes = []interface{}{
 	(*MachineMetricSystem)(nil),    // 0: types.MachineMetricSystem
 	(*MachineMetricValidator)(nil), // 1: types.MachineMetricValidator
 	(*MachineMetricNode)(nil),      // 2: types.MachineMetricNode
+	(*MachineMetricExecution)(nil), // 3: types.MachineMetricExecution
+	(*MachineMetricSlasher)(nil),   // 4: types.MachineMetricSlasher
 }
 var file_machine_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -818,17 +1220,43 @@ func file_machine_proto_init() {
 				return nil
 			}
 		}
+		file_machine_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineMetricExecution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_machine_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineMetricSlasher); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_machine_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	file_machine_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_machine_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_machine_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_machine_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_machine_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -41,3 +41,79 @@ type ValidatorDashboardGroup struct {
 	Name       string `db:"name"`
 	Validators []types.ValidatorIndex
 }
+
+// NotificationRuleMetric is a dashboard group metric a user defined notification rule can be evaluated on
+type NotificationRuleMetric string
+
+const (
+	NotificationRuleMetricEfficiency         NotificationRuleMetric = "efficiency"          // group efficiency, 0-1
+	NotificationRuleMetricMissedAttestations NotificationRuleMetric = "missed_attestations" // number of missed attestations of the group
+	NotificationRuleMetricBalanceDelta       NotificationRuleMetric = "balance_delta"       // change of the group balance in ETH
+	NotificationRuleMetricProposalLuck       NotificationRuleMetric = "proposal_luck"       // proposed / expected blocks, 0-n
+	NotificationRuleMetricSyncParticipation  NotificationRuleMetric = "sync_participation"  // executed / scheduled sync committee duties, 0-1
+)
+
+var NotificationRuleMetrics = []NotificationRuleMetric{
+	NotificationRuleMetricEfficiency,
+	NotificationRuleMetricMissedAttestations,
+	NotificationRuleMetricBalanceDelta,
+	NotificationRuleMetricProposalLuck,
+	NotificationRuleMetricSyncParticipation,
+}
+
+// NotificationRuleComparison is the operator used to compare a rule metric against its threshold
+type NotificationRuleComparison string
+
+const (
+	NotificationRuleComparisonLessThan           NotificationRuleComparison = "lt"
+	NotificationRuleComparisonLessThanOrEqual    NotificationRuleComparison = "lte"
+	NotificationRuleComparisonGreaterThan        NotificationRuleComparison = "gt"
+	NotificationRuleComparisonGreaterThanOrEqual NotificationRuleComparison = "gte"
+)
+
+var NotificationRuleComparisons = []NotificationRuleComparison{
+	NotificationRuleComparisonLessThan,
+	NotificationRuleComparisonLessThanOrEqual,
+	NotificationRuleComparisonGreaterThan,
+	NotificationRuleComparisonGreaterThanOrEqual,
+}
+
+// Matches reports whether value satisfies the comparison against threshold
+func (c NotificationRuleComparison) Matches(value, threshold float64) bool {
+	switch c {
+	case NotificationRuleComparisonLessThan:
+		return value < threshold
+	case NotificationRuleComparisonLessThanOrEqual:
+		return value <= threshold
+	case NotificationRuleComparisonGreaterThan:
+		return value > threshold
+	case NotificationRuleComparisonGreaterThanOrEqual:
+		return value >= threshold
+	default:
+		return false
+	}
+}
+
+// Symbol returns the mathematical representation of the comparison, used when rendering notifications
+func (c NotificationRuleComparison) Symbol() string {
+	switch c {
+	case NotificationRuleComparisonLessThan:
+		return "<"
+	case NotificationRuleComparisonLessThanOrEqual:
+		return "<="
+	case NotificationRuleComparisonGreaterThan:
+		return ">"
+	case NotificationRuleComparisonGreaterThanOrEqual:
+		return ">="
+	default:
+		return string(c)
+	}
+}
+
+// NotificationIncidentStatus is the state of a group of related notifications, e.g. a validator going offline and online again
+type NotificationIncidentStatus string
+
+const (
+	NotificationIncidentStatusOpen     NotificationIncidentStatus = "open"
+	NotificationIncidentStatusResolved NotificationIncidentStatus = "resolved"
+)
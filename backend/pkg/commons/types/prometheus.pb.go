@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.9
+// source: prometheus.proto
+
+package types
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// subset of the prometheus remote-write protocol (prometheus/prompb), field numbers must match
+type PrometheusWriteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeseries []*PrometheusTimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries,omitempty"`
+}
+
+func (x *PrometheusWriteRequest) Reset() {
+	*x = PrometheusWriteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prometheus_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrometheusWriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrometheusWriteRequest) ProtoMessage() {}
+
+func (x *PrometheusWriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prometheus_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrometheusWriteRequest.ProtoReflect.Descriptor instead.
+func (*PrometheusWriteRequest) Descriptor() ([]byte, []int) {
+	return file_prometheus_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrometheusWriteRequest) GetTimeseries() []*PrometheusTimeSeries {
+	if x != nil {
+		return x.Timeseries
+	}
+	return nil
+}
+
+type PrometheusTimeSeries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels  []*PrometheusLabel  `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	Samples []*PrometheusSample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples,omitempty"`
+}
+
+func (x *PrometheusTimeSeries) Reset() {
+	*x = PrometheusTimeSeries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prometheus_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrometheusTimeSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrometheusTimeSeries) ProtoMessage() {}
+
+func (x *PrometheusTimeSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_prometheus_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrometheusTimeSeries.ProtoReflect.Descriptor instead.
+func (*PrometheusTimeSeries) Descriptor() ([]byte, []int) {
+	return file_prometheus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PrometheusTimeSeries) GetLabels() []*PrometheusLabel {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PrometheusTimeSeries) GetSamples() []*PrometheusSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type PrometheusLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PrometheusLabel) Reset() {
+	*x = PrometheusLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prometheus_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrometheusLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrometheusLabel) ProtoMessage() {}
+
+func (x *PrometheusLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_prometheus_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrometheusLabel.ProtoReflect.Descriptor instead.
+func (*PrometheusLabel) Descriptor() ([]byte, []int) {
+	return file_prometheus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PrometheusLabel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PrometheusLabel) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PrometheusSample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value     float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Timestamp int64   `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *PrometheusSample) Reset() {
+	*x = PrometheusSample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_prometheus_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrometheusSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrometheusSample) ProtoMessage() {}
+
+func (x *PrometheusSample) ProtoReflect() protoreflect.Message {
+	mi := &file_prometheus_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrometheusSample.ProtoReflect.Descriptor instead.
+func (*PrometheusSample) Descriptor() ([]byte, []int) {
+	return file_prometheus_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PrometheusSample) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *PrometheusSample) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_prometheus_proto protoreflect.FileDescriptor
+
+var file_prometheus_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x55, 0x0a, 0x16, 0x50, 0x72, 0x6f,
+	0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x50, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x22, 0x79, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x54, 0x69,
+	0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x0f, 0x50,
+	0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x46, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x6d,
+	0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_prometheus_proto_rawDescOnce sync.Once
+	file_prometheus_proto_rawDescData = file_prometheus_proto_rawDesc
+)
+
+func file_prometheus_proto_rawDescGZIP() []byte {
+	file_prometheus_proto_rawDescOnce.Do(func() {
+		file_prometheus_proto_rawDescData = protoimpl.X.CompressGZIP(file_prometheus_proto_rawDescData)
+	})
+	return file_prometheus_proto_rawDescData
+}
+
+var file_prometheus_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_prometheus_proto_goTypes = []interface{}{
+	(*PrometheusWriteRequest)(nil), // 0: types.PrometheusWriteRequest
+	(*PrometheusTimeSeries)(nil),   // 1: types.PrometheusTimeSeries
+	(*PrometheusLabel)(nil),        // 2: types.PrometheusLabel
+	(*PrometheusSample)(nil),       // 3: types.PrometheusSample
+}
+var file_prometheus_proto_depIdxs = []int32{
+	1, // 0: types.PrometheusWriteRequest.timeseries:type_name -> types.PrometheusTimeSeries
+	2, // 1: types.PrometheusTimeSeries.labels:type_name -> types.PrometheusLabel
+	3, // 2: types.PrometheusTimeSeries.samples:type_name -> types.PrometheusSample
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_prometheus_proto_init() }
+func file_prometheus_proto_init() {
+	if File_prometheus_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_prometheus_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrometheusWriteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prometheus_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrometheusTimeSeries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prometheus_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrometheusLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_prometheus_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrometheusSample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_prometheus_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_prometheus_proto_goTypes,
+		DependencyIndexes: file_prometheus_proto_depIdxs,
+		MessageInfos:      file_prometheus_proto_msgTypes,
+	}.Build()
+	File_prometheus_proto = out.File
+	file_prometheus_proto_rawDesc = nil
+	file_prometheus_proto_goTypes = nil
+	file_prometheus_proto_depIdxs = nil
+}
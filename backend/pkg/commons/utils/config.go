@@ -3,11 +3,13 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/gobitfly/beaconchain/pkg/commons/config"
@@ -248,6 +250,19 @@ func ReadConfig(cfg *types.Config, path string) error {
 		cfg.RedisSessionStoreEndpoint = cfg.RedisCacheEndpoint
 	}
 
+	if cfg.DashboardExecutionRewardsMaxWait == 0 {
+		cfg.DashboardExecutionRewardsMaxWait = 30 * time.Minute
+	}
+
+	if cfg.TrackedValidators.Enabled {
+		if cfg.TrackedValidators.NetworkSampleInterval == 0 {
+			cfg.TrackedValidators.NetworkSampleInterval = 64
+		}
+		if len(cfg.TrackedValidators.Indices) == 0 && !cfg.TrackedValidators.IncludeDashboards {
+			log.Warnf("tracked validators are enabled without indices or dashboards, only the network sample will be exported")
+		}
+	}
+
 	confSanityCheck(cfg)
 
 	log.InfoWithFields(log.Fields{
@@ -320,6 +335,17 @@ func setELConfig(cfg *types.Config) error {
 	return nil
 }
 
+// BeaconNodeEndpoints returns the url of the configured beacon node followed by all configured fallback nodes
+func BeaconNodeEndpoints(cfg *types.Config) []string {
+	endpoints := []string{fmt.Sprintf("http://%s", net.JoinHostPort(cfg.Indexer.Node.Host, cfg.Indexer.Node.Port))}
+	for _, fallback := range cfg.Indexer.Node.Fallbacks {
+		if fallback != "" {
+			endpoints = append(endpoints, fallback)
+		}
+	}
+	return endpoints
+}
+
 func setCLConfig(cfg *types.Config) error {
 	var err error
 	if cfg.Chain.ClConfigPath == "" {
@@ -348,8 +374,11 @@ func setCLConfig(cfg *types.Config) error {
 		// 	return fmt.Errorf("error setting chainConfig (%v) for prysmParams: %w", cfg.Chain.Name, err)
 		// }
 	} else if cfg.Chain.ClConfigPath == "node" {
-		nodeEndpoint := fmt.Sprintf("http://%s", net.JoinHostPort(cfg.Indexer.Node.Host, cfg.Indexer.Node.Port))
-		client := consapi.NewClient(nodeEndpoint)
+		client := consapi.NewClientForEndpoints(BeaconNodeEndpoints(cfg))
+		if pool, ok := client.ClientInt.(*consapi.PoolClient); ok {
+			// the client is only needed to read the spec, stop its health checks afterwards
+			defer pool.Close()
+		}
 
 		jr, err := client.GetSpec()
 		if err != nil {
@@ -374,6 +403,10 @@ func setCLConfig(cfg *types.Config) error {
 			log.Warnf("DenebForkEpoch not set, defaulting to maxForkEpoch")
 			jr.Data.DenebForkEpoch = &maxForkEpoch
 		}
+		if jr.Data.ElectraForkEpoch == nil {
+			log.Warnf("ElectraForkEpoch not set, defaulting to maxForkEpoch")
+			jr.Data.ElectraForkEpoch = &maxForkEpoch
+		}
 
 		chainCfg := types.ClChainConfig{
 			PresetBase:                              jr.Data.PresetBase,
@@ -393,6 +426,8 @@ func setCLConfig(cfg *types.Config) error {
 			CappellaForkEpoch:                       *jr.Data.CapellaForkEpoch,
 			DenebForkVersion:                        jr.Data.DenebForkVersion,
 			DenebForkEpoch:                          *jr.Data.DenebForkEpoch,
+			ElectraForkVersion:                      jr.Data.ElectraForkVersion,
+			ElectraForkEpoch:                        *jr.Data.ElectraForkEpoch,
 			SecondsPerSlot:                          uint64(jr.Data.SecondsPerSlot),
 			SecondsPerEth1Block:                     uint64(jr.Data.SecondsPerEth1Block),
 			MinValidatorWithdrawabilityDelay:        uint64(jr.Data.MinValidatorWithdrawabilityDelay),
@@ -456,6 +491,18 @@ func setCLConfig(cfg *types.Config) error {
 			MaxWithdrawalsPerPayload:                uint64(jr.Data.MaxWithdrawalsPerPayload),
 			MaxValidatorsPerWithdrawalSweep:         uint64(jr.Data.MaxValidatorsPerWithdrawalsSweep),
 			MaxBlsToExecutionChange:                 uint64(jr.Data.MaxBlsToExecutionChanges),
+			MinActivationBalance:                    valueOrZero(jr.Data.MinActivationBalance),
+			MaxEffectiveBalanceElectra:              valueOrZero(jr.Data.MaxEffectiveBalanceElectra),
+			MinPerEpochChurnLimitElectra:            valueOrZero(jr.Data.MinPerEpochChurnLimitElectra),
+			MaxPerEpochActivationExitChurnLimit:     valueOrZero(jr.Data.MaxPerEpochActivationExitChurnLimit),
+			MaxPendingPartialsPerWithdrawalsSweep:   valueOrZero(jr.Data.MaxPendingPartialsPerWithdrawalsSweep),
+			MaxPendingDepositsPerEpoch:              valueOrZero(jr.Data.MaxPendingDepositsPerEpoch),
+			MaxDepositRequestsPerPayload:            valueOrZero(jr.Data.MaxDepositRequestsPerPayload),
+			MaxWithdrawalRequestsPerPayload:         valueOrZero(jr.Data.MaxWithdrawalRequestsPerPayload),
+			MaxConsolidationRequestsPerPayload:      valueOrZero(jr.Data.MaxConsolidationRequestsPerPayload),
+			MaxAttesterSlashingsElectra:             valueOrZero(jr.Data.MaxAttesterSlashingsElectra),
+			MaxAttestationsElectra:                  valueOrZero(jr.Data.MaxAttestationsElectra),
+			MaxBlobsPerBlockElectra:                 valueOrZero(jr.Data.MaxBlobsPerBlockElectra),
 		}
 
 		cfg.Chain.ClConfig = chainCfg
@@ -483,6 +530,17 @@ func setCLConfig(cfg *types.Config) error {
 		cfg.Chain.ClConfig = *chainConfig
 	}
 
+	// chain configs predating electra do not define it
+	if cfg.Chain.ClConfig.ElectraForkVersion == "" {
+		cfg.Chain.ClConfig.ElectraForkEpoch = math.MaxUint64
+	}
+	if cfg.Chain.ClConfig.MinActivationBalance == 0 {
+		cfg.Chain.ClConfig.MinActivationBalance = cfg.Chain.ClConfig.MaxEffectiveBalance
+	}
+	if cfg.Chain.ClConfig.MaxEffectiveBalanceElectra == 0 {
+		cfg.Chain.ClConfig.MaxEffectiveBalanceElectra = 2048 * cfg.Chain.ClConfig.EffectiveBalanceIncrement
+	}
+
 	// rewrite to match to allow trace as well
 	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
 	case "trace":
@@ -10,6 +10,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 )
@@ -238,3 +239,36 @@ func FirstEpochOfSyncPeriod(syncPeriod uint64) uint64 {
 func SlotsPerSyncCommittee() uint64 {
 	return Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod * Config.Chain.ClConfig.SlotsPerEpoch
 }
+
+// IsNetworkSampleValidator returns whether the validator is part of the network sample that is exported in tracked validators mode
+func IsNetworkSampleValidator(validatorIndex uint64) bool {
+	return Config.TrackedValidators.NetworkSampleInterval > 0 && validatorIndex%Config.TrackedValidators.NetworkSampleInterval == 0
+}
+
+// GetAttestingValidators resolves the set aggregation bits of an attestation to validator indices.
+// Before electra the bits cover the single committee of the attestation data, since electra (EIP-7549) they are the
+// concatenation of the bits of all committees in committeeIndices. lookup returns the validator at a committee position.
+func GetAttestingValidators(aggregationBits bitfield.Bitlist, committeeIndices []uint64, lookup func(committeeIndex, memberIndex uint64) (uint64, bool)) ([]uint64, error) {
+	attesters := make([]uint64, 0, aggregationBits.Count())
+	offset := uint64(0)
+	for _, committeeIndex := range committeeIndices {
+		member := uint64(0)
+		for ; ; member++ {
+			validator, found := lookup(committeeIndex, member)
+			if !found {
+				break
+			}
+			if offset+member < aggregationBits.Len() && aggregationBits.BitAt(offset+member) {
+				attesters = append(attesters, validator)
+			}
+		}
+		if member == 0 {
+			return nil, fmt.Errorf("no assignments found for committee %v", committeeIndex)
+		}
+		offset += member
+	}
+	if offset != aggregationBits.Len() {
+		return nil, fmt.Errorf("aggregation bits length %v does not match committee sizes %v", aggregationBits.Len(), offset)
+	}
+	return attesters, nil
+}
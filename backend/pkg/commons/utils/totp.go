@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // TOTP (RFC 6238) uses HMAC-SHA1 by default, which authenticator apps expect
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpPeriod     = 30 // seconds
+	totpDigits     = 6
+	totpSecretSize = 20 // bytes, as recommended by RFC 4226
+	totpSkew       = 1  // number of periods before and after the current one which are accepted to allow for clock drift
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random base32 encoded secret for time-based one-time passwords
+func GenerateTotpSecret() (string, error) {
+	secret, err := GenerateRandomBytesSecure(totpSecretSize)
+	if err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpProvisioningUri returns the otpauth uri which can be shown as QR code to be scanned by authenticator apps
+func TotpProvisioningUri(secret, issuer, account string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// ValidateTotpCode checks the code against the secret at the given time.
+// It returns the time step of the matching code, which should be stored to prevent the code from being used twice.
+func ValidateTotpCode(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+	counter := t.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, counter+i, totpDigits)), []byte(code)) == 1 {
+			return counter + i, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateTotpCode returns the code of the secret at the given time, like an authenticator app would
+func GenerateTotpCode(secret string, t time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+	return totpCode(key, t.Unix()/totpPeriod, totpDigits), nil
+}
+
+// totpCode implements the HOTP algorithm of RFC 4226
+func totpCode(key []byte, counter int64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// test vectors from RFC 6238, appendix B (SHA1)
+func TestTotpCode(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		ts   int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		if got := totpCode(key, tt.ts/totpPeriod, 8); got != tt.code {
+			t.Errorf("totpCode at %d = %s, want %s", tt.ts, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTotpCode(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+
+	// the last 6 digits of the RFC test vector
+	counter, ok := ValidateTotpCode(secret, "050471", now)
+	if !ok || counter != 1111111111/totpPeriod {
+		t.Errorf("expected code to be valid for counter %d, got %d, %v", 1111111111/totpPeriod, counter, ok)
+	}
+	// codes of the previous period are accepted to allow for clock drift
+	if _, ok := ValidateTotpCode(secret, "050471", now.Add(totpPeriod*time.Second)); !ok {
+		t.Error("expected code of the previous period to be valid")
+	}
+	if _, ok := ValidateTotpCode(secret, "050471", now.Add(3*totpPeriod*time.Second)); ok {
+		t.Error("expected code to be expired")
+	}
+	if _, ok := ValidateTotpCode(secret, "123456", now); ok {
+		t.Error("expected wrong code to be invalid")
+	}
+}
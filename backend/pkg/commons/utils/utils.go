@@ -27,6 +27,14 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 )
 
+// valueOrZero returns the value of an optional spec field, or 0 if the node does not define it
+func valueOrZero(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
 func mustParseUint(str string) uint64 {
 	if str == "" {
 		return 0
@@ -373,6 +381,32 @@ func GetAddressOfWithdrawalCredentials(withCred []byte) (*common.Address, error)
 	addr := common.BytesToAddress(withCred[12:])
 	return &addr, nil
 }
+
+const (
+	BlsWithdrawalPrefix         byte = 0x00
+	Eth1AddressWithdrawalPrefix byte = 0x01
+	CompoundingWithdrawalPrefix byte = 0x02 // EIP-7251, available since electra
+)
+
+// HasExecutionWithdrawalCredentials returns true if the withdrawal credentials point to an execution layer address (0x01 or 0x02)
+func HasExecutionWithdrawalCredentials(withCred []byte) bool {
+	return len(withCred) == 32 && (withCred[0] == Eth1AddressWithdrawalPrefix || withCred[0] == CompoundingWithdrawalPrefix)
+}
+
+// HasCompoundingWithdrawalCredentials returns true for 0x02 withdrawal credentials
+func HasCompoundingWithdrawalCredentials(withCred []byte) bool {
+	return len(withCred) == 32 && withCred[0] == CompoundingWithdrawalPrefix
+}
+
+// GetMaxEffectiveBalance returns the max effective balance of a validator with the given withdrawal credentials.
+// Compounding validators may grow up to MAX_EFFECTIVE_BALANCE_ELECTRA, all others are capped at MIN_ACTIVATION_BALANCE.
+func GetMaxEffectiveBalance(withCred []byte) uint64 {
+	if HasCompoundingWithdrawalCredentials(withCred) {
+		return Config.Chain.ClConfig.MaxEffectiveBalanceElectra
+	}
+	return Config.Chain.ClConfig.MinActivationBalance
+}
+
 func GetWithdrawalCredentialsOfAddress(addr common.Address) []byte {
 	// Create a new byte slice with the desired prefix
 	prefix := []byte{0x01}
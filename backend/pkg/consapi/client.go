@@ -32,6 +32,10 @@ type ClientInt interface {
 	// /eth/v1/beacon/rewards/attestations/{epoch}
 	GetAttestationRewards(epoch uint64) (*types.StandardAttestationRewardsResponse, error)
 
+	// Only returns the total rewards of the given validators, the ideal rewards are always returned
+	// /eth/v1/beacon/rewards/attestations/{epoch}
+	GetAttestationRewardsOfValidators(epoch uint64, indices []uint64) (*types.StandardAttestationRewardsResponse, error)
+
 	// /eth/v1/beacon/states/{state_id}/sync_committees
 	GetSyncCommitteesAssignments(epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error)
 
@@ -57,12 +61,29 @@ type ClientInt interface {
 	// /eth/v1/beacon/states/%v/committees
 	GetCommittees(stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error)
 
+	// /eth/v1/beacon/states/{state_id}/pending_deposits
+	GetPendingDeposits(stateID any) (*types.StandardPendingDepositsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_partial_withdrawals
+	GetPendingPartialWithdrawals(stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_consolidations
+	GetPendingConsolidations(stateID any) (*types.StandardPendingConsolidationsResponse, error)
+
 	// /eth/v1/beacon/genesis
 	GetGenesis() (*types.StandardGenesisResponse, error)
 
 	// /eth/v1/events
 	GetEvents(topics []types.EventTopic) chan *types.EventResponse
 }
+
+// EndpointProvider is implemented by clients that can tell which node raw, non-standard requests should be sent to
+type EndpointProvider interface {
+	CurrentEndpoint() string
+	// PrimaryEndpoint returns the first configured node, client specific apis must only be requested from it
+	PrimaryEndpoint() string
+}
+
 type NodeClient struct {
 	Endpoint   string
 	httpClient *http.Client
@@ -1,8 +1,10 @@
 package consapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +34,16 @@ func NewClientWithConfig(endpoint string, httpClient *http.Client) Client {
 	return retriever
 }
 
+// CurrentEndpoint returns the endpoint of the node
+func (r *NodeClient) CurrentEndpoint() string {
+	return r.Endpoint
+}
+
+// PrimaryEndpoint returns the endpoint of the node
+func (r *NodeClient) PrimaryEndpoint() string {
+	return r.Endpoint
+}
+
 func (r *NodeClient) GetValidatorBalances(stateID any) (*types.StandardValidatorBalancesResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/validator_balances", r.Endpoint, stateID)
 	return network.Get[types.StandardValidatorBalancesResponse](r.httpClient, requestURL)
@@ -122,6 +134,15 @@ func (r *NodeClient) GetAttestationRewards(epoch uint64) (*types.StandardAttesta
 	return network.Post[types.StandardAttestationRewardsResponse](r.httpClient, requestURL)
 }
 
+func (r *NodeClient) GetAttestationRewardsOfValidators(epoch uint64, indices []uint64) (*types.StandardAttestationRewardsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/rewards/attestations/%v", r.Endpoint, epoch)
+	ids := make([]string, len(indices))
+	for i, index := range indices {
+		ids[i] = strconv.FormatUint(index, 10)
+	}
+	return network.PostJSON[types.StandardAttestationRewardsResponse](r.httpClient, requestURL, ids)
+}
+
 func (r *NodeClient) GetBlobSidecars(blockID any) (*types.StandardBlobSidecarsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%v", r.Endpoint, blockID)
 	return network.Get[types.StandardBlobSidecarsResponse](r.httpClient, requestURL)
@@ -139,40 +160,76 @@ func (r *NodeClient) GetCommittees(stateID any, epoch, index, slot *uint64) (*ty
 	return network.Get[types.StandardCommitteesResponse](r.httpClient, requestURL)
 }
 
+func (r *NodeClient) GetPendingDeposits(stateID any) (*types.StandardPendingDepositsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_deposits", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingDepositsResponse](r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingPartialWithdrawals(stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_partial_withdrawals", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingPartialWithdrawalsResponse](r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingConsolidations(stateID any) (*types.StandardPendingConsolidationsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_consolidations", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingConsolidationsResponse](r.httpClient, requestURL)
+}
+
 func (r *NodeClient) GetGenesis() (*types.StandardGenesisResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/genesis", r.Endpoint)
 	return network.Get[types.StandardGenesisResponse](r.httpClient, requestURL)
 }
 
 func (r *NodeClient) GetEvents(topics []types.EventTopic) chan *types.EventResponse {
+	return r.SubscribeEvents(context.Background(), topics)
+}
+
+// SubscribeEvents subscribes to the event stream of the node until ctx is done, the returned channel is closed once the subscription ended
+func (r *NodeClient) SubscribeEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse {
 	joinedTopics := strings.Join(utils.ConvertToStringSlice(topics), ",")
 	requestURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", r.Endpoint, joinedTopics)
 	responseCh := make(chan *types.EventResponse, 32)
 
-	req, _ := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	// disable gzip compression for sse
 	req.Header.Set("accept-encoding", "identity")
 
+	send := func(response *types.EventResponse) bool {
+		select {
+		case responseCh <- response:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
 	go func() {
+		defer close(responseCh)
 		stream, err := eventsource.SubscribeWithRequest("", req)
 
 		if err != nil {
-			responseCh <- &types.EventResponse{Error: err}
+			send(&types.EventResponse{Error: err})
 			return
 		}
 		defer stream.Close()
 
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
 			case err := <-stream.Errors:
-				responseCh <- &types.EventResponse{Error: err}
+				if !send(&types.EventResponse{Error: err}) {
+					return
+				}
 			case e := <-stream.Events:
 				var response types.EventResponse
 				response.Data = []byte(e.Data())
 				response.Event = types.EventTopic(e.Event())
 
-				responseCh <- &response
+				if !send(&response) {
+					return
+				}
 			}
 		}
 	}()
@@ -0,0 +1,448 @@
+package consapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+const (
+	defaultPoolHealthCheckInterval = 12 * time.Second
+	defaultPoolHealthCheckTimeout  = 5 * time.Second
+	defaultPoolMaxSlotLag          = 2
+	// weight of the latest observation in the moving averages for latency and error rate
+	poolStatsAlpha = 0.2
+)
+
+// PoolConfig configures a PoolClient, zero values are replaced by sane defaults
+type PoolConfig struct {
+	// HealthCheckInterval is the interval in which the head slot of every node is polled
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout is the timeout of a single health check request
+	HealthCheckTimeout time.Duration
+	// MaxSlotLag is the amount of slots a node may lag behind the highest known head before it is considered out of sync
+	MaxSlotLag uint64
+	// HttpClient is used for all regular requests
+	HttpClient *http.Client
+}
+
+// PoolClient is a ClientInt that spreads requests over several beacon nodes. Every request is sent to the
+// healthiest synced node and transparently retried on the next best node if it fails with a transport error or a 5xx response.
+type PoolClient struct {
+	nodes        []*poolNode
+	config       PoolConfig
+	healthClient *http.Client
+	stop         chan struct{}
+	stopOnce     sync.Once
+}
+
+type poolNode struct {
+	client *NodeClient
+
+	mu        sync.RWMutex
+	healthy   bool
+	headSlot  uint64
+	latency   time.Duration
+	errorRate float64
+	lastError error
+}
+
+// PoolNodeStats is a snapshot of the health of a single node of a PoolClient
+type PoolNodeStats struct {
+	Endpoint  string
+	Healthy   bool
+	Synced    bool
+	HeadSlot  uint64
+	Latency   time.Duration
+	ErrorRate float64
+	LastError error
+}
+
+// NewClientForEndpoints returns a plain node client if a single endpoint is given and a PoolClient wrapping all endpoints otherwise
+func NewClientForEndpoints(endpoints []string) Client {
+	if len(endpoints) == 1 {
+		return NewClient(endpoints[0])
+	}
+	return NewPoolClient(endpoints, nil)
+}
+
+// NewPoolClient creates a PoolClient for the given endpoints, the first endpoint is preferred if all nodes are equally healthy.
+// The health of all nodes is checked once before returning and then periodically in the background until Close is called.
+func NewPoolClient(endpoints []string, config *PoolConfig) Client {
+	cfg := PoolConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.HealthCheckInterval == 0 {
+		cfg.HealthCheckInterval = defaultPoolHealthCheckInterval
+	}
+	if cfg.HealthCheckTimeout == 0 {
+		cfg.HealthCheckTimeout = defaultPoolHealthCheckTimeout
+	}
+	if cfg.MaxSlotLag == 0 {
+		cfg.MaxSlotLag = defaultPoolMaxSlotLag
+	}
+	if cfg.HttpClient == nil {
+		cfg.HttpClient = &http.Client{
+			Timeout: 500 * time.Second,
+		}
+	}
+
+	p := &PoolClient{
+		config:       cfg,
+		healthClient: &http.Client{Timeout: cfg.HealthCheckTimeout},
+		stop:         make(chan struct{}),
+	}
+	for _, endpoint := range endpoints {
+		p.nodes = append(p.nodes, &poolNode{
+			client: &NodeClient{
+				Endpoint:   endpoint,
+				httpClient: cfg.HttpClient,
+			},
+			// nodes are assumed to be healthy until proven otherwise
+			healthy: true,
+		})
+	}
+
+	p.checkHealth()
+	go p.healthLoop()
+
+	return Client{ClientInt: p}
+}
+
+// Close stops the background health checks
+func (p *PoolClient) Close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+// CurrentEndpoint returns the endpoint of the node requests are currently routed to
+func (p *PoolClient) CurrentEndpoint() string {
+	return p.orderedNodes()[0].client.Endpoint
+}
+
+// PrimaryEndpoint returns the endpoint of the first configured node regardless of its health
+func (p *PoolClient) PrimaryEndpoint() string {
+	return p.nodes[0].client.Endpoint
+}
+
+// Stats returns a snapshot of the health of all nodes ordered by preference
+func (p *PoolClient) Stats() []PoolNodeStats {
+	maxHead := p.maxHeadSlot()
+	nodes := p.orderedNodes()
+	stats := make([]PoolNodeStats, 0, len(nodes))
+	for _, n := range nodes {
+		n.mu.RLock()
+		stats = append(stats, PoolNodeStats{
+			Endpoint:  n.client.Endpoint,
+			Healthy:   n.healthy,
+			Synced:    n.isSynced(maxHead, p.config.MaxSlotLag),
+			HeadSlot:  n.headSlot,
+			Latency:   n.latency,
+			ErrorRate: n.errorRate,
+			LastError: n.lastError,
+		})
+		n.mu.RUnlock()
+	}
+	return stats
+}
+
+func (p *PoolClient) healthLoop() {
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.checkHealth()
+		}
+	}
+}
+
+// checkHealth polls the head header of all nodes concurrently
+func (p *PoolClient) checkHealth() {
+	wg := sync.WaitGroup{}
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *poolNode) {
+			defer wg.Done()
+			start := time.Now()
+			requestURL := fmt.Sprintf("%s/eth/v1/beacon/headers/head", n.client.Endpoint)
+			header, err := network.Get[types.StandardBeaconHeaderResponse](p.healthClient, requestURL)
+			duration := time.Since(start)
+
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			n.observe(duration, err)
+			if err != nil {
+				n.healthy = false
+				log.Warnf("beacon node %v failed health check: %v", n.client.Endpoint, err)
+				return
+			}
+			n.healthy = true
+			n.headSlot = header.Data.Header.Message.Slot
+		}(n)
+	}
+	wg.Wait()
+
+	maxHead := p.maxHeadSlot()
+	for _, n := range p.nodes {
+		n.mu.RLock()
+		synced := n.isSynced(maxHead, p.config.MaxSlotLag)
+		n.mu.RUnlock()
+		if !synced {
+			metrics.Counter.WithLabelValues("consapi_pool_node_unsynced").Inc()
+		}
+	}
+}
+
+func (p *PoolClient) maxHeadSlot() uint64 {
+	maxHead := uint64(0)
+	for _, n := range p.nodes {
+		n.mu.RLock()
+		if n.healthy && n.headSlot > maxHead {
+			maxHead = n.headSlot
+		}
+		n.mu.RUnlock()
+	}
+	return maxHead
+}
+
+// orderedNodes returns all nodes ordered by preference: synced before unsynced, healthy before unhealthy
+// and within each class by latency weighted with the recent error rate
+func (p *PoolClient) orderedNodes() []*poolNode {
+	type rankedNode struct {
+		node    *poolNode
+		healthy bool
+		synced  bool
+		score   float64
+	}
+
+	maxHead := p.maxHeadSlot()
+	ranked := make([]rankedNode, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		n.mu.RLock()
+		ranked = append(ranked, rankedNode{
+			node:    n,
+			healthy: n.healthy,
+			synced:  n.isSynced(maxHead, p.config.MaxSlotLag),
+			score:   float64(n.latency) * (1 + 10*n.errorRate),
+		})
+		n.mu.RUnlock()
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].synced != ranked[j].synced {
+			return ranked[i].synced
+		}
+		if ranked[i].healthy != ranked[j].healthy {
+			return ranked[i].healthy
+		}
+		return ranked[i].score < ranked[j].score
+	})
+
+	nodes := make([]*poolNode, len(ranked))
+	for i, r := range ranked {
+		nodes[i] = r.node
+	}
+	return nodes
+}
+
+// observe updates the moving averages of the node, must be called with the lock held
+func (n *poolNode) observe(duration time.Duration, err error) {
+	failed := 0.0
+	if err != nil && isFailoverError(err) {
+		failed = 1
+		n.lastError = err
+	}
+	n.errorRate = poolStatsAlpha*failed + (1-poolStatsAlpha)*n.errorRate
+	if n.latency == 0 {
+		n.latency = duration
+	} else {
+		n.latency = time.Duration(poolStatsAlpha*float64(duration) + (1-poolStatsAlpha)*float64(n.latency))
+	}
+}
+
+// isSynced must be called with the read lock held
+func (n *poolNode) isSynced(maxHead, maxLag uint64) bool {
+	return n.healthy && n.headSlot+maxLag >= maxHead
+}
+
+// isFailoverError reports whether a request should be retried on another node.
+// Transport errors and 5xx responses are node failures, any other status code is a valid answer of the node.
+func isFailoverError(err error) bool {
+	httpErr := network.SpecificError(err)
+	if httpErr == nil {
+		return true
+	}
+	return httpErr.StatusCode >= http.StatusInternalServerError
+}
+
+func poolRequest[T any](p *PoolClient, request func(c *NodeClient) (*T, error)) (*T, error) {
+	var res *T
+	var err error
+	nodes := p.orderedNodes()
+	for i, n := range nodes {
+		start := time.Now()
+		res, err = request(n.client)
+		duration := time.Since(start)
+
+		n.mu.Lock()
+		n.observe(duration, err)
+		failover := err != nil && isFailoverError(err)
+		if failover {
+			n.healthy = false
+		}
+		n.mu.Unlock()
+
+		if !failover {
+			return res, err
+		}
+		if i+1 < len(nodes) {
+			log.Warnf("beacon node %v failed, falling back to %v: %v", n.client.Endpoint, nodes[i+1].client.Endpoint, err)
+			metrics.Counter.WithLabelValues("consapi_pool_failover").Inc()
+		}
+	}
+	return res, err
+}
+
+func (p *PoolClient) GetSlot(blockID any) (*types.StandardBeaconSlotResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardBeaconSlotResponse, error) {
+		return c.GetSlot(blockID)
+	})
+}
+
+func (p *PoolClient) GetValidators(state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardValidatorsResponse, error) {
+		return c.GetValidators(state, ids, status)
+	})
+}
+
+func (p *PoolClient) GetValidator(validatorID, stateID any) (*types.StandardSingleValidatorsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardSingleValidatorsResponse, error) {
+		return c.GetValidator(validatorID, stateID)
+	})
+}
+
+func (p *PoolClient) GetPropoalAssignments(epoch uint64) (*types.StandardProposerAssignmentsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardProposerAssignmentsResponse, error) {
+		return c.GetPropoalAssignments(epoch)
+	})
+}
+
+func (p *PoolClient) GetPropoalRewards(blockID any) (*types.StandardBlockRewardsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardBlockRewardsResponse, error) {
+		return c.GetPropoalRewards(blockID)
+	})
+}
+
+func (p *PoolClient) GetSyncRewards(blockID any) (*types.StandardSyncCommitteeRewardsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardSyncCommitteeRewardsResponse, error) {
+		return c.GetSyncRewards(blockID)
+	})
+}
+
+func (p *PoolClient) GetAttestationRewards(epoch uint64) (*types.StandardAttestationRewardsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardAttestationRewardsResponse, error) {
+		return c.GetAttestationRewards(epoch)
+	})
+}
+
+func (p *PoolClient) GetAttestationRewardsOfValidators(epoch uint64, indices []uint64) (*types.StandardAttestationRewardsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardAttestationRewardsResponse, error) {
+		return c.GetAttestationRewardsOfValidators(epoch, indices)
+	})
+}
+
+func (p *PoolClient) GetSyncCommitteesAssignments(epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardSyncCommitteesResponse, error) {
+		return c.GetSyncCommitteesAssignments(epoch, stateID)
+	})
+}
+
+func (p *PoolClient) GetSpec() (*types.StandardSpecResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardSpecResponse, error) {
+		return c.GetSpec()
+	})
+}
+
+func (p *PoolClient) GetBlockHeader(blockID any) (*types.StandardBeaconHeaderResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardBeaconHeaderResponse, error) {
+		return c.GetBlockHeader(blockID)
+	})
+}
+
+func (p *PoolClient) GetBlockHeaders(slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardBeaconHeadersResponse, error) {
+		return c.GetBlockHeaders(slot, parentRoot)
+	})
+}
+
+func (p *PoolClient) GetFinalityCheckpoints(stateID any) (*types.StandardFinalityCheckpointsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardFinalityCheckpointsResponse, error) {
+		return c.GetFinalityCheckpoints(stateID)
+	})
+}
+
+func (p *PoolClient) GetValidatorBalances(stateID any) (*types.StandardValidatorBalancesResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardValidatorBalancesResponse, error) {
+		return c.GetValidatorBalances(stateID)
+	})
+}
+
+func (p *PoolClient) GetBlobSidecars(blockID any) (*types.StandardBlobSidecarsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardBlobSidecarsResponse, error) {
+		return c.GetBlobSidecars(blockID)
+	})
+}
+
+func (p *PoolClient) GetCommittees(stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardCommitteesResponse, error) {
+		return c.GetCommittees(stateID, epoch, index, slot)
+	})
+}
+
+func (p *PoolClient) GetPendingDeposits(stateID any) (*types.StandardPendingDepositsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardPendingDepositsResponse, error) {
+		return c.GetPendingDeposits(stateID)
+	})
+}
+
+func (p *PoolClient) GetPendingPartialWithdrawals(stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+		return c.GetPendingPartialWithdrawals(stateID)
+	})
+}
+
+func (p *PoolClient) GetPendingConsolidations(stateID any) (*types.StandardPendingConsolidationsResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardPendingConsolidationsResponse, error) {
+		return c.GetPendingConsolidations(stateID)
+	})
+}
+
+func (p *PoolClient) GetGenesis() (*types.StandardGenesisResponse, error) {
+	return poolRequest(p, func(c *NodeClient) (*types.StandardGenesisResponse, error) {
+		return c.GetGenesis()
+	})
+}
+
+// GetEvents subscribes to the event stream of the currently preferred node.
+// Stream errors are forwarded to the caller, which can resubscribe to get routed to the then healthiest node.
+func (p *PoolClient) GetEvents(topics []types.EventTopic) chan *types.EventResponse {
+	return p.orderedNodes()[0].client.GetEvents(topics)
+}
+
+// SubscribeEvents subscribes to the event stream of the currently preferred node until ctx is done
+func (p *PoolClient) SubscribeEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse {
+	return p.orderedNodes()[0].client.SubscribeEvents(ctx, topics)
+}
@@ -0,0 +1,124 @@
+package consapi_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+)
+
+type fakeBeaconNode struct {
+	*httptest.Server
+	headSlot      uint64
+	genesisStatus int
+	hits          atomic.Int64
+}
+
+func newFakeBeaconNode(headSlot uint64, genesisStatus int) *fakeBeaconNode {
+	n := &fakeBeaconNode{headSlot: headSlot, genesisStatus: genesisStatus}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/headers/head", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":{"header":{"message":{"slot":"%d"}}}}`, n.headSlot)
+	})
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		n.hits.Add(1)
+		w.WriteHeader(n.genesisStatus)
+		fmt.Fprintf(w, `{"data":{"genesis_time":"%s"}}`, n.URL)
+	})
+	n.Server = httptest.NewServer(mux)
+	return n
+}
+
+func newTestPool(nodes ...*fakeBeaconNode) (consapi.Client, *consapi.PoolClient) {
+	endpoints := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		endpoints = append(endpoints, n.URL)
+	}
+	cl := consapi.NewPoolClient(endpoints, &consapi.PoolConfig{HealthCheckInterval: time.Hour})
+	return cl, cl.ClientInt.(*consapi.PoolClient)
+}
+
+func TestPoolFailsOverOnServerError(t *testing.T) {
+	failing := newFakeBeaconNode(100, http.StatusInternalServerError)
+	defer failing.Close()
+	working := newFakeBeaconNode(100, http.StatusOK)
+	defer working.Close()
+
+	cl, pool := newTestPool(failing, working)
+	defer pool.Close()
+
+	res, err := cl.GetGenesis()
+	if err != nil {
+		t.Fatalf("expected failover to succeed: %v", err)
+	}
+	if res.Data.GenesisTime != working.URL {
+		t.Errorf("expected response of %v, got %v", working.URL, res.Data.GenesisTime)
+	}
+	if pool.CurrentEndpoint() != working.URL {
+		t.Errorf("expected failed node to be demoted, current endpoint is %v", pool.CurrentEndpoint())
+	}
+	if pool.PrimaryEndpoint() != failing.URL {
+		t.Errorf("expected primary endpoint to stay %v, got %v", failing.URL, pool.PrimaryEndpoint())
+	}
+}
+
+func TestPoolPrefersSyncedNode(t *testing.T) {
+	lagging := newFakeBeaconNode(100, http.StatusOK)
+	defer lagging.Close()
+	synced := newFakeBeaconNode(200, http.StatusOK)
+	defer synced.Close()
+
+	cl, pool := newTestPool(lagging, synced)
+	defer pool.Close()
+
+	if _, err := cl.GetGenesis(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lagging.hits.Load() != 0 || synced.hits.Load() != 1 {
+		t.Errorf("expected request to be routed to synced node, hits lagging=%d synced=%d", lagging.hits.Load(), synced.hits.Load())
+	}
+
+	stats := pool.Stats()
+	if !stats[0].Synced || stats[1].Synced {
+		t.Errorf("unexpected sync state in stats: %+v", stats)
+	}
+}
+
+func TestPoolDoesNotRetryClientErrors(t *testing.T) {
+	notFound := newFakeBeaconNode(100, http.StatusNotFound)
+	defer notFound.Close()
+	// the fallback lags behind so the failing node is always preferred
+	other := newFakeBeaconNode(90, http.StatusOK)
+	defer other.Close()
+
+	cl, pool := newTestPool(notFound, other)
+	defer pool.Close()
+
+	if _, err := cl.GetGenesis(); err == nil {
+		t.Fatalf("expected 404 to be returned to the caller")
+	}
+	if other.hits.Load() != 0 {
+		t.Errorf("expected no failover on 404, fallback node got %d requests", other.hits.Load())
+	}
+}
+
+func TestPoolAllNodesDown(t *testing.T) {
+	a := newFakeBeaconNode(100, http.StatusServiceUnavailable)
+	defer a.Close()
+	b := newFakeBeaconNode(100, http.StatusBadGateway)
+	defer b.Close()
+
+	cl, pool := newTestPool(a, b)
+	defer pool.Close()
+
+	if _, err := cl.GetGenesis(); err == nil {
+		t.Fatalf("expected error if all nodes fail")
+	}
+	if a.hits.Load() != 1 || b.hits.Load() != 1 {
+		t.Errorf("expected every node to be tried once, hits a=%d b=%d", a.hits.Load(), b.hits.Load())
+	}
+}
@@ -0,0 +1,330 @@
+package consapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+const (
+	defaultEventStreamStallTimeout     = 60 * time.Second
+	defaultEventStreamMaxBackfillSlots = 64
+	eventStreamMaxReconnectBackoff     = 30 * time.Second
+)
+
+// EventSubscriber is implemented by clients whose event subscriptions can be cancelled
+type EventSubscriber interface {
+	SubscribeEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse
+}
+
+// EventStreamConfig configures an EventStream, zero values are replaced by sane defaults
+type EventStreamConfig struct {
+	SlotsPerEpoch uint64
+	// SecondsPerSlot and GenesisTimestamp are only used to report the head lag metric
+	SecondsPerSlot   uint64
+	GenesisTimestamp uint64
+	// StallTimeout is the time without any event after which the subscription is considered dead and renewed
+	StallTimeout time.Duration
+	// MaxBackfillSlots limits how many missed slots are reconstructed after an interruption
+	MaxBackfillSlots uint64
+}
+
+// EventStream is a self healing subscription to the event stream of a beacon node.
+// It resubscribes if the stream stalls or ends and keeps the head events it emits consecutive:
+// duplicated heads are dropped, skipped slots are backfilled with synthetic head events built from the block headers
+// and heads that replace an already emitted head are preceded by a synthetic chain_reorg event if the node did not send one.
+type EventStream struct {
+	cl     ClientInt
+	topics []types.EventTopic
+	config EventStreamConfig
+	cancel context.CancelFunc
+
+	lastHead *types.StandardEventHeadResponse
+	// block roots of the recently emitted heads by slot
+	recentRoots map[uint64]string
+	// new head block of the last chain_reorg event received from the node
+	reorgedTo string
+}
+
+func NewEventStream(cl ClientInt, topics []types.EventTopic, config *EventStreamConfig) *EventStream {
+	cfg := EventStreamConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.StallTimeout == 0 {
+		cfg.StallTimeout = defaultEventStreamStallTimeout
+	}
+	if cfg.MaxBackfillSlots == 0 {
+		cfg.MaxBackfillSlots = defaultEventStreamMaxBackfillSlots
+	}
+	return &EventStream{
+		cl:          cl,
+		topics:      topics,
+		config:      cfg,
+		recentRoots: make(map[uint64]string),
+	}
+}
+
+// Start subscribes to the node and returns the channel all events are delivered to, it is closed once Stop is called
+func (s *EventStream) Start() chan *types.EventResponse {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	out := make(chan *types.EventResponse, 32)
+	go s.run(ctx, out)
+	return out
+}
+
+// Stop ends the subscription
+func (s *EventStream) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *EventStream) subscribe(ctx context.Context) chan *types.EventResponse {
+	if subscriber, ok := s.cl.(EventSubscriber); ok {
+		return subscriber.SubscribeEvents(ctx, s.topics)
+	}
+	return s.cl.GetEvents(s.topics)
+}
+
+func (s *EventStream) run(ctx context.Context, out chan *types.EventResponse) {
+	defer close(out)
+	backoff := time.Second
+	for {
+		subCtx, subCancel := context.WithCancel(ctx)
+		received := s.consume(ctx, s.subscribe(subCtx), out)
+		subCancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			backoff = time.Second
+		} else {
+			backoff = min(backoff*2, eventStreamMaxReconnectBackoff)
+		}
+		metrics.Counter.WithLabelValues("consapi_event_stream_resubscribe").Inc()
+		log.Warnf("event stream interrupted, resubscribing in %v", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// consume forwards events until the subscription ends or stalls, it reports whether any event was received
+func (s *EventStream) consume(ctx context.Context, events chan *types.EventResponse, out chan *types.EventResponse) bool {
+	received := false
+	stall := time.NewTimer(s.config.StallTimeout)
+	defer stall.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return received
+		case <-stall.C:
+			log.Warnf("no event received for %v", s.config.StallTimeout)
+			return received
+		case e, ok := <-events:
+			if !ok {
+				return received
+			}
+			if e.Error != nil {
+				// the underlying event source reconnects on its own, the next head will be checked for gaps
+				metrics.Counter.WithLabelValues("consapi_event_stream_reconnect").Inc()
+				send(ctx, out, e)
+				continue
+			}
+			received = true
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(s.config.StallTimeout)
+			s.handle(ctx, e, out)
+		}
+	}
+}
+
+// send forwards an event unless the stream is stopped, it reports whether the event was sent
+func send(ctx context.Context, out chan *types.EventResponse, e *types.EventResponse) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- e:
+		return true
+	}
+}
+
+func (s *EventStream) handle(ctx context.Context, e *types.EventResponse, out chan *types.EventResponse) {
+	switch e.Event {
+	case types.EventHead:
+		head, err := e.Head()
+		if err != nil {
+			send(ctx, out, e)
+			return
+		}
+		s.handleHead(ctx, head, e, out)
+	case types.EventChainReorg:
+		reorg, err := e.ChainReorg()
+		if err == nil {
+			newHead := reorg.NewHeadBlock.String()
+			if strings.EqualFold(s.reorgedTo, newHead) {
+				// a synthetic chain_reorg was already emitted for the new head
+				metrics.Counter.WithLabelValues("consapi_event_stream_duplicate_reorg").Inc()
+				return
+			}
+			s.reorgedTo = newHead
+		}
+		send(ctx, out, e)
+	default:
+		send(ctx, out, e)
+	}
+}
+
+func (s *EventStream) handleHead(ctx context.Context, head *types.StandardEventHeadResponse, e *types.EventResponse, out chan *types.EventResponse) {
+	if s.lastHead != nil {
+		if root, ok := s.recentRoots[head.Slot]; ok && strings.EqualFold(root, head.Block) {
+			metrics.Counter.WithLabelValues("consapi_event_stream_duplicate_head").Inc()
+			return
+		}
+		if head.Slot <= s.lastHead.Slot {
+			metrics.Counter.WithLabelValues("consapi_event_stream_out_of_order_head").Inc()
+			if !strings.EqualFold(s.reorgedTo, head.Block) {
+				s.emitReorg(ctx, head.Slot, head.Block, out)
+			}
+		} else if head.Slot > s.lastHead.Slot+1 {
+			s.backfill(ctx, head.Slot, out)
+		}
+	}
+	s.emitHead(ctx, head, e, out)
+}
+
+// backfill emits synthetic head events for all blocks between the last emitted head and toSlot
+func (s *EventStream) backfill(ctx context.Context, toSlot uint64, out chan *types.EventResponse) {
+	from := s.lastHead.Slot + 1
+	parent := s.lastHead.Block
+	if toSlot-from > s.config.MaxBackfillSlots {
+		log.Warnf("event stream missed %v slots, only backfilling the last %v", toSlot-from, s.config.MaxBackfillSlots)
+		from = toSlot - s.config.MaxBackfillSlots
+		parent = ""
+	}
+
+	for slot := from; slot < toSlot && ctx.Err() == nil; slot++ {
+		header, err := s.cl.GetBlockHeader(slot)
+		if err != nil {
+			if httpErr := network.SpecificError(err); httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
+				continue // missed slot
+			}
+			log.Error(err, "error backfilling head event", 0, log.Fields{"slot": slot})
+			return
+		}
+		root := header.Data.Root.String()
+		if parent != "" && !strings.EqualFold(header.Data.Header.Message.ParentRoot.String(), parent) {
+			s.emitReorg(ctx, slot, root, out)
+		}
+		s.emitHead(ctx, &types.StandardEventHeadResponse{
+			Slot:  slot,
+			Block: root,
+			State: header.Data.Header.Message.StateRoot,
+		}, nil, out)
+		metrics.Counter.WithLabelValues("consapi_event_stream_backfilled_head").Inc()
+		parent = root
+	}
+}
+
+// emitHead forwards a head event, a synthetic event is created if e is nil
+func (s *EventStream) emitHead(ctx context.Context, head *types.StandardEventHeadResponse, e *types.EventResponse, out chan *types.EventResponse) {
+	if e == nil {
+		if s.lastHead != nil && s.config.SlotsPerEpoch > 0 {
+			head.EpochTransition = head.Slot/s.config.SlotsPerEpoch != s.lastHead.Slot/s.config.SlotsPerEpoch
+		}
+		data, err := json.Marshal(head)
+		if err != nil {
+			log.Error(err, "error marshalling synthetic head event", 0)
+			return
+		}
+		e = &types.EventResponse{Event: types.EventHead, Data: data, Synthetic: true}
+	}
+
+	s.lastHead = head
+	s.recentRoots[head.Slot] = head.Block
+	for recentSlot := range s.recentRoots {
+		if recentSlot+s.config.MaxBackfillSlots < head.Slot {
+			delete(s.recentRoots, recentSlot)
+		}
+	}
+
+	metrics.State.WithLabelValues("consapi_event_stream_head_slot").Set(float64(head.Slot))
+	if s.config.GenesisTimestamp > 0 && s.config.SecondsPerSlot > 0 {
+		slotTime := time.Unix(int64(s.config.GenesisTimestamp+head.Slot*s.config.SecondsPerSlot), 0)
+		metrics.State.WithLabelValues("consapi_event_stream_head_lag_seconds").Set(time.Since(slotTime).Seconds())
+	}
+
+	send(ctx, out, e)
+}
+
+// emitReorg emits a synthetic chain_reorg event for a new head that replaces already emitted heads.
+// The depth is determined by walking the parents of the new head until an emitted block is found.
+func (s *EventStream) emitReorg(ctx context.Context, slot uint64, newHead string, out chan *types.EventResponse) {
+	if !slices.Contains(s.topics, types.EventChainReorg) || s.lastHead == nil {
+		return
+	}
+
+	slotsByRoot := make(map[string]uint64, len(s.recentRoots))
+	for recentSlot, root := range s.recentRoots {
+		slotsByRoot[strings.ToLower(root)] = recentSlot
+	}
+
+	ancestorSlot, found := uint64(0), false
+	blockID := newHead
+	for i := 0; i < len(s.recentRoots) && !found; i++ {
+		header, err := s.cl.GetBlockHeader(blockID)
+		if err != nil {
+			log.Error(err, "error looking up reorg ancestor", 0, log.Fields{"block": blockID})
+			break
+		}
+		blockID = header.Data.Header.Message.ParentRoot.String()
+		ancestorSlot, found = slotsByRoot[strings.ToLower(blockID)]
+	}
+	if !found {
+		// the common ancestor is older than all tracked heads
+		ancestorSlot = min(slot, s.lastHead.Slot+1) - 1
+	}
+	for recentSlot := range s.recentRoots {
+		if recentSlot > ancestorSlot {
+			delete(s.recentRoots, recentSlot)
+		}
+	}
+
+	oldHeadBlock, _ := hexutil.Decode(s.lastHead.Block)
+	newHeadBlock, _ := hexutil.Decode(newHead)
+	reorg := types.StandardEventChainReorg{
+		Slot:         slot,
+		Depth:        s.lastHead.Slot - ancestorSlot,
+		OldHeadBlock: oldHeadBlock,
+		NewHeadBlock: newHeadBlock,
+		OldHeadState: s.lastHead.State,
+	}
+	if s.config.SlotsPerEpoch > 0 {
+		reorg.Epoch = slot / s.config.SlotsPerEpoch
+	}
+	data, err := json.Marshal(reorg)
+	if err != nil {
+		log.Error(err, "error marshalling synthetic chain reorg event", 0)
+		return
+	}
+	s.reorgedTo = newHead
+	metrics.Counter.WithLabelValues("consapi_event_stream_synthetic_reorg").Inc()
+	send(ctx, out, &types.EventResponse{Event: types.EventChainReorg, Data: data, Synthetic: true})
+}
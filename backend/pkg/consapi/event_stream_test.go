@@ -0,0 +1,197 @@
+package consapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// fakeEventClient serves block headers from a map and events from a channel
+type fakeEventClient struct {
+	consapi.ClientInt
+	headers map[string]*types.StandardBeaconHeaderResponse
+	events  chan *types.EventResponse
+}
+
+func root(name string) string {
+	return fmt.Sprintf("0x%064x", []byte(name))
+}
+
+func (c *fakeEventClient) addHeader(slot uint64, name, parent string) {
+	raw := fmt.Sprintf(`{"data":{"root":"%s","header":{"message":{"slot":"%d","parent_root":"%s","state_root":"0x00"}}}}`, root(name), slot, root(parent))
+	var header types.StandardBeaconHeaderResponse
+	if err := json.Unmarshal([]byte(raw), &header); err != nil {
+		panic(err)
+	}
+	c.headers[root(name)] = &header
+	c.headers[fmt.Sprintf("%d", slot)] = &header
+}
+
+func (c *fakeEventClient) GetBlockHeader(blockID any) (*types.StandardBeaconHeaderResponse, error) {
+	header, ok := c.headers[fmt.Sprintf("%v", blockID)]
+	if !ok {
+		return nil, &network.HttpReqHttpError{StatusCode: http.StatusNotFound}
+	}
+	return header, nil
+}
+
+func (c *fakeEventClient) SubscribeEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse {
+	return c.events
+}
+
+func (c *fakeEventClient) sendHead(slot uint64, name string) {
+	data := fmt.Sprintf(`{"slot":"%d","block":"%s"}`, slot, root(name))
+	c.events <- &types.EventResponse{Event: types.EventHead, Data: []byte(data)}
+}
+
+func receive(t *testing.T, events chan *types.EventResponse) *types.EventResponse {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for event")
+		return nil
+	}
+}
+
+func expectHead(t *testing.T, events chan *types.EventResponse, slot uint64, name string, synthetic bool) {
+	t.Helper()
+	e := receive(t, events)
+	head, err := e.Head()
+	if err != nil || head == nil {
+		t.Fatalf("expected head event, got %v (%v)", e.Event, err)
+	}
+	if head.Slot != slot || head.Block != root(name) || e.Synthetic != synthetic {
+		t.Errorf("expected head %d/%s synthetic=%v, got %d/%s synthetic=%v", slot, name, synthetic, head.Slot, head.Block, e.Synthetic)
+	}
+}
+
+func TestEventStreamBackfillsDeduplicatesAndDetectsReorgs(t *testing.T) {
+	cl := &fakeEventClient{
+		headers: map[string]*types.StandardBeaconHeaderResponse{},
+		events:  make(chan *types.EventResponse, 16),
+	}
+	cl.addHeader(10, "a10", "a9")
+	cl.addHeader(11, "a11", "a10")
+	// slot 12 is missed
+	cl.addHeader(13, "a13", "a11")
+
+	stream := consapi.NewEventStream(cl, []types.EventTopic{types.EventHead, types.EventChainReorg}, &consapi.EventStreamConfig{SlotsPerEpoch: 32})
+	events := stream.Start()
+	defer stream.Stop()
+
+	cl.sendHead(10, "a10")
+	expectHead(t, events, 10, "a10", false)
+
+	// slots 11 and 12 were not received
+	cl.sendHead(13, "a13")
+	expectHead(t, events, 11, "a11", true)
+	expectHead(t, events, 13, "a13", false)
+
+	// duplicated head after a reconnect followed by a competing block without a chain_reorg event
+	cl.addHeader(13, "b13", "a11")
+	cl.sendHead(13, "a13")
+	cl.sendHead(13, "b13")
+
+	e := receive(t, events)
+	reorg, err := e.ChainReorg()
+	if err != nil || reorg == nil {
+		t.Fatalf("expected synthetic chain reorg, got %v (%v)", e.Event, err)
+	}
+	if !e.Synthetic || reorg.Slot != 13 || reorg.Depth != 2 {
+		t.Errorf("unexpected chain reorg: synthetic=%v slot=%d depth=%d", e.Synthetic, reorg.Slot, reorg.Depth)
+	}
+	expectHead(t, events, 13, "b13", false)
+}
+
+func (c *fakeEventClient) sendReorg(slot uint64, oldHead, newHead string) {
+	data := fmt.Sprintf(`{"slot":"%d","depth":"1","old_head_block":"%s","new_head_block":"%s"}`, slot, root(oldHead), root(newHead))
+	c.events <- &types.EventResponse{Event: types.EventChainReorg, Data: []byte(data)}
+}
+
+func TestEventStreamDropsReorgOfReplacedHead(t *testing.T) {
+	cl := &fakeEventClient{
+		headers: map[string]*types.StandardBeaconHeaderResponse{},
+		events:  make(chan *types.EventResponse, 16),
+	}
+	cl.addHeader(10, "a10", "a9")
+	cl.addHeader(11, "a11", "a10")
+	cl.addHeader(11, "b11", "a10")
+	cl.addHeader(12, "b12", "b11")
+
+	stream := consapi.NewEventStream(cl, []types.EventTopic{types.EventHead, types.EventChainReorg}, &consapi.EventStreamConfig{SlotsPerEpoch: 32})
+	events := stream.Start()
+	defer stream.Stop()
+
+	cl.sendHead(10, "a10")
+	expectHead(t, events, 10, "a10", false)
+	cl.sendHead(11, "a11")
+	expectHead(t, events, 11, "a11", false)
+
+	// the replacing head arrives before the chain_reorg event of the node
+	cl.sendHead(11, "b11")
+	e := receive(t, events)
+	if reorg, err := e.ChainReorg(); err != nil || reorg == nil || !e.Synthetic {
+		t.Fatalf("expected synthetic chain reorg, got %v synthetic=%v (%v)", e.Event, e.Synthetic, err)
+	}
+	expectHead(t, events, 11, "b11", false)
+
+	// the chain_reorg of the node for the same head is dropped
+	cl.sendReorg(11, "a11", "b11")
+	cl.sendHead(12, "b12")
+	expectHead(t, events, 12, "b12", false)
+
+	// a chain_reorg of the node to another head is forwarded
+	cl.sendReorg(12, "b12", "c12")
+	e = receive(t, events)
+	if reorg, err := e.ChainReorg(); err != nil || reorg == nil || e.Synthetic {
+		t.Errorf("expected chain reorg of the node, got %v synthetic=%v (%v)", e.Event, e.Synthetic, err)
+	}
+}
+
+func TestEventStreamStopsWhileBlocked(t *testing.T) {
+	cl := &fakeEventClient{
+		headers: map[string]*types.StandardBeaconHeaderResponse{},
+		events:  make(chan *types.EventResponse, 16),
+	}
+	cl.addHeader(1, "a1", "a0")
+	for slot := uint64(2); slot <= 100; slot++ {
+		cl.addHeader(slot, fmt.Sprintf("a%d", slot), fmt.Sprintf("a%d", slot-1))
+	}
+
+	stream := consapi.NewEventStream(cl, []types.EventTopic{types.EventHead}, &consapi.EventStreamConfig{SlotsPerEpoch: 32, MaxBackfillSlots: 128})
+	events := stream.Start()
+
+	cl.sendHead(1, "a1")
+	expectHead(t, events, 1, "a1", false)
+
+	// the backfill fills the buffer of the stream and blocks as nobody reads the events
+	cl.sendHead(100, "a100")
+	time.Sleep(100 * time.Millisecond)
+	stream.Stop()
+
+	received := 0
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				if received >= 99 {
+					t.Errorf("expected the backfill to stop, received %d events", received)
+				}
+				return
+			}
+			received++
+		case <-timeout:
+			t.Fatalf("the stream was not closed after being stopped")
+		}
+	}
+}
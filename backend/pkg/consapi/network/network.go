@@ -2,6 +2,7 @@ package network
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -31,11 +32,30 @@ func Post[T any](r *http.Client, url string) (*T, error) {
 	return utils.Unmarshal[T](result, err)
 }
 
+// Helper for post with a json body and unmarshal
+func PostJSON[T any](r *http.Client, url string, body any) (*T, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		var target T
+		return &target, fmt.Errorf("error marshalling request body: %v", err)
+	}
+	result, err := httpReq("POST", url, r, data)
+	if err != nil || result == nil {
+		var target T
+		return &target, err
+	}
+	return utils.Unmarshal[T](result, err)
+}
+
 func HTTPReq(method string, requestURL string, httpClient *http.Client) (io.ReadCloser, error) {
 	data := []byte{}
 	if method == "POST" {
 		data = []byte("[]")
 	}
+	return httpReq(method, requestURL, httpClient, data)
+}
+
+func httpReq(method string, requestURL string, httpClient *http.Client, data []byte) (io.ReadCloser, error) {
 	r, err := http.NewRequest(method, requestURL, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %v", err)
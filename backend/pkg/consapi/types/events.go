@@ -8,23 +8,25 @@ import (
 type EventTopic string
 
 const (
-	EventHead  EventTopic = "head"
-	EventBlock EventTopic = "block"
-	// EventAttestation                 EventTopic = "attestation"
-	// EventVoluntaryExit               EventTopic = "voluntary_exit"
-	// EventBlsToExecutionChange        EventTopic = "bls_to_execution_change"
-	EventFinalizedCheckpoint EventTopic = "finalized_checkpoint"
-	EventChainReorg          EventTopic = "chain_reorg"
-	// EventContributionAndProof        EventTopic = "contribution_and_proof"
+	EventHead                 EventTopic = "head"
+	EventBlock                EventTopic = "block"
+	EventAttestation          EventTopic = "attestation"
+	EventVoluntaryExit        EventTopic = "voluntary_exit"
+	EventBlsToExecutionChange EventTopic = "bls_to_execution_change"
+	EventFinalizedCheckpoint  EventTopic = "finalized_checkpoint"
+	EventChainReorg           EventTopic = "chain_reorg"
+	EventContributionAndProof EventTopic = "contribution_and_proof"
 	// EventLightClientFinalityUpdate   EventTopic = "light_client_finality_update"
 	// EventLightClientOptimisticUpdate EventTopic = "light_client_optimistic_update"
-	// EventPayloadAttributes           EventTopic = "payload_attributes"
+	EventPayloadAttributes EventTopic = "payload_attributes"
 )
 
 type EventResponse struct {
 	Event EventTopic
 	Data  []byte
 	Error error
+	// Synthetic is set for events that were reconstructed by the event stream instead of being received from the node
+	Synthetic bool
 }
 
 // Helper to get Head response type, returns nil if it is not a head event
@@ -59,6 +61,46 @@ func (e EventResponse) FinalizedCheckpoint() (*StandardFinalizedCheckpointRespon
 	return utils.UnmarshalOld[StandardFinalizedCheckpointResponse](e.Data, e.Error)
 }
 
+// Helper to get Attestation response type, returns nil if it is not an attestation event
+func (e EventResponse) Attestation() (*Attestation, error) {
+	if e.Event != EventAttestation {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[Attestation](e.Data, e.Error)
+}
+
+// Helper to get VoluntaryExit response type, returns nil if it is not a voluntary exit event
+func (e EventResponse) VoluntaryExit() (*VoluntaryExit, error) {
+	if e.Event != EventVoluntaryExit {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[VoluntaryExit](e.Data, e.Error)
+}
+
+// Helper to get BlsToExecutionChange response type, returns nil if it is not a bls to execution change event
+func (e EventResponse) BlsToExecutionChange() (*SignedBLSToExecutionChange, error) {
+	if e.Event != EventBlsToExecutionChange {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[SignedBLSToExecutionChange](e.Data, e.Error)
+}
+
+// Helper to get ContributionAndProof response type, returns nil if it is not a contribution and proof event
+func (e EventResponse) ContributionAndProof() (*StandardEventContributionAndProof, error) {
+	if e.Event != EventContributionAndProof {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventContributionAndProof](e.Data, e.Error)
+}
+
+// Helper to get PayloadAttributes response type, returns nil if it is not a payload attributes event
+func (e EventResponse) PayloadAttributes() (*StandardEventPayloadAttributes, error) {
+	if e.Event != EventPayloadAttributes {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[StandardEventPayloadAttributes](e.Data, e.Error)
+}
+
 type StandardEventHeadResponse struct {
 	Slot                      uint64        `json:"slot,string"`
 	Block                     string        `json:"block"`
@@ -92,3 +134,37 @@ type StandardFinalizedCheckpointResponse struct {
 	Epoch               uint64        `json:"epoch,string"`
 	ExecutionOptimistic bool          `json:"execution_optimistic"`
 }
+
+type StandardEventContributionAndProof struct {
+	Message struct {
+		AggregatorIndex uint64 `json:"aggregator_index,string"`
+		Contribution    struct {
+			Slot              uint64        `json:"slot,string"`
+			BeaconBlockRoot   hexutil.Bytes `json:"beacon_block_root"`
+			SubcommitteeIndex uint64        `json:"subcommittee_index,string"`
+			AggregationBits   hexutil.Bytes `json:"aggregation_bits"`
+			Signature         hexutil.Bytes `json:"signature"`
+		} `json:"contribution"`
+		SelectionProof hexutil.Bytes `json:"selection_proof"`
+	} `json:"message"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+type StandardEventPayloadAttributes struct {
+	Version string `json:"version"`
+	Data    struct {
+		ProposerIndex     uint64        `json:"proposer_index,string"`
+		ProposalSlot      uint64        `json:"proposal_slot,string"`
+		ParentBlockNumber uint64        `json:"parent_block_number,string"`
+		ParentBlockRoot   hexutil.Bytes `json:"parent_block_root"`
+		ParentBlockHash   hexutil.Bytes `json:"parent_block_hash"`
+		PayloadAttributes struct {
+			Timestamp             uint64              `json:"timestamp,string"`
+			PrevRandao            hexutil.Bytes       `json:"prev_randao"`
+			SuggestedFeeRecipient hexutil.Bytes       `json:"suggested_fee_recipient"`
+			Withdrawals           []WithdrawalPayload `json:"withdrawals"`
+			// present only after deneb
+			ParentBeaconBlockRoot hexutil.Bytes `json:"parent_beacon_block_root"`
+		} `json:"payload_attributes"`
+	} `json:"data"`
+}
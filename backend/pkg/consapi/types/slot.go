@@ -37,6 +37,9 @@ type AnySignedBlock struct {
 
 			// present only after deneb
 			BlobKZGCommitments []hexutil.Bytes `json:"blob_kzg_commitments"`
+
+			// present only after electra
+			ExecutionRequests *ExecutionRequests `json:"execution_requests,omitempty"`
 		} `json:"body"`
 	} `json:"message"`
 	Signature hexutil.Bytes `json:"signature"`
@@ -120,8 +123,10 @@ func (a *AttesterSlashing) GetSlashedIndices() []uint64 {
 
 type Attestation struct {
 	AggregationBits hexutil.Bytes `json:"aggregation_bits"`
-	Signature       hexutil.Bytes `json:"signature"`
-	Data            struct {
+	// present only after electra, aggregation_bits then span all committees set here (EIP-7549)
+	CommitteeBits hexutil.Bytes `json:"committee_bits,omitempty"`
+	Signature     hexutil.Bytes `json:"signature"`
+	Data          struct {
 		Slot            uint64        `json:"slot,string"`
 		Index           uint16        `json:"index,string"`
 		BeaconBlockRoot hexutil.Bytes `json:"beacon_block_root"`
@@ -136,6 +141,20 @@ type Attestation struct {
 	} `json:"data"`
 }
 
+// CommitteeIndices returns the indices of the committees the attestation aggregates, in ascending order
+func (a *Attestation) CommitteeIndices() []uint64 {
+	if len(a.CommitteeBits) == 0 {
+		return []uint64{uint64(a.Data.Index)}
+	}
+	indices := make([]uint64, 0, 1)
+	for i := 0; i < len(a.CommitteeBits)*8; i++ {
+		if a.CommitteeBits[i/8]&(1<<uint(i%8)) > 0 {
+			indices = append(indices, uint64(i))
+		}
+	}
+	return indices
+}
+
 type Deposit struct {
 	Proof []hexutil.Bytes `json:"proof"`
 	Data  struct {
@@ -204,3 +223,33 @@ type SignedBLSToExecutionChange struct {
 	} `json:"message"`
 	Signature hexutil.Bytes `json:"signature"`
 }
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#executionrequests
+type ExecutionRequests struct {
+	Deposits       []DepositRequest       `json:"deposits"`
+	Withdrawals    []WithdrawalRequest    `json:"withdrawals"`
+	Consolidations []ConsolidationRequest `json:"consolidations"`
+}
+
+// EIP-6110
+type DepositRequest struct {
+	Pubkey                hexutil.Bytes `json:"pubkey"`
+	WithdrawalCredentials hexutil.Bytes `json:"withdrawal_credentials"`
+	Amount                uint64        `json:"amount,string"`
+	Signature             hexutil.Bytes `json:"signature"`
+	Index                 uint64        `json:"index,string"`
+}
+
+// EIP-7002, an amount of 0 requests a full exit
+type WithdrawalRequest struct {
+	SourceAddress   hexutil.Bytes `json:"source_address"`
+	ValidatorPubkey hexutil.Bytes `json:"validator_pubkey"`
+	Amount          uint64        `json:"amount,string"`
+}
+
+// EIP-7251, equal source and target pubkeys request a switch to compounding withdrawal credentials
+type ConsolidationRequest struct {
+	SourceAddress hexutil.Bytes `json:"source_address"`
+	SourcePubkey  hexutil.Bytes `json:"source_pubkey"`
+	TargetPubkey  hexutil.Bytes `json:"target_pubkey"`
+}
@@ -23,6 +23,8 @@ type StandardSpec struct {
 	CapellaForkEpoch                        *uint64  `json:"CAPELLA_FORK_EPOCH,string"`
 	DenebForkVersion                        string   `json:"DENEB_FORK_VERSION"`
 	DenebForkEpoch                          *uint64  `json:"DENEB_FORK_EPOCH,string"`
+	ElectraForkVersion                      string   `json:"ELECTRA_FORK_VERSION"`
+	ElectraForkEpoch                        *uint64  `json:"ELECTRA_FORK_EPOCH,string"`
 	SecondsPerSlot                          int64    `json:"SECONDS_PER_SLOT,string"`
 	SecondsPerEth1Block                     int64    `json:"SECONDS_PER_ETH1_BLOCK,string"`
 	MinValidatorWithdrawabilityDelay        int64    `json:"MIN_VALIDATOR_WITHDRAWABILITY_DELAY,string"`
@@ -117,4 +119,17 @@ type StandardSpec struct {
 	MaxRequestBlobSidecars           *uint64 `json:"MAX_REQUEST_BLOB_SIDECARS,string"`
 	MinEpochsForBlobSidecarsRequests *uint64 `json:"MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS,string"`
 	BlobSidecarSubnetCount           *uint64 `json:"BLOB_SIDECAR_SUBNET_COUNT,string"`
+	// ELECTRA
+	MinActivationBalance                  *uint64 `json:"MIN_ACTIVATION_BALANCE,string"`
+	MaxEffectiveBalanceElectra            *uint64 `json:"MAX_EFFECTIVE_BALANCE_ELECTRA,string"`
+	MinPerEpochChurnLimitElectra          *uint64 `json:"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA,string"`
+	MaxPerEpochActivationExitChurnLimit   *uint64 `json:"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT,string"`
+	MaxPendingPartialsPerWithdrawalsSweep *uint64 `json:"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP,string"`
+	MaxPendingDepositsPerEpoch            *uint64 `json:"MAX_PENDING_DEPOSITS_PER_EPOCH,string"`
+	MaxDepositRequestsPerPayload          *uint64 `json:"MAX_DEPOSIT_REQUESTS_PER_PAYLOAD,string"`
+	MaxWithdrawalRequestsPerPayload       *uint64 `json:"MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD,string"`
+	MaxConsolidationRequestsPerPayload    *uint64 `json:"MAX_CONSOLIDATION_REQUESTS_PER_PAYLOAD,string"`
+	MaxAttesterSlashingsElectra           *uint64 `json:"MAX_ATTESTER_SLASHINGS_ELECTRA,string"`
+	MaxAttestationsElectra                *uint64 `json:"MAX_ATTESTATIONS_ELECTRA,string"`
+	MaxBlobsPerBlockElectra               *uint64 `json:"MAX_BLOBS_PER_BLOCK_ELECTRA,string"`
 }
@@ -126,6 +126,33 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 	}
 	defer stmtDeposits.Close()
 
+	stmtDepositRequests, err := tx.Prepare(`
+		INSERT INTO blocks_deposits (block_slot, block_index, block_root, proof, publickey, withdrawalcredentials, amount, signature, valid_signature, request_index)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (block_slot, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtDepositRequests.Close()
+
+	stmtWithdrawalRequests, err := tx.Prepare(`
+		INSERT INTO blocks_withdrawal_requests (block_slot, block_index, block_root, source_address, validator_pubkey, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtWithdrawalRequests.Close()
+
+	stmtConsolidationRequests, err := tx.Prepare(`
+		INSERT INTO blocks_consolidation_requests (block_slot, block_index, block_root, source_address, source_pubkey, target_pubkey)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtConsolidationRequests.Close()
+
 	stmtBlobs, err := tx.Prepare(`
 		INSERT INTO blocks_blob_sidecars (block_slot, block_root, index, kzg_commitment, kzg_proof, blob_versioned_hash)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -267,7 +294,7 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 				len(b.ProposerSlashings),
 				len(b.AttesterSlashings),
 				len(b.Attestations),
-				len(b.Deposits),
+				len(b.Deposits)+len(b.DepositRequests),
 				execData.WithdrawalCount,
 				len(b.VoluntaryExits),
 				syncAggParticipation,
@@ -351,6 +378,37 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 				}
 			}
 
+			// deposit requests continue the block_index of the deposits of the block
+			for i, d := range b.DepositRequests {
+				err := utils.VerifyDepositSignature(&phase0.DepositData{
+					PublicKey:             phase0.BLSPubKey(d.PublicKey),
+					WithdrawalCredentials: d.WithdrawalCredentials,
+					Amount:                phase0.Gwei(d.Amount),
+					Signature:             phase0.BLSSignature(d.Signature),
+				}, domain)
+
+				signatureValid := err == nil
+
+				_, err = stmtDepositRequests.Exec(b.Slot, len(b.Deposits)+i, b.BlockRoot, nil, d.PublicKey, d.WithdrawalCredentials, d.Amount, d.Signature, signatureValid, d.Index)
+				if err != nil {
+					return fmt.Errorf("error executing stmtDepositRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
+			for i, wr := range b.WithdrawalRequests {
+				_, err := stmtWithdrawalRequests.Exec(b.Slot, i, b.BlockRoot, wr.SourceAddress, wr.ValidatorPubkey, wr.Amount)
+				if err != nil {
+					return fmt.Errorf("error executing stmtWithdrawalRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
+			for i, cr := range b.ConsolidationRequests {
+				_, err := stmtConsolidationRequests.Exec(b.Slot, i, b.BlockRoot, cr.SourceAddress, cr.SourcePubkey, cr.TargetPubkey)
+				if err != nil {
+					return fmt.Errorf("error executing stmtConsolidationRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
 			for i, ve := range b.VoluntaryExits {
 				_, err := stmtVoluntaryExits.Exec(b.Slot, i, b.BlockRoot, ve.Epoch, ve.ValidatorIndex, ve.Signature)
 				if err != nil {
@@ -1063,3 +1121,5 @@ const RollingDailyWriterTable = "validator_dashboard_data_rolling_daily"
 const RollingWeeklyWriterTable = "validator_dashboard_data_rolling_weekly"
 const RollingMonthlyWriterTable = "validator_dashboard_data_rolling_monthly"
 const RollingNinetyDaysWriterTable = "validator_dashboard_data_rolling_90d"
+
+const ReprocessProgressTableName = "validator_dashboard_data_reprocess_progress"
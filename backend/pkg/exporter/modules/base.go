@@ -11,7 +11,6 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/consapi"
 	"github.com/gobitfly/beaconchain/pkg/consapi/types"
-	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -29,6 +28,29 @@ type ModuleInterface interface {
 	OnChainReorg(*types.StandardEventChainReorg) error // !Do not block in this functions for an extended period of time!
 }
 
+// Optional event handlers, the exporter only subscribes to a topic if at least one module implements its handler.
+// The same blocking rules as for the ModuleInterface handlers apply, attestation events arrive at a very high rate.
+
+type AttestationHandler interface {
+	OnAttestation(*types.Attestation) error
+}
+
+type VoluntaryExitHandler interface {
+	OnVoluntaryExit(*types.VoluntaryExit) error
+}
+
+type BlsToExecutionChangeHandler interface {
+	OnBlsToExecutionChange(*types.SignedBLSToExecutionChange) error
+}
+
+type ContributionAndProofHandler interface {
+	OnContributionAndProof(*types.StandardEventContributionAndProof) error
+}
+
+type PayloadAttributesHandler interface {
+	OnPayloadAttributes(*types.StandardEventPayloadAttributes) error
+}
+
 var Client *rpc.Client
 
 // Start will start the export of data from rpc into the database
@@ -89,12 +111,21 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 
 	log.Infof("subscribing to node events")
 
-	// subscribe to node events and notify modules
-	events := context.CL.GetEvents([]types.EventTopic{
+	topics := []types.EventTopic{
 		types.EventHead,
 		types.EventFinalizedCheckpoint,
 		types.EventChainReorg,
+	}
+	topics = append(topics, optionalEventTopics(modules)...)
+	log.Infof("subscribing to event topics %v", topics)
+
+	// subscribe to node events and notify modules, the stream resubscribes on its own and backfills missed heads
+	stream := consapi.NewEventStream(context.CL.ClientInt, topics, &consapi.EventStreamConfig{
+		SlotsPerEpoch:    utils.Config.Chain.ClConfig.SlotsPerEpoch,
+		SecondsPerSlot:   utils.Config.Chain.ClConfig.SecondsPerSlot,
+		GenesisTimestamp: utils.Config.Chain.GenesisTimestamp,
 	})
+	events := stream.Start()
 
 	for event := range events {
 		if event.Error != nil {
@@ -110,7 +141,7 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 				continue
 			}
 			log.InfoWithFields(
-				log.Fields{"slot": res.Slot, "epoch-transition": res.EpochTransition},
+				log.Fields{"slot": res.Slot, "epoch-transition": res.EpochTransition, "synthetic": event.Synthetic},
 				"notifying exporter modules about new head",
 			)
 			notifyAllModules(eventPool, modules, func(module ModuleInterface) error {
@@ -134,11 +165,111 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 				log.Error(err, "error getting chain reorg event", 0)
 				continue
 			}
-			log.InfoWithFields(log.Fields{"slot": res.Slot, "depth": res.Depth}, "notifying exporter modules about chain reorg")
+			log.InfoWithFields(log.Fields{"slot": res.Slot, "depth": res.Depth, "synthetic": event.Synthetic}, "notifying exporter modules about chain reorg")
 			notifyAllModules(eventPool, modules, func(module ModuleInterface) error {
 				return module.OnChainReorg(res)
 			})
+
+		case types.EventAttestation:
+			res, err := event.Attestation()
+			if err != nil {
+				log.Error(err, "error getting attestation event", 0)
+				continue
+			}
+			notifyHandlers(eventPool, modules, func(handler AttestationHandler) error {
+				return handler.OnAttestation(res)
+			})
+
+		case types.EventVoluntaryExit:
+			res, err := event.VoluntaryExit()
+			if err != nil {
+				log.Error(err, "error getting voluntary exit event", 0)
+				continue
+			}
+			log.InfoWithFields(log.Fields{"validator": res.Message.ValidatorIndex, "epoch": res.Message.Epoch}, "notifying exporter modules about voluntary exit")
+			notifyHandlers(eventPool, modules, func(handler VoluntaryExitHandler) error {
+				return handler.OnVoluntaryExit(res)
+			})
+
+		case types.EventBlsToExecutionChange:
+			res, err := event.BlsToExecutionChange()
+			if err != nil {
+				log.Error(err, "error getting bls to execution change event", 0)
+				continue
+			}
+			log.InfoWithFields(log.Fields{"validator": res.Message.ValidatorIndex}, "notifying exporter modules about bls to execution change")
+			notifyHandlers(eventPool, modules, func(handler BlsToExecutionChangeHandler) error {
+				return handler.OnBlsToExecutionChange(res)
+			})
+
+		case types.EventContributionAndProof:
+			res, err := event.ContributionAndProof()
+			if err != nil {
+				log.Error(err, "error getting contribution and proof event", 0)
+				continue
+			}
+			notifyHandlers(eventPool, modules, func(handler ContributionAndProofHandler) error {
+				return handler.OnContributionAndProof(res)
+			})
+
+		case types.EventPayloadAttributes:
+			res, err := event.PayloadAttributes()
+			if err != nil {
+				log.Error(err, "error getting payload attributes event", 0)
+				continue
+			}
+			notifyHandlers(eventPool, modules, func(handler PayloadAttributesHandler) error {
+				return handler.OnPayloadAttributes(res)
+			})
+		}
+	}
+}
+
+// optionalEventTopics returns the topics of all optional handlers implemented by at least one module
+func optionalEventTopics(modules []ModuleInterface) []types.EventTopic {
+	topics := []types.EventTopic{}
+	if hasHandler[AttestationHandler](modules) {
+		topics = append(topics, types.EventAttestation)
+	}
+	if hasHandler[VoluntaryExitHandler](modules) {
+		topics = append(topics, types.EventVoluntaryExit)
+	}
+	if hasHandler[BlsToExecutionChangeHandler](modules) {
+		topics = append(topics, types.EventBlsToExecutionChange)
+	}
+	if hasHandler[ContributionAndProofHandler](modules) {
+		topics = append(topics, types.EventContributionAndProof)
+	}
+	if hasHandler[PayloadAttributesHandler](modules) {
+		topics = append(topics, types.EventPayloadAttributes)
+	}
+	return topics
+}
+
+func hasHandler[H any](modules []ModuleInterface) bool {
+	for _, module := range modules {
+		if _, ok := module.(H); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyHandlers calls f for every module implementing the optional handler H
+func notifyHandlers[H any](goPool *errgroup.Group, modules []ModuleInterface, f func(H) error) {
+	for _, module := range modules {
+		handler, ok := module.(H)
+		if !ok {
+			continue
 		}
+		module := module
+		goPool.Go(func() error {
+			err := f(handler)
+			if err != nil {
+				log.Error(err, fmt.Sprintf("error in module %s", module.GetName()), 0)
+			}
+			return nil
+		})
 	}
 }
 
@@ -156,7 +287,7 @@ func notifyAllModules(goPool *errgroup.Group, modules []ModuleInterface, f func(
 }
 
 func GetModuleContext() (ModuleContext, error) {
-	cl := consapi.NewClient("http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port)
+	cl := consapi.NewClientForEndpoints(utils.BeaconNodeEndpoints(utils.Config))
 
 	spec, err := cl.GetSpec()
 	if err != nil {
@@ -165,14 +296,9 @@ func GetModuleContext() (ModuleContext, error) {
 
 	config.ClConfig = &spec.Data
 
-	nodeImpl, ok := cl.ClientInt.(*consapi.NodeClient)
-	if !ok {
-		return ModuleContext{}, errors.New("lighthouse client can only be used with real node impl")
-	}
-
 	chainID := new(big.Int).SetUint64(utils.Config.Chain.ClConfig.DepositChainID)
 
-	clClient, err := rpc.NewLighthouseClient(nodeImpl, chainID)
+	clClient, err := rpc.NewLighthouseClient(cl.ClientInt, chainID)
 	if err != nil {
 		log.Fatal(err, "error creating lighthouse client", 0)
 	}
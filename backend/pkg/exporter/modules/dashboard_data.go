@@ -2,6 +2,7 @@ package modules
 
 import (
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"math"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/consapi/network"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"golang.org/x/sync/errgroup"
@@ -79,14 +81,16 @@ type dashboardData struct {
 	ModuleContext
 	log               ModuleLog
 	signingDomain     []byte
-	epochWriter       *epochWriter
+	epochWriter       epochDataWriter
 	epochToTotal      *epochToTotalAggregator
 	epochToHour       *epochToHourAggregator
 	epochToDay        *epochToDayAggregator
 	dayUp             *dayUpAggregator
+	storage           db.DashboardAggregateStorage
 	headEpochQueue    chan uint64
 	backFillCompleted bool
 	responseCache     ResponseCache
+	trackedValidators trackedValidators
 }
 
 func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
@@ -106,6 +110,13 @@ func NewDashboardDataModule(moduleContext ModuleContext) ModuleInterface {
 	// Once an epoch is aggregated to its respective UTC day, we can use the UTC day table to aggregate up to the rolling window tables (7d, 30d, 90d)
 	temp.dayUp = newDayUpAggregator(temp)
 
+	// The api reads some aggregates through the dashboard storage that are written after the rolling windows
+	storage, err := db.NewDashboardAggregateStorage(utils.Config.DashboardStorage, nil, nil, db.AlloyWriter)
+	if err != nil {
+		temp.log.Fatal(err, "failed to initialize dashboard storage", 0)
+	}
+	temp.storage = storage
+
 	// This channel is used to queue up epochs from chain head that need to be exported
 	temp.headEpochQueue = make(chan uint64, 100)
 
@@ -207,7 +218,7 @@ func (d *dashboardData) processHeadQueue() {
 			// Get epoch data from node and write to database
 			if stage <= 1 {
 				doRollingAggregate = currentFinalizedEpoch.Data.Finalized.Epoch <= epoch+1 // only near head
-				err := d.exportEpochAndTails(epoch, debugAggregateMidEveryEpoch || doRollingAggregate)
+				err := d.exportEpochAndTails(epoch, debugAggregateMidEveryEpoch || doRollingAggregate, debugForceBootstrapRollingTables)
 				if err != nil {
 					d.log.Error(err, "failed to export epoch tail data", 0, map[string]interface{}{"epoch": epoch})
 					metrics.Errors.WithLabelValues("exporter_v2dash_export_epoch_tail_fail").Inc()
@@ -219,7 +230,7 @@ func (d *dashboardData) processHeadQueue() {
 
 			// Run aggregations
 			if stage <= 2 {
-				err := d.aggregatePerEpoch(debugAggregateMidEveryEpoch || doRollingAggregate, backfillResult.DidPerformBackfill) // keep epoch data if backfill was needed
+				err := d.aggregatePerEpoch(debugAggregateMidEveryEpoch || doRollingAggregate, backfillResult.DidPerformBackfill, debugForceBootstrapRollingTables) // keep epoch data if backfill was needed
 				if err != nil {
 					d.log.Error(err, "failed to aggregate", 0, map[string]interface{}{"epoch": epoch})
 					metrics.Errors.WithLabelValues("exporter_v2dash_agg_fail").Inc()
@@ -238,12 +249,13 @@ func (d *dashboardData) processHeadQueue() {
 
 // exports the provided headEpoch plus any tail epochs that are needed for rolling aggregation
 // fE a tail epoch for rolling 1 day aggregation (225 epochs) for head 227 on ethereum would correspond to two tail epochs [0,1]
-func (d *dashboardData) exportEpochAndTails(headEpoch uint64, fetchRollingTails bool) error {
+// forceBootstrapRollingTables must match the flag passed to aggregatePerEpoch afterwards
+func (d *dashboardData) exportEpochAndTails(headEpoch uint64, fetchRollingTails bool, forceBootstrapRollingTables bool) error {
 	missingTails := make([]uint64, 0)
 	var err error
 	if fetchRollingTails {
 		// for 24h aggregation
-		missingTails, err = d.epochToDay.getMissingRolling24TailEpochs(headEpoch)
+		missingTails, err = d.epochToDay.getMissingRolling24TailEpochs(headEpoch, forceBootstrapRollingTables)
 		if err != nil {
 			return errors.Wrap(err, "failed to get missing 24h tail epochs")
 		}
@@ -251,7 +263,7 @@ func (d *dashboardData) exportEpochAndTails(headEpoch uint64, fetchRollingTails
 		d.log.Infof("missing 24h tails: %v", missingTails)
 
 		// day aggregation
-		daysMissingTails, err := d.dayUp.getMissingRollingDayTailEpochs(headEpoch)
+		daysMissingTails, err := d.dayUp.getMissingRollingDayTailEpochs(headEpoch, forceBootstrapRollingTables)
 		if err != nil {
 			return errors.Wrap(err, "failed to get missing day tail epochs")
 		}
@@ -642,7 +654,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 		// This is just a precaution so that the aggregated epochs tables are up to date
 		// before exporting new epochs
 		if latestExportedEpoch > 0 {
-			err = d.aggregatePerEpoch(false, true)
+			err = d.aggregatePerEpoch(false, true, debugForceBootstrapRollingTables)
 			if err != nil {
 				return result, errors.Wrap(err, "failed to aggregate")
 			}
@@ -673,7 +685,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 						// write subset
 						d.writeEpochDatas(datas[:i+1])
 						for {
-							err = d.aggregatePerEpoch(true, false) // if we do a bootstrap rolling we don't have to prevent any epoch cleanup
+							err = d.aggregatePerEpoch(true, false, debugForceBootstrapRollingTables) // if we do a bootstrap rolling we don't have to prevent any epoch cleanup
 							if err != nil {
 								metrics.Errors.WithLabelValues("exporter_v2dash_agg_per_epoch_fail").Inc()
 								d.log.Error(err, "backfill, failed to aggregate", 0, map[string]interface{}{"epoch start": datas[0].Epoch, "epoch end": datas[len(datas)-1].Epoch})
@@ -721,7 +733,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 						// or prevent if previous rolling aggregation has been interrupted.
 						preventClearOldEpochs = done || ancientEpochsPresent && isSmallBackfill
 
-						err = d.aggregatePerEpoch(false, preventClearOldEpochs)
+						err = d.aggregatePerEpoch(false, preventClearOldEpochs, debugForceBootstrapRollingTables)
 						if err != nil {
 							d.log.Error(err, "backfill, failed to aggregate", 0, map[string]interface{}{"epoch start": datas[0].Epoch, "epoch end": lastEpoch})
 							metrics.Errors.WithLabelValues("exporter_v2dash_agg_per_epoch_fail").Inc()
@@ -815,7 +827,7 @@ func (d *dashboardData) writeEpochDatas(datas []DataEpochProcessed) {
 // Contains all aggregation logic that should happen for every new exported epoch
 // forceAggregate triggers an aggregation, use this when calling on head.
 // updateRollingWindows specifies whether we should update rolling windows
-func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClearOldEpochs bool) error {
+func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClearOldEpochs bool, forceBootstrapRollingTables bool) error {
 	currentExportedEpoch, err := edb.GetLatestDashboardEpoch()
 	if err != nil {
 		return errors.Wrap(err, "failed to get last exported epoch")
@@ -863,7 +875,7 @@ func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClea
 	if updateRollingWindows {
 		// todo you could add it to the err group above IF no bootstrap is needed.
 
-		err = d.aggregateRollingWindows(currentExportedEpoch)
+		err = d.aggregateRollingWindows(currentExportedEpoch, forceBootstrapRollingTables)
 		if err != nil {
 			metrics.Errors.WithLabelValues("exporter_v2dash_agg_non_fail").Inc()
 			return errors.Wrap(err, "failed to aggregate rolling windows")
@@ -875,6 +887,12 @@ func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClea
 		if err != nil {
 			return errors.Wrap(err, "failed to refresh slashed by counts")
 		}
+
+		// needs the epochs of the epoch table, so it has to run before they are cleared
+		err = d.storage.WriteAggregates(currentExportedEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to write dashboard storage aggregates")
+		}
 	}
 
 	if !preventClearOldEpochs {
@@ -904,7 +922,7 @@ func (d *dashboardData) aggregatePerEpoch(updateRollingWindows bool, preventClea
 // This function contains more heavy aggregation like rolling 7d, 30d, 90d
 // This function assumes that epoch aggregation is finished before calling THOUGH it could run in parallel
 // as long as the rolling tables do not require a bootstrap
-func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) error {
+func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64, forceBootstrap bool) error {
 	start := time.Now()
 	defer func() {
 		d.log.Infof("[time] all of mid aggregation took %v", time.Since(start))
@@ -915,7 +933,7 @@ func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) err
 	errGroup.SetLimit(databaseAggregationParallelism)
 
 	errGroup.Go(func() error {
-		err := d.epochToDay.rolling24hAggregate(currentExportedEpoch)
+		err := d.epochToDay.rolling24hAggregate(currentExportedEpoch, forceBootstrap)
 		if err != nil {
 			return errors.Wrap(err, "failed to rolling 24h aggregate")
 		}
@@ -924,7 +942,7 @@ func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) err
 	})
 
 	errGroup.Go(func() error {
-		err := d.dayUp.rolling7dAggregate(currentExportedEpoch)
+		err := d.dayUp.rolling7dAggregate(currentExportedEpoch, forceBootstrap)
 		if err != nil {
 			return errors.Wrap(err, "failed to aggregate 7d")
 		}
@@ -932,7 +950,7 @@ func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) err
 	})
 
 	errGroup.Go(func() error {
-		err := d.dayUp.rolling30dAggregate(currentExportedEpoch)
+		err := d.dayUp.rolling30dAggregate(currentExportedEpoch, forceBootstrap)
 		if err != nil {
 			return errors.Wrap(err, "failed to aggregate 30d")
 		}
@@ -940,7 +958,7 @@ func (d *dashboardData) aggregateRollingWindows(currentExportedEpoch uint64) err
 	})
 
 	errGroup.Go(func() error {
-		err := d.dayUp.rolling90dAggregate(currentExportedEpoch)
+		err := d.dayUp.rolling90dAggregate(currentExportedEpoch, forceBootstrap)
 		if err != nil {
 			return errors.Wrap(err, "failed to aggregate 90d")
 		}
@@ -1067,6 +1085,7 @@ type Data struct {
 	idealAttestationRewards map[int64]constypes.AttestationIdealReward // effective-balance -> ideal reward
 	beaconBlockData         map[uint64]*constypes.StandardBeaconSlotResponse
 	beaconBlockRewardData   map[uint64]*constypes.StandardBlockRewardsResponse
+	executionRewards        map[string]int64 // exec block hash -> el reward of the proposer in gwei
 	syncCommitteeRewardData map[uint64]*constypes.StandardSyncCommitteeRewardsResponse
 	attestationAssignments  map[uint64]uint32
 	missedslots             map[uint64]bool
@@ -1075,9 +1094,30 @@ type Data struct {
 
 	// Contains the validator state of the epoch where the current sync committee election took place
 	syncCommitteeElectedState *constypes.StandardValidatorsResponse
+
+	// Since electra deposits are credited from the pending deposits queue during epoch processing
+	pendingDepositsStart *constypes.StandardPendingDepositsResponse
+	pendingDepositsEnd   *constypes.StandardPendingDepositsResponse
+
+	// Sorted indices of the validators that are exported in tracked validators mode, nil if all validators are exported
+	exportedValidators []uint64
 }
 
-const MAX_EFFECTIVE_BALANCE = 32e9
+// pendingDepositKey identifies a pending deposit, duplicates are possible so the key is used to count occurrences
+func pendingDepositKey(d *constypes.PendingDeposit) string {
+	return fmt.Sprintf("%x-%x-%d-%x-%d", d.Pubkey, d.WithdrawalCredentials, d.Amount, d.Signature, d.Slot)
+}
+
+// hasPendingDepositsStartState returns whether the pending deposits queue at the start of an epoch can be read from the
+// state at lastSlotOfPreviousEpoch. For the first electra epoch that state is a pre-electra state without a queue, the
+// queue of the fork epoch starts empty. The entries added by upgrade_to_electra (pre-activation and excess balances) are
+// not taken into account as they have already been counted as deposits before the fork.
+func hasPendingDepositsStartState(lastSlotOfPreviousEpoch int64) bool {
+	if lastSlotOfPreviousEpoch < 0 {
+		return false
+	}
+	return uint64(lastSlotOfPreviousEpoch)/utils.Config.Chain.ClConfig.SlotsPerEpoch >= utils.Config.Chain.ClConfig.ElectraForkEpoch
+}
 
 // Data for a single validator
 // use skipSerialCalls = false if you are not sure what you are doing. This flag is mainly
@@ -1151,10 +1191,16 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		}
 	}
 
-	errGroup.Go(func() error {
-		// attestation rewards
+	// attestation rewards, a nil validators slice requests the rewards of all validators
+	getAttestationRewards := func(validators []uint64) error {
 		start := time.Now()
-		data, err := cl.GetAttestationRewards(epoch)
+		var data *constypes.StandardAttestationRewardsResponse
+		var err error
+		if validators == nil {
+			data, err = cl.GetAttestationRewards(epoch)
+		} else {
+			data, err = cl.GetAttestationRewardsOfValidators(epoch, validators)
+		}
 		if err != nil {
 			d.log.Error(err, "can not get attestation rewards", 0, map[string]interface{}{"epoch": epoch})
 			return err
@@ -1170,7 +1216,14 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 
 		d.log.Debugf("retrieved attestation rewards data in %v", time.Since(start))
 		return nil
-	})
+	}
+
+	// in tracked validators mode the rewards are requested once the validator count of the epoch is known
+	if !utils.Config.TrackedValidators.Enabled {
+		errGroup.Go(func() error {
+			return getAttestationRewards(nil)
+		})
+	}
 
 	errGroup.Go(func() error {
 		// retrieve the validator balances at the end of the epoch
@@ -1273,6 +1326,29 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		})
 	}
 
+	if epoch >= utils.Config.Chain.ClConfig.ElectraForkEpoch {
+		errGroup.Go(func() error {
+			start := time.Now()
+			var err error
+			if hasPendingDepositsStartState(lastSlotOfPreviousEpoch) {
+				result.pendingDepositsStart, err = cl.GetPendingDeposits(lastSlotOfPreviousEpoch)
+				if err != nil {
+					d.log.Error(err, "can not get pending deposits", 0, map[string]interface{}{"lastSlotOfPreviousEpoch": lastSlotOfPreviousEpoch})
+					return err
+				}
+			} else {
+				result.pendingDepositsStart = &constypes.StandardPendingDepositsResponse{}
+			}
+			result.pendingDepositsEnd, err = cl.GetPendingDeposits(lastSlotOfEpoch)
+			if err != nil {
+				d.log.Error(err, "can not get pending deposits", 0, map[string]interface{}{"lastSlotOfEpoch": lastSlotOfEpoch})
+				return err
+			}
+			d.log.Debugf("retrieved pending deposits in %v", time.Since(start))
+			return nil
+		})
+	}
+
 	currentSyncPeriod := utils.SyncPeriodOfEpoch(epoch)
 	if epoch == utils.FirstEpochOfSyncPeriod(currentSyncPeriod) {
 		syncCommitteElectionEpoch := getSyncCommitteeElectionEpochOf(currentSyncPeriod)
@@ -1296,11 +1372,124 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		return nil, err
 	}
 
+	if utils.Config.TrackedValidators.Enabled {
+		tracked, err := d.trackedValidators.get()
+		if err != nil {
+			d.log.Error(err, "can not get tracked validators", 0, map[string]interface{}{"epoch": epoch})
+			return nil, err
+		}
+		result.exportedValidators = getExportedValidators(tracked, uint64(len(result.currentEpochStateEnd.Data)))
+
+		err = getAttestationRewards(result.exportedValidators)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the epoch is deferred until its el data is complete
+	result.executionRewards, err = d.waitForExecutionRewards(epoch, func() (map[string]int64, error) {
+		return d.getExecutionRewards(epoch, result.beaconBlockData)
+	}, utils.Config.DashboardExecutionRewardsMaxWait, executionRewardsRetryInterval)
+	if err != nil {
+		d.log.Error(err, "can not get execution rewards", 0, map[string]interface{}{"epoch": epoch})
+		return nil, err
+	}
+
 	d.log.Infof("[time] retrieved all data for epoch %d in %v", epoch, time.Since(totalStart))
 
 	return &result, nil
 }
 
+// errExecutionRewardsNotReady is returned while the el data of an epoch is incomplete
+var errExecutionRewardsNotReady = errors.New("execution rewards not ready")
+
+const executionRewardsRetryInterval = time.Second * 10
+
+// waitForExecutionRewards retries getRewards as long as the el data of the epoch is not ready.
+// After maxWait the epoch is exported without el rewards (blocks_el_reward stays NULL) instead of halting the exporter.
+func (d *dashboardData) waitForExecutionRewards(epoch uint64, getRewards func() (map[string]int64, error), maxWait, retryInterval time.Duration) (map[string]int64, error) {
+	start := time.Now()
+	for {
+		rewards, err := getRewards()
+		if !errors.Is(err, errExecutionRewardsNotReady) {
+			return rewards, err
+		}
+		if time.Since(start) >= maxWait {
+			d.log.Warnf("el data of epoch %d is still incomplete after %v, exporting the epoch without el rewards: %v", epoch, maxWait, err)
+			metrics.Counter.WithLabelValues("exporter_v2dash_el_reward_timeout").Inc()
+			return nil, nil
+		}
+		d.log.Infof("waiting for the el data of epoch %d: %v", epoch, err)
+		time.Sleep(retryInterval)
+	}
+}
+
+// getExecutionRewards returns the el reward of the proposer for each execution payload of the given blocks.
+// The mev reward of the relay bid trace takes precedence over the fee recipient reward of the execution payloads exporter.
+// Returns errExecutionRewardsNotReady until the relays have been exported past the epoch and the reward of every block is known,
+// the rows of an epoch are written only once so partial rewards would never be corrected.
+func (d *dashboardData) getExecutionRewards(epoch uint64, blocks map[uint64]*constypes.StandardBeaconSlotResponse) (map[string]int64, error) {
+	execBlockHashes := make([][]byte, 0, len(blocks))
+	for _, block := range blocks {
+		// pre merge payloads are empty
+		if block.Data.Message.Body.ExecutionPayload != nil && block.Data.Message.Body.ExecutionPayload.BlockNumber > 0 {
+			execBlockHashes = append(execBlockHashes, block.Data.Message.Body.ExecutionPayload.BlockHash)
+		}
+	}
+	if len(execBlockHashes) == 0 {
+		return nil, nil
+	}
+
+	// relays that currently fail to export are not waited for, otherwise a single unreachable relay would halt the exporter
+	var pendingRelays uint64
+	err := db.ReaderDb.Get(&pendingRelays, `
+		SELECT COUNT(*) FROM relays
+		WHERE export_failure_count = 0 AND (last_export_success_ts IS NULL OR last_export_success_ts < TO_TIMESTAMP($1) AT TIME ZONE 'utc')
+	`, utils.EpochToTime(epoch+1).Unix())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get relay export status")
+	}
+	if pendingRelays > 0 {
+		return nil, errors.Wrapf(errExecutionRewardsNotReady, "%d relays have not been exported past epoch %d", pendingRelays, epoch)
+	}
+
+	var rewards []struct {
+		BlockHash []byte        `db:"block_hash"`
+		Reward    sql.NullInt64 `db:"reward"`
+	}
+	err = db.ReaderDb.Select(&rewards, `
+		WITH hashes AS (
+			SELECT unnest($1::bytea[]) AS block_hash
+		)
+		SELECT
+			hashes.block_hash,
+			COALESCE(
+				(SELECT MAX(rb.value) FROM relays_blocks rb WHERE rb.exec_block_hash = hashes.block_hash) / 1e9,
+				ep.fee_recipient_reward * 1e9
+			)::BIGINT AS reward
+		FROM hashes
+		LEFT JOIN execution_payloads ep ON ep.block_hash = hashes.block_hash
+	`, pq.ByteaArray(execBlockHashes))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get execution rewards")
+	}
+
+	result := make(map[string]int64, len(rewards))
+	missing := 0
+	for _, reward := range rewards {
+		if !reward.Reward.Valid {
+			missing++
+			continue
+		}
+		result[hex.EncodeToString(reward.BlockHash)] = reward.Reward.Int64
+	}
+	if missing > 0 {
+		metrics.Counter.WithLabelValues("exporter_v2dash_el_reward_missing").Inc()
+		return nil, errors.Wrapf(errExecutionRewardsNotReady, "el reward of %d blocks of epoch %d is not known yet", missing, epoch)
+	}
+	return result, nil
+}
+
 func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboardDataRow, error) {
 	validatorsData := make([]*validatorDashboardDataRow, len(data.currentEpochStateEnd.Data))
 
@@ -1311,6 +1500,7 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 	currentSyncPeriod := utils.SyncPeriodOfEpoch(data.epoch)
 
 	postAltair := data.epoch >= utils.Config.Chain.ClConfig.AltairForkEpoch
+	postElectra := data.epoch >= utils.Config.Chain.ClConfig.ElectraForkEpoch
 
 	// write start & end balances and slashed status
 	for i := 0; i < len(validatorsData); i++ {
@@ -1377,7 +1567,6 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 
 	size := uint64(len(validatorsData))
 	sizeInt := int64(len(validatorsData))
-	size32 := uint32(len(validatorsData))
 
 	// write scheduled block data
 	for _, proposerAssignment := range data.proposerAssignments.Data {
@@ -1449,53 +1638,62 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 			validatorsData[block.Data.Message.ProposerIndex].BlocksProposed.Int16++
 			validatorsData[block.Data.Message.ProposerIndex].BlocksProposed.Valid = true
 			validatorsData[block.Data.Message.ProposerIndex].LastSubmittedDutyEpoch = utils.NullInt32(int32(block.Data.Message.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch))
+
+			if payload := block.Data.Message.Body.ExecutionPayload; payload != nil {
+				if reward, ok := data.executionRewards[hex.EncodeToString(payload.BlockHash)]; ok {
+					validatorsData[block.Data.Message.ProposerIndex].BlocksElReward.Int64 += reward
+					validatorsData[block.Data.Message.ProposerIndex].BlocksElReward.Valid = true
+				}
+			}
 		}
 
-		for depositIndex, depositData := range block.Data.Message.Body.Deposits {
-			// Properly verify that deposit is valid:
-			// if signature is valid I count the deposit towards the balance
-			// if signature is invalid and the validator was in the state at the beginning of the epoch I count the deposit towards the balance
-			// if signature is invalid and the validator was NOT in the state at the beginning of the epoch and there were no valid deposits in the block prior I DO NOT count the deposit towards the balance
-			// if signature is invalid and the validator was NOT in the state at the beginning of the epoch and there was a VALID deposit in the blocks prior I DO COUNT the deposit towards the balance
+		if !postElectra { // since electra deposits are credited from the pending deposits queue, see below
+			for depositIndex, depositData := range block.Data.Message.Body.Deposits {
+				// Properly verify that deposit is valid:
+				// if signature is valid I count the deposit towards the balance
+				// if signature is invalid and the validator was in the state at the beginning of the epoch I count the deposit towards the balance
+				// if signature is invalid and the validator was NOT in the state at the beginning of the epoch and there were no valid deposits in the block prior I DO NOT count the deposit towards the balance
+				// if signature is invalid and the validator was NOT in the state at the beginning of the epoch and there was a VALID deposit in the blocks prior I DO COUNT the deposit towards the balance
 
-			err := utils.VerifyDepositSignature(&phase0.DepositData{
-				PublicKey:             phase0.BLSPubKey(depositData.Data.Pubkey),
-				WithdrawalCredentials: depositData.Data.WithdrawalCredentials,
-				Amount:                phase0.Gwei(depositData.Data.Amount),
-				Signature:             phase0.BLSSignature(depositData.Data.Signature),
-			}, domain)
+				err := utils.VerifyDepositSignature(&phase0.DepositData{
+					PublicKey:             phase0.BLSPubKey(depositData.Data.Pubkey),
+					WithdrawalCredentials: depositData.Data.WithdrawalCredentials,
+					Amount:                phase0.Gwei(depositData.Data.Amount),
+					Signature:             phase0.BLSSignature(depositData.Data.Signature),
+				}, domain)
 
-			if err != nil {
-				d.log.Error(fmt.Errorf("deposit at index %d in slot %v is invalid: %v (domain: %x, PublicKey: %s, WithdrawalCredentials: %s, Amount: %d, Signature: %s)",
-					depositIndex, block.Data.Message.Slot, err, domain, depositData.Data.Pubkey, depositData.Data.WithdrawalCredentials, depositData.Data.Amount, depositData.Data.Signature), "", 0)
-
-				// if the validator hat a valid deposit prior to the current one, count the invalid towards the balance
-				if validatorsData[pubkeyToIndexMapNewlyActivatedValidators[string(depositData.Data.Pubkey)]].DepositsCount.Int16 > 0 {
-					d.log.Infof("validator had a valid deposit in some earlier block of the epoch, count the invalid towards the balance")
-				} else if _, ok := pubkeyToIndexMapOldValidators[string(depositData.Data.Pubkey)]; ok {
-					d.log.Infof("validator had a valid deposit in some block prior to the current epoch, count the invalid towards the balance")
-				} else {
-					d.log.Infof("validator did not have a prior valid deposit, do not count the invalid towards the balance")
-					continue
+				if err != nil {
+					d.log.Error(fmt.Errorf("deposit at index %d in slot %v is invalid: %v (domain: %x, PublicKey: %s, WithdrawalCredentials: %s, Amount: %d, Signature: %s)",
+						depositIndex, block.Data.Message.Slot, err, domain, depositData.Data.Pubkey, depositData.Data.WithdrawalCredentials, depositData.Data.Amount, depositData.Data.Signature), "", 0)
+
+					// if the validator hat a valid deposit prior to the current one, count the invalid towards the balance
+					if validatorsData[pubkeyToIndexMapNewlyActivatedValidators[string(depositData.Data.Pubkey)]].DepositsCount.Int16 > 0 {
+						d.log.Infof("validator had a valid deposit in some earlier block of the epoch, count the invalid towards the balance")
+					} else if _, ok := pubkeyToIndexMapOldValidators[string(depositData.Data.Pubkey)]; ok {
+						d.log.Infof("validator had a valid deposit in some block prior to the current epoch, count the invalid towards the balance")
+					} else {
+						d.log.Infof("validator did not have a prior valid deposit, do not count the invalid towards the balance")
+						continue
+					}
 				}
-			}
 
-			validator_index, ok := pubkeyToIndexMapNewlyActivatedValidators[string(depositData.Data.Pubkey)]
-			if !ok {
-				validator_index, ok = pubkeyToIndexMapOldValidators[string(depositData.Data.Pubkey)]
+				validator_index, ok := pubkeyToIndexMapNewlyActivatedValidators[string(depositData.Data.Pubkey)]
 				if !ok {
+					validator_index, ok = pubkeyToIndexMapOldValidators[string(depositData.Data.Pubkey)]
+					if !ok {
+						return nil, errors.New("proposer index out of range")
+					}
+				}
+				if validator_index >= sizeInt {
 					return nil, errors.New("proposer index out of range")
 				}
-			}
-			if validator_index >= sizeInt {
-				return nil, errors.New("proposer index out of range")
-			}
 
-			validatorsData[validator_index].DepositsAmount.Int64 += int64(depositData.Data.Amount)
-			validatorsData[validator_index].DepositsAmount.Valid = true
+				validatorsData[validator_index].DepositsAmount.Int64 += int64(depositData.Data.Amount)
+				validatorsData[validator_index].DepositsAmount.Valid = true
 
-			validatorsData[validator_index].DepositsCount.Int16++
-			validatorsData[validator_index].DepositsCount.Valid = true
+				validatorsData[validator_index].DepositsCount.Int16++
+				validatorsData[validator_index].DepositsCount.Valid = true
+			}
 		}
 
 		for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
@@ -1512,34 +1710,34 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 		}
 
 		for _, attestation := range block.Data.Message.Body.Attestations {
-			aggregationBits := bitfield.Bitlist(attestation.AggregationBits)
-
-			for i := uint64(0); i < aggregationBits.Len(); i++ {
-				if aggregationBits.BitAt(i) {
-					validator_index, found := data.attestationAssignments[utils.FormatAttestorAssignmentKeyLowMem(attestation.Data.Slot, attestation.Data.Index, uint32(i))]
-					if !found { // This should never happen!
-						d.log.Error(fmt.Errorf("validator not found in attestation assignments"), "validator not found in attestation assignments", 0, map[string]interface{}{"slot": attestation.Data.Slot, "index": attestation.Data.Index, "i": i})
-						return nil, fmt.Errorf("validator not found in attestation assignments")
-					}
-					if validator_index >= size32 {
-						return nil, errors.New("proposer index out of range")
-					}
+			attesters, err := utils.GetAttestingValidators(bitfield.Bitlist(attestation.AggregationBits), attestation.CommitteeIndices(), func(committeeIndex, memberIndex uint64) (uint64, bool) {
+				validator_index, found := data.attestationAssignments[utils.FormatAttestorAssignmentKeyLowMem(attestation.Data.Slot, uint16(committeeIndex), uint32(memberIndex))]
+				return uint64(validator_index), found
+			})
+			if err != nil { // This should never happen!
+				d.log.Error(err, "can not resolve attesting validators from attestation assignments", 0, map[string]interface{}{"slot": attestation.Data.Slot, "index": attestation.Data.Index})
+				return nil, err
+			}
 
-					validatorsData[validator_index].InclusionDelaySum = utils.NullInt16(int16(block.Data.Message.Slot - attestation.Data.Slot - 1))
+			optimalInclusionDistance := 0
+			for i := attestation.Data.Slot + 1; i < block.Data.Message.Slot; i++ {
+				if _, ok := data.missedslots[i]; ok {
+					optimalInclusionDistance++
+				} else {
+					break
+				}
+			}
 
-					validatorsData[validator_index].LastSubmittedDutyEpoch = utils.NullInt32(int32(attestation.Data.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch))
+			for _, validator_index := range attesters {
+				if validator_index >= size {
+					return nil, errors.New("proposer index out of range")
+				}
 
-					optimalInclusionDistance := 0
-					for i := attestation.Data.Slot + 1; i < block.Data.Message.Slot; i++ {
-						if _, ok := data.missedslots[i]; ok {
-							optimalInclusionDistance++
-						} else {
-							break
-						}
-					}
+				validatorsData[validator_index].InclusionDelaySum = utils.NullInt16(int16(block.Data.Message.Slot - attestation.Data.Slot - 1))
 
-					validatorsData[validator_index].OptimalInclusionDelay = utils.NullInt16(int16(optimalInclusionDistance))
-				}
+				validatorsData[validator_index].LastSubmittedDutyEpoch = utils.NullInt32(int32(attestation.Data.Slot / utils.Config.Chain.ClConfig.SlotsPerEpoch))
+
+				validatorsData[validator_index].OptimalInclusionDelay = utils.NullInt16(int16(optimalInclusionDistance))
 			}
 		}
 
@@ -1561,6 +1759,87 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 		}
 	}
 
+	// write deposits data (electra)
+	// Since electra deposits are no longer applied when included in a block but appended to the pending deposits queue
+	// and credited during epoch processing. The deposits credited in this epoch are the ones that were in the queue at the
+	// start of the epoch or got added by a block of the epoch and are no longer in the queue at the end of the epoch.
+	if postElectra && data.pendingDepositsStart != nil && data.pendingDepositsEnd != nil {
+		remaining := make(map[string]int, len(data.pendingDepositsEnd.Data))
+		for _, pendingDeposit := range data.pendingDepositsEnd.Data {
+			remaining[pendingDepositKey(&pendingDeposit)]++
+		}
+
+		queued := make([]constypes.PendingDeposit, 0, len(data.pendingDepositsStart.Data))
+		queued = append(queued, data.pendingDepositsStart.Data...)
+		slots := make([]uint64, 0, len(data.beaconBlockData))
+		for slot := range data.beaconBlockData {
+			slots = append(slots, slot)
+		}
+		sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+		for _, slot := range slots {
+			block := data.beaconBlockData[slot]
+			for _, depositData := range block.Data.Message.Body.Deposits {
+				queued = append(queued, constypes.PendingDeposit{
+					Pubkey:                depositData.Data.Pubkey,
+					WithdrawalCredentials: depositData.Data.WithdrawalCredentials,
+					Amount:                depositData.Data.Amount,
+					Signature:             depositData.Data.Signature,
+				})
+			}
+			if block.Data.Message.Body.ExecutionRequests != nil {
+				for _, depositRequest := range block.Data.Message.Body.ExecutionRequests.Deposits {
+					queued = append(queued, constypes.PendingDeposit{
+						Pubkey:                depositRequest.Pubkey,
+						WithdrawalCredentials: depositRequest.WithdrawalCredentials,
+						Amount:                depositRequest.Amount,
+						Signature:             depositRequest.Signature,
+						Slot:                  slot,
+					})
+				}
+			}
+		}
+
+		for _, pendingDeposit := range queued {
+			key := pendingDepositKey(&pendingDeposit)
+			if remaining[key] > 0 { // still pending (or postponed) at the end of the epoch
+				remaining[key]--
+				continue
+			}
+
+			validator_index, ok := pubkeyToIndexMapOldValidators[string(pendingDeposit.Pubkey)]
+			if !ok {
+				validator_index, ok = pubkeyToIndexMapNewlyActivatedValidators[string(pendingDeposit.Pubkey)]
+				if !ok {
+					// a deposit with an invalid signature for an unknown pubkey is dropped without creating a validator
+					continue
+				}
+
+				// for validators created in this epoch the first credited deposit must carry a valid signature
+				if validatorsData[validator_index].DepositsCount.Int16 == 0 {
+					err := utils.VerifyDepositSignature(&phase0.DepositData{
+						PublicKey:             phase0.BLSPubKey(pendingDeposit.Pubkey),
+						WithdrawalCredentials: pendingDeposit.WithdrawalCredentials,
+						Amount:                phase0.Gwei(pendingDeposit.Amount),
+						Signature:             phase0.BLSSignature(pendingDeposit.Signature),
+					}, domain)
+					if err != nil {
+						d.log.Infof("pending deposit for %s has an invalid signature and no prior valid deposit, do not count it towards the balance", pendingDeposit.Pubkey)
+						continue
+					}
+				}
+			}
+			if validator_index >= sizeInt {
+				return nil, errors.New("proposer index out of range")
+			}
+
+			validatorsData[validator_index].DepositsAmount.Int64 += int64(pendingDeposit.Amount)
+			validatorsData[validator_index].DepositsAmount.Valid = true
+
+			validatorsData[validator_index].DepositsCount.Int16++
+			validatorsData[validator_index].DepositsCount.Valid = true
+		}
+	}
+
 	// write attestation rewards data
 	for _, attestationReward := range data.attestationRewards {
 		validator_index := attestationReward.ValidatorIndex
@@ -1601,6 +1880,18 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 		}
 	}
 
+	// in tracked validators mode only the rows of the exported validators are kept, the writer skips nil rows
+	if data.exportedValidators != nil {
+		exportedData := make([]*validatorDashboardDataRow, len(validatorsData))
+		for _, validatorIndex := range data.exportedValidators {
+			if validatorIndex >= size {
+				return nil, errors.New("exported validator index out of range")
+			}
+			exportedData[validatorIndex] = validatorsData[validatorIndex]
+		}
+		validatorsData = exportedData
+	}
+
 	return validatorsData, nil
 }
 
@@ -1700,6 +1991,7 @@ type validatorDashboardDataRow struct {
 	BlocksClReward                sql.NullInt64 // done
 	BlocksClAttestestationsReward sql.NullInt64 // done
 	BlocksClSyncAggregateReward   sql.NullInt64 // done
+	BlocksElReward                sql.NullInt64 // done
 
 	SyncScheduled sql.NullInt16 // done
 	SyncExecuted  sql.NullInt16 // done
@@ -0,0 +1,53 @@
+package modules
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestWaitForExecutionRewards(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	d := NewDashboardDataModule(ModuleContext{}).(*dashboardData)
+	want := map[string]int64{"aa": 42}
+
+	calls := 0
+	got, err := d.waitForExecutionRewards(10, func() (map[string]int64, error) {
+		calls++
+		if calls < 3 {
+			return nil, errExecutionRewardsNotReady
+		}
+		return want, nil
+	}, time.Minute, time.Millisecond)
+	if err != nil || !reflect.DeepEqual(got, want) || calls != 3 {
+		t.Errorf("expected %v after 3 calls, got %v after %d calls (err %v)", want, got, calls, err)
+	}
+
+	// the epoch is exported without el rewards once the wait times out
+	calls = 0
+	got, err = d.waitForExecutionRewards(10, func() (map[string]int64, error) {
+		calls++
+		return nil, errExecutionRewardsNotReady
+	}, 5*time.Millisecond, time.Millisecond)
+	if err != nil || got != nil || calls < 2 {
+		t.Errorf("expected no rewards after the timeout, got %v after %d calls (err %v)", got, calls, err)
+	}
+
+	// other errors are returned immediately
+	calls = 0
+	failure := errors.New("db down")
+	_, err = d.waitForExecutionRewards(10, func() (map[string]int64, error) {
+		calls++
+		return nil, failure
+	}, time.Minute, time.Millisecond)
+	if !errors.Is(err, failure) || calls != 1 {
+		t.Errorf("expected %v after 1 call, got %v after %d calls", failure, err, calls)
+	}
+}
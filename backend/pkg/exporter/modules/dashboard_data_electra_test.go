@@ -0,0 +1,53 @@
+package modules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+func TestHasPendingDepositsStartState(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+	utils.Config.Chain.ClConfig.ElectraForkEpoch = 10
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	tests := []struct {
+		name                    string
+		lastSlotOfPreviousEpoch int64
+		want                    bool
+	}{
+		{"genesis", -1, false},
+		{"fork epoch starts from a pre-electra state", 319, false},
+		{"epoch after the fork", 351, true},
+	}
+	for _, tt := range tests {
+		if got := hasPendingDepositsStartState(tt.lastSlotOfPreviousEpoch); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// In the fork epoch the queue starts empty, the balance moved into the queue by upgrade_to_electra must not be
+// counted as a deposit once it is credited again
+func TestProcessPendingDepositsAtElectraFork(t *testing.T) {
+	snapshot, err := loadEpochDataSnapshot(filepath.Join("testdata", "epoch_snapshots", "synthetic_epoch_10.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.ChainConfig.ElectraForkEpoch = snapshot.Epoch
+	snapshot.PendingDepositsStart = &constypes.StandardPendingDepositsResponse{} // as returned by getData for the fork epoch
+	// the excess balance queued by the upgrade has been credited during the epoch and is no longer pending
+	snapshot.PendingDepositsEnd = &constypes.StandardPendingDepositsResponse{}
+
+	rows := replaySnapshot(t, snapshot)
+	for i, row := range rows {
+		if row.DepositsAmount.Valid || row.DepositsCount.Valid {
+			t.Errorf("validator %d must not have a deposit in the fork epoch, got %v", i, row.DepositsAmount)
+		}
+	}
+}
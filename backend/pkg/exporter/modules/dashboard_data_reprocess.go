@@ -0,0 +1,318 @@
+package modules
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	edb "github.com/gobitfly/beaconchain/pkg/exporter/db"
+	"github.com/pkg/errors"
+)
+
+/**
+Reprocessing re-exports an already exported epoch range from the node and rewrites all aggregates that contain it.
+This is needed after fixing a bug in ProcessEpochData or after adding a new column, as the head exporter only ever moves forward.
+
+The range is expanded to full UTC days and processed day by day:
+	1. the epochs of the day are fetched and processed again and replace the epochs in the epoch table
+	2. all hours of the day that are still retained in the hourly table are aggregated again
+	3. the day is aggregated again and the difference between the old and the new day is applied to the total table.
+	   This happens in the same transaction that stores the checkpoint so a day is never applied twice to the total
+	4. epochs that are older than the epoch retention are removed again
+Once all days are done the rolling windows are bootstrapped again from the hourly and daily tables.
+
+The progress is stored in the reprocess progress table, running the same range again resumes after the last completed day.
+The dashboard data exporter must not run while reprocessing, it catches up with the head afterwards.
+*/
+
+// columns of the dashboard data tables that are summed up in the aggregates
+var dashboardDataSumColumns = []string{
+	"attestations_source_reward",
+	"attestations_target_reward",
+	"attestations_head_reward",
+	"attestations_inactivity_reward",
+	"attestations_inclusion_reward",
+	"attestations_reward",
+	"attestations_ideal_source_reward",
+	"attestations_ideal_target_reward",
+	"attestations_ideal_head_reward",
+	"attestations_ideal_inactivity_reward",
+	"attestations_ideal_inclusion_reward",
+	"attestations_ideal_reward",
+	"blocks_scheduled",
+	"blocks_proposed",
+	"blocks_cl_reward",
+	"blocks_cl_attestations_reward",
+	"blocks_cl_sync_aggregate_reward",
+	"blocks_el_reward",
+	"sync_scheduled",
+	"sync_executed",
+	"sync_rewards",
+	"deposits_count",
+	"deposits_amount",
+	"withdrawals_count",
+	"withdrawals_amount",
+	"inclusion_delay_sum",
+	"blocks_expected",
+	"sync_committees_expected",
+	"attestations_scheduled",
+	"attestations_executed",
+	"attestation_head_executed",
+	"attestation_source_executed",
+	"attestation_target_executed",
+	"optimal_inclusion_delay_sum",
+	"slasher_reward",
+}
+
+type DashboardDataReprocessor struct {
+	*dashboardData
+}
+
+func NewDashboardDataReprocessor(moduleContext ModuleContext) *DashboardDataReprocessor {
+	return &DashboardDataReprocessor{
+		dashboardData: NewDashboardDataModule(moduleContext).(*dashboardData),
+	}
+}
+
+type reprocessProgress struct {
+	NextEpoch   uint64       `db:"next_epoch"`
+	CompletedAt sql.NullTime `db:"completed_at"`
+}
+
+// Reprocess re-exports the epochs startEpoch to endEpoch (both incl) and updates all aggregates containing them.
+// If restart is set a previous checkpoint of the same range is discarded.
+func (d *DashboardDataReprocessor) Reprocess(startEpoch, endEpoch uint64, restart bool) error {
+	if startEpoch > endEpoch {
+		return fmt.Errorf("start epoch %d is after end epoch %d", startEpoch, endEpoch)
+	}
+
+	latestExportedEpoch, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest dashboard epoch")
+	}
+	if endEpoch > latestExportedEpoch {
+		return fmt.Errorf("end epoch %d has not been exported yet, latest exported epoch is %d", endEpoch, latestExportedEpoch)
+	}
+
+	// the total table is corrected using the old day aggregates, so only retained days can be reprocessed
+	var oldestDayEpoch sql.NullInt64
+	err = db.AlloyWriter.Get(&oldestDayEpoch, fmt.Sprintf("SELECT MIN(epoch_start) FROM %s", edb.DayWriterTableName))
+	if err != nil {
+		return errors.Wrap(err, "failed to get oldest exported day")
+	}
+	firstDayStart, _ := getDayAggregateBounds(startEpoch)
+	if !oldestDayEpoch.Valid || firstDayStart < uint64(oldestDayEpoch.Int64) {
+		return fmt.Errorf("start epoch %d is older than the oldest retained day (epoch %d)", startEpoch, oldestDayEpoch.Int64)
+	}
+
+	progress, err := d.getReprocessProgress(startEpoch, endEpoch, firstDayStart, restart)
+	if err != nil {
+		return err
+	}
+	if progress.CompletedAt.Valid {
+		d.log.Infof("reprocessing of epochs %d - %d already completed at %v, use restart to reprocess again", startEpoch, endEpoch, progress.CompletedAt.Time)
+		return nil
+	}
+
+	d.log.Warnf("reprocessing dashboard data of epochs %d - %d, make sure the dashboard data exporter is not running", startEpoch, endEpoch)
+
+	for _, day := range getReprocessDays(progress.NextEpoch, endEpoch, latestExportedEpoch) {
+		start := time.Now()
+
+		err = d.reprocessDay(startEpoch, endEpoch, day.dayStart, day.epochEnd, day.nextEpoch)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to reprocess day of epoch %d", day.dayStart))
+		}
+
+		d.log.Infof("[time] reprocessed day %s (epochs %d - %d) in %v, %.2f%% done", utils.EpochToTime(day.dayStart).Format("2006-01-02"), day.dayStart, day.nextEpoch-1, time.Since(start), float64(min(day.nextEpoch, endEpoch+1)-firstDayStart)*100/float64(endEpoch+1-firstDayStart))
+		metrics.State.WithLabelValues("exporter_v2dash_reprocess_next_epoch").Set(float64(day.nextEpoch))
+	}
+
+	err = d.reprocessRollingWindows(latestExportedEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to bootstrap rolling windows")
+	}
+
+	_, err = db.AlloyWriter.Exec(fmt.Sprintf(`UPDATE %s SET completed_at = NOW(), updated_at = NOW() WHERE start_epoch = $1 AND end_epoch = $2`, edb.ReprocessProgressTableName), startEpoch, endEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to mark reprocessing as completed")
+	}
+
+	d.log.Infof("reprocessing of epochs %d - %d completed", startEpoch, endEpoch)
+	return nil
+}
+
+// reprocessDayBounds are the epochs of a day to reprocess, dayStart incl, epochEnd excl (end of the day or the head),
+// nextEpoch is the first epoch of the next day and is stored as checkpoint once the day is done
+type reprocessDayBounds struct {
+	dayStart  uint64
+	epochEnd  uint64
+	nextEpoch uint64
+}
+
+// getReprocessDays returns the days that are left to reprocess when resuming at the checkpoint nextEpoch, endEpoch is incl
+func getReprocessDays(nextEpoch, endEpoch, latestExportedEpoch uint64) []reprocessDayBounds {
+	days := []reprocessDayBounds{}
+	for epoch := nextEpoch; epoch <= endEpoch; {
+		dayStart, dayEnd := getDayAggregateBounds(epoch)
+		days = append(days, reprocessDayBounds{dayStart: dayStart, epochEnd: min(dayEnd, latestExportedEpoch+1), nextEpoch: dayEnd})
+		epoch = dayEnd
+	}
+	return days
+}
+
+func (d *DashboardDataReprocessor) getReprocessProgress(startEpoch, endEpoch, firstDayStart uint64, restart bool) (*reprocessProgress, error) {
+	if restart {
+		_, err := db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE start_epoch = $1 AND end_epoch = $2`, edb.ReprocessProgressTableName), startEpoch, endEpoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete reprocess progress")
+		}
+	}
+
+	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`
+		INSERT INTO %s (start_epoch, end_epoch, next_epoch) VALUES ($1, $2, $3)
+		ON CONFLICT (start_epoch, end_epoch) DO NOTHING
+	`, edb.ReprocessProgressTableName), startEpoch, endEpoch, firstDayStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create reprocess progress")
+	}
+
+	var progress reprocessProgress
+	err = db.AlloyWriter.Get(&progress, fmt.Sprintf(`SELECT next_epoch, completed_at FROM %s WHERE start_epoch = $1 AND end_epoch = $2`, edb.ReprocessProgressTableName), startEpoch, endEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get reprocess progress")
+	}
+
+	if progress.NextEpoch > firstDayStart && !progress.CompletedAt.Valid {
+		d.log.Infof("resuming reprocessing of epochs %d - %d at epoch %d", startEpoch, endEpoch, progress.NextEpoch)
+	}
+	return &progress, nil
+}
+
+// dayStart incl, epochEnd excl (end of the day or the head), nextEpoch is the first epoch of the next day
+func (d *DashboardDataReprocessor) reprocessDay(startEpoch, endEpoch, dayStart, epochEnd, nextEpoch uint64) error {
+	// Step 1: replace the epochs of the day
+	_, err := db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch >= $1 AND epoch < $2`, edb.EpochWriterTableName), dayStart, epochEnd)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete epochs")
+	}
+
+	epochs := make([]uint64, 0, epochEnd-dayStart)
+	for epoch := dayStart; epoch < epochEnd; epoch++ {
+		epochs = append(epochs, epoch)
+	}
+
+	var nextDataChan chan []DataEpochProcessed = make(chan []DataEpochProcessed, 1)
+	go func() {
+		d.epochDataFetcher(epochs, epochFetchParallelism, nextDataChan)
+	}()
+
+	for {
+		datas := <-nextDataChan
+		d.writeEpochDatas(datas)
+		if containsEpoch(datas, epochs[len(epochs)-1]) {
+			break
+		}
+	}
+
+	// Step 2: aggregate the hours of the day that are still retained
+	latestExportedEpoch, err := edb.GetLatestDashboardEpoch()
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest dashboard epoch")
+	}
+	hourRetentionStart := int64(latestExportedEpoch) - int64(d.epochToHour.getHourRetentionDurationEpochs())
+	for epoch := dayStart; epoch < epochEnd; {
+		hourStart, hourEnd := getHourAggregateBounds(epoch)
+		epoch = hourEnd
+		if int64(hourEnd) <= hourRetentionStart {
+			continue
+		}
+
+		_, err = db.AlloyWriter.Exec(fmt.Sprintf(`DELETE FROM %s WHERE epoch_start >= $1 AND epoch_start < $2`, edb.HourWriterTableName), hourStart, hourEnd)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete hour")
+		}
+
+		err = d.epochToHour.aggregate1hWithBounds(hourStart, min(hourEnd, epochEnd))
+		if err != nil {
+			return errors.Wrap(err, "failed to aggregate hour")
+		}
+	}
+
+	// Step 3: replace the day and apply the difference to the total
+	partitionStartRange, partitionEndRange := d.epochToDay.GetDayPartitionRange(epochEnd - 1)
+	err = d.epochToDay.createDayPartition(partitionStartRange, partitionEndRange)
+	if err != nil {
+		return errors.Wrap(err, "failed to create day partition")
+	}
+
+	tx, err := db.AlloyWriter.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer utils.Rollback(tx)
+
+	day := utils.EpochToTime(dayStart).Format("2006-01-02")
+	_, err = tx.Exec(fmt.Sprintf(`CREATE TEMP TABLE reprocess_old_day ON COMMIT DROP AS SELECT * FROM %s WHERE day = $1`, edb.DayWriterTableName), day)
+	if err != nil {
+		return errors.Wrap(err, "failed to copy old day")
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE day = $1`, edb.DayWriterTableName), day)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete old day")
+	}
+
+	err = d.epochToDay.addUtcDayWithBounds(tx, dayStart, epochEnd)
+	if err != nil {
+		return errors.Wrap(err, "failed to aggregate day")
+	}
+
+	// all validators of the day are already part of the total since the day has been exported before
+	sets := make([]string, 0, len(dashboardDataSumColumns))
+	for _, column := range dashboardDataSumColumns {
+		sets = append(sets, fmt.Sprintf("%[1]s = NULLIF(COALESCE(t.%[1]s, 0) - COALESCE(o.%[1]s, 0) + COALESCE(n.%[1]s, 0), 0)", column))
+	}
+	_, err = tx.Exec(fmt.Sprintf(`
+		UPDATE %[1]s AS t SET
+			%[3]s,
+			balance_end = CASE WHEN n.epoch_end = t.epoch_end THEN COALESCE(n.balance_end, t.balance_end) ELSE t.balance_end END,
+			slashed = COALESCE(t.slashed, false) OR COALESCE(n.slashed, false),
+			slashed_by = COALESCE(t.slashed_by, n.slashed_by),
+			slashed_violation = COALESCE(t.slashed_violation, n.slashed_violation),
+			last_executed_duty_epoch = GREATEST(t.last_executed_duty_epoch, n.last_executed_duty_epoch)
+		FROM reprocess_old_day o
+		FULL OUTER JOIN (SELECT * FROM %[2]s WHERE day = $1) n ON o.validator_index = n.validator_index
+		WHERE t.validator_index = COALESCE(n.validator_index, o.validator_index)
+	`, edb.RollingTotalWriterTableName, edb.DayWriterTableName, strings.Join(sets, ",\n\t\t\t")), day)
+	if err != nil {
+		return errors.Wrap(err, "failed to update total")
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`UPDATE %s SET next_epoch = $3, updated_at = NOW() WHERE start_epoch = $1 AND end_epoch = $2`, edb.ReprocessProgressTableName), startEpoch, endEpoch, nextEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to store reprocess progress")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Step 4: remove epochs that are not needed by the head exporter anymore
+	return d.epochWriter.clearOldEpochs(int64(latestExportedEpoch - d.epochWriter.getRetentionEpochDuration()))
+}
+
+// bootstraps all rolling windows at the current head from the reprocessed hourly and daily tables
+func (d *DashboardDataReprocessor) reprocessRollingWindows(latestExportedEpoch uint64) error {
+	err := d.exportEpochAndTails(latestExportedEpoch, true, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to export rolling tail epochs")
+	}
+
+	return d.aggregatePerEpoch(true, false, true)
+}
@@ -0,0 +1,38 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestGetReprocessDays(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	// mainnet, 225 epochs per day and the first utc day ends at epoch 113
+	utils.Config.Chain.GenesisTimestamp = 1606824023
+	utils.Config.Chain.ClConfig.SecondsPerSlot = 12
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	tests := []struct {
+		name                string
+		nextEpoch           uint64
+		endEpoch            uint64
+		latestExportedEpoch uint64
+		want                []reprocessDayBounds
+	}{
+		{"first run starts at the first day", 113, 400, 1000, []reprocessDayBounds{{113, 338, 338}, {338, 563, 563}}},
+		{"resume skips the completed days", 338, 400, 1000, []reprocessDayBounds{{338, 563, 563}}},
+		{"the last day ends at the head", 338, 400, 450, []reprocessDayBounds{{338, 451, 563}}},
+		{"nothing left after the last checkpoint", 563, 400, 1000, []reprocessDayBounds{}},
+	}
+	for _, tt := range tests {
+		got := getReprocessDays(tt.nextEpoch, tt.endEpoch, tt.latestExportedEpoch)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package modules
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+/**
+Epoch data snapshots store everything ProcessEpochData needs for a single epoch as gzip compressed json so an epoch can be
+processed again without a node or a database. This allows deterministic tests of the processing against recorded epochs.
+
+Besides the raw epoch data a snapshot contains the sync committee of the epoch's sync period, which process() takes from
+the response cache, and the consensus layer chain config the epoch was recorded with.
+Snapshots are recorded with the dashboard-record-epoch misc command.
+*/
+
+const epochDataSnapshotVersion = 1
+
+type epochDataSnapshot struct {
+	Version       int                                       `json:"version"`
+	ChainConfig   types.ClChainConfig                       `json:"chain_config"`
+	SyncCommittee *constypes.StandardSyncCommitteesResponse `json:"sync_committee,omitempty"`
+
+	LastEpochStateEnd         *constypes.StandardValidatorsResponse                      `json:"last_epoch_state_end"`
+	CurrentEpochStateEnd      *constypes.StandardValidatorsResponse                      `json:"current_epoch_state_end"`
+	ProposerAssignments       *constypes.StandardProposerAssignmentsResponse             `json:"proposer_assignments"`
+	AttestationRewards        []constypes.AttestationReward                              `json:"attestation_rewards"`
+	IdealAttestationRewards   map[int64]constypes.AttestationIdealReward                 `json:"ideal_attestation_rewards"`
+	BeaconBlockData           map[uint64]*constypes.StandardBeaconSlotResponse           `json:"beacon_block_data"`
+	BeaconBlockRewardData     map[uint64]*constypes.StandardBlockRewardsResponse         `json:"beacon_block_reward_data"`
+	ExecutionRewards          map[string]int64                                           `json:"execution_rewards"`
+	SyncCommitteeRewardData   map[uint64]*constypes.StandardSyncCommitteeRewardsResponse `json:"sync_committee_reward_data"`
+	AttestationAssignments    map[uint64]uint32                                          `json:"attestation_assignments"`
+	Missedslots               map[uint64]bool                                            `json:"missedslots"`
+	Genesis                   bool                                                       `json:"genesis"`
+	Epoch                     uint64                                                     `json:"epoch"`
+	SyncCommitteeElectedState *constypes.StandardValidatorsResponse                      `json:"sync_committee_elected_state,omitempty"`
+	PendingDepositsStart      *constypes.StandardPendingDepositsResponse                 `json:"pending_deposits_start,omitempty"`
+	PendingDepositsEnd        *constypes.StandardPendingDepositsResponse                 `json:"pending_deposits_end,omitempty"`
+	ExportedValidators        []uint64                                                   `json:"exported_validators,omitempty"`
+}
+
+func newEpochDataSnapshot(data *Data, syncCommittee *constypes.StandardSyncCommitteesResponse) *epochDataSnapshot {
+	return &epochDataSnapshot{
+		Version:                   epochDataSnapshotVersion,
+		ChainConfig:               utils.Config.Chain.ClConfig,
+		SyncCommittee:             syncCommittee,
+		LastEpochStateEnd:         data.lastEpochStateEnd,
+		CurrentEpochStateEnd:      data.currentEpochStateEnd,
+		ProposerAssignments:       data.proposerAssignments,
+		AttestationRewards:        data.attestationRewards,
+		IdealAttestationRewards:   data.idealAttestationRewards,
+		BeaconBlockData:           data.beaconBlockData,
+		BeaconBlockRewardData:     data.beaconBlockRewardData,
+		ExecutionRewards:          data.executionRewards,
+		SyncCommitteeRewardData:   data.syncCommitteeRewardData,
+		AttestationAssignments:    data.attestationAssignments,
+		Missedslots:               data.missedslots,
+		Genesis:                   data.genesis,
+		Epoch:                     data.epoch,
+		SyncCommitteeElectedState: data.syncCommitteeElectedState,
+		PendingDepositsStart:      data.pendingDepositsStart,
+		PendingDepositsEnd:        data.pendingDepositsEnd,
+		ExportedValidators:        data.exportedValidators,
+	}
+}
+
+func (s *epochDataSnapshot) data() *Data {
+	return &Data{
+		lastEpochStateEnd:         s.LastEpochStateEnd,
+		currentEpochStateEnd:      s.CurrentEpochStateEnd,
+		proposerAssignments:       s.ProposerAssignments,
+		attestationRewards:        s.AttestationRewards,
+		idealAttestationRewards:   s.IdealAttestationRewards,
+		beaconBlockData:           s.BeaconBlockData,
+		beaconBlockRewardData:     s.BeaconBlockRewardData,
+		executionRewards:          s.ExecutionRewards,
+		syncCommitteeRewardData:   s.SyncCommitteeRewardData,
+		attestationAssignments:    s.AttestationAssignments,
+		missedslots:               s.Missedslots,
+		genesis:                   s.Genesis,
+		epoch:                     s.Epoch,
+		syncCommitteeElectedState: s.SyncCommitteeElectedState,
+		pendingDepositsStart:      s.PendingDepositsStart,
+		pendingDepositsEnd:        s.PendingDepositsEnd,
+		exportedValidators:        s.ExportedValidators,
+	}
+}
+
+func writeEpochDataSnapshot(w io.Writer, snapshot *epochDataSnapshot) error {
+	gz := gzip.NewWriter(w)
+	err := json.NewEncoder(gz).Encode(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode epoch data snapshot")
+	}
+	return gz.Close()
+}
+
+func readEpochDataSnapshot(r io.Reader) (*epochDataSnapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open epoch data snapshot")
+	}
+	defer gz.Close()
+
+	snapshot := &epochDataSnapshot{}
+	err = json.NewDecoder(gz).Decode(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode epoch data snapshot")
+	}
+	if snapshot.Version != epochDataSnapshotVersion {
+		return nil, fmt.Errorf("unsupported epoch data snapshot version %d, expected %d", snapshot.Version, epochDataSnapshotVersion)
+	}
+	if snapshot.CurrentEpochStateEnd == nil || snapshot.LastEpochStateEnd == nil || snapshot.ProposerAssignments == nil {
+		return nil, errors.New("epoch data snapshot is incomplete")
+	}
+	return snapshot, nil
+}
+
+func loadEpochDataSnapshot(path string) (*epochDataSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	return readEpochDataSnapshot(f)
+}
+
+// Processes the snapshot with the given module, the chain config of the snapshot must already be set in utils.Config
+func (s *epochDataSnapshot) replay(d *dashboardData) ([]*validatorDashboardDataRow, error) {
+	if s.SyncCommittee != nil {
+		d.responseCache.SetSyncCommittee(utils.SyncPeriodOfEpoch(s.Epoch), s.SyncCommittee)
+	}
+	return d.ProcessEpochData(s.data())
+}
+
+func epochDataSnapshotFileName(epoch uint64) string {
+	return fmt.Sprintf("epoch_%d.json.gz", epoch)
+}
+
+type DashboardDataRecorder struct {
+	*dashboardData
+}
+
+func NewDashboardDataRecorder(moduleContext ModuleContext) *DashboardDataRecorder {
+	return &DashboardDataRecorder{
+		dashboardData: NewDashboardDataModule(moduleContext).(*dashboardData),
+	}
+}
+
+// Fetches the data of the given epoch from the node and stores it as snapshot in dir, returns the path of the snapshot
+func (r *DashboardDataRecorder) Record(epoch uint64, dir string) (string, error) {
+	syncPeriod := utils.SyncPeriodOfEpoch(epoch)
+	if epoch >= utils.Config.Chain.ClConfig.AltairForkEpoch {
+		errGroup := &errgroup.Group{}
+		r.getSyncCommitteesData(errGroup, map[uint64]bool{syncPeriod: true})
+		if err := errGroup.Wait(); err != nil {
+			return "", errors.Wrap(err, "failed to get sync committee")
+		}
+	}
+
+	data, err := r.GetEpochDataRaw(epoch, false)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get data of epoch %d", epoch)
+	}
+
+	// make sure the recorded data can actually be processed before storing it
+	_, err = r.ProcessEpochData(data)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to process data of epoch %d", epoch)
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create %s", dir)
+	}
+
+	path := filepath.Join(dir, epochDataSnapshotFileName(epoch))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create %s", path)
+	}
+	defer f.Close()
+
+	err = writeEpochDataSnapshot(f, newEpochDataSnapshot(data, r.responseCache.GetSyncCommittee(syncPeriod)))
+	if err != nil {
+		return "", err
+	}
+	return path, f.Close()
+}
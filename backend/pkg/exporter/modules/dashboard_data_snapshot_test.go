@@ -0,0 +1,133 @@
+package modules
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// run with -update to write the golden files after an intended change of ProcessEpochData
+var updateGolden = flag.Bool("update", false, "update the golden files of the epoch data snapshots")
+
+// The snapshots in testdata/epoch_snapshots are recorded with the dashboard-record-epoch misc command,
+// synthetic_epoch_10 is a hand written epoch of a 4 validator network covering blocks, a missed slot, attestations,
+// withdrawals, sync committee and execution rewards. The processed rows of each snapshot are compared to its golden file.
+func TestEpochDataSnapshots(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "epoch_snapshots", "*.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no epoch data snapshots found")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			snapshot, err := loadEpochDataSnapshot(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := replaySnapshot(t, snapshot)
+
+			got, err := json.MarshalIndent(rows, "", "\t")
+			if err != nil {
+				t.Fatal(err)
+			}
+			goldenPath := strings.TrimSuffix(path, ".json.gz") + ".golden.json"
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, append(got, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("%v, run the test with -update to create it", err)
+			}
+			if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+				t.Errorf("processed rows of %s differ from %s, run the test with -update if the change is intended", path, goldenPath)
+			}
+		})
+	}
+}
+
+// A snapshot written from processed data must produce the same rows when it is replayed
+func TestEpochDataSnapshotRoundTrip(t *testing.T) {
+	snapshot, err := loadEpochDataSnapshot(filepath.Join("testdata", "epoch_snapshots", "synthetic_epoch_10.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := replaySnapshot(t, snapshot)
+
+	var buf bytes.Buffer
+	if err := writeEpochDataSnapshot(&buf, newEpochDataSnapshot(snapshot.data(), snapshot.SyncCommittee)); err != nil {
+		t.Fatal(err)
+	}
+	roundTripped, err := readEpochDataSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roundTripped.ChainConfig, snapshot.ChainConfig) {
+		t.Error("chain config differs after round trip")
+	}
+
+	got := replaySnapshot(t, roundTripped)
+	if !reflect.DeepEqual(got, want) {
+		t.Error("processed rows differ after round trip")
+	}
+}
+
+func TestEpochDataSnapshotProcessing(t *testing.T) {
+	snapshot, err := loadEpochDataSnapshot(filepath.Join("testdata", "epoch_snapshots", "synthetic_epoch_10.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := replaySnapshot(t, snapshot)
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	if rows[0].BlocksProposed.Int16 != 1 || rows[2].BlocksProposed.Int16 != 1 || rows[1].BlocksProposed.Valid {
+		t.Error("unexpected proposed blocks")
+	}
+	if rows[0].BlocksElReward.Int64 != 42000000 || rows[2].BlocksElReward.Valid {
+		t.Error("execution reward must only be credited for the known block")
+	}
+	if rows[1].WithdrawalsAmount.Int64 != 11000 || rows[1].WithdrawalsCount.Int16 != 1 {
+		t.Error("unexpected withdrawal")
+	}
+	// attestation of the first slot is included two slots later with a missed slot in between
+	if !rows[0].InclusionDelaySum.Valid || rows[0].InclusionDelaySum.Int16 != 1 || rows[0].OptimalInclusionDelay.Int16 != 1 {
+		t.Errorf("unexpected inclusion delay %v / optimal %v", rows[0].InclusionDelaySum, rows[0].OptimalInclusionDelay)
+	}
+	if rows[3].SyncScheduled.Int16 != 2 || rows[3].SyncExecuted.Int16 != 1 || rows[3].SyncReward.Int64 != 0 {
+		t.Error("unexpected sync committee data")
+	}
+	if rows[3].AttestationsExecuted.Valid || rows[3].AttestationReward.Int64 != -35 {
+		t.Error("unexpected attestation data of the offline validator")
+	}
+}
+
+func replaySnapshot(t *testing.T, snapshot *epochDataSnapshot) []*validatorDashboardDataRow {
+	t.Helper()
+
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig = snapshot.ChainConfig
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	rows, err := snapshot.replay(NewDashboardDataModule(ModuleContext{}).(*dashboardData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
@@ -0,0 +1,73 @@
+package modules
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/pkg/errors"
+)
+
+/**
+In tracked validators mode (trackedValidators config) the dashboard data exporter only exports a subset of the network so
+compute and storage scale with the tracked validators instead of the whole network. Exported are
+	1. the configured validator indices and, with includeDashboards, every validator that is part of a validator dashboard
+	2. every networkSampleInterval-th validator index as network sample. The api calculates the network wide averages
+	   (e.g. the average network efficiency) from the sample only, so they are not skewed by the tracked validators
+
+Attestation rewards are only requested for the exported validators and only their rows are written to the epoch table,
+which all aggregates are built from. The validator states, duties and blocks of an epoch are still fetched in full as the
+expected proposals and sync committees depend on the total active balance and attestations are resolved via the committees.
+Validators that are added to a dashboard are exported from the next refresh on, their history is not backfilled.
+*/
+
+// How often the dashboard validators are read from the db again
+const trackedValidatorsRefreshInterval = time.Minute
+
+type trackedValidators struct {
+	mutex     sync.Mutex
+	indices   map[uint64]bool
+	updatedAt time.Time
+}
+
+// Returns the configured and (if enabled) the dashboard validators
+func (t *trackedValidators) get() (map[uint64]bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.indices != nil && time.Since(t.updatedAt) < trackedValidatorsRefreshInterval {
+		return t.indices, nil
+	}
+
+	indices := make(map[uint64]bool, len(utils.Config.TrackedValidators.Indices))
+	for _, index := range utils.Config.TrackedValidators.Indices {
+		indices[index] = true
+	}
+
+	if utils.Config.TrackedValidators.IncludeDashboards {
+		var dashboardValidators []uint64
+		err := db.AlloyReader.Select(&dashboardValidators, `SELECT DISTINCT validator_index FROM users_val_dashboards_validators`)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get dashboard validators")
+		}
+		for _, index := range dashboardValidators {
+			indices[index] = true
+		}
+	}
+
+	t.indices = indices
+	t.updatedAt = time.Now()
+	return indices, nil
+}
+
+// Returns the sorted indices of the tracked validators and the network sample out of validatorCount validators
+func getExportedValidators(tracked map[uint64]bool, validatorCount uint64) []uint64 {
+	exported := make([]uint64, 0, len(tracked)+int(validatorCount/utils.Config.TrackedValidators.NetworkSampleInterval)+1)
+	for i := uint64(0); i < validatorCount; i++ {
+		if tracked[i] || utils.IsNetworkSampleValidator(i) {
+			exported = append(exported, i)
+		}
+	}
+	return exported
+}
@@ -0,0 +1,48 @@
+package modules
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestGetExportedValidators(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.TrackedValidators.Enabled = true
+	utils.Config.TrackedValidators.NetworkSampleInterval = 4
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	got := getExportedValidators(map[uint64]bool{3: true, 4: true, 9: true, 100: true}, 10)
+	want := []uint64{0, 3, 4, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// Only the rows of the exported validators must be kept, their values must not change
+func TestProcessExportedValidators(t *testing.T) {
+	snapshot, err := loadEpochDataSnapshot(filepath.Join("testdata", "epoch_snapshots", "synthetic_epoch_10.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := replaySnapshot(t, snapshot)
+
+	snapshot.ExportedValidators = []uint64{0, 3}
+	rows := replaySnapshot(t, snapshot)
+	if len(rows) != len(all) {
+		t.Fatalf("expected %d rows, got %d", len(all), len(rows))
+	}
+	for i, row := range rows {
+		exported := i == 0 || i == 3
+		if exported && !reflect.DeepEqual(row, all[i]) {
+			t.Errorf("row of exported validator %d differs", i)
+		}
+		if !exported && row != nil {
+			t.Errorf("row of validator %d must not be exported", i)
+		}
+	}
+}
@@ -14,6 +14,14 @@ import (
 	"github.com/pkg/errors"
 )
 
+// epochDataWriter stores the processed rows of an epoch for the aggregators and removes the epochs that are no longer needed
+type epochDataWriter interface {
+	WriteEpochData(epoch uint64, data []*validatorDashboardDataRow) error
+	getRetentionEpochDuration() uint64
+	clearOldEpochs(removeBelowEpoch int64) error
+}
+
+// epochWriter copies the rows into the partitioned epoch table of postgres
 type epochWriter struct {
 	*dashboardData
 	mutex *sync.Mutex
@@ -92,6 +100,14 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 		return errors.Wrap(err, "failed to create epoch partition")
 	}
 
+	// rows of validators that are not exported in tracked validators mode are nil
+	indices := make([]int, 0, len(data))
+	for i, row := range data {
+		if row != nil {
+			indices = append(indices, i)
+		}
+	}
+
 	conn, err := db.AlloyWriter.Conn(context.Background())
 	if err != nil {
 		return fmt.Errorf("error retrieving raw sql connection: %w", err)
@@ -157,8 +173,10 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 			"last_executed_duty_epoch",
 			"blocks_cl_attestations_reward",
 			"blocks_cl_sync_aggregate_reward",
+			"blocks_el_reward",
 			"sync_committees_expected",
-		}, pgx.CopyFromSlice(len(data), func(i int) ([]interface{}, error) {
+		}, pgx.CopyFromSlice(len(indices), func(j int) ([]interface{}, error) {
+			i := indices[j]
 			return []interface{}{
 				i,
 				epoch,
@@ -201,6 +219,7 @@ func (d *epochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDat
 				data[i].LastSubmittedDutyEpoch,
 				data[i].BlocksClAttestestationsReward,
 				data[i].BlocksClSyncAggregateReward,
+				data[i].BlocksElReward,
 				data[i].SyncCommitteesExpectedThisPeriod,
 			}, nil
 		}))
@@ -0,0 +1,49 @@
+package modules
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+type recordingEpochWriter struct {
+	mutex  sync.Mutex
+	epochs []uint64
+}
+
+func (w *recordingEpochWriter) WriteEpochData(epoch uint64, data []*validatorDashboardDataRow) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.epochs = append(w.epochs, epoch)
+	return nil
+}
+
+func (w *recordingEpochWriter) getRetentionEpochDuration() uint64 {
+	return 10
+}
+
+func (w *recordingEpochWriter) clearOldEpochs(removeBelowEpoch int64) error {
+	return nil
+}
+
+// The epoch rows are written through the epoch writer of the module
+func TestWriteEpochDatas(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	d := NewDashboardDataModule(ModuleContext{}).(*dashboardData)
+	writer := &recordingEpochWriter{}
+	d.epochWriter = writer
+
+	d.writeEpochDatas([]DataEpochProcessed{{Epoch: 12}, {Epoch: 10}, {Epoch: 11}})
+
+	sort.Slice(writer.epochs, func(i, j int) bool { return writer.epochs[i] < writer.epochs[j] })
+	if want := []uint64{10, 11, 12}; !reflect.DeepEqual(writer.epochs, want) {
+		t.Errorf("expected epochs %v to be written, got %v", want, writer.epochs)
+	}
+}
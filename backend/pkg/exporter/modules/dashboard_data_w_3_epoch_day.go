@@ -59,12 +59,12 @@ func (d *epochToDayAggregator) dayAggregate(currentExportedEpoch uint64) error {
 
 // used to retrieve missing historic epochs in database for rolling 24h aggregation
 // intentedHeadEpoch is the head you currently want to export
-func (d *epochToDayAggregator) getMissingRolling24TailEpochs(intendedHeadEpoch uint64) ([]uint64, error) {
-	return d.rollingAggregator.getMissingRollingTailEpochs(1, intendedHeadEpoch, edb.RollingDailyWriterTable)
+func (d *epochToDayAggregator) getMissingRolling24TailEpochs(intendedHeadEpoch uint64, forceBootstrap bool) ([]uint64, error) {
+	return d.rollingAggregator.getMissingRollingTailEpochs(1, intendedHeadEpoch, edb.RollingDailyWriterTable, forceBootstrap)
 }
 
-func (d *epochToDayAggregator) rolling24hAggregate(currentEpochHead uint64) error {
-	return d.rollingAggregator.Aggregate(1, edb.RollingDailyWriterTable, currentEpochHead)
+func (d *epochToDayAggregator) rolling24hAggregate(currentEpochHead uint64, forceBootstrap bool) error {
+	return d.rollingAggregator.Aggregate(1, edb.RollingDailyWriterTable, currentEpochHead, forceBootstrap)
 }
 
 // Returns the epoch_start and epoch_end (the epoch bounds of a UTC day) for a given epoch.
@@ -145,15 +145,25 @@ func (d *epochToDayAggregator) aggregateUtcDayWithBounds(firstEpochOfDay, lastEp
 		return errors.Wrap(err, "failed to create day partition")
 	}
 
-	boundsStart, _ := getDayAggregateBounds(firstEpochOfDay)
-
 	tx, err := db.AlloyWriter.Beginx()
 	if err != nil {
 		return errors.Wrap(err, "failed to start transaction")
 	}
 	defer utils.Rollback(tx)
 
-	err = AddToRollingCustom(tx, CustomRolling{
+	err = d.addUtcDayWithBounds(tx, firstEpochOfDay, lastEpochOfDay)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// adds the epochs firstEpochOfDay (incl) to lastEpochOfDay (excl) to their utc day, the day partition must exist
+func (d *epochToDayAggregator) addUtcDayWithBounds(tx *sqlx.Tx, firstEpochOfDay, lastEpochOfDay uint64) error {
+	boundsStart, _ := getDayAggregateBounds(firstEpochOfDay)
+
+	err := AddToRollingCustom(tx, CustomRolling{
 		StartEpoch:           firstEpochOfDay,
 		EndEpoch:             lastEpochOfDay,
 		StartBoundEpoch:      int64(boundsStart),
@@ -175,7 +185,7 @@ func (d *epochToDayAggregator) aggregateUtcDayWithBounds(firstEpochOfDay, lastEp
 		return errors.Wrap(err, "failed to insert daily aggregate")
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (d *epochToDayAggregator) GetDayPartitionRange(epoch uint64) (time.Time, time.Time) {
@@ -310,6 +320,7 @@ func (d *DayRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tableName st
 					SUM(blocks_cl_reward) as blocks_cl_reward,
 					SUM(blocks_cl_attestations_reward) as blocks_cl_attestations_reward,
 					SUM(blocks_cl_sync_aggregate_reward) as blocks_cl_sync_aggregate_reward,
+					SUM(blocks_el_reward) as blocks_el_reward,
 					SUM(sync_scheduled) as sync_scheduled,
 					SUM(sync_executed) as sync_executed,
 					SUM(sync_rewards) as sync_rewards,
@@ -356,6 +367,7 @@ func (d *DayRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tableName st
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -401,6 +413,7 @@ func (d *DayRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tableName st
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
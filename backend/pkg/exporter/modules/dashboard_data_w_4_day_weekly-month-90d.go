@@ -33,29 +33,29 @@ func newDayUpAggregator(d *dashboardData) *dayUpAggregator {
 	}
 }
 
-func (d *dayUpAggregator) rolling7dAggregate(currentEpochHead uint64) error {
-	return d.aggregateRollingXDays(7, edb.RollingWeeklyWriterTable, currentEpochHead)
+func (d *dayUpAggregator) rolling7dAggregate(currentEpochHead uint64, forceBootstrap bool) error {
+	return d.aggregateRollingXDays(7, edb.RollingWeeklyWriterTable, currentEpochHead, forceBootstrap)
 }
 
-func (d *dayUpAggregator) rolling30dAggregate(currentEpochHead uint64) error {
-	return d.aggregateRollingXDays(30, edb.RollingMonthlyWriterTable, currentEpochHead)
+func (d *dayUpAggregator) rolling30dAggregate(currentEpochHead uint64, forceBootstrap bool) error {
+	return d.aggregateRollingXDays(30, edb.RollingMonthlyWriterTable, currentEpochHead, forceBootstrap)
 }
 
-func (d *dayUpAggregator) rolling90dAggregate(currentEpochHead uint64) error {
-	return d.aggregateRollingXDays(90, edb.RollingNinetyDaysWriterTable, currentEpochHead)
+func (d *dayUpAggregator) rolling90dAggregate(currentEpochHead uint64, forceBootstrap bool) error {
+	return d.aggregateRollingXDays(90, edb.RollingNinetyDaysWriterTable, currentEpochHead, forceBootstrap)
 }
 
 // for a given epoch intendedHeadEpoch, what epochs are needed for removal from the rolling tables
-func (d *dayUpAggregator) getMissingRollingDayTailEpochs(intendedHeadEpoch uint64) ([]uint64, error) {
-	week, err := d.getMissingRollingXDaysTailEpochs(7, intendedHeadEpoch, edb.RollingWeeklyWriterTable)
+func (d *dayUpAggregator) getMissingRollingDayTailEpochs(intendedHeadEpoch uint64, forceBootstrap bool) ([]uint64, error) {
+	week, err := d.getMissingRollingXDaysTailEpochs(7, intendedHeadEpoch, edb.RollingWeeklyWriterTable, forceBootstrap)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get missing 7d tail epochs")
 	}
-	month, err := d.getMissingRollingXDaysTailEpochs(30, intendedHeadEpoch, edb.RollingMonthlyWriterTable)
+	month, err := d.getMissingRollingXDaysTailEpochs(30, intendedHeadEpoch, edb.RollingMonthlyWriterTable, forceBootstrap)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get missing 30d tail epochs")
 	}
-	ninety, err := d.getMissingRollingXDaysTailEpochs(90, intendedHeadEpoch, edb.RollingNinetyDaysWriterTable)
+	ninety, err := d.getMissingRollingXDaysTailEpochs(90, intendedHeadEpoch, edb.RollingNinetyDaysWriterTable, forceBootstrap)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get missing 90d tail epochs")
 	}
@@ -88,8 +88,8 @@ func (d *dayUpAggregator) getMissingRollingDayHeadEpochs(intendedHeadEpoch uint6
 	return heads, nil
 }
 
-func (d *dayUpAggregator) getMissingRollingXDaysTailEpochs(days int, intendedHeadEpoch uint64, tableName string) ([]uint64, error) {
-	return d.rollingAggregator.getMissingRollingTailEpochs(days, intendedHeadEpoch, tableName)
+func (d *dayUpAggregator) getMissingRollingXDaysTailEpochs(days int, intendedHeadEpoch uint64, tableName string, forceBootstrap bool) ([]uint64, error) {
+	return d.rollingAggregator.getMissingRollingTailEpochs(days, intendedHeadEpoch, tableName, forceBootstrap)
 }
 
 func (d *dayUpAggregator) getMissingRollingXDaysHeadEpochs(days int, intendedHeadEpoch uint64, tableName string) ([]uint64, error) {
@@ -112,7 +112,7 @@ func (d *dayUpAggregator) getMissingRollingXDaysHeadEpochs(days int, intendedHea
 	return edb.GetMissingEpochsBetween(int64(bounds.EpochEnd), int64(intendedHeadEpoch)+1)
 }
 
-func (d *dayUpAggregator) aggregateRollingXDays(days int, tableName string, currentEpochHead uint64) error {
+func (d *dayUpAggregator) aggregateRollingXDays(days int, tableName string, currentEpochHead uint64, forceBootstrap bool) error {
 	d.setupMutex.Lock()
 	if _, ok := d.mutexes[tableName]; !ok {
 		d.mutexes[tableName] = &sync.Mutex{}
@@ -122,7 +122,7 @@ func (d *dayUpAggregator) aggregateRollingXDays(days int, tableName string, curr
 	d.mutexes[tableName].Lock()
 	defer d.mutexes[tableName].Unlock()
 
-	return d.rollingAggregator.Aggregate(days, tableName, currentEpochHead)
+	return d.rollingAggregator.Aggregate(days, tableName, currentEpochHead, forceBootstrap)
 }
 
 // -- rolling aggregate --
@@ -206,6 +206,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 					SUM(blocks_cl_reward) as blocks_cl_reward,
 					SUM(blocks_cl_attestations_reward) as blocks_cl_attestations_reward,
 					SUM(blocks_cl_sync_aggregate_reward) as blocks_cl_sync_aggregate_reward,
+					SUM(blocks_el_reward) as blocks_el_reward,
 					SUM(sync_scheduled) as sync_scheduled,
 					SUM(sync_executed) as sync_executed,
 					SUM(sync_rewards) as sync_rewards,
@@ -252,6 +253,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -297,6 +299,7 @@ func (d *MultipleDaysRollingAggregatorImpl) bootstrap(tx *sqlx.Tx, days int, tab
 				blocks_cl_reward,
 				blocks_cl_attestations_reward,
 				blocks_cl_sync_aggregate_reward,
+				blocks_el_reward,
 				sync_scheduled,
 				sync_executed,
 				sync_rewards,
@@ -0,0 +1,234 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/lib/pq"
+)
+
+// QueueItem is an unsent entry of the notification queue
+type QueueItem struct {
+	Id      uint64                    `db:"id"`
+	Created time.Time                 `db:"created"`
+	Channel types.NotificationChannel `db:"channel"`
+	Content []byte                    `db:"content"`
+}
+
+// Message is a queue item rendered by a channel
+type Message struct {
+	QueueId uint64
+	UserId  types.UserId
+	// Size is the number of notifications contained in the message, used for metrics
+	Size int
+	// Payload is the channel specific content of the message
+	Payload interface{}
+
+	// set by the dispatcher if the channel is rate limited
+	SentToday int64
+	RateLimit int64
+}
+
+type SendStatus int
+
+const (
+	// SendStatusSent marks the message as delivered
+	SendStatusSent SendStatus = iota
+	// SendStatusFailed marks the message as failed, it will not be retried
+	SendStatusFailed
+	// SendStatusRateLimited marks the message as not sent because the user exceeded the rate limit of the channel
+	SendStatusRateLimited
+	// SendStatusRetry leaves the message in the queue, it will be retried by the next dispatch run until the queue is garbage collected
+	SendStatusRetry
+)
+
+type SendResult struct {
+	Status SendStatus
+	// Label is used as status label of the notifications_sent metric, no metric is recorded if empty
+	Label string
+	Err   error
+	// Details contains channel specific data for acknowledging the message
+	Details interface{}
+}
+
+// Channel delivers the entries of the notification queue of a specific channel.
+// The dispatcher takes care of selecting the queue entries, rate limit counting, metrics and updating the queue.
+type Channel interface {
+	// Name returns the channel of the queue entries handled by the channel
+	Name() types.NotificationChannel
+	// Render decodes a queue entry into a message, entries that can not be rendered are removed from the queue
+	Render(item QueueItem) (*Message, error)
+	// RateLimit returns the rate limit bucket and the maximum number of messages per day of a user.
+	// Messages are not counted if the bucket is empty and not limited if the limit is 0.
+	RateLimit(ctx context.Context, userId types.UserId) (bucket string, limit int64)
+	// Send delivers the messages and returns one result per message
+	Send(ctx context.Context, messages []*Message) []SendResult
+	// Ack is called after the queue has been updated with the results of the messages
+	Ack(ctx context.Context, messages []*Message, results []SendResult) error
+}
+
+var channelRegistry = struct {
+	sync.RWMutex
+	channels []Channel
+}{}
+
+// RegisterChannel adds a channel to the dispatcher, channels are dispatched in the order of registration
+func RegisterChannel(channel Channel) error {
+	channelRegistry.Lock()
+	defer channelRegistry.Unlock()
+	for _, c := range channelRegistry.channels {
+		if c.Name() == channel.Name() {
+			return fmt.Errorf("notification channel %v is already registered", channel.Name())
+		}
+	}
+	channelRegistry.channels = append(channelRegistry.channels, channel)
+	return nil
+}
+
+// GetChannel returns the registered channel with the given name
+func GetChannel(name types.NotificationChannel) (Channel, bool) {
+	channelRegistry.RLock()
+	defer channelRegistry.RUnlock()
+	for _, c := range channelRegistry.channels {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func registeredChannels() []Channel {
+	channelRegistry.RLock()
+	defer channelRegistry.RUnlock()
+	return append([]Channel{}, channelRegistry.channels...)
+}
+
+func dispatchNotifications() error {
+	ctx := context.Background()
+	for _, channel := range registeredChannels() {
+		err := dispatchChannel(ctx, channel)
+		if err != nil {
+			return fmt.Errorf("error sending %v notifications, err: %w", channel.Name(), err)
+		}
+	}
+	return nil
+}
+
+func dispatchChannel(ctx context.Context, channel Channel) error {
+	var items []QueueItem
+	err := db.WriterDb.SelectContext(ctx, &items, `SELECT
+		id,
+		created,
+		channel,
+		content
+	FROM notification_queue WHERE sent IS null AND channel = $1 ORDER BY created ASC`, channel.Name())
+	if err != nil {
+		return fmt.Errorf("error querying notification queue, err: %w", err)
+	}
+
+	log.Infof("processing %v %v notifications", len(items), channel.Name())
+
+	d := deliver(ctx, channel, items, db.CountSentMessage)
+
+	if len(d.Dropped) > 0 {
+		_, err = db.WriterDb.ExecContext(ctx, `DELETE FROM notification_queue WHERE id = ANY($1)`, pq.Array(d.Dropped))
+		if err != nil {
+			return fmt.Errorf("error deleting from notification queue: %w", err)
+		}
+	}
+	if len(d.Done) > 0 {
+		_, err = db.WriterDb.ExecContext(ctx, `UPDATE notification_queue SET sent = now() WHERE id = ANY($1)`, pq.Array(d.Done))
+		if err != nil {
+			return fmt.Errorf("error updating sent status for %v notifications: %w", channel.Name(), err)
+		}
+	}
+
+	err = channel.Ack(ctx, d.Messages, d.Results)
+	if err != nil {
+		log.Error(err, "error acknowledging notifications", 0, log.Fields{"channel": channel.Name()})
+	}
+	return nil
+}
+
+// delivery is the outcome of sending queue items through a channel
+type delivery struct {
+	Messages []*Message
+	Results  []SendResult
+	// queue ids which are done and can be marked as sent
+	Done []uint64
+	// queue ids which can not be sent and should be removed from the queue
+	Dropped []uint64
+}
+
+// deliver renders, rate limits and sends the queue items through the channel
+func deliver(ctx context.Context, channel Channel, items []QueueItem, countSentMessage func(bucket string, userId types.UserId) (int64, error)) delivery {
+	var d delivery
+	name := string(channel.Name())
+
+	toSend := make([]*Message, 0, len(items))
+	for _, item := range items {
+		m, err := channel.Render(item)
+		if err != nil {
+			log.Warnf("dropping %v notification %v: %v", name, item.Id, err)
+			d.Dropped = append(d.Dropped, item.Id)
+			continue
+		}
+		m.QueueId = item.Id
+
+		bucket, limit := channel.RateLimit(ctx, m.UserId)
+		if bucket != "" {
+			count, err := countSentMessage(bucket, m.UserId)
+			if err != nil {
+				// keep the item queued, sending it without a count could exceed the rate limit
+				log.Error(err, "error counting sent notification", 0, log.Fields{"channel": name, "queue_id": item.Id})
+				continue
+			}
+			m.SentToday, m.RateLimit = count, limit
+			if limit > 0 && count > limit {
+				d.Messages = append(d.Messages, m)
+				d.Results = append(d.Results, SendResult{Status: SendStatusRateLimited, Label: "429"})
+				continue
+			}
+		}
+		toSend = append(toSend, m)
+	}
+
+	if len(toSend) > 0 {
+		results := channel.Send(ctx, toSend)
+		if len(results) != len(toSend) {
+			// should never happen, treat all messages as failed to not send them twice
+			log.Error(fmt.Errorf("channel returned %v results for %v messages", len(results), len(toSend)), "error sending notifications", 0, log.Fields{"channel": name})
+			results = make([]SendResult, len(toSend))
+			for i := range results {
+				results[i] = SendResult{Status: SendStatusFailed}
+			}
+		}
+		d.Messages = append(d.Messages, toSend...)
+		d.Results = append(d.Results, results...)
+	}
+
+	for i, m := range d.Messages {
+		r := d.Results[i]
+		if r.Err != nil {
+			metrics.Errors.WithLabelValues(fmt.Sprintf("notifications_send_%s", name)).Inc()
+			log.Error(r.Err, "error sending notification", 0, log.Fields{"channel": name, "queue_id": m.QueueId})
+		}
+		if r.Label != "" {
+			size := m.Size
+			if size < 1 || r.Status != SendStatusSent {
+				size = 1
+			}
+			metrics.NotificationsSent.WithLabelValues(name, r.Label).Add(float64(size))
+		}
+		if r.Status != SendStatusRetry {
+			d.Done = append(d.Done, m.QueueId)
+		}
+	}
+	return d
+}
@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliver(t *testing.T) {
+	channel := &FakeChannel{
+		ChannelName:     "fake",
+		RateLimitBucket: "n_fake",
+		RateLimitPerDay: 2,
+		Results: []SendResult{
+			{Status: SendStatusSent, Label: "200"},
+			{Status: SendStatusRetry, Label: "error"},
+		},
+	}
+	items := []QueueItem{
+		{Id: 1, Channel: "fake", Content: []byte(`{"userId": 1}`)},
+		{Id: 2, Channel: "fake", Content: []byte(`not json`)},
+		{Id: 3, Channel: "fake", Content: []byte(`{"userId": 2}`)},
+		{Id: 4, Channel: "fake", Content: []byte(`{"userId": 3}`)},
+	}
+	// user 3 exceeded the rate limit
+	sentToday := map[types.UserId]int64{1: 1, 2: 2, 3: 3}
+	countSentMessage := func(bucket string, userId types.UserId) (int64, error) {
+		assert.Equal(t, "n_fake", bucket)
+		return sentToday[userId], nil
+	}
+
+	d := deliver(context.Background(), channel, items, countSentMessage)
+
+	sent := channel.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, uint64(1), sent[0].QueueId)
+	assert.Equal(t, uint64(3), sent[1].QueueId)
+	assert.Equal(t, int64(2), sent[1].SentToday)
+	assert.Equal(t, int64(2), sent[1].RateLimit)
+
+	assert.Equal(t, []uint64{2}, d.Dropped)
+	// the retried message stays in the queue
+	assert.ElementsMatch(t, []uint64{1, 4}, d.Done)
+
+	require.Len(t, d.Results, 3)
+	for i, m := range d.Messages {
+		switch m.QueueId {
+		case 1:
+			assert.Equal(t, SendStatusSent, d.Results[i].Status)
+		case 3:
+			assert.Equal(t, SendStatusRetry, d.Results[i].Status)
+		case 4:
+			assert.Equal(t, SendStatusRateLimited, d.Results[i].Status)
+		}
+	}
+}
+
+func TestDeliverCountError(t *testing.T) {
+	channel := &FakeChannel{ChannelName: "fake", RateLimitBucket: "n_fake", RateLimitPerDay: 1}
+	items := []QueueItem{{Id: 1, Channel: "fake", Content: []byte(`{"userId": 1}`)}}
+	countSentMessage := func(bucket string, userId types.UserId) (int64, error) {
+		return 0, errors.New("redis unavailable")
+	}
+
+	d := deliver(context.Background(), channel, items, countSentMessage)
+
+	// messages stay queued if counting fails
+	assert.Empty(t, channel.Sent())
+	assert.Empty(t, d.Messages)
+	assert.Empty(t, d.Done)
+	assert.Empty(t, d.Dropped)
+}
+
+func TestRegisterChannel(t *testing.T) {
+	channel := &FakeChannel{ChannelName: "fake_register"}
+	require.NoError(t, RegisterChannel(channel))
+	assert.Error(t, RegisterChannel(&FakeChannel{ChannelName: "fake_register"}))
+
+	c, ok := GetChannel("fake_register")
+	assert.True(t, ok)
+	assert.Equal(t, channel, c)
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -51,14 +54,19 @@ func notificationCollector() {
 		gob.Register(&ValidatorProposalNotification{})
 		gob.Register(&ValidatorUpcomingProposalNotification{})
 		gob.Register(&ValidatorGroupEfficiencyNotification{})
+		gob.Register(&ValidatorDashboardRuleNotification{})
 		gob.Register(&ValidatorAttestationNotification{})
 		gob.Register(&ValidatorIsOfflineNotification{})
 		gob.Register(&ValidatorIsOnlineNotification{})
+		gob.Register(&ValidatorIsUnstableNotification{})
 		gob.Register(&ValidatorGotSlashedNotification{})
 		gob.Register(&ValidatorWithdrawalNotification{})
+		gob.Register(&ValidatorPartialWithdrawalNotification{})
+		gob.Register(&ValidatorConsolidationNotification{})
 		gob.Register(&NetworkNotification{})
 		gob.Register(&RocketpoolNotification{})
 		gob.Register(&MonitorMachineNotification{})
+		gob.Register(&MachineClientOutdatedNotification{})
 		gob.Register(&TaxReportNotification{})
 		gob.Register(&EthClientNotification{})
 		gob.Register(&SyncCommitteeSoonNotification{})
@@ -328,6 +336,13 @@ func collectNotifications(mc modules.ModuleContext, epoch uint64) (types.Notific
 	}
 	log.Infof("collecting group efficiency notifications took: %v", time.Since(start))
 
+	err = collectValidatorDashboardRuleNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_validator_dashboard_rule").Inc()
+		return nil, fmt.Errorf("error collecting validator_dashboard_rule notifications: %v", err)
+	}
+	log.Infof("collecting validator dashboard rule notifications took: %v", time.Since(start))
+
 	err = collectAttestationAndOfflineValidatorNotifications(notificationsByUserID, epoch)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_missed_attestation").Inc()
@@ -370,6 +385,20 @@ func collectNotifications(mc modules.ModuleContext, epoch uint64) (types.Notific
 	}
 	log.Infof("collecting withdrawal notifications took: %v", time.Since(start))
 
+	err = collectPartialWithdrawalNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_validator_partial_withdrawal").Inc()
+		return nil, fmt.Errorf("error collecting partial withdrawal notifications: %v", err)
+	}
+	log.Infof("collecting partial withdrawal notifications took: %v", time.Since(start))
+
+	err = collectConsolidationNotifications(notificationsByUserID, epoch, mc)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_validator_consolidation").Inc()
+		return nil, fmt.Errorf("error collecting consolidation notifications: %v", err)
+	}
+	log.Infof("collecting consolidation notifications took: %v", time.Since(start))
+
 	err = collectNetworkNotifications(notificationsByUserID)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_network").Inc()
@@ -463,6 +492,48 @@ func collectUserDbNotifications(epoch uint64) (types.NotificationsPerUserId, err
 		return nil, fmt.Errorf("error collecting Eth client memory notifications: %v", err)
 	}
 
+	// Monitoring (premium): beacon node sync
+	err = collectMonitoringMachineNotSynced(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_not_synced").Inc()
+		return nil, fmt.Errorf("error collecting Eth client not synced notifications: %v", err)
+	}
+
+	// Monitoring (premium): execution client connection
+	err = collectMonitoringMachineElDisconnected(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_el_disconnected").Inc()
+		return nil, fmt.Errorf("error collecting Eth client execution disconnected notifications: %v", err)
+	}
+
+	// Monitoring (premium): peer count
+	err = collectMonitoringMachineLowPeerCount(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_low_peer_count").Inc()
+		return nil, fmt.Errorf("error collecting Eth client peer count notifications: %v", err)
+	}
+
+	// Monitoring (premium): head slot lag
+	err = collectMonitoringMachineHeadSlotLag(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_head_slot_lag").Inc()
+		return nil, fmt.Errorf("error collecting Eth client head slot lag notifications: %v", err)
+	}
+
+	// Monitoring (premium): fallback nodes
+	err = collectMonitoringMachineFallbackInUse(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_fallback_in_use").Inc()
+		return nil, fmt.Errorf("error collecting Eth client fallback notifications: %v", err)
+	}
+
+	// Monitoring (premium): outdated clients
+	err = collectMonitoringMachineClientOutdated(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_monitoring_machine_client_outdated").Inc()
+		return nil, fmt.Errorf("error collecting Eth client outdated notifications: %v", err)
+	}
+
 	// New ETH clients
 	err = collectEthClientNotifications(notificationsByUserID)
 	if err != nil {
@@ -961,15 +1032,22 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ty
 		EventFilter    []byte `db:"pubkey"`
 	}
 
-	// get attestations for all validators for the last 4 epochs
-	// we need 4 epochs so that can detect the online / offline status of validators
+	// get attestations for all validators for the configured lookback window
+	// we need offline + online threshold epochs so that can detect the online / offline status of validators
+	thresholds := getLivenessThresholds()
+	lookback := thresholds.LookbackEpochs()
+	if epoch+1 < lookback {
+		return fmt.Errorf("epoch %v is too low to detect online / offline validators (lookback: %v epochs)", epoch, lookback)
+	}
+	firstLookbackEpoch := epoch + 1 - lookback
+
 	validators, err := db.GetValidatorIndices()
 	if err != nil {
 		return err
 	}
 
-	// this reads the submitted attestations for the last 4 epochs
-	participationPerEpoch, err := db.GetValidatorAttestationHistoryForNotifications(epoch-3, epoch)
+	// this reads the submitted attestations for the lookback window
+	participationPerEpoch, err := db.GetValidatorAttestationHistoryForNotifications(firstLookbackEpoch, epoch)
 	if err != nil {
 		return fmt.Errorf("error getting validator attestations from db %w", err)
 	}
@@ -1053,39 +1131,19 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ty
 	var offlineValidators []*indexPubkeyPair
 	var onlineValidators []*indexPubkeyPair
 
-	epochNMinus1 := types.Epoch(epoch - 1)
-	epochNMinus2 := types.Epoch(epoch - 2)
-	epochNMinus3 := types.Epoch(epoch - 3)
-
-	if epochTotal[types.Epoch(epoch)] == 0 {
-		return fmt.Errorf("consistency error, did not retrieve attestation data for epoch %v", epoch)
-	}
-	if epochTotal[epochNMinus1] == 0 {
-		return fmt.Errorf("consistency error, did not retrieve attestation data for epoch %v", epochNMinus1)
-	}
-	if epochTotal[epochNMinus2] == 0 {
-		return fmt.Errorf("consistency error, did not retrieve attestation data for epoch %v", epochNMinus2)
-	}
-	if epochTotal[epochNMinus3] == 0 {
-		return fmt.Errorf("consistency error, did not retrieve attestation data for epoch %v", epochNMinus3)
-	}
-
-	if epochAttested[types.Epoch(epoch)]*100/epochTotal[types.Epoch(epoch)] < 60 {
-		return fmt.Errorf("consistency error, did receive more than 60%% of missed attestation in epoch %v (total: %v, attested: %v)", epoch, epochTotal[types.Epoch(epoch)], epochAttested[types.Epoch(epoch)])
-	}
-	if epochAttested[epochNMinus1]*100/epochTotal[epochNMinus1] < 60 {
-		return fmt.Errorf("consistency error, did receive more than 60%% of missed attestation in epoch %v (total: %v, attested: %v)", epochNMinus1, epochTotal[epochNMinus1], epochAttested[epochNMinus1])
-	}
-	if epochAttested[epochNMinus2]*100/epochTotal[epochNMinus2] < 60 {
-		return fmt.Errorf("consistency error, did receive more than 60%% of missed attestation in epoch %v (total: %v, attested: %v)", epochNMinus2, epochTotal[epochNMinus2], epochAttested[epochNMinus2])
-	}
-	if epochAttested[epochNMinus3]*100/epochTotal[epochNMinus3] < 60 {
-		return fmt.Errorf("consistency error, did receive more than 60%% of missed attestation in epoch %v (total: %v, attested: %v)", epochNMinus3, epochTotal[epochNMinus3], epochAttested[epochNMinus3])
+	for e := firstLookbackEpoch; e <= epoch; e++ {
+		currentEpoch := types.Epoch(e)
+		if epochTotal[currentEpoch] == 0 {
+			return fmt.Errorf("consistency error, did not retrieve attestation data for epoch %v", currentEpoch)
+		}
+		if epochAttested[currentEpoch]*100/epochTotal[currentEpoch] < 60 {
+			return fmt.Errorf("consistency error, did receive more than 60%% of missed attestation in epoch %v (total: %v, attested: %v)", currentEpoch, epochTotal[currentEpoch], epochAttested[currentEpoch])
+		}
 	}
 
 	for _, validator := range validators {
-		if participationPerEpoch[epochNMinus3][types.ValidatorIndex(validator)] && !participationPerEpoch[epochNMinus2][types.ValidatorIndex(validator)] && !participationPerEpoch[epochNMinus1][types.ValidatorIndex(validator)] && !participationPerEpoch[types.Epoch(epoch)][types.ValidatorIndex(validator)] {
-			//log.Infof("validator %v detected as offline in epoch %v (did not attest since epoch %v)", validator, epoch, epochNMinus2)
+		if thresholds.isOfflineTransition(participationPerEpoch, types.ValidatorIndex(validator), epoch) {
+			//log.Infof("validator %v detected as offline in epoch %v (did not attest for %v epochs)", validator, epoch, thresholds.OfflineEpochs)
 			pubkey, err := GetPubkeyForIndex(validator)
 			if err != nil {
 				return err
@@ -1093,8 +1151,8 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ty
 			offlineValidators = append(offlineValidators, &indexPubkeyPair{Index: validator, Pubkey: pubkey})
 		}
 
-		if !participationPerEpoch[epochNMinus3][types.ValidatorIndex(validator)] && !participationPerEpoch[epochNMinus2][types.ValidatorIndex(validator)] && !participationPerEpoch[epochNMinus1][types.ValidatorIndex(validator)] && participationPerEpoch[types.Epoch(epoch)][types.ValidatorIndex(validator)] {
-			//log.Infof("validator %v detected as online in epoch %v (attested again in epoch %v)", validator, epoch, epoch)
+		if thresholds.isOnlineTransition(participationPerEpoch, types.ValidatorIndex(validator), epoch) {
+			//log.Infof("validator %v detected as online in epoch %v (attested again for %v epochs)", validator, epoch, thresholds.OnlineEpochs)
 			pubkey, err := GetPubkeyForIndex(validator)
 			if err != nil {
 				return err
@@ -1126,67 +1184,158 @@ func collectAttestationAndOfflineValidatorNotifications(notificationsByUserID ty
 		return fmt.Errorf("failed to get subs for %v: %v", types.ValidatorIsOfflineEventName, err)
 	}
 
+	// the hysteresis state is only tracked for validators that somebody is subscribed to
+	trackedIndices := make([]uint64, 0, len(offlineValidators)+len(onlineValidators))
 	for _, validator := range offlineValidators {
-		t := hex.EncodeToString(validator.Pubkey)
+		if len(subMapOnlineOffline[hex.EncodeToString(validator.Pubkey)]) > 0 {
+			trackedIndices = append(trackedIndices, validator.Index)
+		}
+	}
+	for _, validator := range onlineValidators {
+		if len(subMapOnlineOffline[hex.EncodeToString(validator.Pubkey)]) > 0 {
+			trackedIndices = append(trackedIndices, validator.Index)
+		}
+	}
+	livenessStates, err := getValidatorLivenessStates(trackedIndices)
+	if err != nil {
+		return err
+	}
+	unstableStates, err := getUnstableValidatorLivenessStates()
+	if err != nil {
+		return err
+	}
+	for _, state := range unstableStates {
+		if _, ok := livenessStates[state.ValidatorIndex]; !ok {
+			livenessStates[state.ValidatorIndex] = state
+		}
+	}
+
+	createLivenessNotifications := func(validatorIndex uint64, pubkey []byte, eventName types.EventName, state *validatorLivenessState) error {
+		t := hex.EncodeToString(pubkey)
 		subs := subMapOnlineOffline[t]
 		for _, sub := range subs {
 			if sub.UserID == nil || sub.ID == nil {
 				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
 			}
-			log.Infof("new event: validator %v detected as offline since epoch %v", validator.Index, epoch)
 
-			n := &ValidatorIsOfflineNotification{
-				NotificationBaseImpl: types.NotificationBaseImpl{
-					SubscriptionID:     *sub.ID,
-					Epoch:              epoch,
-					EventName:          sub.EventName,
-					LatestState:        fmt.Sprint(epoch - 2), // first epoch the validator stopped attesting
-					EventFilter:        hex.EncodeToString(validator.Pubkey),
-					UserID:             *sub.UserID,
-					DashboardId:        sub.DashboardId,
-					DashboardName:      sub.DashboardName,
-					DashboardGroupId:   sub.DashboardGroupId,
-					DashboardGroupName: sub.DashboardGroupName,
-				},
-				ValidatorIndex: validator.Index,
+			base := types.NotificationBaseImpl{
+				SubscriptionID:     *sub.ID,
+				UserID:             *sub.UserID,
+				Epoch:              epoch,
+				EventName:          eventName,
+				EventFilter:        t,
+				LatestState:        "-",
+				DashboardId:        sub.DashboardId,
+				DashboardName:      sub.DashboardName,
+				DashboardGroupId:   sub.DashboardGroupId,
+				DashboardGroupName: sub.DashboardGroupName,
+			}
+
+			var n types.Notification
+			switch eventName {
+			case types.ValidatorIsOfflineEventName:
+				log.Infof("new event: validator %v detected as offline since epoch %v", validatorIndex, epoch)
+				base.LatestState = fmt.Sprint(state.StateSinceEpoch + 1 - thresholds.OfflineEpochs) // first epoch the validator stopped attesting
+				n = &ValidatorIsOfflineNotification{
+					NotificationBaseImpl: base,
+					ValidatorIndex:       validatorIndex,
+				}
+			case types.ValidatorIsOnlineEventName:
+				log.Infof("new event: validator %v detected as online again at epoch %v", validatorIndex, epoch)
+				n = &ValidatorIsOnlineNotification{
+					NotificationBaseImpl: base,
+					ValidatorIndex:       validatorIndex,
+				}
+			case types.ValidatorIsUnstableEventName:
+				log.Infof("new event: validator %v detected as unstable at epoch %v (%v transitions since epoch %v)", validatorIndex, epoch, state.WindowTransitions, state.WindowStartEpoch)
+				n = &ValidatorIsUnstableNotification{
+					NotificationBaseImpl: base,
+					ValidatorIndex:       validatorIndex,
+					Transitions:          state.WindowTransitions,
+					WindowStartEpoch:     state.WindowStartEpoch,
+					IsOffline:            state.IsOffline,
+				}
+			default:
+				return fmt.Errorf("unexpected liveness event %v", eventName)
 			}
 
 			notificationsByUserID.AddNotification(n)
 			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 		}
+		return nil
 	}
 
-	for _, validator := range onlineValidators {
-		t := hex.EncodeToString(validator.Pubkey)
-		subs := subMapOnlineOffline[t]
-		for _, sub := range subs {
-			if sub.UserID == nil || sub.ID == nil {
-				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+	// keyed by validator index so every validator is saved once even if it is changed multiple times
+	changedStates := make(map[uint64]*validatorLivenessState, len(trackedIndices))
+	handleTransition := func(validator *indexPubkeyPair, isOffline bool) error {
+		eventName := types.ValidatorIsOnlineEventName
+		if isOffline {
+			eventName = types.ValidatorIsOfflineEventName
+		}
+		if len(subMapOnlineOffline[hex.EncodeToString(validator.Pubkey)]) == 0 {
+			return nil
+		}
+
+		state, ok := livenessStates[validator.Index]
+		if !ok {
+			state = &validatorLivenessState{
+				ValidatorIndex:   validator.Index,
+				IsOffline:        !isOffline,
+				WindowStartEpoch: epoch,
 			}
+			livenessStates[validator.Index] = state
+		}
 
-			log.Infof("new event: validator %v detected as online again at epoch %v", validator.Index, epoch)
+		action := state.transition(isOffline, epoch, thresholds)
+		changedStates[state.ValidatorIndex] = state
 
-			n := &ValidatorIsOnlineNotification{
-				NotificationBaseImpl: types.NotificationBaseImpl{
-					SubscriptionID:     *sub.ID,
-					UserID:             *sub.UserID,
-					Epoch:              epoch,
-					EventName:          types.ValidatorIsOnlineEventName,
-					EventFilter:        hex.EncodeToString(validator.Pubkey),
-					LatestState:        "-",
-					DashboardId:        sub.DashboardId,
-					DashboardName:      sub.DashboardName,
-					DashboardGroupId:   sub.DashboardGroupId,
-					DashboardGroupName: sub.DashboardGroupName,
-				},
-				ValidatorIndex: validator.Index,
-			}
+		switch action {
+		case livenessActionNotify:
+			return createLivenessNotifications(validator.Index, validator.Pubkey, eventName, state)
+		case livenessActionUnstable:
+			return createLivenessNotifications(validator.Index, validator.Pubkey, types.ValidatorIsUnstableEventName, state)
+		}
+		return nil
+	}
 
-			notificationsByUserID.AddNotification(n)
-			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+	for _, validator := range offlineValidators {
+		err := handleTransition(validator, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, validator := range onlineValidators {
+		err := handleTransition(validator, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	// unstable validators that kept their state for a whole window are reported with their final state,
+	// livenessStates holds the single state object of each validator including the transitions of this epoch
+	for _, state := range settleLivenessStates(livenessStates, epoch, thresholds) {
+		changedStates[state.ValidatorIndex] = state
+
+		pubkey, err := GetPubkeyForIndex(state.ValidatorIndex)
+		if err != nil {
+			return err
+		}
+		eventName := types.ValidatorIsOnlineEventName
+		if state.IsOffline {
+			eventName = types.ValidatorIsOfflineEventName
+		}
+		err = createLivenessNotifications(state.ValidatorIndex, pubkey, eventName, state)
+		if err != nil {
+			return err
 		}
 	}
 
+	err = saveValidatorLivenessStates(slices.Collect(maps.Values(changedStates)))
+	if err != nil {
+		return err
+	}
+
 	// subMapGroupOnlineOffline, err := GetSubsForEventFilter(types.ValidatorGroupIsOfflineEventName, "", nil, nil, validatorDashboardConfig)
 	// if err != nil {
 	// 	return fmt.Errorf("failed to get subs for %v: %v", types.ValidatorGroupIsOfflineEventName, err)
@@ -1364,6 +1513,153 @@ func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPer
 	return nil
 }
 
+// collectPartialWithdrawalNotifications collects all notifications for processed partial withdrawals that were requested from the execution layer
+func collectPartialWithdrawalNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	if epoch < utils.Config.Chain.ClConfig.ElectraForkEpoch {
+		return nil
+	}
+
+	subMap, err := GetSubsForEventFilter(types.ValidatorPartialWithdrawalEventName, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for partial withdrawals %w", err)
+	}
+
+	events, err := db.GetEpochPartialWithdrawals(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting partial withdrawals from database, err: %w", err)
+	}
+
+	log.Infof("retrieved %v partial withdrawal events", len(events))
+	for _, event := range events {
+		subscribers, ok := subMap[hex.EncodeToString(event.Pubkey)]
+		if !ok {
+			continue
+		}
+		for _, sub := range subscribers {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			if sub.LastEpoch != nil {
+				lastSentEpoch := *sub.LastEpoch
+				if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
+					continue
+				}
+			}
+			log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorPartialWithdrawalEventName, event.ValidatorIndex, epoch)
+			n := &ValidatorPartialWithdrawalNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					SubscriptionID:     *sub.ID,
+					UserID:             *sub.UserID,
+					EventFilter:        hex.EncodeToString(event.Pubkey),
+					EventName:          sub.EventName,
+					DashboardId:        sub.DashboardId,
+					DashboardName:      sub.DashboardName,
+					DashboardGroupId:   sub.DashboardGroupId,
+					DashboardGroupName: sub.DashboardGroupName,
+					Epoch:              epoch,
+				},
+				ValidatorIndex: event.ValidatorIndex,
+				Epoch:          epoch,
+				Slot:           event.Slot,
+				Amount:         event.Amount,
+				Address:        event.Address,
+			}
+			notificationsByUserID.AddNotification(n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+
+	return nil
+}
+
+// collectConsolidationNotifications collects all notifications for completed consolidations, subscribers of the source as well as the target validator are notified
+func collectConsolidationNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64, mc modules.ModuleContext) error {
+	if epoch < utils.Config.Chain.ClConfig.ElectraForkEpoch || epoch == 0 {
+		return nil
+	}
+
+	subMap, err := GetSubsForEventFilter(types.ValidatorConsolidationEventName, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for consolidations %w", err)
+	}
+
+	events, err := db.GetEpochConsolidations(epoch)
+	if err != nil {
+		return fmt.Errorf("error getting consolidations from database, err: %w", err)
+	}
+
+	if len(events) > 0 {
+		// the consolidations of the epoch are applied by the epoch transition into it, the state before still lists the accepted ones as pending
+		pending, err := mc.CL.GetPendingConsolidations(epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1)
+		if err != nil {
+			return fmt.Errorf("error getting pending consolidations for epoch %v: %w", epoch, err)
+		}
+		events = filterAcceptedConsolidations(events, pending.Data)
+	}
+
+	log.Infof("retrieved %v consolidation events", len(events))
+	for _, event := range events {
+		for _, validator := range []struct {
+			index  uint64
+			pubkey []byte
+		}{{event.SourceValidatorIndex, event.SourcePubkey}, {event.TargetValidatorIndex, event.TargetPubkey}} {
+			subscribers, ok := subMap[hex.EncodeToString(validator.pubkey)]
+			if !ok {
+				continue
+			}
+			for _, sub := range subscribers {
+				if sub.UserID == nil || sub.ID == nil {
+					return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+				}
+				if sub.LastEpoch != nil {
+					lastSentEpoch := *sub.LastEpoch
+					if lastSentEpoch >= epoch || epoch < sub.CreatedEpoch {
+						continue
+					}
+				}
+				log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorConsolidationEventName, validator.index, epoch)
+				n := &ValidatorConsolidationNotification{
+					NotificationBaseImpl: types.NotificationBaseImpl{
+						SubscriptionID:     *sub.ID,
+						UserID:             *sub.UserID,
+						EventFilter:        hex.EncodeToString(validator.pubkey),
+						EventName:          sub.EventName,
+						DashboardId:        sub.DashboardId,
+						DashboardName:      sub.DashboardName,
+						DashboardGroupId:   sub.DashboardGroupId,
+						DashboardGroupName: sub.DashboardGroupName,
+						Epoch:              epoch,
+					},
+					ValidatorIndex:       validator.index,
+					SourceValidatorIndex: event.SourceValidatorIndex,
+					TargetValidatorIndex: event.TargetValidatorIndex,
+					Epoch:                epoch,
+				}
+				notificationsByUserID.AddNotification(n)
+				metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			}
+		}
+	}
+
+	return nil
+}
+
+// filterAcceptedConsolidations drops the consolidation requests that were rejected by the beacon chain, as the source validator might have exited for another reason
+func filterAcceptedConsolidations(events []*types.ConsolidationsNotification, pending []constypes.PendingConsolidation) []*types.ConsolidationsNotification {
+	accepted := make(map[constypes.PendingConsolidation]bool, len(pending))
+	for _, p := range pending {
+		accepted[p] = true
+	}
+
+	filtered := make([]*types.ConsolidationsNotification, 0, len(events))
+	for _, event := range events {
+		if accepted[constypes.PendingConsolidation{SourceIndex: event.SourceValidatorIndex, TargetIndex: event.TargetValidatorIndex}] {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
 func collectEthClientNotifications(notificationsByUserID types.NotificationsPerUserId) error {
 	updatedClients := ethclients.GetUpdatedClients() //only check if there are new updates
 	for _, client := range updatedClients {
@@ -1416,10 +1712,11 @@ func collectEthClientNotifications(notificationsByUserID types.NotificationsPerU
 
 func collectMonitoringMachineOffline(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
 	nowTs := time.Now().Unix()
-	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineOfflineEventName, 120,
+	// a machine is only offline if none of its processes reported metrics recently
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineOfflineEventName, 120, machineMetricProcesses,
 		// notify condition
 		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
-			if machineData.CurrentDataInsertTs < nowTs-10*60 && machineData.CurrentDataInsertTs > nowTs-90*60 {
+			if machineData.LastDataInsertTs < nowTs-10*60 && machineData.LastDataInsertTs > nowTs-90*60 {
 				return true
 			}
 			return false
@@ -1429,12 +1726,15 @@ func collectMonitoringMachineOffline(notificationsByUserID types.NotificationsPe
 }
 
 func isMachineDataRecent(machineData *types.MachineMetricSystemUser) bool {
+	if machineData.CurrentData == nil { // machine does not report system metrics
+		return false
+	}
 	nowTs := time.Now().Unix()
 	return machineData.CurrentDataInsertTs >= nowTs-60*60
 }
 
 func collectMonitoringMachineDiskAlmostFull(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
-	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineDiskAlmostFullEventName, 750,
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineDiskAlmostFullEventName, 750, []string{"system"},
 		// notify condition
 		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
 			if !isMachineDataRecent(machineData) {
@@ -1449,7 +1749,7 @@ func collectMonitoringMachineDiskAlmostFull(notificationsByUserID types.Notifica
 }
 
 func collectMonitoringMachineCPULoad(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
-	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineCpuLoadEventName, 10,
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineCpuLoadEventName, 10, []string{"system"},
 		// notify condition
 		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
 			if !isMachineDataRecent(machineData) {
@@ -1471,7 +1771,7 @@ func collectMonitoringMachineCPULoad(notificationsByUserID types.NotificationsPe
 }
 
 func collectMonitoringMachineMemoryUsage(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
-	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineMemoryUsageEventName, 10,
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineMemoryUsageEventName, 10, []string{"system"},
 		// notify condition
 		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
 			if !isMachineDataRecent(machineData) {
@@ -1488,51 +1788,130 @@ func collectMonitoringMachineMemoryUsage(notificationsByUserID types.Notificatio
 	)
 }
 
+// client data older than this is not used for client alerts, the machine offline alert covers machines that stopped reporting
+const machineClientDataMaxAge = 15 * 60
+
+func isMachineNodeDataRecent(machineData *types.MachineMetricSystemUser) bool {
+	return len(machineData.NodeData) > 0 && machineData.NodeDataInsertTs >= time.Now().Unix()-machineClientDataMaxAge
+}
+
+func isMachineValidatorDataRecent(machineData *types.MachineMetricSystemUser) bool {
+	return len(machineData.ValidatorData) > 0 && machineData.ValidatorDataInsertTs >= time.Now().Unix()-machineClientDataMaxAge
+}
+
+// machineConditionPersists returns true if the condition holds for the latest samples covering the threshold in minutes.
+// Machines push one sample per minute, so the threshold is the number of consecutive samples
+func machineConditionPersists[T any](samples []*T, thresholdMinutes float64, condition func(sample *T) bool) bool {
+	count := max(int(math.Ceil(thresholdMinutes)), 1)
+	if len(samples) < count {
+		return false
+	}
+	for _, sample := range samples[:count] {
+		if !condition(sample) {
+			return false
+		}
+	}
+	return true
+}
+
+func collectMonitoringMachineNotSynced(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineNotSyncedEventName, 10, []string{"beaconnode"},
+		// notify condition
+		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
+			if !isMachineNodeDataRecent(machineData) {
+				return false
+			}
+
+			return machineConditionPersists(machineData.NodeData, subscribeData.EventThreshold, func(sample *types.MachineMetricNode) bool {
+				return !sample.SyncEth2Synced
+			})
+		},
+		epoch,
+	)
+}
+
+func collectMonitoringMachineElDisconnected(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineElDisconnectedEventName, 10, []string{"beaconnode"},
+		// notify condition
+		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
+			if !isMachineNodeDataRecent(machineData) {
+				return false
+			}
+
+			return machineConditionPersists(machineData.NodeData, subscribeData.EventThreshold, func(sample *types.MachineMetricNode) bool {
+				return !sample.SyncEth1Connected
+			})
+		},
+		epoch,
+	)
+}
+
+func collectMonitoringMachineLowPeerCount(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineLowPeerCountEventName, 10, []string{"beaconnode"},
+		// notify condition
+		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
+			if !isMachineNodeDataRecent(machineData) {
+				return false
+			}
+
+			return float64(machineData.NodeData[0].NetworkPeersConnected) < subscribeData.EventThreshold
+		},
+		epoch,
+	)
+}
+
+func collectMonitoringMachineHeadSlotLag(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineHeadSlotLagEventName, 10, []string{"beaconnode"},
+		// notify condition
+		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
+			if !isMachineNodeDataRecent(machineData) {
+				return false
+			}
+
+			// compare against the network slot at the time the sample was pushed
+			networkSlot := utils.TimeToSlot(uint64(machineData.NodeDataInsertTs))
+			headSlot := machineData.NodeData[0].SyncBeaconHeadSlot
+			if headSlot >= networkSlot {
+				return false
+			}
+			return float64(networkSlot-headSlot) > subscribeData.EventThreshold
+		},
+		epoch,
+	)
+}
+
+func collectMonitoringMachineFallbackInUse(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	return collectMonitoringMachine(notificationsByUserID, types.MonitoringMachineFallbackInUseEventName, 10, []string{"beaconnode", "validator"},
+		// notify condition
+		func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool {
+			// the beacon node may fall back to another execution client, the validator client to another beacon node
+			if isMachineNodeDataRecent(machineData) && machineConditionPersists(machineData.NodeData, subscribeData.EventThreshold, func(sample *types.MachineMetricNode) bool {
+				return sample.SyncEth1FallbackConnected
+			}) {
+				return true
+			}
+			return isMachineValidatorDataRecent(machineData) && machineConditionPersists(machineData.ValidatorData, subscribeData.EventThreshold, func(sample *types.MachineMetricValidator) bool {
+				return sample.SyncEth2FallbackConnected
+			})
+		},
+		epoch,
+	)
+}
+
 var isFirstNotificationCheck = true
 
+// processes that push machine metrics
+var machineMetricProcesses = []string{"system", "beaconnode", "validator", "execution", "slasher"}
+
 func collectMonitoringMachine(
 	notificationsByUserID types.NotificationsPerUserId,
 	eventName types.EventName,
 	epochWaitInBetween int,
+	processes []string,
 	notifyConditionFulfilled func(subscribeData *types.Subscription, machineData *types.MachineMetricSystemUser) bool,
 	epoch uint64,
 ) error {
-	// event_filter == machine name
-
-	dbResult, err := GetSubsForEventFilter(
-		eventName,
-		"(created_epoch <= ? AND (last_sent_epoch < ? OR last_sent_epoch IS NULL))",
-		[]interface{}{epoch, int64(epoch) - int64(epochWaitInBetween)},
-		nil,
-	)
-
-	// TODO: clarify why we need grouping here?!
-	// err := db.FrontendWriterDB.Select(&allSubscribed,
-	// 	`SELECT
-	// 		us.user_id,
-	// 		max(us.id) AS id,
-	// 		ENCODE((array_agg(us.unsubscribe_hash))[1], 'hex') AS unsubscribe_hash,
-	// 		event_filter,
-	// 		COALESCE(event_threshold, 0) AS event_threshold
-	// 	FROM users_subscriptions us
-	// 	WHERE us.event_name = $1 AND us.created_epoch <= $2
-	// 	AND (us.last_sent_epoch < ($2 - $3) OR us.last_sent_epoch IS NULL)
-	// 	group by us.user_id, event_filter, event_threshold`,
-	// 	eventName, epoch, epochWaitInBetween)
-	if err != nil {
-		return err
-	}
-
-	rowKeys := gcp_bigtable.RowList{}
-	totalSubscribed := 0
-	for _, data := range dbResult {
-		for _, sub := range data {
-			rowKeys = append(rowKeys, db.BigtableClient.GetMachineRowKey(*sub.UserID, "system", sub.EventFilter))
-			totalSubscribed++
-		}
-	}
-
-	machineDataOfSubscribed, err := db.BigtableClient.GetMachineMetricsForNotifications(rowKeys)
+	dbResult, machineDataOfSubscribed, totalSubscribed, err := getSubscribedMachineData(eventName, epochWaitInBetween, processes, epoch)
 	if err != nil {
 		return err
 	}
@@ -1626,6 +2005,138 @@ func collectMonitoringMachine(
 	return nil
 }
 
+// getSubscribedMachineData returns the subscriptions of the event which are due and the metrics of the subscribed machines
+func getSubscribedMachineData(
+	eventName types.EventName,
+	epochWaitInBetween int,
+	processes []string,
+	epoch uint64,
+) (map[string][]*types.Subscription, map[types.UserId]map[string]*types.MachineMetricSystemUser, int, error) {
+	// event_filter == machine name
+
+	dbResult, err := GetSubsForEventFilter(
+		eventName,
+		"(created_epoch <= ? AND (last_sent_epoch < ? OR last_sent_epoch IS NULL))",
+		[]interface{}{epoch, int64(epoch) - int64(epochWaitInBetween)},
+		nil,
+	)
+
+	// TODO: clarify why we need grouping here?!
+	// err := db.FrontendWriterDB.Select(&allSubscribed,
+	// 	`SELECT
+	// 		us.user_id,
+	// 		max(us.id) AS id,
+	// 		ENCODE((array_agg(us.unsubscribe_hash))[1], 'hex') AS unsubscribe_hash,
+	// 		event_filter,
+	// 		COALESCE(event_threshold, 0) AS event_threshold
+	// 	FROM users_subscriptions us
+	// 	WHERE us.event_name = $1 AND us.created_epoch <= $2
+	// 	AND (us.last_sent_epoch < ($2 - $3) OR us.last_sent_epoch IS NULL)
+	// 	group by us.user_id, event_filter, event_threshold`,
+	// 	eventName, epoch, epochWaitInBetween)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	rowKeys := gcp_bigtable.RowList{}
+	totalSubscribed := 0
+	for _, data := range dbResult {
+		for _, sub := range data {
+			for _, process := range processes {
+				rowKeys = append(rowKeys, db.BigtableClient.GetMachineRowKey(*sub.UserID, process, sub.EventFilter))
+			}
+			totalSubscribed++
+		}
+	}
+
+	machineDataOfSubscribed, err := db.BigtableClient.GetMachineMetricsForNotifications(rowKeys)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return dbResult, machineDataOfSubscribed, totalSubscribed, nil
+}
+
+func collectMonitoringMachineClientOutdated(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	releasedClients := ethclients.GetReleasedClients()
+	if len(releasedClients) == 0 { // release data not fetched yet
+		return nil
+	}
+
+	dbResult, machineDataOfSubscribed, _, err := getSubscribedMachineData(types.MonitoringMachineClientOutdatedEventName, 225, []string{"beaconnode", "validator", "execution"}, epoch)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range dbResult {
+		for _, sub := range data {
+			machineData, found := machineDataOfSubscribed[*sub.UserID][sub.EventFilter]
+			if !found {
+				continue
+			}
+
+			// the latest sample of every client process
+			reported := make([]struct{ name, version string }, 0, 3)
+			if isMachineNodeDataRecent(machineData) {
+				reported = append(reported, struct{ name, version string }{machineData.NodeData[0].ClientName, machineData.NodeData[0].ClientVersion})
+			}
+			if isMachineValidatorDataRecent(machineData) {
+				reported = append(reported, struct{ name, version string }{machineData.ValidatorData[0].ClientName, machineData.ValidatorData[0].ClientVersion})
+			}
+			if len(machineData.ExecutionData) > 0 && machineData.ExecutionDataInsertTs >= time.Now().Unix()-machineClientDataMaxAge {
+				reported = append(reported, struct{ name, version string }{machineData.ExecutionData[0].ClientName, machineData.ExecutionData[0].ClientVersion})
+			}
+
+			outdated := make([]MachineOutdatedClient, 0)
+			seen := make(map[string]bool)
+			for _, r := range reported {
+				client := ethclients.MatchClient(r.name, releasedClients)
+				if client == "" || seen[client] {
+					continue
+				}
+				seen[client] = true
+				status := ethclients.GetClientVersionStatus(client, r.version)
+				if status == nil || !status.IsOutdated {
+					continue
+				}
+				// a threshold of 1 only notifies about releases required for a hard fork
+				if sub.EventThreshold >= 1 && !status.IsForkIncompatible {
+					continue
+				}
+				outdated = append(outdated, MachineOutdatedClient{
+					Client:             status.Name,
+					RunningVersion:     status.RunningVersion,
+					LatestVersion:      status.LatestVersion,
+					RequiredVersion:    status.RequiredVersion,
+					IsForkIncompatible: status.IsForkIncompatible,
+				})
+			}
+			if len(outdated) == 0 {
+				continue
+			}
+
+			n := &MachineClientOutdatedNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					SubscriptionID:     *sub.ID,
+					UserID:             *sub.UserID,
+					EventName:          sub.EventName,
+					Epoch:              epoch,
+					EventFilter:        sub.EventFilter,
+					DashboardId:        sub.DashboardId,
+					DashboardName:      sub.DashboardName,
+					DashboardGroupId:   sub.DashboardGroupId,
+					DashboardGroupName: sub.DashboardGroupName,
+				},
+				MachineName: sub.EventFilter,
+				Clients:     outdated,
+			}
+			notificationsByUserID.AddNotification(n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+	return nil
+}
+
 func collectTaxReportNotificationNotifications(notificationsByUserID types.NotificationsPerUserId) error {
 	lastStatsDay, err := cache.LatestExportedStatisticDay.GetOrDefault(db.GetLastExportedStatisticDay)
 
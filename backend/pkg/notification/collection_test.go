@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAcceptedConsolidations(t *testing.T) {
+	accepted := &types.ConsolidationsNotification{SourceValidatorIndex: 1, TargetValidatorIndex: 2}
+	// the source exited voluntarily, its consolidation request was rejected
+	exited := &types.ConsolidationsNotification{SourceValidatorIndex: 3, TargetValidatorIndex: 4}
+	// the source consolidates into another target than the one of the request
+	otherTarget := &types.ConsolidationsNotification{SourceValidatorIndex: 5, TargetValidatorIndex: 6}
+
+	filtered := filterAcceptedConsolidations(
+		[]*types.ConsolidationsNotification{accepted, exited, otherTarget},
+		[]constypes.PendingConsolidation{{SourceIndex: 1, TargetIndex: 2}, {SourceIndex: 5, TargetIndex: 7}},
+	)
+	assert.Equal(t, []*types.ConsolidationsNotification{accepted}, filtered)
+	assert.Empty(t, filterAcceptedConsolidations([]*types.ConsolidationsNotification{accepted}, nil))
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// FakeChannel is a notification channel which keeps all messages in memory instead of delivering them.
+// It is meant to be used in tests to assert which notifications would have been sent.
+type FakeChannel struct {
+	ChannelName types.NotificationChannel
+	// Results are returned in order for the sent messages, messages without a result are sent successfully
+	Results []SendResult
+	// RateLimitBucket and RateLimitPerDay are returned by RateLimit
+	RateLimitBucket string
+	RateLimitPerDay int64
+
+	mu    sync.Mutex
+	sent  []*Message
+	acked []*Message
+}
+
+func (c *FakeChannel) Name() types.NotificationChannel {
+	return c.ChannelName
+}
+
+// Render keeps the raw queue content as payload, the content must be json with a `userId` field
+func (c *FakeChannel) Render(item QueueItem) (*Message, error) {
+	var content struct {
+		UserId types.UserId `json:"userId"`
+	}
+	err := json.Unmarshal(item.Content, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{UserId: content.UserId, Size: 1, Payload: item.Content}, nil
+}
+
+func (c *FakeChannel) RateLimit(ctx context.Context, userId types.UserId) (string, int64) {
+	return c.RateLimitBucket, c.RateLimitPerDay
+}
+
+func (c *FakeChannel) Send(ctx context.Context, messages []*Message) []SendResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := make([]SendResult, len(messages))
+	for i, m := range messages {
+		results[i] = SendResult{Status: SendStatusSent, Label: "200"}
+		if len(c.Results) > 0 {
+			results[i], c.Results = c.Results[0], c.Results[1:]
+		}
+		c.sent = append(c.sent, m)
+	}
+	return results
+}
+
+func (c *FakeChannel) Ack(ctx context.Context, messages []*Message, results []SendResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acked = append(c.acked, messages...)
+	return nil
+}
+
+// Sent returns all messages passed to Send
+func (c *FakeChannel) Sent() []*Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Message{}, c.sent...)
+}
+
+// Acked returns all messages passed to Ack
+func (c *FakeChannel) Acked() []*Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Message{}, c.acked...)
+}
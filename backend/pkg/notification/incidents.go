@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// IncidentResolvingEvents maps events that resolve an incident to the event that opened it
+var IncidentResolvingEvents = map[types.EventName]types.EventName{
+	types.ValidatorIsOnlineEventName: types.ValidatorIsOfflineEventName,
+}
+
+// IncidentOpeningEvents contains all events that open an incident
+var IncidentOpeningEvents = map[types.EventName]struct{}{
+	types.ValidatorIsOfflineEventName: {},
+}
+
+// updateNotificationIncidents groups the dashboard notifications into incidents, opening events
+// create a new incident per entity while resolving events close the open incident of the entity
+func updateNotificationIncidents(epoch uint64, notificationsByUserID types.NotificationsPerUserId) error {
+	tx, err := db.AlloyWriter.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	opened, resolved := 0, 0
+	for _, notificationsPerDashboard := range notificationsByUserID {
+		for dashboardId, notificationsPerGroup := range notificationsPerDashboard {
+			if dashboardId == 0 {
+				continue
+			}
+			for groupId, notificationsPerEventName := range notificationsPerGroup {
+				for eventName, notifications := range notificationsPerEventName {
+					if _, ok := IncidentOpeningEvents[eventName]; ok {
+						for _, n := range notifications {
+							res, err := tx.Exec(`
+								INSERT INTO users_val_dashboards_notification_incidents (dashboard_id, group_id, event_type, entity_id, status, opened_epoch)
+								VALUES ($1, $2, $3, $4, $5, $6)
+								ON CONFLICT (dashboard_id, group_id, event_type, entity_id) WHERE status = 'open' DO NOTHING`,
+								dashboardId, groupId, eventName, n.GetEntitiyId(), types.NotificationIncidentStatusOpen, n.GetEpoch())
+							if err != nil {
+								return fmt.Errorf("error opening incident for %v of dashboard %v group %v: %w", n.GetEntitiyId(), dashboardId, groupId, err)
+							}
+							if rows, err := res.RowsAffected(); err == nil {
+								opened += int(rows)
+							}
+						}
+					}
+					if openingEvent, ok := IncidentResolvingEvents[eventName]; ok {
+						for _, n := range notifications {
+							res, err := tx.Exec(`
+								UPDATE users_val_dashboards_notification_incidents
+								SET status = $1, resolved_epoch = $2
+								WHERE dashboard_id = $3 AND group_id = $4 AND event_type = $5 AND entity_id = $6 AND status = $7`,
+								types.NotificationIncidentStatusResolved, n.GetEpoch(), dashboardId, groupId, openingEvent, n.GetEntitiyId(), types.NotificationIncidentStatusOpen)
+							if err != nil {
+								return fmt.Errorf("error resolving incident for %v of dashboard %v group %v: %w", n.GetEntitiyId(), dashboardId, groupId, err)
+							}
+							if rows, err := res.RowsAffected(); err == nil {
+								resolved += int(rows)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing incidents: %w", err)
+	}
+	if opened > 0 || resolved > 0 {
+		log.Infof("opened %d and resolved %d notification incidents in epoch %v", opened, resolved, epoch)
+	}
+	return nil
+}
+
+// filterSnoozedNotifications returns a copy of the notifications without the ones the users have snoozed
+func filterSnoozedNotifications(notificationsByUserID types.NotificationsPerUserId) (types.NotificationsPerUserId, error) {
+	type snoozeRow struct {
+		DashboardId types.DashboardId      `db:"dashboard_id"`
+		GroupId     types.DashboardGroupId `db:"group_id"`
+		EventType   *types.EventName       `db:"event_type"`
+	}
+	var snoozes []snoozeRow
+	err := db.AlloyWriter.Select(&snoozes, `
+		SELECT dashboard_id, group_id, event_type
+		FROM users_val_dashboards_notification_snoozes
+		WHERE snoozed_until > NOW()`)
+	if err != nil {
+		return nil, fmt.Errorf("error getting notification snoozes: %w", err)
+	}
+	if len(snoozes) == 0 {
+		return notificationsByUserID, nil
+	}
+
+	// a nil event map means all events of the group are snoozed
+	snoozedEvents := make(map[types.DashboardId]map[types.DashboardGroupId]map[types.EventName]struct{})
+	for _, s := range snoozes {
+		if _, ok := snoozedEvents[s.DashboardId]; !ok {
+			snoozedEvents[s.DashboardId] = make(map[types.DashboardGroupId]map[types.EventName]struct{})
+		}
+		events, exists := snoozedEvents[s.DashboardId][s.GroupId]
+		if exists && events == nil {
+			continue
+		}
+		if s.EventType == nil {
+			snoozedEvents[s.DashboardId][s.GroupId] = nil
+			continue
+		}
+		if events == nil {
+			events = make(map[types.EventName]struct{})
+			snoozedEvents[s.DashboardId][s.GroupId] = events
+		}
+		events[*s.EventType] = struct{}{}
+	}
+
+	result := types.NotificationsPerUserId{}
+	snoozed := 0
+	for _, notificationsPerDashboard := range notificationsByUserID {
+		for dashboardId, notificationsPerGroup := range notificationsPerDashboard {
+			for groupId, notificationsPerEventName := range notificationsPerGroup {
+				for eventName, notifications := range notificationsPerEventName {
+					if events, ok := snoozedEvents[dashboardId][groupId]; ok && dashboardId != 0 {
+						if _, eventSnoozed := events[eventName]; events == nil || eventSnoozed {
+							snoozed += len(notifications)
+							continue
+						}
+					}
+					for _, n := range notifications {
+						result.AddNotification(n)
+					}
+				}
+			}
+		}
+	}
+	if snoozed > 0 {
+		log.Infof("skipped %d snoozed notifications", snoozed)
+	}
+	return result, nil
+}
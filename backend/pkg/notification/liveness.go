@@ -0,0 +1,215 @@
+package notification
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+)
+
+// the liveness hysteresis defaults reproduce the previous hardcoded behaviour:
+// a validator is offline after missing 3 epochs and online again after attesting once
+const (
+	defaultOfflineEpochsThreshold       = 3
+	defaultOnlineEpochsThreshold        = 1
+	defaultUnstableWindowEpochs         = 10 // roughly one hour
+	defaultUnstableTransitionsThreshold = 3
+)
+
+type livenessThresholds struct {
+	OfflineEpochs       uint64 // consecutive missed epochs before a validator is considered offline
+	OnlineEpochs        uint64 // consecutive attested epochs before an offline validator is considered online
+	UnstableWindow      uint64 // epochs in which repeated transitions are collapsed into a single unstable event
+	UnstableTransitions uint64 // transitions inside the window after which a validator is considered unstable
+}
+
+func getLivenessThresholds() livenessThresholds {
+	t := livenessThresholds{
+		OfflineEpochs:       defaultOfflineEpochsThreshold,
+		OnlineEpochs:        defaultOnlineEpochsThreshold,
+		UnstableWindow:      defaultUnstableWindowEpochs,
+		UnstableTransitions: defaultUnstableTransitionsThreshold,
+	}
+	if utils.Config.Notifications.OfflineEpochsThreshold != 0 {
+		t.OfflineEpochs = utils.Config.Notifications.OfflineEpochsThreshold
+	}
+	if utils.Config.Notifications.OnlineEpochsThreshold != 0 {
+		t.OnlineEpochs = utils.Config.Notifications.OnlineEpochsThreshold
+	}
+	if utils.Config.Notifications.UnstableWindowEpochs != 0 {
+		t.UnstableWindow = utils.Config.Notifications.UnstableWindowEpochs
+	}
+	if utils.Config.Notifications.UnstableTransitionsThreshold != 0 {
+		t.UnstableTransitions = utils.Config.Notifications.UnstableTransitionsThreshold
+	}
+	return t
+}
+
+// LookbackEpochs returns the number of epochs (including the current one) that
+// need to be inspected to detect both offline and online transitions
+func (t livenessThresholds) LookbackEpochs() uint64 {
+	return t.OfflineEpochs + t.OnlineEpochs
+}
+
+// isOfflineTransition reports whether the validator attested in epoch-N and missed all N epochs after it
+func (t livenessThresholds) isOfflineTransition(participation map[types.Epoch]map[types.ValidatorIndex]bool, validator types.ValidatorIndex, epoch uint64) bool {
+	if !participation[types.Epoch(epoch-t.OfflineEpochs)][validator] {
+		return false
+	}
+	for e := epoch - t.OfflineEpochs + 1; e <= epoch; e++ {
+		if participation[types.Epoch(e)][validator] {
+			return false
+		}
+	}
+	return true
+}
+
+// isOnlineTransition reports whether the validator missed N consecutive epochs and attested in all M epochs after them
+func (t livenessThresholds) isOnlineTransition(participation map[types.Epoch]map[types.ValidatorIndex]bool, validator types.ValidatorIndex, epoch uint64) bool {
+	firstOnlineEpoch := epoch - t.OnlineEpochs + 1
+	for e := firstOnlineEpoch - t.OfflineEpochs; e < firstOnlineEpoch; e++ {
+		if participation[types.Epoch(e)][validator] {
+			return false
+		}
+	}
+	for e := firstOnlineEpoch; e <= epoch; e++ {
+		if !participation[types.Epoch(e)][validator] {
+			return false
+		}
+	}
+	return true
+}
+
+type livenessAction int
+
+const (
+	livenessActionNotify   livenessAction = iota // send the regular offline / online notification
+	livenessActionUnstable                       // send a single unstable notification summarizing the transitions
+	livenessActionSuppress                       // the validator is already flagged as unstable, stay quiet
+)
+
+// validatorLivenessState is the per validator hysteresis state stored in the validator_liveness_states table
+type validatorLivenessState struct {
+	ValidatorIndex    uint64 `db:"validatorindex"`
+	IsOffline         bool   `db:"is_offline"`
+	StateSinceEpoch   uint64 `db:"state_since_epoch"`
+	WindowStartEpoch  uint64 `db:"window_start_epoch"`
+	WindowTransitions uint64 `db:"window_transitions"`
+	IsUnstable        bool   `db:"is_unstable"`
+}
+
+// transition records a state change of the validator and decides how it should be notified
+func (s *validatorLivenessState) transition(isOffline bool, epoch uint64, t livenessThresholds) livenessAction {
+	// a validator that is already flagged as unstable stays silent as long as it keeps flapping
+	if s.IsUnstable && epoch-s.StateSinceEpoch < t.UnstableWindow {
+		s.WindowTransitions++
+		s.IsOffline = isOffline
+		s.StateSinceEpoch = epoch
+		return livenessActionSuppress
+	}
+
+	if s.IsUnstable || epoch-s.WindowStartEpoch >= t.UnstableWindow {
+		s.IsUnstable = false
+		s.WindowStartEpoch = epoch
+		s.WindowTransitions = 0
+	}
+
+	s.WindowTransitions++
+	s.IsOffline = isOffline
+	s.StateSinceEpoch = epoch
+
+	if s.WindowTransitions >= t.UnstableTransitions {
+		s.IsUnstable = true
+		return livenessActionUnstable
+	}
+	return livenessActionNotify
+}
+
+// hasSettled reports whether an unstable validator kept its current state for a whole window
+func (s *validatorLivenessState) hasSettled(epoch uint64, t livenessThresholds) bool {
+	return s.IsUnstable && epoch-s.StateSinceEpoch >= t.UnstableWindow
+}
+
+// settleLivenessStates resets the unstable validators of states that kept their state for a whole window and returns them sorted by index.
+// Validators that transitioned in epoch have not settled, so every validator gets at most one notification per epoch.
+func settleLivenessStates(states map[uint64]*validatorLivenessState, epoch uint64, t livenessThresholds) []*validatorLivenessState {
+	settled := make([]*validatorLivenessState, 0)
+	for _, state := range states {
+		if !state.hasSettled(epoch, t) {
+			continue
+		}
+		state.IsUnstable = false
+		state.WindowStartEpoch = epoch
+		state.WindowTransitions = 0
+		settled = append(settled, state)
+	}
+	slices.SortFunc(settled, func(a, b *validatorLivenessState) int {
+		return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+	})
+	return settled
+}
+
+func getValidatorLivenessStates(validatorIndices []uint64) (map[uint64]*validatorLivenessState, error) {
+	states := make(map[uint64]*validatorLivenessState, len(validatorIndices))
+	if len(validatorIndices) == 0 {
+		return states, nil
+	}
+
+	rows := []*validatorLivenessState{}
+	err := db.WriterDb.Select(&rows, `
+		SELECT validatorindex, is_offline, state_since_epoch, window_start_epoch, window_transitions, is_unstable
+		FROM validator_liveness_states
+		WHERE validatorindex = ANY($1)`, pq.Array(validatorIndices))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validator liveness states: %w", err)
+	}
+	for _, row := range rows {
+		states[row.ValidatorIndex] = row
+	}
+	return states, nil
+}
+
+func getUnstableValidatorLivenessStates() ([]*validatorLivenessState, error) {
+	rows := []*validatorLivenessState{}
+	err := db.WriterDb.Select(&rows, `
+		SELECT validatorindex, is_offline, state_since_epoch, window_start_epoch, window_transitions, is_unstable
+		FROM validator_liveness_states
+		WHERE is_unstable`)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving unstable validator liveness states: %w", err)
+	}
+	return rows, nil
+}
+
+func saveValidatorLivenessStates(states []*validatorLivenessState) error {
+	if len(states) == 0 {
+		return nil
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	for _, s := range states {
+		_, err := tx.Exec(`
+			INSERT INTO validator_liveness_states (validatorindex, is_offline, state_since_epoch, window_start_epoch, window_transitions, is_unstable)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (validatorindex) DO UPDATE SET
+				is_offline = EXCLUDED.is_offline,
+				state_since_epoch = EXCLUDED.state_since_epoch,
+				window_start_epoch = EXCLUDED.window_start_epoch,
+				window_transitions = EXCLUDED.window_transitions,
+				is_unstable = EXCLUDED.is_unstable`,
+			s.ValidatorIndex, s.IsOffline, s.StateSinceEpoch, s.WindowStartEpoch, s.WindowTransitions, s.IsUnstable)
+		if err != nil {
+			return fmt.Errorf("error saving liveness state of validator %v: %w", s.ValidatorIndex, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLivenessThresholds = livenessThresholds{
+	OfflineEpochs:       defaultOfflineEpochsThreshold,
+	OnlineEpochs:        defaultOnlineEpochsThreshold,
+	UnstableWindow:      defaultUnstableWindowEpochs,
+	UnstableTransitions: defaultUnstableTransitionsThreshold,
+}
+
+func TestLivenessTransition(t *testing.T) {
+	state := &validatorLivenessState{ValidatorIndex: 1, WindowStartEpoch: 100}
+
+	assert.Equal(t, livenessActionNotify, state.transition(true, 100, testLivenessThresholds))
+	assert.Equal(t, livenessActionNotify, state.transition(false, 102, testLivenessThresholds))
+	// the third transition inside the window flags the validator as unstable
+	assert.Equal(t, livenessActionUnstable, state.transition(true, 104, testLivenessThresholds))
+	assert.True(t, state.IsUnstable)
+	assert.Equal(t, uint64(3), state.WindowTransitions)
+
+	// further transitioned is suppressed
+	assert.Equal(t, livenessActionSuppress, state.transition(false, 106, testLivenessThresholds))
+	assert.True(t, state.IsUnstable)
+	assert.False(t, state.IsOffline)
+	assert.Equal(t, uint64(106), state.StateSinceEpoch)
+	assert.Equal(t, uint64(4), state.WindowTransitions)
+}
+
+func TestLivenessTransitionAfterWindow(t *testing.T) {
+	state := &validatorLivenessState{ValidatorIndex: 1, WindowStartEpoch: 100, WindowTransitions: 2, StateSinceEpoch: 105}
+
+	// the window is over, the transitions start counting again
+	assert.Equal(t, livenessActionNotify, state.transition(true, 110, testLivenessThresholds))
+	assert.Equal(t, uint64(110), state.WindowStartEpoch)
+	assert.Equal(t, uint64(1), state.WindowTransitions)
+
+	// an unstable validator that was stable for a whole window notifies normally again
+	state = &validatorLivenessState{ValidatorIndex: 1, IsUnstable: true, WindowStartEpoch: 100, WindowTransitions: 5, StateSinceEpoch: 100}
+	assert.Equal(t, livenessActionNotify, state.transition(true, 110, testLivenessThresholds))
+	assert.False(t, state.IsUnstable)
+	assert.Equal(t, uint64(1), state.WindowTransitions)
+}
+
+func TestLivenessHasSettled(t *testing.T) {
+	state := &validatorLivenessState{ValidatorIndex: 1, IsUnstable: true, StateSinceEpoch: 100}
+	assert.False(t, state.hasSettled(109, testLivenessThresholds))
+	assert.True(t, state.hasSettled(110, testLivenessThresholds))
+
+	state.IsUnstable = false
+	assert.False(t, state.hasSettled(110, testLivenessThresholds))
+}
+
+func TestSettleLivenessStates(t *testing.T) {
+	settling := &validatorLivenessState{ValidatorIndex: 1, IsUnstable: true, IsOffline: true, StateSinceEpoch: 100, WindowStartEpoch: 95, WindowTransitions: 4}
+	transitioned := &validatorLivenessState{ValidatorIndex: 2, IsUnstable: true, StateSinceEpoch: 100, WindowStartEpoch: 95, WindowTransitions: 4}
+	stable := &validatorLivenessState{ValidatorIndex: 3, StateSinceEpoch: 50}
+	states := map[uint64]*validatorLivenessState{1: settling, 2: transitioned, 3: stable}
+
+	// the validator transitions in the same epoch it would have settled in, it must only get the notification of the transition
+	assert.Equal(t, livenessActionNotify, transitioned.transition(true, 110, testLivenessThresholds))
+
+	settled := settleLivenessStates(states, 110, testLivenessThresholds)
+	require.Len(t, settled, 1)
+	assert.Same(t, settling, settled[0])
+	assert.False(t, settling.IsUnstable)
+	assert.True(t, settling.IsOffline)
+	assert.Equal(t, uint64(110), settling.WindowStartEpoch)
+	assert.Equal(t, uint64(0), settling.WindowTransitions)
+	assert.True(t, transitioned.IsOffline)
+	assert.Equal(t, uint64(1), transitioned.WindowTransitions)
+}
@@ -25,23 +25,35 @@ import (
 )
 
 func queueNotifications(epoch uint64, notificationsByUserID types.NotificationsPerUserId) error {
+	err := updateNotificationIncidents(epoch, notificationsByUserID)
+	if err != nil {
+		log.Error(err, "error updating notification incidents", 0)
+		metrics.Errors.WithLabelValues("notifications_update_incidents").Inc()
+	}
+
+	// snoozed notifications are not sent but still end up in the notification history
+	notificationsToSend, err := filterSnoozedNotifications(notificationsByUserID)
+	if err != nil {
+		return fmt.Errorf("error filtering snoozed notifications: %w", err)
+	}
+
 	tx, err := db.WriterDb.Beginx()
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %w", err)
 	}
 	defer utils.Rollback(tx)
 
-	err = QueueEmailNotifications(epoch, notificationsByUserID, tx)
+	err = QueueEmailNotifications(epoch, notificationsToSend, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing email notifications: %w", err)
 	}
 
-	err = QueuePushNotification(epoch, notificationsByUserID, tx)
+	err = QueuePushNotification(epoch, notificationsToSend, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing push notifications: %w", err)
 	}
 
-	err = QueueWebhookNotifications(notificationsByUserID, tx)
+	err = QueueWebhookNotifications(notificationsToSend, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing webhook notifications: %w", err)
 	}
@@ -56,26 +68,14 @@ func queueNotifications(epoch uint64, notificationsByUserID types.NotificationsP
 		log.Error(err, "error exporting notification historyw", 0)
 	}
 
-	subByEpoch := map[uint64][]uint64{}
-	for _, notificationsPerDashboard := range notificationsByUserID {
-		for _, notificationsPerGroup := range notificationsPerDashboard {
-			for _, events := range notificationsPerGroup {
-				for _, notifications := range events {
-					for _, n := range notifications {
-						e := n.GetEpoch()
-						if _, exists := subByEpoch[e]; !exists {
-							subByEpoch[e] = []uint64{n.GetSubscriptionID()}
-						} else {
-							subByEpoch[e] = append(subByEpoch[e], n.GetSubscriptionID())
-						}
-					}
-				}
-			}
-		}
+	err = updateValidatorDashboardRulesLastTriggered(notificationsByUserID)
+	if err != nil {
+		log.Error(err, "error updating last triggered epoch of validator dashboard rules", 0)
+		metrics.Errors.WithLabelValues("notifications_updating_rules_last_triggered").Inc()
 	}
 
 	// obsolete as notifications are anyway sent on a per-epoch basis
-	for epoch, subIDs := range subByEpoch {
+	for epoch, subIDs := range getSubscriptionIdsByEpoch(notificationsByUserID) {
 		// update that we've queued the subscription (last sent rather means last queued)
 		err := db.UpdateSubscriptionsLastSent(subIDs, time.Now(), epoch)
 		if err != nil {
@@ -390,6 +390,9 @@ func RenderEmailsForUserEvents(epoch uint64, notificationsByUserID types.Notific
 			case types.ValidatorGroupEfficiencyEventName:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d Group%s", types.EventLabel[event], count, plural))
+			case types.ValidatorDashboardRuleEventName:
+				//nolint:gosec // this is a static string
+				bodySummary += template.HTML(fmt.Sprintf("%s: %d Rule%s", types.EventLabel[event], count, plural))
 			default:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d Validator%s", types.EventLabel[event], count, plural))
@@ -519,12 +522,15 @@ func RenderPushMessagesForUserEvents(epoch uint64, notificationsByUserID types.N
 						bodySummary += fmt.Sprintf("%s: %d event%s", types.EventLabel[event], count, plural)
 					case types.EthClientUpdateEventName:
 						bodySummary += fmt.Sprintf("%s: %d client%s", types.EventLabel[event], count, plural)
-					case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName:
+					case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName,
+						types.MonitoringMachineNotSyncedEventName, types.MonitoringMachineElDisconnectedEventName, types.MonitoringMachineLowPeerCountEventName, types.MonitoringMachineHeadSlotLagEventName, types.MonitoringMachineFallbackInUseEventName, types.MonitoringMachineClientOutdatedEventName:
 						bodySummary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
 					case types.ValidatorExecutedProposalEventName:
 						bodySummary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
 					case types.ValidatorGroupEfficiencyEventName:
 						bodySummary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
+					case types.ValidatorDashboardRuleEventName:
+						bodySummary += fmt.Sprintf("%s: %d rule%s", types.EventLabel[event], count, plural)
 					default:
 						bodySummary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
 					}
@@ -635,6 +641,42 @@ func QueueTestPushNotification(ctx context.Context, userId types.UserId, userDbC
 	return err
 }
 
+// getSubscriptionIdsByEpoch returns the ids of the subscriptions of the notifications by their epoch.
+// Validator dashboard rule notifications are skipped, they have no subscription and their cooldown is tracked by the rules.
+func getSubscriptionIdsByEpoch(notificationsByUserID types.NotificationsPerUserId) map[uint64][]uint64 {
+	subByEpoch := map[uint64][]uint64{}
+	for _, notificationsPerDashboard := range notificationsByUserID {
+		for _, notificationsPerGroup := range notificationsPerDashboard {
+			for _, events := range notificationsPerGroup {
+				for event, notifications := range events {
+					if event == types.ValidatorDashboardRuleEventName {
+						continue
+					}
+					for _, n := range notifications {
+						e := n.GetEpoch()
+						subByEpoch[e] = append(subByEpoch[e], n.GetSubscriptionID())
+					}
+				}
+			}
+		}
+	}
+	return subByEpoch
+}
+
+// isWebhookSubscribed reports whether an account webhook receives the notifications of the event of the given dashboard.
+// Notifications of validator dashboards are sent to the webhooks of their groups, except for the rule notifications
+// which are also sent to the account webhooks subscribed to them.
+func isWebhookSubscribed(w types.UserWebhook, dashboardId types.DashboardId, event types.EventName) bool {
+	if dashboardId != 0 {
+		return event == types.ValidatorDashboardRuleEventName && slices.Contains(w.EventNames, string(event))
+	}
+	// if the user has enabled webhooks for validator offline also send the notifications for validator online & unstable
+	if (event == types.ValidatorIsOnlineEventName || event == types.ValidatorIsUnstableEventName) && slices.Contains(w.EventNames, string(types.ValidatorIsOfflineEventName)) {
+		return true
+	}
+	return slices.Contains(w.EventNames, string(event))
+}
+
 func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserId, tx *sqlx.Tx) error {
 	var webhooks []types.UserWebhook
 	userIds := slices.Collect(maps.Keys(notificationsByUserID))
@@ -710,98 +752,86 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 			for _, w := range webhooks {
 				for dashboardId, notificationsPerDashboard := range userNotifications {
 					for _, notificationsPerGroup := range notificationsPerDashboard {
-						if dashboardId != 0 {
-							continue
-						} else {
-							for event, notifications := range notificationsPerGroup {
-								// check if the webhook is subscribed to the type of event
-
-								// if the user has enabled webhooks for validator offline also send the notifications for validator online
-								if slices.Contains(w.EventNames, string(types.ValidatorIsOfflineEventName)) {
-									w.EventNames = append(w.EventNames, string(types.ValidatorIsOnlineEventName))
+						for event, notifications := range notificationsPerGroup {
+							// check if the webhook is subscribed to the type of event
+							if !isWebhookSubscribed(w, dashboardId, event) {
+								continue
+							}
+							if len(notifications) > 0 {
+								// reset Retries
+								if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
+									_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = 0 WHERE id = $1;`, w.ID)
+									if err != nil {
+										log.Error(err, "error updating users_webhooks table; setting retries to zero", 0)
+										continue
+									}
+								} else if w.Retries > 5 && !w.LastSent.Valid {
+									log.Warnf("webhook '%v' has more than 5 retries and does not have a valid last_sent timestamp", w.Url)
+									continue
 								}
 
-								eventSubscribed := slices.Contains(w.EventNames, string(event))
-
-								if !eventSubscribed {
+								if w.Retries >= 5 {
+									// early return
 									continue
 								}
-								if len(notifications) > 0 {
-									// reset Retries
-									if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
-										_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = 0 WHERE id = $1;`, w.ID)
-										if err != nil {
-											log.Error(err, "error updating users_webhooks table; setting retries to zero", 0)
-											continue
-										}
-									} else if w.Retries > 5 && !w.LastSent.Valid {
-										log.Warnf("webhook '%v' has more than 5 retries and does not have a valid last_sent timestamp", w.Url)
-										continue
-									}
+							}
 
-									if w.Retries >= 5 {
-										// early return
-										continue
+							for _, n := range notifications {
+								if w.Destination.Valid && w.Destination.String == "webhook_discord" {
+									if _, exists := discordNotifMap[w.ID]; !exists {
+										discordNotifMap[w.ID] = make([]types.TransitDiscordContent, 0)
+									}
+									l_notifs := len(discordNotifMap[w.ID])
+									if l_notifs == 0 || len(discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds) >= 10 {
+										discordNotifMap[w.ID] = append(discordNotifMap[w.ID], types.TransitDiscordContent{
+											Webhook: w,
+											DiscordRequest: types.DiscordReq{
+												Username: utils.Config.Frontend.SiteDomain,
+											},
+											UserId: userID,
+										})
+										l_notifs++
 									}
-								}
 
-								for _, n := range notifications {
-									if w.Destination.Valid && w.Destination.String == "webhook_discord" {
-										if _, exists := discordNotifMap[w.ID]; !exists {
-											discordNotifMap[w.ID] = make([]types.TransitDiscordContent, 0)
-										}
-										l_notifs := len(discordNotifMap[w.ID])
-										if l_notifs == 0 || len(discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds) >= 10 {
-											discordNotifMap[w.ID] = append(discordNotifMap[w.ID], types.TransitDiscordContent{
-												Webhook: w,
-												DiscordRequest: types.DiscordReq{
-													Username: utils.Config.Frontend.SiteDomain,
-												},
-												UserId: userID,
-											})
-											l_notifs++
-										}
+									fields := []types.DiscordEmbedField{
+										{
+											Name:   "Epoch",
+											Value:  fmt.Sprintf("[%[1]v](https://%[2]s/%[1]v)", n.GetEpoch(), utils.Config.Frontend.SiteDomain+"/epoch"),
+											Inline: false,
+										},
+									}
 
-										fields := []types.DiscordEmbedField{
-											{
-												Name:   "Epoch",
-												Value:  fmt.Sprintf("[%[1]v](https://%[2]s/%[1]v)", n.GetEpoch(), utils.Config.Frontend.SiteDomain+"/epoch"),
+									if strings.HasPrefix(string(n.GetEventName()), "monitoring") || n.GetEventName() == types.EthClientUpdateEventName || n.GetEventName() == types.RocketpoolCollateralMaxReachedEventName || n.GetEventName() == types.RocketpoolCollateralMinReachedEventName {
+										fields = append(fields,
+											types.DiscordEmbedField{
+												Name:   "Target",
+												Value:  fmt.Sprintf("%v", n.GetEventFilter()),
 												Inline: false,
-											},
-										}
-
-										if strings.HasPrefix(string(n.GetEventName()), "monitoring") || n.GetEventName() == types.EthClientUpdateEventName || n.GetEventName() == types.RocketpoolCollateralMaxReachedEventName || n.GetEventName() == types.RocketpoolCollateralMinReachedEventName {
-											fields = append(fields,
-												types.DiscordEmbedField{
-													Name:   "Target",
-													Value:  fmt.Sprintf("%v", n.GetEventFilter()),
-													Inline: false,
-												})
-										}
-										discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds = append(discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds, types.DiscordEmbed{
-											Type:        "rich",
-											Color:       "16745472",
-											Description: n.GetLegacyInfo(),
-											Title:       n.GetLegacyTitle(),
-											Fields:      fields,
-										})
-									} else {
-										notifs = append(notifs, types.TransitWebhook{
-											Channel: w.Destination.String,
-											Content: types.TransitWebhookContent{
-												Webhook: w,
-												Event: &types.WebhookEvent{
-													Network:     utils.GetNetwork(),
-													Name:        string(n.GetEventName()),
-													Title:       n.GetLegacyTitle(),
-													Description: n.GetLegacyInfo(),
-													Epoch:       n.GetEpoch(),
-													Target:      n.GetEventFilter(),
-												},
-												UserId: userID,
-											},
-										})
+											})
 									}
+									discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds = append(discordNotifMap[w.ID][l_notifs-1].DiscordRequest.Embeds, types.DiscordEmbed{
+										Type:        "rich",
+										Color:       "16745472",
+										Description: n.GetLegacyInfo(),
+										Title:       n.GetLegacyTitle(),
+										Fields:      fields,
+									})
+								} else {
+									notifs = append(notifs, types.TransitWebhook{
+										Channel: w.Destination.String,
+										Content: types.TransitWebhookContent{
+											Webhook: w,
+											Event: &types.WebhookEvent{
+												Network:     utils.GetNetwork(),
+												Name:        string(n.GetEventName()),
+												Title:       n.GetLegacyTitle(),
+												Description: n.GetLegacyInfo(),
+												Epoch:       n.GetEpoch(),
+												Target:      n.GetEventFilter(),
+											},
+											UserId: userID,
+										},
+									})
 								}
 							}
 						}
@@ -893,12 +923,15 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 							summary += fmt.Sprintf("%s: %d event%s", types.EventLabel[event], count, plural)
 						case types.EthClientUpdateEventName:
 							summary += fmt.Sprintf("%s: %d client%s", types.EventLabel[event], count, plural)
-						case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName:
+						case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName,
+							types.MonitoringMachineNotSyncedEventName, types.MonitoringMachineElDisconnectedEventName, types.MonitoringMachineLowPeerCountEventName, types.MonitoringMachineHeadSlotLagEventName, types.MonitoringMachineFallbackInUseEventName, types.MonitoringMachineClientOutdatedEventName:
 							summary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
 						case types.ValidatorExecutedProposalEventName:
 							summary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
 						case types.ValidatorGroupEfficiencyEventName:
 							summary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
+						case types.ValidatorDashboardRuleEventName:
+							summary += fmt.Sprintf("%s: %d rule%s", types.EventLabel[event], count, plural)
 						default:
 							summary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
 						}
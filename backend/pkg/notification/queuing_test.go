@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueueDB answers queries with the rows of the first matching query substring and records the executed statements
+type fakeQueueDB struct {
+	mutex sync.Mutex
+	rows  map[string]*fakeQueueRows
+	execs []fakeQueueExec
+}
+
+type fakeQueueExec struct {
+	query string
+	args  []driver.Value
+}
+
+func newFakeQueueDB(t *testing.T, rows map[string]*fakeQueueRows) (*sqlx.DB, *fakeQueueDB) {
+	fake := &fakeQueueDB{rows: rows}
+	conn := sqlx.NewDb(sql.OpenDB(fake), "postgres")
+	t.Cleanup(func() { conn.Close() })
+	return conn, fake
+}
+
+func (f *fakeQueueDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeQueueConn{db: f}, nil
+}
+
+func (f *fakeQueueDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeQueueConn struct {
+	db *fakeQueueDB
+}
+
+func (c *fakeQueueConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeQueueConn) Close() error {
+	return nil
+}
+
+func (c *fakeQueueConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeQueueConn) Commit() error {
+	return nil
+}
+
+func (c *fakeQueueConn) Rollback() error {
+	return nil
+}
+
+func (c *fakeQueueConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mutex.Lock()
+	defer c.db.mutex.Unlock()
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.db.execs = append(c.db.execs, fakeQueueExec{query: query, args: values})
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeQueueConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	for match, rows := range c.db.rows {
+		if strings.Contains(query, match) {
+			return &fakeQueueRows{columns: rows.columns, values: rows.values}, nil
+		}
+	}
+	return &fakeQueueRows{}, nil
+}
+
+type fakeQueueRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeQueueRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeQueueRows) Close() error {
+	return nil
+}
+
+func (r *fakeQueueRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestGetSubscriptionIdsByEpoch(t *testing.T) {
+	notificationsByUserID := types.NotificationsPerUserId{}
+	notificationsByUserID.AddNotification(&ValidatorIsOfflineNotification{
+		NotificationBaseImpl: types.NotificationBaseImpl{UserID: 1, SubscriptionID: 5, Epoch: 100, EventName: types.ValidatorIsOfflineEventName, EventFilter: "1"},
+	})
+	notificationsByUserID.AddNotification(&ValidatorIsOfflineNotification{
+		NotificationBaseImpl: types.NotificationBaseImpl{UserID: 2, SubscriptionID: 6, Epoch: 100, EventName: types.ValidatorIsOfflineEventName, EventFilter: "2"},
+	})
+	// rule notifications have no subscription
+	notificationsByUserID.AddNotification(newTestRuleNotification(1, 10, 0, 1, 100))
+
+	subByEpoch := getSubscriptionIdsByEpoch(notificationsByUserID)
+	assert.Len(t, subByEpoch, 1)
+	assert.ElementsMatch(t, []uint64{5, 6}, subByEpoch[100])
+}
+
+func TestIsWebhookSubscribed(t *testing.T) {
+	w := types.UserWebhook{EventNames: []string{string(types.ValidatorIsOfflineEventName), string(types.ValidatorDashboardRuleEventName)}}
+
+	assert.True(t, isWebhookSubscribed(w, 0, types.ValidatorIsOfflineEventName))
+	assert.True(t, isWebhookSubscribed(w, 0, types.ValidatorIsOnlineEventName))
+	assert.False(t, isWebhookSubscribed(w, 0, types.ValidatorExecutedProposalEventName))
+	assert.True(t, isWebhookSubscribed(w, 10, types.ValidatorDashboardRuleEventName))
+	// the other notifications of a dashboard are sent to the webhooks of its groups
+	assert.False(t, isWebhookSubscribed(w, 10, types.ValidatorIsOfflineEventName))
+	assert.False(t, isWebhookSubscribed(types.UserWebhook{}, 10, types.ValidatorDashboardRuleEventName))
+}
+
+func TestQueueWebhookNotificationsRule(t *testing.T) {
+	previousConfig := utils.Config
+	utils.Config = &types.Config{}
+	t.Cleanup(func() { utils.Config = previousConfig })
+
+	previousFrontendWriterDB, previousReaderDb := db.FrontendWriterDB, db.ReaderDb
+	t.Cleanup(func() { db.FrontendWriterDB, db.ReaderDb = previousFrontendWriterDB, previousReaderDb })
+	conn, fake := newFakeQueueDB(t, map[string]*fakeQueueRows{
+		"users_webhooks": {
+			columns: []string{"id", "user_id", "url", "retries", "event_names", "last_sent", "destination"},
+			values:  [][]driver.Value{{int64(1), int64(1), "https://example.com", int64(0), fmt.Sprintf("{%s}", types.ValidatorDashboardRuleEventName), nil, "webhook"}},
+		},
+	})
+	db.FrontendWriterDB, db.ReaderDb = conn, conn
+
+	notificationsByUserID := types.NotificationsPerUserId{}
+	notificationsByUserID.AddNotification(newTestRuleNotification(1, 10, 0, 1, 100))
+
+	tx, err := conn.Beginx()
+	require.NoError(t, err)
+	require.NoError(t, QueueWebhookNotifications(notificationsByUserID, tx))
+
+	require.Len(t, fake.execs, 1)
+	assert.Contains(t, fake.execs[0].query, "INSERT INTO notification_queue")
+	require.Len(t, fake.execs[0].args, 1)
+	content, ok := fake.execs[0].args[0].([]byte)
+	require.True(t, ok)
+	assert.Contains(t, string(content), string(types.ValidatorDashboardRuleEventName))
+}
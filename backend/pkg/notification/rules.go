@@ -0,0 +1,353 @@
+package notification
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// validatorDashboardRule is a user defined notification rule as stored in the users_val_dashboards_notification_rules table
+type validatorDashboardRule struct {
+	Id                 uint64                           `db:"id"`
+	UserId             types.UserId                     `db:"user_id"`
+	DashboardId        int64                            `db:"dashboard_id"`
+	DashboardName      string                           `db:"dashboard_name"`
+	GroupId            int64                            `db:"group_id"`
+	GroupName          string                           `db:"group_name"`
+	Name               string                           `db:"name"`
+	Metric             types.NotificationRuleMetric     `db:"metric"`
+	Comparison         types.NotificationRuleComparison `db:"comparison"`
+	Threshold          float64                          `db:"threshold"`
+	WindowEpochs       uint64                           `db:"window_epochs"`
+	CooldownEpochs     uint64                           `db:"cooldown_epochs"`
+	LastTriggeredEpoch sql.NullInt64                    `db:"last_triggered_epoch"`
+
+	Validators []uint64 `db:"-"`
+}
+
+// isCoolingDown reports whether the rule has been triggered too recently to be triggered again in the given epoch
+func (r *validatorDashboardRule) isCoolingDown(epoch uint64) bool {
+	if !r.LastTriggeredEpoch.Valid {
+		return false
+	}
+	lastTriggered := uint64(r.LastTriggeredEpoch.Int64)
+	return epoch <= lastTriggered || epoch-lastTriggered <= r.CooldownEpochs
+}
+
+// validatorDashboardRuleMetrics holds the duty data of a set of validators summed up over a window of epochs
+type validatorDashboardRuleMetrics struct {
+	AttestationsReward      decimal.Decimal `db:"attestations_reward"`
+	AttestationsIdealReward decimal.Decimal `db:"attestations_ideal_reward"`
+	AttestationsScheduled   uint64          `db:"attestations_scheduled"`
+	AttestationsExecuted    uint64          `db:"attestations_executed"`
+	BlocksScheduled         uint64          `db:"blocks_scheduled"`
+	BlocksProposed          uint64          `db:"blocks_proposed"`
+	BlocksExpected          float64         `db:"blocks_expected"`
+	SyncScheduled           uint64          `db:"sync_scheduled"`
+	SyncExecuted            uint64          `db:"sync_executed"`
+	BalanceStart            int64           `db:"balance_start"`
+	BalanceEnd              int64           `db:"balance_end"`
+}
+
+func (m *validatorDashboardRuleMetrics) add(o *validatorDashboardRuleMetrics) {
+	m.AttestationsReward = m.AttestationsReward.Add(o.AttestationsReward)
+	m.AttestationsIdealReward = m.AttestationsIdealReward.Add(o.AttestationsIdealReward)
+	m.AttestationsScheduled += o.AttestationsScheduled
+	m.AttestationsExecuted += o.AttestationsExecuted
+	m.BlocksScheduled += o.BlocksScheduled
+	m.BlocksProposed += o.BlocksProposed
+	m.BlocksExpected += o.BlocksExpected
+	m.SyncScheduled += o.SyncScheduled
+	m.SyncExecuted += o.SyncExecuted
+	m.BalanceStart += o.BalanceStart
+	m.BalanceEnd += o.BalanceEnd
+}
+
+// value returns the value of the given metric, ok is false if the metric is undefined for the window (e.g. no sync duties)
+func (m *validatorDashboardRuleMetrics) value(metric types.NotificationRuleMetric) (value float64, ok bool) {
+	switch metric {
+	case types.NotificationRuleMetricEfficiency:
+		var attestationEfficiency, proposerEfficiency, syncEfficiency sql.NullFloat64
+		if !m.AttestationsIdealReward.IsZero() {
+			attestationEfficiency.Float64 = m.AttestationsReward.Div(m.AttestationsIdealReward).InexactFloat64()
+			attestationEfficiency.Valid = true
+		}
+		if m.BlocksScheduled > 0 {
+			proposerEfficiency.Float64 = float64(m.BlocksProposed) / float64(m.BlocksScheduled)
+			proposerEfficiency.Valid = true
+		}
+		if m.SyncScheduled > 0 {
+			syncEfficiency.Float64 = float64(m.SyncExecuted) / float64(m.SyncScheduled)
+			syncEfficiency.Valid = true
+		}
+		if !attestationEfficiency.Valid && !proposerEfficiency.Valid && !syncEfficiency.Valid {
+			return 0, false
+		}
+		return utils.CalculateTotalEfficiency(attestationEfficiency, proposerEfficiency, syncEfficiency) / 100, true
+	case types.NotificationRuleMetricMissedAttestations:
+		if m.AttestationsExecuted > m.AttestationsScheduled {
+			return 0, true
+		}
+		return float64(m.AttestationsScheduled - m.AttestationsExecuted), true
+	case types.NotificationRuleMetricBalanceDelta:
+		return utils.GWeiToEther(big.NewInt(m.BalanceEnd - m.BalanceStart)).InexactFloat64(), true
+	case types.NotificationRuleMetricProposalLuck:
+		if m.BlocksExpected == 0 {
+			return 0, false
+		}
+		return float64(m.BlocksProposed) / m.BlocksExpected, true
+	case types.NotificationRuleMetricSyncParticipation:
+		if m.SyncScheduled == 0 {
+			return 0, false
+		}
+		return float64(m.SyncExecuted) / float64(m.SyncScheduled), true
+	default:
+		return 0, false
+	}
+}
+
+// collectValidatorDashboardRuleNotifications evaluates the user defined rules of all validator dashboard groups
+// against the dashboard data exported to clickhouse and creates a notification for every matching rule
+func collectValidatorDashboardRuleNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	rules, err := getValidatorDashboardRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		log.Info("no validator dashboard rules defined, skipping")
+		return nil
+	}
+
+	// the dashboard data exporter might lag behind, only evaluate rules over epochs that have already been exported
+	var latestExportedTs time.Time
+	err = db.ClickHouseReader.Get(&latestExportedTs, "SELECT MAX(t) FROM view_validator_dashboard_data_epoch_max_ts")
+	if err != nil {
+		return fmt.Errorf("error getting latest exported dashboard data epoch: %w", err)
+	}
+	latestExportedEpoch := utils.TimeToEpoch(latestExportedTs)
+	if latestExportedEpoch < 0 {
+		return nil
+	}
+	endEpoch := min(epoch, uint64(latestExportedEpoch))
+
+	// users that enabled do not disturb mode do not receive any notifications
+	var mutedUserIds []types.UserId
+	err = db.FrontendWriterDB.Select(&mutedUserIds, `SELECT id FROM users WHERE notifications_do_not_disturb_ts >= NOW()`)
+	if err != nil {
+		return fmt.Errorf("error getting users with do not disturb enabled: %w", err)
+	}
+	mutedUsers := make(map[types.UserId]struct{}, len(mutedUserIds))
+	for _, userId := range mutedUserIds {
+		mutedUsers[userId] = struct{}{}
+	}
+
+	// rules sharing the same window are evaluated on the same data
+	rulesByWindow := make(map[uint64][]*validatorDashboardRule)
+	for _, rule := range rules {
+		if _, ok := mutedUsers[rule.UserId]; ok || len(rule.Validators) == 0 || rule.isCoolingDown(endEpoch) {
+			continue
+		}
+		rulesByWindow[rule.WindowEpochs] = append(rulesByWindow[rule.WindowEpochs], rule)
+	}
+
+	triggeredRules := 0
+	for window, windowRules := range rulesByWindow {
+		validators := make([]uint64, 0)
+		for _, rule := range windowRules {
+			validators = append(validators, rule.Validators...)
+		}
+		startEpoch := uint64(0)
+		if endEpoch+1 > window {
+			startEpoch = endEpoch + 1 - window
+		}
+		metricsByValidator, err := getValidatorDashboardRuleMetrics(validators, startEpoch, endEpoch)
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range windowRules {
+			groupMetrics := &validatorDashboardRuleMetrics{}
+			for _, validator := range rule.Validators {
+				if m, ok := metricsByValidator[validator]; ok {
+					groupMetrics.add(m)
+				}
+			}
+			value, ok := groupMetrics.value(rule.Metric)
+			if !ok || !rule.Comparison.Matches(value, rule.Threshold) {
+				continue
+			}
+
+			log.Infof("creating validator dashboard rule notification for user %v, dashboard %v, group %v, rule %v in epoch %v", rule.UserId, rule.DashboardId, rule.GroupId, rule.Id, endEpoch)
+			n := &ValidatorDashboardRuleNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					UserID:             rule.UserId,
+					Epoch:              epoch,
+					EventName:          types.ValidatorDashboardRuleEventName,
+					EventFilter:        fmt.Sprintf("%d", rule.Id),
+					DashboardId:        &rule.DashboardId,
+					DashboardName:      rule.DashboardName,
+					DashboardGroupId:   &rule.GroupId,
+					DashboardGroupName: rule.GroupName,
+				},
+				RuleId:         rule.Id,
+				RuleName:       rule.Name,
+				Metric:         rule.Metric,
+				Comparison:     rule.Comparison,
+				Threshold:      rule.Threshold,
+				Value:          value,
+				WindowEpochs:   rule.WindowEpochs,
+				TriggeredEpoch: endEpoch,
+			}
+			notificationsByUserID.AddNotification(n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+			triggeredRules++
+		}
+	}
+
+	log.Infof("done collecting validator dashboard rule notifications, %d of %d rules triggered", triggeredRules, len(rules))
+
+	return nil
+}
+
+// getTriggeredValidatorDashboardRules returns the ids of the rules of the validator dashboard rule notifications by their triggered epoch
+func getTriggeredValidatorDashboardRules(notificationsByUserID types.NotificationsPerUserId) map[uint64][]uint64 {
+	ruleIdsByEpoch := make(map[uint64][]uint64)
+	for _, notificationsPerDashboard := range notificationsByUserID {
+		for _, notificationsPerGroup := range notificationsPerDashboard {
+			for _, events := range notificationsPerGroup {
+				for _, n := range events[types.ValidatorDashboardRuleEventName] {
+					if rn, ok := n.(*ValidatorDashboardRuleNotification); ok {
+						ruleIdsByEpoch[rn.TriggeredEpoch] = append(ruleIdsByEpoch[rn.TriggeredEpoch], rn.RuleId)
+					}
+				}
+			}
+		}
+	}
+	return ruleIdsByEpoch
+}
+
+// updateValidatorDashboardRulesLastTriggered starts the cooldown of the rules whose notifications have been queued,
+// rules of notifications that failed to queue are evaluated again in the next epoch
+func updateValidatorDashboardRulesLastTriggered(notificationsByUserID types.NotificationsPerUserId) error {
+	for epoch, ruleIds := range getTriggeredValidatorDashboardRules(notificationsByUserID) {
+		_, err := db.AlloyWriter.Exec(`UPDATE users_val_dashboards_notification_rules SET last_triggered_epoch = $1 WHERE id = ANY($2)`, epoch, pq.Array(ruleIds))
+		if err != nil {
+			return fmt.Errorf("error updating last triggered epoch of validator dashboard rules: %w", err)
+		}
+	}
+	return nil
+}
+
+// getValidatorDashboardRules returns all rules of the current network together with the validators of their group
+func getValidatorDashboardRules() ([]*validatorDashboardRule, error) {
+	rules := []*validatorDashboardRule{}
+	err := db.AlloyWriter.Select(&rules, `
+		SELECT
+			r.id,
+			d.user_id,
+			r.dashboard_id,
+			d.name AS dashboard_name,
+			r.group_id,
+			g.name AS group_name,
+			r.name,
+			r.metric,
+			r.comparison,
+			r.threshold,
+			r.window_epochs,
+			r.cooldown_epochs,
+			r.last_triggered_epoch
+		FROM users_val_dashboards_notification_rules r
+		INNER JOIN users_val_dashboards d ON d.id = r.dashboard_id
+		INNER JOIN users_val_dashboards_groups g ON g.dashboard_id = r.dashboard_id AND g.id = r.group_id
+		WHERE d.network = $1`, utils.Config.Chain.ClConfig.DepositChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator dashboard rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return rules, nil
+	}
+
+	dashboardIds := make([]int64, 0, len(rules))
+	for _, rule := range rules {
+		dashboardIds = append(dashboardIds, rule.DashboardId)
+	}
+	type validatorRow struct {
+		DashboardId    int64  `db:"dashboard_id"`
+		GroupId        int64  `db:"group_id"`
+		ValidatorIndex uint64 `db:"validator_index"`
+	}
+	var validatorRows []validatorRow
+	err = db.AlloyWriter.Select(&validatorRows, `
+		SELECT dashboard_id, group_id, validator_index
+		FROM users_val_dashboards_validators
+		WHERE dashboard_id = ANY($1)`, pq.Array(dashboardIds))
+	if err != nil {
+		return nil, fmt.Errorf("error getting validators of validator dashboard rules: %w", err)
+	}
+	validatorsByGroup := make(map[int64]map[int64][]uint64)
+	for _, row := range validatorRows {
+		if _, ok := validatorsByGroup[row.DashboardId]; !ok {
+			validatorsByGroup[row.DashboardId] = make(map[int64][]uint64)
+		}
+		validatorsByGroup[row.DashboardId][row.GroupId] = append(validatorsByGroup[row.DashboardId][row.GroupId], row.ValidatorIndex)
+	}
+	for _, rule := range rules {
+		rule.Validators = validatorsByGroup[rule.DashboardId][rule.GroupId]
+	}
+	return rules, nil
+}
+
+// getValidatorDashboardRuleMetrics sums up the dashboard data of the given validators over the epochs [startEpoch, endEpoch]
+func getValidatorDashboardRuleMetrics(validators []uint64, startEpoch, endEpoch uint64) (map[uint64]*validatorDashboardRuleMetrics, error) {
+	type dbResult struct {
+		ValidatorIndex uint64 `db:"validator_index"`
+		validatorDashboardRuleMetrics
+	}
+	var queryResult []*dbResult
+
+	ds := goqu.Dialect("postgres").
+		From(goqu.L("validator_dashboard_data_epoch AS r")).
+		Select(
+			goqu.L("validator_index"),
+			goqu.L("SUM(COALESCE(r.attestations_reward, 0)) AS attestations_reward"),
+			goqu.L("SUM(COALESCE(r.attestations_ideal_reward, 0)) AS attestations_ideal_reward"),
+			goqu.L("SUM(COALESCE(r.attestations_scheduled, 0)) AS attestations_scheduled"),
+			goqu.L("SUM(COALESCE(r.attestations_executed, 0)) AS attestations_executed"),
+			goqu.L("SUM(COALESCE(r.blocks_scheduled, 0)) AS blocks_scheduled"),
+			goqu.L("SUM(COALESCE(r.blocks_proposed, 0)) AS blocks_proposed"),
+			goqu.L("SUM(COALESCE(r.blocks_expected, 0)) AS blocks_expected"),
+			goqu.L("SUM(COALESCE(r.sync_scheduled, 0)) AS sync_scheduled"),
+			goqu.L("SUM(COALESCE(r.sync_executed, 0)) AS sync_executed"),
+			goqu.L("COALESCE(argMin(r.balance_start, r.epoch), 0) AS balance_start"),
+			goqu.L("COALESCE(argMax(r.balance_end, r.epoch), 0) AS balance_end")).
+		Where(
+			goqu.L("r.epoch_timestamp >= fromUnixTimestamp(?)", utils.EpochToTime(startEpoch).Unix()),
+			goqu.L("r.epoch_timestamp <= fromUnixTimestamp(?)", utils.EpochToTime(endEpoch).Unix()),
+			goqu.L("r.validator_index IN ?", validators)).
+		GroupBy(goqu.L("validator_index"))
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+	err = db.ClickHouseReader.Select(&queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validator dashboard rule metrics for epochs %v-%v: %w", startEpoch, endEpoch, err)
+	}
+
+	result := make(map[uint64]*validatorDashboardRuleMetrics, len(queryResult))
+	for _, row := range queryResult {
+		result[row.ValidatorIndex] = &row.validatorDashboardRuleMetrics
+	}
+	return result, nil
+}
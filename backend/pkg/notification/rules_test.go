@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRuleNotification(userId types.UserId, dashboardId, groupId int64, ruleId, triggeredEpoch uint64) *ValidatorDashboardRuleNotification {
+	return &ValidatorDashboardRuleNotification{
+		NotificationBaseImpl: types.NotificationBaseImpl{
+			UserID:           userId,
+			EventName:        types.ValidatorDashboardRuleEventName,
+			EventFilter:      fmt.Sprintf("%d", ruleId),
+			DashboardId:      &dashboardId,
+			DashboardGroupId: &groupId,
+		},
+		RuleId:         ruleId,
+		TriggeredEpoch: triggeredEpoch,
+	}
+}
+
+func TestGetTriggeredValidatorDashboardRules(t *testing.T) {
+	notificationsByUserID := types.NotificationsPerUserId{}
+	notificationsByUserID.AddNotification(newTestRuleNotification(1, 10, 0, 1, 100))
+	notificationsByUserID.AddNotification(newTestRuleNotification(1, 10, 1, 2, 100))
+	notificationsByUserID.AddNotification(newTestRuleNotification(2, 20, 0, 3, 99))
+	// other notifications do not start a cooldown
+	notificationsByUserID.AddNotification(&ValidatorIsOfflineNotification{
+		NotificationBaseImpl: types.NotificationBaseImpl{UserID: 2, EventName: types.ValidatorIsOfflineEventName, EventFilter: "1"},
+	})
+
+	ruleIdsByEpoch := getTriggeredValidatorDashboardRules(notificationsByUserID)
+	assert.Len(t, ruleIdsByEpoch, 2)
+	assert.ElementsMatch(t, []uint64{1, 2}, ruleIdsByEpoch[100])
+	assert.ElementsMatch(t, []uint64{3}, ruleIdsByEpoch[99])
+
+	assert.Empty(t, getTriggeredValidatorDashboardRules(types.NotificationsPerUserId{}))
+}
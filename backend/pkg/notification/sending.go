@@ -8,7 +8,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
@@ -19,7 +18,6 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/jmoiron/sqlx"
-	"github.com/lib/pq"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -31,6 +29,12 @@ const NOTIFICATION_TEST_EMAIL_RATE_LIMIT_BUCKET = "n_test_mails"
 
 func InitNotificationSender() {
 	log.Infof("starting notifications-sender")
+	for _, channel := range []Channel{&emailChannel{}, &pushChannel{}, &webhookChannel{}, &discordChannel{}} {
+		err := RegisterChannel(channel)
+		if err != nil {
+			log.Fatal(err, "error registering notification channel", 0)
+		}
+	}
 	go notificationSender()
 }
 
@@ -121,366 +125,355 @@ func garbageCollectNotificationQueue() error {
 	return nil
 }
 
-func dispatchNotifications() error {
-	err := sendEmailNotifications()
-	if err != nil {
-		return fmt.Errorf("error sending email notifications, err: %w", err)
-	}
-
-	err = sendPushNotifications()
-	if err != nil {
-		return fmt.Errorf("error sending push notifications, err: %w", err)
-	}
+type emailChannel struct{}
 
-	err = sendWebhookNotifications()
-	if err != nil {
-		return fmt.Errorf("error sending webhook notifications, err: %w", err)
-	}
+func (*emailChannel) Name() types.NotificationChannel {
+	return types.EmailNotificationChannel
+}
 
-	err = sendDiscordNotifications()
+func (*emailChannel) Render(item QueueItem) (*Message, error) {
+	var content types.TransitEmailContent
+	err := content.Scan(item.Content)
 	if err != nil {
-		return fmt.Errorf("error sending webhook discord notifications, err: %w", err)
+		return nil, err
 	}
-
-	return nil
+	return &Message{UserId: content.UserId, Size: 1, Payload: content}, nil
 }
 
-func sendEmailNotifications() error {
-	var notificationQueueItem []types.TransitEmail
-
-	err := db.WriterDb.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'email' ORDER BY created ASC`)
+func (*emailChannel) RateLimit(ctx context.Context, userId types.UserId) (string, int64) {
+	emailNotificationsPerDay := uint64(10)
+	userInfo, err := db.GetUserInfo(ctx, uint64(userId), db.FrontendReaderDB)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		log.Error(err, "error getting user info", 0)
+	} else {
+		emailNotificationsPerDay = userInfo.PremiumPerks.EmailNotificationsPerDay
 	}
+	return NOTIFICAION_EMAIL_RATE_LIMIT_BUCKET, int64(emailNotificationsPerDay)
+}
 
-	log.Infof("processing %v email notifications", len(notificationQueueItem))
-
-	for _, n := range notificationQueueItem {
-		userInfo, err := db.GetUserInfo(context.Background(), uint64(n.Content.UserId), db.FrontendReaderDB)
-		emailNotificationsPerDay := uint64(10)
+func (*emailChannel) Send(ctx context.Context, messages []*Message) []SendResult {
+	results := make([]SendResult, len(messages))
+	for i, m := range messages {
+		content := m.Payload.(types.TransitEmailContent)
+		err := mail.SendHTMLMail(content.Address, content.Subject, content.Email, content.Attachments)
 		if err != nil {
-			log.Error(err, "error getting user info", 0)
-		} else {
-			emailNotificationsPerDay = userInfo.PremiumPerks.EmailNotificationsPerDay
+			results[i] = SendResult{Status: SendStatusFailed, Label: "error", Err: err}
+			continue
 		}
-		err = mail.SendMailRateLimited(n.Content, int64(emailNotificationsPerDay), NOTIFICAION_EMAIL_RATE_LIMIT_BUCKET)
-		if err != nil {
-			if !strings.Contains(err.Error(), "rate limit has been exceeded") {
-				metrics.Errors.WithLabelValues("notifications_send_email").Inc()
-				log.Error(err, "error sending email notification", 0)
-			} else {
-				metrics.NotificationsSent.WithLabelValues("email", "429").Inc()
+		results[i] = SendResult{Status: SendStatusSent, Label: "200"}
+
+		// make sure the threshold reached email arrives last
+		if m.SentToday == m.RateLimit {
+			err = mail.SendThresholdReachedMail(content.Address, m.RateLimit)
+			if err != nil {
+				log.Error(err, "error sending email notification threshold reached mail", 0)
 			}
-		} else {
-			metrics.NotificationsSent.WithLabelValues("email", "200").Inc()
-		}
-		_, err = db.WriterDb.Exec(`UPDATE notification_queue set sent = now() where id = $1`, n.Id)
-		if err != nil {
-			return fmt.Errorf("error updating sent status for email notification with id: %v, err: %w", n.Id, err)
 		}
 	}
+	return results
+}
+
+func (*emailChannel) Ack(ctx context.Context, messages []*Message, results []SendResult) error {
 	return nil
 }
 
-func sendPushNotifications() error {
-	var notificationQueueItem []types.TransitPush
+type pushChannel struct{}
 
-	err := db.WriterDb.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'push' ORDER BY created ASC`)
+func (*pushChannel) Name() types.NotificationChannel {
+	return types.PushNotificationChannel
+}
+
+func (*pushChannel) Render(item QueueItem) (*Message, error) {
+	var content types.TransitPushContent
+	err := content.Scan(item.Content)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return nil, err
 	}
+	return &Message{UserId: content.UserId, Size: len(content.Messages), Payload: content}, nil
+}
 
-	log.Infof("processing %v push notifications", len(notificationQueueItem))
+// push messages are counted per firebase message by SendPushBatch
+func (*pushChannel) RateLimit(ctx context.Context, userId types.UserId) (string, int64) {
+	return "", 0
+}
 
+func (*pushChannel) Send(ctx context.Context, messages []*Message) []SendResult {
+	results := make([]SendResult, len(messages))
 	batchSize := 500
-	for _, n := range notificationQueueItem {
-		for b := 0; b < len(n.Content.Messages); b += batchSize {
-			start := b
-			end := b + batchSize
-			if len(n.Content.Messages) < end {
-				end = len(n.Content.Messages)
-			}
-
-			err = SendPushBatch(n.Content.UserId, n.Content.Messages[start:end], false)
-			if err != nil {
-				metrics.Errors.WithLabelValues("notifications_send_push_batch").Inc()
-				log.Error(err, "error sending firebase batch job", 0)
-			} else {
-				metrics.NotificationsSent.WithLabelValues("push", "200").Add(float64(len(n.Content.Messages)))
-			}
-
-			_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+	for i, m := range messages {
+		content := m.Payload.(types.TransitPushContent)
+		results[i] = SendResult{Status: SendStatusSent, Label: "200"}
+		for b := 0; b < len(content.Messages); b += batchSize {
+			end := min(b+batchSize, len(content.Messages))
+			err := SendPushBatch(content.UserId, content.Messages[b:end], false)
 			if err != nil {
-				return fmt.Errorf("error updating sent status for push notification with id: %v, err: %w", n.Id, err)
+				results[i] = SendResult{Status: SendStatusFailed, Err: fmt.Errorf("error sending firebase batch job: %w", err)}
 			}
 		}
 	}
+	return results
+}
+
+func (*pushChannel) Ack(ctx context.Context, messages []*Message, results []SendResult) error {
 	return nil
 }
 
-func sendWebhookNotifications() error {
-	var notificationQueueItem []types.TransitWebhook
+type webhookMessage struct {
+	Content types.TransitWebhookContent
+	Body    []byte
+}
+
+type webhookChannel struct{}
+
+func (*webhookChannel) Name() types.NotificationChannel {
+	return types.WebhookNotificationChannel
+}
 
-	err := db.WriterDb.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook' ORDER BY created ASC`)
+func (*webhookChannel) Render(item QueueItem) (*Message, error) {
+	var content types.TransitWebhookContent
+	err := content.Scan(item.Content)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return nil, err
+	}
+	// do not retry after 5 attempts
+	if content.Webhook.Retries > 5 {
+		return nil, fmt.Errorf("webhook %v exceeded the maximum number of retries", content.Webhook.ID)
 	}
+	_, err = url.Parse(content.Webhook.Url)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url of webhook %v: %w", content.Webhook.ID, err)
+	}
+	body, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling webhook event: %w", err)
+	}
+	return &Message{UserId: content.UserId, Size: 1, Payload: webhookMessage{Content: content, Body: body}}, nil
+}
+
+func (*webhookChannel) RateLimit(ctx context.Context, userId types.UserId) (string, int64) {
+	return NOTIFICAION_WEBHOOK_RATE_LIMIT_BUCKET, 0
+}
+
+func (*webhookChannel) Send(ctx context.Context, messages []*Message) []SendResult {
+	results := make([]SendResult, len(messages))
 
 	// webhooks have 5 seconds to respond
 	client := &http.Client{Timeout: time.Second * 5}
 
-	log.Infof("processing %v webhook notifications", len(notificationQueueItem))
-
 	// use an error group to throttle webhook requests
 	g := &errgroup.Group{}
 	g.SetLimit(50) // issue at most 50 requests at a time
-	for _, n := range notificationQueueItem {
-		n := n
-		_, err := db.CountSentMessage(NOTIFICAION_WEBHOOK_RATE_LIMIT_BUCKET, n.Content.UserId)
-		if err != nil {
-			log.Error(err, "error counting sent webhook", 0)
-		}
-
-		// do not retry after 5 attempts
-		if n.Content.Webhook.Retries > 5 {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
-			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
-			}
-			continue
-		}
-
-		reqBody := new(bytes.Buffer)
-
-		err = json.NewEncoder(reqBody).Encode(n.Content)
-		if err != nil {
-			log.Error(err, "error marshalling webhook event", 0)
-		}
-
-		_, err = url.Parse(n.Content.Webhook.Url)
-		if err != nil {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
-			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
-			}
-			continue
-		}
-
+	for i, m := range messages {
+		i, wm := i, m.Payload.(webhookMessage)
 		g.Go(func() error {
-			if n.Content.Webhook.Retries > 0 {
-				time.Sleep(time.Duration(n.Content.Webhook.Retries) * time.Second)
+			if wm.Content.Webhook.Retries > 0 {
+				time.Sleep(time.Duration(wm.Content.Webhook.Retries) * time.Second)
 			}
-			resp, err := client.Post(n.Content.Webhook.Url, "application/json", reqBody)
+			resp, err := client.Post(wm.Content.Webhook.Url, "application/json", bytes.NewReader(wm.Body))
 			if err != nil {
 				log.Warnf("error sending webhook request: %v", err)
-				metrics.NotificationsSent.WithLabelValues("webhook", "error").Inc()
+				results[i] = SendResult{Status: SendStatusRetry, Label: "error"}
 				return nil
-			} else {
-				metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
 			}
 			defer resp.Body.Close()
 
-			_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
-			if err != nil {
-				log.Error(err, "error updating notification_queue table", 0)
+			if resp.StatusCode < 400 {
+				results[i] = SendResult{Status: SendStatusSent, Label: resp.Status}
 				return nil
 			}
-
-			if resp != nil && resp.StatusCode < 400 {
-				// update retries counters in db based on end result
-				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
-					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, n.Content.Webhook.Retries, n.Content.Webhook.ID)
-				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, n.Content.Webhook.Retries, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
-				}
-				if err != nil {
-					log.Warnf("failed to update retries counter to %v for webhook %v: %v", n.Content.Webhook.Retries, n.Content.Webhook.ID, err)
-				}
-			} else {
-				var errResp types.ErrorResponse
-
-				if resp != nil {
-					b, err := io.ReadAll(resp.Body)
-					if err != nil {
-						log.Error(err, "error reading body", 0)
-					}
-
-					errResp.Status = resp.Status
-					errResp.Body = string(b)
-				}
-
-				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
-					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
-				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
-				}
-				if err != nil {
-					log.Error(err, "error updating users_webhooks table", 0)
-					return nil
-				}
+			errResp := types.ErrorResponse{Status: resp.Status}
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				log.Error(err, "error reading body", 0)
 			}
+			errResp.Body = string(b)
+			results[i] = SendResult{Status: SendStatusFailed, Label: resp.Status, Details: errResp}
 			return nil
 		})
 	}
 
-	err = g.Wait()
+	err := g.Wait()
 	if err != nil {
 		log.Error(err, "error waiting for errgroup", 0)
 	}
+	return results
+}
+
+// Ack updates the retry counters of the webhooks based on the end result
+func (*webhookChannel) Ack(ctx context.Context, messages []*Message, results []SendResult) error {
+	for i, m := range messages {
+		webhook := m.Payload.(webhookMessage).Content.Webhook
+		var err error
+		switch results[i].Status {
+		case SendStatusSent:
+			if webhook.DashboardId == 0 && webhook.DashboardGroupId == 0 {
+				_, err = db.FrontendWriterDB.ExecContext(ctx, `UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, webhook.Retries, webhook.ID)
+			} else {
+				_, err = db.WriterDb.ExecContext(ctx, `UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, webhook.Retries, webhook.DashboardGroupId, webhook.DashboardId)
+			}
+		case SendStatusFailed:
+			errResp, _ := results[i].Details.(types.ErrorResponse)
+			if webhook.DashboardId == 0 && webhook.DashboardGroupId == 0 {
+				_, err = db.FrontendWriterDB.ExecContext(ctx, `UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, webhook.ID, m.Payload.(webhookMessage).Content, errResp)
+			} else {
+				_, err = db.WriterDb.ExecContext(ctx, `UPDATE users_val_dashboards_groups SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, webhook.DashboardGroupId, webhook.DashboardId)
+			}
+		}
+		if err != nil {
+			log.Warnf("failed to update retries counter for webhook %v: %v", webhook.ID, err)
+		}
+	}
 	return nil
 }
 
-func sendDiscordNotifications() error {
-	var notificationQueueItem []types.TransitDiscord
+// discordWebhookState is the state of a discord webhook after sending its messages
+type discordWebhookState struct {
+	Webhook types.UserWebhook
+	// last failed request and response of the webhook
+	Request  *types.DiscordReq
+	Response *types.ErrorResponse
+}
+
+type discordChannel struct{}
+
+func (*discordChannel) Name() types.NotificationChannel {
+	return types.WebhookDiscordNotificationChannel
+}
 
-	err := db.WriterDb.Select(&notificationQueueItem, `SELECT
-		id,
-		created,
-		sent,
-		channel,
-		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook_discord' ORDER BY created ASC`)
+func (*discordChannel) Render(item QueueItem) (*Message, error) {
+	var content types.TransitDiscordContent
+	err := content.Scan(item.Content)
 	if err != nil {
-		return fmt.Errorf("error querying notification queue, err: %w", err)
+		return nil, err
 	}
-	client := &http.Client{Timeout: time.Second * 30}
+	// purge the event from existence if the retry counter is over 5
+	if content.Webhook.Retries > 5 {
+		return nil, fmt.Errorf("discord webhook %v exceeded the maximum number of retries", content.Webhook.ID)
+	}
+	return &Message{UserId: content.UserId, Size: 1, Payload: content}, nil
+}
 
-	log.Infof("processing %v discord webhook notifications", len(notificationQueueItem))
-	webhookMap := make(map[uint64]types.UserWebhook)
+func (*discordChannel) RateLimit(ctx context.Context, userId types.UserId) (string, int64) {
+	return "", 0
+}
 
-	notifMap := make(map[uint64][]types.TransitDiscord)
-	// generate webhook id => discord req
-	// while mapping. aggregate embeds while doing so, up to 10 per req can be sent
-	for _, n := range notificationQueueItem {
-		// purge the event from existence if the retry counter is over 5
-		if n.Content.Webhook.Retries > 5 {
-			_, err = db.WriterDb.Exec(`DELETE FROM notification_queue where id = $1`, n.Id)
-			if err != nil {
-				log.Warnf("failed to delete notification from queue: %v", err)
-			}
-			continue
-		}
-		if _, exists := webhookMap[n.Content.Webhook.ID]; !exists {
-			webhookMap[n.Content.Webhook.ID] = n.Content.Webhook
-		}
-		if _, exists := notifMap[n.Content.Webhook.ID]; !exists {
-			notifMap[n.Content.Webhook.ID] = make([]types.TransitDiscord, 0)
+// Send sends the messages of each webhook in order, a failed message is retried until the webhook exceeds its retries
+func (*discordChannel) Send(ctx context.Context, messages []*Message) []SendResult {
+	results := make([]SendResult, len(messages))
+	client := &http.Client{Timeout: time.Second * 30}
+
+	// generate webhook id => message indices
+	webhookMap := make(map[uint64]*discordWebhookState)
+	notifMap := make(map[uint64][]int)
+	for i, m := range messages {
+		content := m.Payload.(types.TransitDiscordContent)
+		if _, exists := webhookMap[content.Webhook.ID]; !exists {
+			webhookMap[content.Webhook.ID] = &discordWebhookState{Webhook: content.Webhook}
 		}
-		notifMap[n.Content.Webhook.ID] = append(notifMap[n.Content.Webhook.ID], n)
+		notifMap[content.Webhook.ID] = append(notifMap[content.Webhook.ID], i)
+		// all messages of a webhook share its state, messages which are not sent count as failed
+		results[i] = SendResult{Status: SendStatusFailed, Details: webhookMap[content.Webhook.ID]}
 	}
+
 	// use an error group to throttle webhook requests
 	g := &errgroup.Group{}
 	g.SetLimit(50) // issue at most 50 requests at a time
 
-	for _, webhook := range webhookMap {
-		webhook := webhook
+	for webhookId, state := range webhookMap {
+		indices := notifMap[webhookId]
+		state := state
 		g.Go(func() error {
-			defer func() {
-				// update retries counters in db based on end result
-				if webhook.DashboardId == 0 && webhook.DashboardGroupId == 0 {
-					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, webhook.Retries, webhook.ID)
-				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, webhook.Retries, webhook.DashboardGroupId, webhook.DashboardId)
-				}
-				if err != nil {
-					log.Warnf("failed to update retries counter to %v for webhook %v: %v", webhook.Retries, webhook.ID, err)
-				}
-
-				// mark notifcations as sent in db
-				ids := make([]uint64, 0)
-				for _, req := range notifMap[webhook.ID] {
-					ids = append(ids, req.Id)
-				}
-				_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() where id = ANY($1)`, pq.Array(ids))
-				if err != nil {
-					log.Warnf("failed to update sent for notifcations in queue: %v", err)
-				}
-			}()
-
-			_, err = url.Parse(webhook.Url)
+			webhook := &state.Webhook
+			_, err := url.Parse(webhook.Url)
 			if err != nil {
 				log.Error(err, "error parsing url", 0, log.Fields{"webhook_id": webhook.ID})
 				return nil
 			}
 
-			for i := 0; i < len(notifMap[webhook.ID]); i++ {
+			for n := 0; n < len(indices); n++ {
 				if webhook.Retries > 5 {
 					break // stop
 				}
 				// sleep between retries
 				time.Sleep(time.Duration(webhook.Retries) * time.Second)
 
+				i := indices[n]
+				discordRequest := messages[i].Payload.(types.TransitDiscordContent).DiscordRequest
 				reqBody := new(bytes.Buffer)
-				err := json.NewEncoder(reqBody).Encode(notifMap[webhook.ID][i].Content.DiscordRequest)
+				err := json.NewEncoder(reqBody).Encode(discordRequest)
 				if err != nil {
-					log.Error(err, "error marshalling discord webhook event", 0)
+					results[i].Err = fmt.Errorf("error marshalling discord webhook event: %w", err)
 					continue // skip
 				}
 
 				resp, err := client.Post(webhook.Url, "application/json", reqBody)
 				if err != nil {
 					log.Warnf("failed sending discord webhook request %v: %v", webhook.ID, err)
-					metrics.NotificationsSent.WithLabelValues("webhook_discord", "error").Inc()
+					results[i].Label = "error"
 				} else {
-					metrics.NotificationsSent.WithLabelValues("webhook_discord", resp.Status).Inc()
+					results[i].Label = resp.Status
 				}
 				if resp != nil && resp.StatusCode < 400 {
+					resp.Body.Close()
 					webhook.Retries = 0
-				} else {
-					webhook.Retries++
-					var errResp types.ErrorResponse
-
-					if resp != nil {
-						b, err := io.ReadAll(resp.Body)
-						if err != nil {
-							log.Error(err, "error reading body", 0)
-						} else {
-							errResp.Body = string(b)
-						}
-						errResp.Status = resp.Status
-						resp.Body.Close()
-
-						if resp.StatusCode != http.StatusOK {
-							log.WarnWithFields(map[string]interface{}{"errResp.Body": utils.FirstN(errResp.Body, 1000), "webhook.Url": webhook.Url}, "error pushing discord webhook")
-						}
-						if webhook.DashboardId == 0 && webhook.DashboardGroupId == 0 {
-							_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET request = $2, response = $3 WHERE id = $1;`, webhook.ID, notifMap[webhook.ID][i].Content.DiscordRequest, errResp)
-						}
-						if err != nil {
-							log.Error(err, "error storing failure data in users_webhooks table", 0)
-						}
-					}
+					results[i].Status = SendStatusSent
+					continue
+				}
 
-					i-- // retry, IMPORTANT to be at the END of the ELSE, otherwise the wrong index will be used in the commands above!
+				webhook.Retries++
+				if resp != nil {
+					errResp := types.ErrorResponse{Status: resp.Status}
+					b, err := io.ReadAll(resp.Body)
+					if err != nil {
+						log.Error(err, "error reading body", 0)
+					} else {
+						errResp.Body = string(b)
+					}
+					resp.Body.Close()
+					log.WarnWithFields(map[string]interface{}{"errResp.Body": utils.FirstN(errResp.Body, 1000), "webhook.Url": webhook.Url}, "error pushing discord webhook")
+					state.Request, state.Response = &discordRequest, &errResp
 				}
+
+				n-- // retry, IMPORTANT to be at the END of the loop, otherwise the wrong message will be retried
 			}
 			return nil
 		})
 	}
 
-	err = g.Wait()
+	err := g.Wait()
 	if err != nil {
 		log.Error(err, "error waiting for errgroup", 0)
 	}
+	return results
+}
 
+// Ack updates the retry counters of the webhooks based on the end result and stores the last failure of user webhooks
+func (*discordChannel) Ack(ctx context.Context, messages []*Message, results []SendResult) error {
+	acked := make(map[*discordWebhookState]struct{})
+	for _, r := range results {
+		state, ok := r.Details.(*discordWebhookState)
+		if !ok {
+			continue
+		}
+		if _, exists := acked[state]; exists {
+			continue
+		}
+		acked[state] = struct{}{}
+
+		var err error
+		webhook := state.Webhook
+		if webhook.DashboardId == 0 && webhook.DashboardGroupId == 0 {
+			_, err = db.FrontendWriterDB.ExecContext(ctx, `UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, webhook.Retries, webhook.ID)
+			if err == nil && state.Response != nil {
+				_, err = db.FrontendWriterDB.ExecContext(ctx, `UPDATE users_webhooks SET request = $2, response = $3 WHERE id = $1;`, webhook.ID, *state.Request, *state.Response)
+			}
+		} else {
+			_, err = db.WriterDb.ExecContext(ctx, `UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, webhook.Retries, webhook.DashboardGroupId, webhook.DashboardId)
+		}
+		if err != nil {
+			log.Warnf("failed to update retries counter to %v for webhook %v: %v", webhook.Retries, webhook.ID, err)
+		}
+	}
 	return nil
 }
 
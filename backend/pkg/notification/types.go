@@ -245,6 +245,46 @@ func (n *ValidatorIsOnlineNotification) GetLegacyTitle() string {
 	return "Validator Back Online"
 }
 
+type ValidatorIsUnstableNotification struct {
+	types.NotificationBaseImpl
+
+	ValidatorIndex   uint64
+	Transitions      uint64 // number of online / offline transitions since WindowStartEpoch
+	WindowStartEpoch uint64
+	IsOffline        bool // state of the validator when the notification was created
+}
+
+func (n *ValidatorIsUnstableNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%d", n.ValidatorIndex)
+}
+
+func (n *ValidatorIsUnstableNotification) currentState() string {
+	if n.IsOffline {
+		return "offline"
+	}
+	return "online"
+}
+
+// Overwrite specific methods
+func (n *ValidatorIsUnstableNotification) GetInfo(format types.NotificationFormat) string {
+	vali := formatValidatorLink(format, n.ValidatorIndex)
+	epoch := formatEpochLink(format, n.WindowStartEpoch)
+	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
+	return fmt.Sprintf(`Validator %v%v is unstable, it switched between online and offline %v times since epoch %s and is currently %v. Further transitions will not be notified until it is stable again.`, vali, dashboardAndGroupInfo, n.Transitions, epoch, n.currentState())
+}
+
+func (n *ValidatorIsUnstableNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *ValidatorIsUnstableNotification) GetLegacyInfo() string {
+	return fmt.Sprintf(`Validator %v is unstable, it switched between online and offline %v times since epoch %v and is currently %v.`, n.ValidatorIndex, n.Transitions, n.WindowStartEpoch, n.currentState())
+}
+
+func (n *ValidatorIsUnstableNotification) GetLegacyTitle() string {
+	return "Validator is Unstable"
+}
+
 type ValidatorGroupEfficiencyNotification struct {
 	types.NotificationBaseImpl
 
@@ -275,6 +315,54 @@ func (n *ValidatorGroupEfficiencyNotification) GetLegacyTitle() string {
 	return n.GetTitle()
 }
 
+type ValidatorDashboardRuleNotification struct {
+	types.NotificationBaseImpl
+
+	RuleId         uint64
+	RuleName       string
+	Metric         types.NotificationRuleMetric
+	Comparison     types.NotificationRuleComparison
+	Threshold      float64
+	Value          float64
+	WindowEpochs   uint64
+	TriggeredEpoch uint64 // last epoch of the evaluated window, the cooldown of the rule starts at this epoch once the notification is queued
+}
+
+func (n *ValidatorDashboardRuleNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%s - %s - %s", n.GetDashboardName(), n.GetDashboardGroupName(), n.RuleName)
+}
+
+// formatValue renders ratio based metrics as percentages and all others as plain numbers
+func (n *ValidatorDashboardRuleNotification) formatValue(value float64) string {
+	switch n.Metric {
+	case types.NotificationRuleMetricEfficiency, types.NotificationRuleMetricProposalLuck, types.NotificationRuleMetricSyncParticipation:
+		return fmt.Sprintf("%.2f%%", value*100)
+	case types.NotificationRuleMetricMissedAttestations:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.5f ETH", value)
+	}
+}
+
+// Overwrite specific methods
+func (n *ValidatorDashboardRuleNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatPureDashboardAndGroupLink(format, n)
+	epoch := formatEpochLink(format, n.Epoch)
+	return fmt.Sprintf(`Rule "%s" was triggered for %s: %s was %s over the last %d epoch(s) (%s %s) in epoch %s.`, n.RuleName, dashboardAndGroupInfo, strings.ReplaceAll(string(n.Metric), "_", " "), n.formatValue(n.Value), n.WindowEpochs, n.Comparison.Symbol(), n.formatValue(n.Threshold), epoch)
+}
+
+func (n *ValidatorDashboardRuleNotification) GetTitle() string {
+	return "Group rule triggered"
+}
+
+func (n *ValidatorDashboardRuleNotification) GetLegacyInfo() string {
+	return n.GetInfo(types.NotifciationFormatText)
+}
+
+func (n *ValidatorDashboardRuleNotification) GetLegacyTitle() string {
+	return n.GetTitle()
+}
+
 type ValidatorAttestationNotification struct {
 	types.NotificationBaseImpl
 
@@ -419,6 +507,77 @@ func (n *ValidatorWithdrawalNotification) GetLegacyTitle() string {
 	return "Withdrawal Processed"
 }
 
+type ValidatorPartialWithdrawalNotification struct {
+	types.NotificationBaseImpl
+
+	ValidatorIndex uint64
+	Epoch          uint64
+	Slot           uint64
+	Amount         uint64
+	Address        []byte
+}
+
+func (n *ValidatorPartialWithdrawalNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%v", n.ValidatorIndex)
+}
+
+func (n *ValidatorPartialWithdrawalNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
+	vali := formatValidatorLink(format, n.ValidatorIndex)
+	amount := utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false)
+	generalPart := fmt.Sprintf(`A requested partial withdrawal of %s has been processed for validator %s%s.`, amount, vali, dashboardAndGroupInfo)
+
+	return generalPart
+}
+
+func (n *ValidatorPartialWithdrawalNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *ValidatorPartialWithdrawalNotification) GetLegacyInfo() string {
+	generalPart := fmt.Sprintf(`A requested partial withdrawal of %v has been processed for validator %v.`, utils.FormatClCurrencyString(n.Amount, utils.Config.Frontend.MainCurrency, 6, true, false, false), n.ValidatorIndex)
+	return generalPart
+}
+
+func (n *ValidatorPartialWithdrawalNotification) GetLegacyTitle() string {
+	return "Partial Withdrawal Processed"
+}
+
+type ValidatorConsolidationNotification struct {
+	types.NotificationBaseImpl
+
+	ValidatorIndex       uint64 // the subscribed validator, either the source or the target of the consolidation
+	SourceValidatorIndex uint64
+	TargetValidatorIndex uint64
+	Epoch                uint64
+}
+
+func (n *ValidatorConsolidationNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%v", n.ValidatorIndex)
+}
+
+func (n *ValidatorConsolidationNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
+	source := formatValidatorLink(format, n.SourceValidatorIndex)
+	target := formatValidatorLink(format, n.TargetValidatorIndex)
+	generalPart := fmt.Sprintf(`The consolidation of validator %s into validator %s%s has been completed.`, source, target, dashboardAndGroupInfo)
+
+	return generalPart
+}
+
+func (n *ValidatorConsolidationNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *ValidatorConsolidationNotification) GetLegacyInfo() string {
+	generalPart := fmt.Sprintf(`The consolidation of validator %v into validator %v has been completed.`, n.SourceValidatorIndex, n.TargetValidatorIndex)
+	return generalPart
+}
+
+func (n *ValidatorConsolidationNotification) GetLegacyTitle() string {
+	return "Consolidation Completed"
+}
+
 type EthClientNotification struct {
 	types.NotificationBaseImpl
 
@@ -496,6 +655,16 @@ func (n *MonitorMachineNotification) GetLegacyInfo() string {
 		return fmt.Sprintf(`Your staking machine "%v" has reached your configured CPU usage threshold.`, n.MachineName)
 	case types.MonitoringMachineMemoryUsageEventName:
 		return fmt.Sprintf(`Your staking machine "%v" has reached your configured RAM threshold.`, n.MachineName)
+	case types.MonitoringMachineNotSyncedEventName:
+		return fmt.Sprintf(`The beacon node on your staking machine "%v" is not synced.`, n.MachineName)
+	case types.MonitoringMachineElDisconnectedEventName:
+		return fmt.Sprintf(`The beacon node on your staking machine "%v" is not connected to an execution client.`, n.MachineName)
+	case types.MonitoringMachineLowPeerCountEventName:
+		return fmt.Sprintf(`The beacon node on your staking machine "%v" has less than %.0f peers.`, n.MachineName, n.EventThreshold)
+	case types.MonitoringMachineHeadSlotLagEventName:
+		return fmt.Sprintf(`The beacon node on your staking machine "%v" is lagging more than %.0f slots behind the network.`, n.MachineName, n.EventThreshold)
+	case types.MonitoringMachineFallbackInUseEventName:
+		return fmt.Sprintf(`A client on your staking machine "%v" is connected to its fallback node.`, n.MachineName)
 	}
 	return ""
 }
@@ -510,6 +679,16 @@ func (n *MonitorMachineNotification) GetLegacyTitle() string {
 		return "High CPU Load"
 	case types.MonitoringMachineMemoryUsageEventName:
 		return "Memory Warning"
+	case types.MonitoringMachineNotSyncedEventName:
+		return "Beacon Node Not Synced"
+	case types.MonitoringMachineElDisconnectedEventName:
+		return "Execution Client Disconnected"
+	case types.MonitoringMachineLowPeerCountEventName:
+		return "Low Peer Count"
+	case types.MonitoringMachineHeadSlotLagEventName:
+		return "Beacon Node Lagging"
+	case types.MonitoringMachineFallbackInUseEventName:
+		return "Fallback Node In Use"
 	}
 	return ""
 }
@@ -518,6 +697,63 @@ func (n *MonitorMachineNotification) GetEventFilter() string {
 	return n.MachineName
 }
 
+type MachineOutdatedClient struct {
+	Client             string
+	RunningVersion     string
+	LatestVersion      string
+	RequiredVersion    string
+	IsForkIncompatible bool
+}
+
+// MachineClientOutdatedNotification reports all outdated clients of a machine
+type MachineClientOutdatedNotification struct {
+	types.NotificationBaseImpl
+
+	MachineName string
+	Clients     []MachineOutdatedClient
+}
+
+func (n *MachineClientOutdatedNotification) GetEntitiyId() string {
+	return n.MachineName
+}
+
+func (n *MachineClientOutdatedNotification) GetInfo(format types.NotificationFormat) string {
+	return n.GetLegacyInfo()
+}
+
+func (n *MachineClientOutdatedNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *MachineClientOutdatedNotification) GetLegacyInfo() string {
+	infos := make([]string, 0, len(n.Clients))
+	for _, client := range n.Clients {
+		info := fmt.Sprintf(`Your staking machine "%v" is running %s %s while %s is out`, n.MachineName, client.Client, client.RunningVersion, client.LatestVersion)
+		if client.IsForkIncompatible {
+			if client.RequiredVersion == client.LatestVersion {
+				info += ", including a mandatory hard-fork release"
+			} else {
+				info += fmt.Sprintf(", %s is mandatory for the hard fork", client.RequiredVersion)
+			}
+		}
+		infos = append(infos, info+".")
+	}
+	return strings.Join(infos, "\n")
+}
+
+func (n *MachineClientOutdatedNotification) GetLegacyTitle() string {
+	for _, client := range n.Clients {
+		if client.IsForkIncompatible {
+			return "Mandatory Client Update"
+		}
+	}
+	return "Client Update Available"
+}
+
+func (n *MachineClientOutdatedNotification) GetEventFilter() string {
+	return n.MachineName
+}
+
 type TaxReportNotification struct {
 	types.NotificationBaseImpl
 }
@@ -86,9 +86,11 @@ export function useNotificationsManagementDashboards() {
     accountDashboarSettings.group_efficiency_below_threshold = 0
     accountDashboarSettings.is_attestations_missed_subscribed = false
     accountDashboarSettings.is_block_proposal_subscribed = false
+    accountDashboarSettings.is_consolidation_completed_subscribed = false
     accountDashboarSettings.is_group_efficiency_below_subscribed = false
     accountDashboarSettings.is_max_collateral_subscribed = false
     accountDashboarSettings.is_min_collateral_subscribed = false
+    accountDashboarSettings.is_partial_withdrawal_processed_subscribed = false
     accountDashboarSettings.is_slashed_subscribed = false
     accountDashboarSettings.is_sync_subscribed = false
     accountDashboarSettings.is_upcoming_block_proposal_subscribed = false
@@ -0,0 +1,65 @@
+import { defineStore } from 'pinia'
+import type { GetValidatorDashboardConsolidationsResponse } from '~/types/api/validator_dashboard'
+import type { DashboardKey } from '~/types/dashboard'
+import type { TableQueryParams } from '~/types/datatable'
+import { API_PATH } from '~/types/customFetch'
+
+const validatorDashboardConsolidationsStore = defineStore(
+  'validator_dashboard_consolidations_store',
+  () => {
+    const data = ref<GetValidatorDashboardConsolidationsResponse>()
+    const query = ref<TableQueryParams>()
+
+    return {
+      data,
+      query,
+    }
+  },
+)
+
+export function useValidatorDashboardConsolidationsStore() {
+  const { fetch } = useCustomFetch()
+  const {
+    data,
+    query: storedQuery,
+  } = storeToRefs(validatorDashboardConsolidationsStore())
+
+  const consolidations = computed(() => data.value)
+  const query = computed(() => storedQuery.value)
+  const isLoading = ref(false)
+
+  async function getConsolidations(
+    dashboardKey: DashboardKey,
+    query?: TableQueryParams,
+  ) {
+    if (!dashboardKey) {
+      data.value = undefined
+      isLoading.value = false
+      storedQuery.value = undefined
+      return undefined
+    }
+    storedQuery.value = query
+    isLoading.value = true
+    const res = await fetch<GetValidatorDashboardConsolidationsResponse>(
+      API_PATH.DASHBOARD_VALIDATOR_CONSOLIDATIONS,
+      undefined,
+      { dashboardKey },
+      query,
+    )
+
+    if (JSON.stringify(storedQuery.value) !== JSON.stringify(query)) {
+      return // in case some query params change while loading
+    }
+    isLoading.value = false
+
+    data.value = res
+    return res
+  }
+
+  return {
+    consolidations,
+    getConsolidations,
+    isLoading,
+    query,
+  }
+}
@@ -0,0 +1,65 @@
+import { defineStore } from 'pinia'
+import type { GetValidatorDashboardWithdrawalRequestsResponse } from '~/types/api/validator_dashboard'
+import type { DashboardKey } from '~/types/dashboard'
+import type { TableQueryParams } from '~/types/datatable'
+import { API_PATH } from '~/types/customFetch'
+
+const validatorDashboardWithdrawalRequestsStore = defineStore(
+  'validator_dashboard_withdrawal_requests_store',
+  () => {
+    const data = ref<GetValidatorDashboardWithdrawalRequestsResponse>()
+    const query = ref<TableQueryParams>()
+
+    return {
+      data,
+      query,
+    }
+  },
+)
+
+export function useValidatorDashboardWithdrawalRequestsStore() {
+  const { fetch } = useCustomFetch()
+  const {
+    data,
+    query: storedQuery,
+  } = storeToRefs(validatorDashboardWithdrawalRequestsStore())
+
+  const withdrawalRequests = computed(() => data.value)
+  const query = computed(() => storedQuery.value)
+  const isLoading = ref(false)
+
+  async function getWithdrawalRequests(
+    dashboardKey: DashboardKey,
+    query?: TableQueryParams,
+  ) {
+    if (!dashboardKey) {
+      data.value = undefined
+      isLoading.value = false
+      storedQuery.value = undefined
+      return undefined
+    }
+    storedQuery.value = query
+    isLoading.value = true
+    const res = await fetch<GetValidatorDashboardWithdrawalRequestsResponse>(
+      API_PATH.DASHBOARD_VALIDATOR_WITHDRAWAL_REQUESTS,
+      undefined,
+      { dashboardKey },
+      query,
+    )
+
+    if (JSON.stringify(storedQuery.value) !== JSON.stringify(query)) {
+      return // in case some query params change while loading
+    }
+    isLoading.value = false
+
+    data.value = res
+    return res
+  }
+
+  return {
+    withdrawalRequests,
+    getWithdrawalRequests,
+    isLoading,
+    query,
+  }
+}
@@ -17,14 +17,26 @@ export const useNotificationsManagementStore = defineStore('notifications-manage
       general_settings: {
         do_not_disturb_timestamp: 0,
         is_email_notifications_enabled: false,
+        is_machine_client_outdated_subscribed: false,
         is_machine_cpu_usage_subscribed: false,
+        is_machine_el_disconnected_subscribed: false,
+        is_machine_fallback_in_use_subscribed: false,
+        is_machine_head_slot_lag_subscribed: false,
+        is_machine_low_peer_count_subscribed: false,
         is_machine_memory_usage_subscribed: false,
+        is_machine_not_synced_subscribed: false,
         is_machine_offline_subscribed: false,
         is_machine_storage_usage_subscribed: false,
         is_push_notifications_enabled: false,
         is_webhook_notifications_enabled: false,
+        machine_client_outdated_only_mandatory: false,
         machine_cpu_usage_threshold: 0.0,
+        machine_el_disconnected_threshold: 0,
+        machine_fallback_in_use_threshold: 0,
+        machine_head_slot_lag_threshold: 0,
+        machine_low_peer_count_threshold: 0,
         machine_memory_usage_threshold: 0.0,
+        machine_not_synced_threshold: 0,
         machine_storage_usage_threshold: 0.0,
       },
       has_machines: true,
@@ -9,5 +9,87 @@ export interface MachineMetricsData {
   system_metrics: (any /* types.MachineMetricSystem */ | undefined)[];
   validator_metrics: (any /* types.MachineMetricValidator */ | undefined)[];
   node_metrics: (any /* types.MachineMetricNode */ | undefined)[];
+  execution_metrics: (any /* types.MachineMetricExecution */ | undefined)[];
+  slasher_metrics: (any /* types.MachineMetricSlasher */ | undefined)[];
 }
 export type GetUserMachineMetricsRespone = ApiDataResponse<MachineMetricsData>;
+/**
+ * counters are converted to per second rates (or fractions for cpu time) between consecutive points of a machine
+ */
+export interface MachineMetricsHistoryData {
+  resolution: number /* uint64 */; // seconds between points
+  system_metrics: MachineMetricsSystemPoint[];
+  validator_metrics: MachineMetricsValidatorPoint[];
+  node_metrics: MachineMetricsNodePoint[];
+  execution_metrics: MachineMetricsExecutionPoint[];
+  slasher_metrics: MachineMetricsSlasherPoint[];
+}
+export interface MachineMetricsSystemPoint {
+  machine: string;
+  timestamp: number /* int64 */;
+  cpu_cores: number /* uint64 */;
+  cpu_threads: number /* uint64 */;
+  cpu_usage: number /* float64 */; // fraction of total cpu time not spent idle
+  cpu_iowait: number /* float64 */;
+  memory_total_bytes: number /* uint64 */;
+  memory_used_bytes: number /* uint64 */; // excluding cache and buffers
+  disk_total_bytes: number /* uint64 */;
+  disk_free_bytes: number /* uint64 */;
+  disk_reads_per_second: number /* float64 */;
+  disk_writes_per_second: number /* float64 */;
+  disk_io_utilization: number /* float64 */; // fraction of time spent doing io
+  network_receive_bytes_per_second: number /* float64 */;
+  network_transmit_bytes_per_second: number /* float64 */;
+  misc_os: string;
+}
+export interface MachineMetricsProcessPoint {
+  machine: string;
+  timestamp: number /* int64 */;
+  client_name: string;
+  client_version: string;
+  cpu_usage: number /* float64 */; // cpu seconds per second, can exceed 1 on multiple cores
+  memory_bytes: number /* uint64 */;
+}
+export interface MachineMetricsValidatorPoint extends MachineMetricsProcessPoint {
+  validator_total: number /* uint64 */;
+  validator_active: number /* uint64 */;
+}
+export interface MachineMetricsNodePoint extends MachineMetricsProcessPoint {
+  synced: boolean;
+  head_slot: number /* uint64 */;
+  peers_connected: number /* uint64 */;
+  disk_bytes: number /* uint64 */;
+  network_receive_bytes_per_second: number /* float64 */;
+  network_transmit_bytes_per_second: number /* float64 */;
+}
+export interface MachineMetricsExecutionPoint extends MachineMetricsProcessPoint {
+  synced: boolean;
+  head_block: number /* uint64 */;
+  peers_connected: number /* uint64 */;
+  txpool_transactions: number /* uint64 */;
+  disk_bytes: number /* uint64 */;
+}
+export interface MachineMetricsSlasherPoint extends MachineMetricsProcessPoint {
+  synced: boolean;
+  head_epoch: number /* uint64 */;
+  attestations_per_second: number /* float64 */;
+  blocks_per_second: number /* float64 */;
+  attester_slashings_found_total: number /* uint64 */;
+  proposer_slashings_found_total: number /* uint64 */;
+  disk_bytes: number /* uint64 */;
+}
+export type GetUserMachineMetricsHistoryResponse = ApiDataResponse<MachineMetricsHistoryData>;
+export interface MachineClientVersion {
+  machine: string;
+  process: 'beaconnode' | 'validator' | 'execution' | 'slasher';
+  client_id?: number /* uint64 */; // nil if the client is unknown
+  client_name: string;
+  category?: string; // Execution Clients, Consensus Clients, Other
+  running_version: string;
+  latest_version?: string; // empty if no release is known for the client
+  required_version?: string; // minimum version for the current or upcoming hard fork, empty if unknown
+  is_outdated: boolean;
+  is_fork_incompatible: boolean;
+  last_seen: number /* int64 */;
+}
+export type GetUserMachineClientsResponse = ApiDataResponse<MachineClientVersion[]>;
@@ -44,25 +44,43 @@ export interface NotificationDashboardsTableRow {
   group_id: number /* uint64 */;
   group_name: string;
   entity_count: number /* uint64 */;
-  event_types: ('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
+  event_types: ('validator_online' | 'validator_offline' | 'validator_unstable' | 'group_efficiency_below' | 'custom_rule' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'partial_withdrawal' | 'consolidation' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
 }
 export type InternalGetUserNotificationDashboardsResponse = ApiPagingResponse<NotificationDashboardsTableRow>;
 export interface NotificationEventValidatorBackOnline {
   index: number /* uint64 */;
   epoch_count: number /* uint64 */;
 }
+export interface NotificationEventValidatorUnstable {
+  index: number /* uint64 */;
+  transitions: number /* uint64 */;
+}
+export interface NotificationEventValidatorDashboardRule {
+  rule_id: number /* uint64 */;
+  name: string;
+  metric: 'efficiency' | 'missed_attestations' | 'balance_delta' | 'proposal_luck' | 'sync_participation';
+  comparison: 'lt' | 'lte' | 'gt' | 'gte';
+  threshold: number /* float64 */;
+  value: number /* float64 */;
+}
 export interface NotificationEventWithdrawal {
   index: number /* uint64 */;
   amount: string /* decimal.Decimal */;
   address: Address;
 }
+export interface NotificationEventConsolidation {
+  source_index: number /* uint64 */;
+  target_index: number /* uint64 */;
+}
 export interface NotificationValidatorDashboardDetail {
   dashboard_name: string;
   group_name: string;
   validator_offline: number /* uint64 */[]; // validator indices
   validator_offline_reminder: number /* uint64 */[]; // validator indices; TODO not filled yet
   validator_online: NotificationEventValidatorBackOnline[];
+  validator_unstable: NotificationEventValidatorUnstable[];
   group_efficiency_below?: number /* float64 */; // fill with the `group_efficiency_below` threshold if event is present
+  custom_rules: NotificationEventValidatorDashboardRule[];
   proposal_missed: IndexSlots[];
   proposal_success: IndexBlocks[];
   proposal_upcoming: IndexSlots[];
@@ -70,6 +88,8 @@ export interface NotificationValidatorDashboardDetail {
   sync: number /* uint64 */[]; // validator indices
   attestation_missed: IndexEpoch[]; // index (epoch)
   withdrawal: NotificationEventWithdrawal[];
+  partial_withdrawal: NotificationEventWithdrawal[]; // withdrawals requested from the execution layer
+  consolidation: NotificationEventConsolidation[];
   min_collateral: Address[]; // node addresses
   max_collateral: Address[]; // node addresses
 }
@@ -95,7 +115,7 @@ export type InternalGetUserNotificationsAccountDashboardResponse = ApiDataRespon
 export interface NotificationMachinesTableRow {
   machine_name: string;
   threshold?: number /* float64 */;
-  event_type: 'offline' | 'storage' | 'cpu' | 'memory';
+  event_type: 'offline' | 'storage' | 'cpu' | 'memory' | 'not_synced' | 'el_disconnected' | 'low_peer_count' | 'head_slot_lag' | 'fallback_in_use' | 'client_outdated';
   timestamp: number /* int64 */;
 }
 export type InternalGetUserNotificationMachinesResponse = ApiPagingResponse<NotificationMachinesTableRow>;
@@ -121,6 +141,24 @@ export interface NotificationNetworksTableRow {
   threshold?: string /* decimal.Decimal */; // participation rate threshold should also be passed as decimal string
 }
 export type InternalGetUserNotificationNetworksResponse = ApiPagingResponse<NotificationNetworksTableRow>;
+/**
+ * ------------------------------------------------------------
+ * Incidents Table
+ */
+export interface NotificationIncidentsTableRow {
+  id: number /* uint64 */;
+  dashboard_id: number /* uint64 */;
+  dashboard_name: string;
+  group_id: number /* uint64 */;
+  group_name: string;
+  event_type: 'validator_offline';
+  index: number /* uint64 */;
+  status: 'open' | 'resolved';
+  opened_epoch: number /* uint64 */;
+  resolved_epoch?: number /* uint64 */;
+  is_acknowledged: boolean;
+}
+export type InternalGetUserNotificationIncidentsResponse = ApiPagingResponse<NotificationIncidentsTableRow>;
 /**
  * ------------------------------------------------------------
  * Notification Settings
@@ -165,6 +203,18 @@ export interface NotificationSettingsGeneral {
   machine_cpu_usage_threshold: number /* float64 */;
   is_machine_memory_usage_subscribed: boolean;
   machine_memory_usage_threshold: number /* float64 */;
+  is_machine_not_synced_subscribed: boolean;
+  machine_not_synced_threshold: number /* float64 */; // minutes
+  is_machine_el_disconnected_subscribed: boolean;
+  machine_el_disconnected_threshold: number /* float64 */; // minutes
+  is_machine_low_peer_count_subscribed: boolean;
+  machine_low_peer_count_threshold: number /* float64 */; // peers
+  is_machine_head_slot_lag_subscribed: boolean;
+  machine_head_slot_lag_threshold: number /* float64 */; // slots
+  is_machine_fallback_in_use_subscribed: boolean;
+  machine_fallback_in_use_threshold: number /* float64 */; // minutes
+  is_machine_client_outdated_subscribed: boolean;
+  machine_client_outdated_only_mandatory: boolean; // only notify about releases required for a hard fork
 }
 export type InternalPutUserNotificationSettingsGeneralResponse = ApiDataResponse<NotificationSettingsGeneral>;
 export interface NotificationSettings {
@@ -187,12 +237,34 @@ export interface NotificationSettingsValidatorDashboard {
   is_sync_subscribed: boolean;
   is_withdrawal_processed_subscribed: boolean;
   is_slashed_subscribed: boolean;
+  is_partial_withdrawal_processed_subscribed: boolean;
+  is_consolidation_completed_subscribed: boolean;
   is_max_collateral_subscribed: boolean;
   max_collateral_threshold: number /* float64 */;
   is_min_collateral_subscribed: boolean;
   min_collateral_threshold: number /* float64 */;
 }
 export type InternalPutUserNotificationSettingsValidatorDashboardResponse = ApiDataResponse<NotificationSettingsValidatorDashboard>;
+export interface NotificationRuleValidatorDashboard {
+  id: number /* uint64 */;
+  name: string;
+  metric: 'efficiency' | 'missed_attestations' | 'balance_delta' | 'proposal_luck' | 'sync_participation';
+  comparison: 'lt' | 'lte' | 'gt' | 'gte';
+  threshold: number /* float64 */;
+  window_epochs: number /* uint64 */;
+  cooldown_epochs: number /* uint64 */;
+  last_triggered_epoch?: number /* uint64 */;
+}
+export type InternalGetUserNotificationSettingsValidatorDashboardRulesResponse = ApiDataResponse<NotificationRuleValidatorDashboard[]>;
+export type InternalPostUserNotificationSettingsValidatorDashboardRulesResponse = ApiDataResponse<NotificationRuleValidatorDashboard>;
+export type InternalPutUserNotificationSettingsValidatorDashboardRuleResponse = ApiDataResponse<NotificationRuleValidatorDashboard>;
+export interface NotificationSnoozeValidatorDashboard {
+  id: number /* uint64 */;
+  event_type?: string; // all events of the group are snoozed if not set
+  snoozed_until: number /* int64 */;
+}
+export type InternalGetUserNotificationSettingsValidatorDashboardSnoozesResponse = ApiDataResponse<NotificationSnoozeValidatorDashboard[]>;
+export type InternalPostUserNotificationSettingsValidatorDashboardSnoozesResponse = ApiDataResponse<NotificationSnoozeValidatorDashboard>;
 export interface NotificationSettingsAccountDashboard {
   webhook_url: string;
   is_webhook_discord_enabled: boolean;
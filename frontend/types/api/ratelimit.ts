@@ -12,3 +12,30 @@ export interface ApiWeightItem {
   Weight: number /* int */;
 }
 export type InternalGetRatelimitWeightsResponse = ApiDataResponse<ApiWeightItem[]>;
+export interface ApiUsageDataPoint {
+  timestamp: number /* int64 */;
+  count: number /* uint64 */;
+}
+export interface ApiEndpointUsage {
+  endpoint: string;
+  hourly: ApiUsageDataPoint[];
+  daily: ApiUsageDataPoint[];
+}
+export interface ApiKeyUsage {
+  api_key: string; // only the first characters of the key, "oauth" for requests authorized by an oauth access token
+  hourly: ApiUsageDataPoint[];
+  daily: ApiUsageDataPoint[];
+  endpoints: ApiEndpointUsage[];
+}
+export interface ApiUsageQuota {
+  limit: number /* uint64 */; // 0 means unlimited
+  used: number /* uint64 */;
+}
+export interface ApiUsageData {
+  second_limit: number /* uint64 */;
+  hour: ApiUsageQuota;
+  month: ApiUsageQuota;
+  projected_month_usage: number /* uint64 */;
+  keys: ApiKeyUsage[];
+}
+export type InternalGetUserApiUsageResponse = ApiDataResponse<ApiUsageData>;
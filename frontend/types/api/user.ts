@@ -24,6 +24,39 @@ export interface UserSubscription {
   end: number /* int64 */;
 }
 export type InternalGetUserInfoResponse = ApiDataResponse<UserInfo>;
+/**
+ * scopes of an api key, keys without a scope can not be used for the matching endpoints
+ */
+export const ApiKeyScopeNetworkRead = "network:read";
+/**
+ * scopes of an api key, keys without a scope can not be used for the matching endpoints
+ */
+export const ApiKeyScopeDashboardsManage = "dashboards:manage";
+/**
+ * scopes of an api key, keys without a scope can not be used for the matching endpoints
+ */
+export const ApiKeyScopeNotificationsManage = "notifications:manage";
+/**
+ * scopes of an api key, keys without a scope can not be used for the matching endpoints
+ */
+export const ApiKeyScopeAccountRead = "account:read";
+/**
+ * scopes of an api key, keys without a scope can not be used for the matching endpoints
+ */
+export const ApiKeyScopeMachineMetricsWrite = "machine-metrics:write";
+export interface UserApiKey {
+  id: number /* uint64 */;
+  label: string;
+  prefix: string; // first characters of the key, the full key is only returned once after creation
+  scopes: ('network:read' | 'dashboards:manage' | 'notifications:manage' | 'account:read' | 'machine-metrics:write')[];
+  created_at: number /* int64 */;
+}
+export type InternalGetUserApiKeysResponse = ApiDataResponse<UserApiKey[]>;
+export interface CreatedUserApiKey extends UserApiKey {
+  api_key: string;
+}
+export type InternalPostUserApiKeysResponse = ApiDataResponse<CreatedUserApiKey>;
+export type InternalPutUserApiKeyResponse = ApiDataResponse<UserApiKey>;
 export interface EmailUpdate {
   id: number /* uint64 */;
   current_email: string;
@@ -135,4 +168,157 @@ export interface OAuthAppData {
   AppName: string;
   RedirectURI: string;
   Active: boolean;
+  ClientSecret?: string; // hashed, nil for public clients
+}
+/**
+ * OAuthCodeData is a consumed authorization code
+ */
+export interface OAuthCodeData {
+  UserId: number /* uint64 */;
+  AppId: number /* uint64 */;
+  RedirectUri: string;
+  Scopes: string[];
+  CodeChallenge: string;
+  CodeChallengeMethod: string;
+}
+/**
+ * OAuthDeviceData is an authorized third-party app session, stored alongside the mobile app devices
+ */
+export interface OAuthDeviceData {
+  Id: number /* uint64 */;
+  UserId: number /* uint64 */;
+  AppId: number /* uint64 */;
+  Scopes: string[];
+}
+export interface OAuthApp {
+  client_id: string;
+  name: string;
+  redirect_uri: string;
+  confidential: boolean; // confidential apps have to authenticate with their client secret when requesting tokens
+  created_at: number /* int64 */;
+}
+export type InternalGetUserOAuthAppsResponse = ApiDataResponse<OAuthApp[]>;
+export interface CreatedOAuthApp extends OAuthApp {
+  client_secret?: string; // only returned once after creation
+}
+export type InternalPostUserOAuthAppsResponse = ApiDataResponse<CreatedOAuthApp>;
+export interface OAuthConsent {
+  app_name: string;
+  redirect_uri: string;
+  scopes: ('network:read' | 'dashboards:manage' | 'notifications:manage' | 'account:read' | 'machine-metrics:write')[];
+}
+export type InternalGetOAuthAuthorizeResponse = ApiDataResponse<OAuthConsent>;
+export interface OAuthAuthorizeRedirect {
+  redirect_uri: string; // contains the authorization code or the error
+}
+export type InternalPostOAuthAuthorizeResponse = ApiDataResponse<OAuthAuthorizeRedirect>;
+/**
+ * token endpoint responses follow RFC 6749 and are not wrapped
+ */
+export interface OAuthTokenResponse {
+  access_token: string;
+  token_type: string;
+  expires_in: number /* uint64 */;
+  refresh_token: string;
+  scope: string;
+}
+export interface OAuthErrorResponse {
+  error: string;
+  error_description?: string;
+}
+export interface LoginResponse {
+  two_factor_required: boolean; // if true, the login has to be completed with a second factor
+}
+export type InternalPostLoginResponse = ApiDataResponse<LoginResponse>;
+export interface UserTwoFactorStatus {
+  enabled: boolean;
+  recovery_codes_remaining: number /* uint64 */;
+}
+export type InternalGetUserTwoFactorResponse = ApiDataResponse<UserTwoFactorStatus>;
+export interface TotpEnrolment {
+  secret: string;
+  provisioning_uri: string; // otpauth uri, should be displayed as QR code
+}
+export type InternalPostUserTotpResponse = ApiDataResponse<TotpEnrolment>;
+export interface RecoveryCodes {
+  recovery_codes: string[]; // only returned once, each code can be used once instead of a totp code
+}
+export type InternalPostUserRecoveryCodesResponse = ApiDataResponse<RecoveryCodes>;
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventLogin = "login";
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventPasswordChange = "password_change";
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventEmailChange = "email_change";
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventTwoFactorEnabled = "two_factor_enabled";
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventTwoFactorDisabled = "two_factor_disabled";
+/**
+ * security events recorded for the audit list of a user
+ */
+export const SecurityEventSessionRevoked = "session_revoked";
+export interface UserSession {
+  id: string;
+  type: 'web' | 'device'; // devices are mobile app and third-party app sessions using refresh tokens
+  device_name?: string;
+  app_name?: string;
+  created_at: number /* int64 */;
+  last_used_at: number /* int64 */;
+  ip: string;
+  user_agent: string;
+  current: boolean; // the session of the request
+}
+export type InternalGetUserSessionsResponse = ApiDataResponse<UserSession[]>;
+export interface UserSecurityEvent {
+  type: 'login' | 'password_change' | 'email_change' | 'two_factor_enabled' | 'two_factor_disabled' | 'session_revoked';
+  timestamp: number /* int64 */;
+  ip: string;
+  user_agent: string;
+}
+export type InternalGetUserSecurityEventsResponse = ApiDataResponse<UserSecurityEvent[]>;
+/**
+ * roles of organization members
+ */
+export const OrganizationRoleOwner = "owner"; // can manage members, perks of all members are pooled
+/**
+ * roles of organization members
+ */
+export const OrganizationRoleEditor = "editor"; // can create and modify dashboards
+/**
+ * roles of organization members
+ */
+export const OrganizationRoleViewer = "viewer"; // can only view dashboards
+export interface Organization {
+  id: number /* uint64 */;
+  name: string;
+  role: 'owner' | 'editor' | 'viewer'; // role of the requesting user
+  member_count: number /* uint64 */;
+  created_at: number /* int64 */;
+}
+export type InternalGetUserOrganizationsResponse = ApiDataResponse<Organization[]>;
+export type InternalPostOrganizationsResponse = ApiDataResponse<Organization>;
+export type InternalPutOrganizationResponse = ApiDataResponse<Organization>;
+export interface OrganizationDetails extends Organization {
+  premium_perks: PremiumPerks; // pooled perks of all members, used for dashboards owned by the organization
+}
+export type InternalGetOrganizationResponse = ApiDataResponse<OrganizationDetails>;
+export interface OrganizationMember {
+  user_id: number /* uint64 */;
+  email: string; // censored
+  role: 'owner' | 'editor' | 'viewer';
+  joined_at: number /* int64 */;
 }
+export type InternalGetOrganizationMembersResponse = ApiDataResponse<OrganizationMember[]>;
+export type InternalPostOrganizationMembersResponse = ApiDataResponse<OrganizationMember>;
+export type InternalPutOrganizationMemberResponse = ApiDataResponse<OrganizationMember>;
@@ -243,6 +243,38 @@ export interface VDBTotalWithdrawalsData {
   total_amount: string /* decimal.Decimal */;
 }
 export type GetValidatorDashboardTotalWithdrawalsResponse = ApiDataResponse<VDBTotalWithdrawalsData>;
+/**
+ * ------------------------------------------------------------
+ * Consolidations Tab
+ */
+export interface VDBConsolidationsTableRow {
+  epoch: number /* uint64 */;
+  slot: number /* uint64 */;
+  group_id: number /* uint64 */;
+  source_index?: number /* uint64 */; // not set if the source pubkey is not a known validator
+  source_public_key: PubKey;
+  target_index?: number /* uint64 */; // not set if the target pubkey is not a known validator
+  target_public_key: PubKey;
+  source_address: Address;
+  status: 'pending' | 'completed' | 'rejected';
+}
+export type GetValidatorDashboardConsolidationsResponse = ApiPagingResponse<VDBConsolidationsTableRow>;
+/**
+ * ------------------------------------------------------------
+ * Withdrawal Requests Tab (EIP-7002 execution layer triggered exits and partial withdrawals)
+ */
+export interface VDBWithdrawalRequestsTableRow {
+  epoch: number /* uint64 */;
+  slot: number /* uint64 */;
+  index?: number /* uint64 */; // not set if the pubkey is not a known validator
+  public_key: PubKey;
+  group_id: number /* uint64 */;
+  source_address: Address;
+  type: 'exit' | 'partial';
+  amount: string /* decimal.Decimal */; // zero for exits
+  status: 'pending' | 'completed' | 'rejected';
+}
+export type GetValidatorDashboardWithdrawalRequestsResponse = ApiPagingResponse<VDBWithdrawalRequestsTableRow>;
 /**
  * ------------------------------------------------------------
  * Rocket Pool Tab
@@ -19,6 +19,7 @@ export enum API_PATH {
   DASHBOARD_SUMMARY_CHART = '/dashboard/validatorSummaryChart',
   DASHBOARD_SUMMARY_DETAILS = '/dashboard/validatorSummaryDetails',
   DASHBOARD_VALIDATOR_BLOCKS = '/validator-dashboards/blocks',
+  DASHBOARD_VALIDATOR_CONSOLIDATIONS = '/validator-dashboards/consolidations',
   DASHBOARD_VALIDATOR_CREATE_PUBLIC_ID = '/validator-dashboards/publicIds',
   DASHBOARD_VALIDATOR_EDIT_PUBLIC_ID = '/validator-dashboards/editPublicIds',
   DASHBOARD_VALIDATOR_EPOCH_DUTY = '/validator-dashboards/epoch_duty',
@@ -31,6 +32,7 @@ export enum API_PATH {
   DASHBOARD_VALIDATOR_REWARDS_CHART = '/dashboard/validatorRewardsChart',
   DASHBOARD_VALIDATOR_REWARDS_DETAILS = '/dashboard/validatorRewardsDetails',
   DASHBOARD_VALIDATOR_TOTAL_WITHDRAWALS = '/validator-dashboards/total-withdrawals',
+  DASHBOARD_VALIDATOR_WITHDRAWAL_REQUESTS = '/validator-dashboards/withdrawal-requests',
   DASHBOARD_VALIDATOR_WITHDRAWALS = '/validator-dashboards/withdrawals',
   GET_NOTIFICATIONS_SETTINGS_DASHBOARD = '/notifications/managementDashboard',
   LATEST_STATE = '/latestState',
@@ -186,6 +188,12 @@ export const mapping: Record<string, MappingData> = {
     mock: false,
     path: 'validator-dashboards/{dashboard_id}/blocks',
   },
+  [API_PATH.DASHBOARD_VALIDATOR_CONSOLIDATIONS]: {
+    getPath: values =>
+      `/validator-dashboards/${values?.dashboardKey}/consolidations`,
+    mock: false,
+    path: 'validator-dashboards/{dashboard_id}/consolidations',
+  },
   [API_PATH.DASHBOARD_VALIDATOR_CREATE_PUBLIC_ID]: {
     getPath: values =>
       `/validator-dashboards/${values?.dashboardKey}/public-ids`,
@@ -261,6 +269,12 @@ export const mapping: Record<string, MappingData> = {
     mock: false,
     path: 'validator-dashboards/{dashboard_id}/total-withdrawals',
   },
+  [API_PATH.DASHBOARD_VALIDATOR_WITHDRAWAL_REQUESTS]: {
+    getPath: values =>
+      `/validator-dashboards/${values?.dashboardKey}/withdrawal-requests`,
+    mock: false,
+    path: 'validator-dashboards/{dashboard_id}/withdrawal-requests',
+  },
   [API_PATH.DASHBOARD_VALIDATOR_WITHDRAWALS]: {
     getPath: values =>
       `/validator-dashboards/${values?.dashboardKey}/withdrawals`,